@@ -0,0 +1,303 @@
+// Package calendar derives holiday and business day features from the time columns of a DataFrame,
+// using pluggable regional calendars:
+//
+//	features, err := calendar.AddFeatures(df, "date", calendar.USFederal())
+//	busy, err := features.Query("date_is_business_day && orders > 100")
+//
+// A Calendar tells the holidays and the weekend days. RuleCalendar builds one from holiday rules
+// (fixed dates, nth weekdays of a month, offsets from Easter) and USFederal and UKEnglandWales are
+// ready-made ones; any type implementing Calendar can be used instead.
+package calendar
+
+/*
+
+	This is where the calendars are defined: the Calendar interface, the rules of a RuleCalendar
+	and how holidays falling on a weekend are observed on another day.
+
+*/
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Calendar decides which days are holidays and weekend days. Only the date of a time counts, in its location.
+type Calendar interface {
+	// Holiday returns the name of the holiday on the day of t, false if the day is not a holiday.
+	Holiday(t time.Time) (name string, ok bool)
+	// IsWeekend reports whether the day of t is a weekend day.
+	IsWeekend(t time.Time) bool
+}
+
+// BusinessDay reports whether the day of t is neither a weekend day nor a holiday of the calendar,
+// see IsBusinessDay for a whole series.
+//
+// Parameters:
+//   - cal: The calendar.
+//   - t: The day.
+//
+// Returns:
+//   - bool: True for a business day.
+func BusinessDay(cal Calendar, t time.Time) bool {
+	if cal.IsWeekend(t) {
+		return false
+	}
+	_, holiday := cal.Holiday(t)
+	return !holiday
+}
+
+// Observance tells on which day a holiday falling on a weekend day is observed, on top of its actual day.
+type Observance int
+
+const (
+	// NotObserved holidays are only on their actual day.
+	NotObserved Observance = iota
+	// NearestWeekday holidays on a Saturday are observed on the Friday before, and on a Sunday on the Monday after.
+	NearestWeekday
+	// NextWeekday holidays are observed on the next day that is neither a weekend day nor an other holiday
+	// (a substitute day).
+	NextWeekday
+)
+
+// Rule gives the dates of a holiday in a year, see Fixed, NthWeekday, Easter and Custom.
+//
+// Fields:
+//   - Name: The name of the holiday.
+//   - Observance: The day the holiday is observed when it falls on a weekend day.
+//   - From, To: The first and last years of the holiday, 0 for no bound.
+type Rule struct {
+	Name       string
+	Observance Observance
+	From, To   int
+
+	dates func(year int) []time.Time
+}
+
+// Fixed is a holiday on the same date every year, e.g. Fixed("Christmas Day", time.December, 25).
+func Fixed(name string, month time.Month, day int) Rule {
+	return Rule{Name: name, dates: func(year int) []time.Time {
+		return []time.Time{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}}
+}
+
+// NthWeekday is a holiday on the nth weekday of a month, counted from the end of the month when n is
+// negative, e.g. NthWeekday("Memorial Day", time.May, time.Monday, -1) for the last Monday of May.
+func NthWeekday(name string, month time.Month, weekday time.Weekday, n int) Rule {
+	return Rule{Name: name, dates: func(year int) []time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			back := (int(last.Weekday()) - int(weekday) + 7) % 7
+			return []time.Time{last.AddDate(0, 0, -back+7*(n+1))}
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		ahead := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return []time.Time{first.AddDate(0, 0, ahead+7*(n-1))}
+	}}
+}
+
+// Easter is a holiday a number of days after Easter Sunday (Western), e.g. Easter("Good Friday", -2).
+func Easter(name string, offset int) Rule {
+	return Rule{Name: name, dates: func(year int) []time.Time {
+		return []time.Time{easterSunday(year).AddDate(0, 0, offset)}
+	}}
+}
+
+// Custom is a holiday whose dates in a year are computed by a function, e.g. one-off holidays or lunar
+// calendars. Only the year, month and day of the returned times are used.
+func Custom(name string, dates func(year int) []time.Time) Rule {
+	return Rule{Name: name, dates: dates}
+}
+
+// Observed returns the rule with an observance, e.g. Fixed(...).Observed(NearestWeekday).
+func (r Rule) Observed(observance Observance) Rule {
+	r.Observance = observance
+	return r
+}
+
+// Years returns the rule limited to the years from and to (both inclusive), 0 for no bound.
+func (r Rule) Years(from, to int) Rule {
+	r.From, r.To = from, to
+	return r
+}
+
+// easterSunday computes the date of Easter Sunday with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// date is a day of the calendar, without time or location
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// dayOf returns the day of t in its location
+func dayOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+// time returns the day at midnight UTC
+func (d date) time() time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)
+}
+
+// Holiday is a holiday of a RuleCalendar, see RuleCalendar.Holidays.
+//
+// Fields:
+//   - Date: The day, at midnight UTC.
+//   - Name: The name of the holiday, followed by " (observed)" on the day it is observed instead of its actual day.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// RuleCalendar is a Calendar built from holiday rules. It is safe for concurrent use.
+//
+// Fields:
+//   - Name: The name of the calendar.
+//   - Weekend: The weekend days. Defaults to Saturday and Sunday.
+//   - Rules: The holidays.
+type RuleCalendar struct {
+	Name    string
+	Weekend []time.Weekday
+	Rules   []Rule
+
+	mu    sync.Mutex
+	years map[int]map[date]string // holidays by year, computed on first use
+}
+
+// NewRuleCalendar creates a calendar with a Saturday and Sunday weekend.
+//
+// Parameters:
+//   - name: The name of the calendar.
+//   - rules: The holidays.
+//
+// Returns:
+//   - *RuleCalendar: The calendar.
+func NewRuleCalendar(name string, rules ...Rule) *RuleCalendar {
+	return &RuleCalendar{Name: name, Rules: rules}
+}
+
+// IsWeekend reports whether the day of t is a weekend day of the calendar.
+func (c *RuleCalendar) IsWeekend(t time.Time) bool {
+	if c.Weekend == nil {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	}
+	return slices.Contains(c.Weekend, t.Weekday())
+}
+
+// Holiday returns the name of the holiday on the day of t, false if the day is not a holiday.
+func (c *RuleCalendar) Holiday(t time.Time) (string, bool) {
+	day := dayOf(t)
+	name, ok := c.holidays(day.year)[day]
+	return name, ok
+}
+
+// Holidays lists the holidays of a year, by date.
+//
+// Parameters:
+//   - year: The year.
+//
+// Returns:
+//   - []Holiday: The holidays and their observed days.
+func (c *RuleCalendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for day, name := range c.holidays(year) {
+		holidays = append(holidays, Holiday{Date: day.time(), Name: name})
+	}
+	slices.SortFunc(holidays, func(a, b Holiday) int { return a.Date.Compare(b.Date) })
+	return holidays
+}
+
+// holidays returns the holidays of a year, computing them on first use
+func (c *RuleCalendar) holidays(year int) map[date]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if holidays, ok := c.years[year]; ok {
+		return holidays
+	}
+	if c.years == nil {
+		c.years = make(map[int]map[date]string)
+	}
+
+	// the holidays of the surrounding years may be observed in this one, e.g. on December 31st
+	actual := make(map[date]string)
+	var rules []Rule
+	var days []date
+	for y := year - 1; y <= year+1; y++ {
+		for _, rule := range c.Rules {
+			if (rule.From != 0 && y < rule.From) || (rule.To != 0 && y > rule.To) {
+				continue
+			}
+			for _, t := range rule.dates(y) {
+				day := dayOf(t)
+				if _, taken := actual[day]; !taken {
+					actual[day] = rule.Name
+				}
+				rules = append(rules, rule)
+				days = append(days, day)
+			}
+		}
+	}
+
+	all := make(map[date]string, len(actual))
+	for day, name := range actual {
+		all[day] = name
+	}
+	// substitute days are handed out in date order, so two weekend holidays get two different days
+	order := make([]int, len(days))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return days[a].time().Compare(days[b].time()) })
+	for _, i := range order {
+		t := days[i].time()
+		if !c.IsWeekend(t) {
+			continue
+		}
+		switch rules[i].Observance {
+		case NearestWeekday:
+			switch t.Weekday() {
+			case time.Saturday:
+				t = t.AddDate(0, 0, -1)
+			case time.Sunday:
+				t = t.AddDate(0, 0, 1)
+			}
+		case NextWeekday:
+			for {
+				t = t.AddDate(0, 0, 1)
+				if _, taken := all[dayOf(t)]; !taken && !c.IsWeekend(t) {
+					break
+				}
+			}
+		default:
+			continue
+		}
+		if _, taken := all[dayOf(t)]; !taken {
+			all[dayOf(t)] = rules[i].Name + " (observed)"
+		}
+	}
+
+	holidays := make(map[date]string)
+	for day, name := range all {
+		if day.year == year {
+			holidays[day] = name
+		}
+	}
+	c.years[year] = holidays
+	return holidays
+}
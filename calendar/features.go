@@ -0,0 +1,93 @@
+package calendar
+
+/*
+
+	This is where the calendar features of a time column are defined, as Series that WithColumn
+	adds to a DataFrame, or as the columns added by AddFeatures for Query and Eval expressions.
+
+*/
+
+import (
+	"time"
+
+	"github.com/kishyassin/goframe/dataframe"
+)
+
+// mapDays applies fn to the time.Time values of a series, other values give missing
+func mapDays(s *dataframe.Series, missing any, fn func(t time.Time) any) *dataframe.Series {
+	if s.Err() != nil {
+		return s // an error of the chain is passed on
+	}
+	result := make([]any, len(s.Data))
+	for i, v := range s.Data {
+		result[i] = missing
+		if t, ok := v.(time.Time); ok {
+			result[i] = fn(t)
+		}
+	}
+	return dataframe.NewSeries(s.Name, result)
+}
+
+// IsHoliday marks the time values that fall on a holiday of the calendar.
+//
+// Parameters:
+//   - s: The time.Time values, e.g. df.Col("date").
+//   - cal: The calendar.
+//
+// Returns:
+//   - *dataframe.Series: A boolean Series with the same length, nil and values that are not time.Time give false.
+func IsHoliday(s *dataframe.Series, cal Calendar) *dataframe.Series {
+	return mapDays(s, false, func(t time.Time) any {
+		_, ok := cal.Holiday(t)
+		return ok
+	})
+}
+
+// IsBusinessDay marks the time values that fall on neither a weekend day nor a holiday of the calendar.
+//
+// Parameters:
+//   - s: The time.Time values, e.g. df.Col("date").
+//   - cal: The calendar.
+//
+// Returns:
+//   - *dataframe.Series: A boolean Series with the same length, nil and values that are not time.Time give false.
+func IsBusinessDay(s *dataframe.Series, cal Calendar) *dataframe.Series {
+	return mapDays(s, false, func(t time.Time) any { return BusinessDay(cal, t) })
+}
+
+// HolidayName returns the name of the holiday of each time value.
+//
+// Parameters:
+//   - s: The time.Time values, e.g. df.Col("date").
+//   - cal: The calendar.
+//
+// Returns:
+//   - *dataframe.Series: The names, nil for the days that are not holidays.
+func HolidayName(s *dataframe.Series, cal Calendar) *dataframe.Series {
+	return mapDays(s, nil, func(t time.Time) any {
+		if name, ok := cal.Holiday(t); ok {
+			return name
+		}
+		return nil
+	})
+}
+
+// AddFeatures returns a copy of the DataFrame with the boolean columns <column>_is_holiday and
+// <column>_is_business_day, to be used in Query and Eval expressions or as model features.
+//
+// Parameters:
+//   - df: The DataFrame.
+//   - column: The time.Time column.
+//   - cal: The calendar.
+//
+// Returns:
+//   - *dataframe.DataFrame: A new DataFrame with the two columns.
+//   - error: An error if the column does not exist.
+func AddFeatures(df *dataframe.DataFrame, column string, cal Calendar) (*dataframe.DataFrame, error) {
+	days := df.Col(column)
+	result, err := df.WithColumn(column+"_is_holiday", IsHoliday(days, cal))
+	if err != nil {
+		return nil, err
+	}
+	return result.WithColumn(column+"_is_business_day", IsBusinessDay(days, cal))
+}
@@ -0,0 +1,50 @@
+package calendar
+
+/*
+
+	This is where the ready-made regional calendars are defined. They follow the statutory rules of
+	each region and leave out the one-off holidays (royal events, moved bank holidays, ...), add
+	them with a Custom rule.
+
+*/
+
+import "time"
+
+// USFederal returns the calendar of the United States federal holidays. Holidays on a Saturday are
+// observed on the Friday before and holidays on a Sunday on the Monday after.
+//
+// Returns:
+//   - *RuleCalendar: The calendar.
+func USFederal() *RuleCalendar {
+	return NewRuleCalendar("US Federal",
+		Fixed("New Year's Day", time.January, 1).Observed(NearestWeekday),
+		NthWeekday("Martin Luther King Jr. Day", time.January, time.Monday, 3).Years(1986, 0),
+		NthWeekday("Washington's Birthday", time.February, time.Monday, 3),
+		NthWeekday("Memorial Day", time.May, time.Monday, -1),
+		Fixed("Juneteenth", time.June, 19).Observed(NearestWeekday).Years(2021, 0),
+		Fixed("Independence Day", time.July, 4).Observed(NearestWeekday),
+		NthWeekday("Labor Day", time.September, time.Monday, 1),
+		NthWeekday("Columbus Day", time.October, time.Monday, 2),
+		Fixed("Veterans Day", time.November, 11).Observed(NearestWeekday),
+		NthWeekday("Thanksgiving Day", time.November, time.Thursday, 4),
+		Fixed("Christmas Day", time.December, 25).Observed(NearestWeekday),
+	)
+}
+
+// UKEnglandWales returns the calendar of the bank holidays of England and Wales. Holidays on a
+// weekend are observed on the next weekday that is not already a holiday (a substitute day).
+//
+// Returns:
+//   - *RuleCalendar: The calendar.
+func UKEnglandWales() *RuleCalendar {
+	return NewRuleCalendar("UK England and Wales",
+		Fixed("New Year's Day", time.January, 1).Observed(NextWeekday),
+		Easter("Good Friday", -2),
+		Easter("Easter Monday", 1),
+		NthWeekday("Early May Bank Holiday", time.May, time.Monday, 1),
+		NthWeekday("Spring Bank Holiday", time.May, time.Monday, -1),
+		NthWeekday("Summer Bank Holiday", time.August, time.Monday, -1),
+		Fixed("Christmas Day", time.December, 25).Observed(NextWeekday),
+		Fixed("Boxing Day", time.December, 26).Observed(NextWeekday),
+	)
+}
@@ -6,14 +6,22 @@ package dataframe
 
 */
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
-// Mean calculates the mean of numeric values for each column in the DataFrame
-func (df *DataFrame) Mean() (map[string]float64, error) {
+// Mean calculates the mean of numeric values for each column in the DataFrame.
+// NaN values are skipped unless AggOption.KeepNaN is set.
+func (df *DataFrame) Mean(options ...AggOption) (map[string]float64, error) {
 	results := make(map[string]float64)
 	for name, col := range df.Columns {
-		series := &Series{Name: name, Data: col.Data}
-		mean, err := series.Mean()
+		if nums, ok := col.nativeFloats(); ok {
+			results[name] = floatMean(nums, keepNaN(options))
+			continue
+		}
+		series := &Series{Name: name, Data: col.Values()}
+		mean, err := series.Mean(options...)
 		if err != nil {
 			return nil, fmt.Errorf("error calculating mean for column '%s': %w", name, err)
 		}
@@ -22,12 +30,17 @@ func (df *DataFrame) Mean() (map[string]float64, error) {
 	return results, nil
 }
 
-// Sum calculates the sum of numeric values for each column in the DataFrame
-func (df *DataFrame) Sum() (map[string]float64, error) {
+// Sum calculates the sum of numeric values for each column in the DataFrame.
+// NaN values are skipped unless AggOption.KeepNaN is set.
+func (df *DataFrame) Sum(options ...AggOption) (map[string]float64, error) {
 	results := make(map[string]float64)
 	for name, col := range df.Columns {
-		series := &Series{Name: name, Data: col.Data}
-		sum, err := series.Sum()
+		if nums, ok := col.nativeFloats(); ok {
+			results[name] = floatSum(nums, keepNaN(options))
+			continue
+		}
+		series := &Series{Name: name, Data: col.Values()}
+		sum, err := series.Sum(options...)
 		if err != nil {
 			return nil, fmt.Errorf("error calculating sum for column '%s': %w", name, err)
 		}
@@ -36,12 +49,17 @@ func (df *DataFrame) Sum() (map[string]float64, error) {
 	return results, nil
 }
 
-// Min calculates the minimum value for each column in the DataFrame
-func (df *DataFrame) Min() (map[string]float64, error) {
+// Min calculates the minimum value for each column in the DataFrame.
+// NaN values are skipped unless AggOption.KeepNaN is set.
+func (df *DataFrame) Min(options ...AggOption) (map[string]float64, error) {
 	results := make(map[string]float64)
 	for name, col := range df.Columns {
-		series := &Series{Name: name, Data: col.Data}
-		min, err := series.Min()
+		if nums, ok := col.nativeFloats(); ok {
+			results[name] = floatMin(nums, keepNaN(options))
+			continue
+		}
+		series := &Series{Name: name, Data: col.Values()}
+		min, err := series.Min(options...)
 		if err != nil {
 			return nil, fmt.Errorf("error calculating min for column '%s': %w", name, err)
 		}
@@ -50,12 +68,17 @@ func (df *DataFrame) Min() (map[string]float64, error) {
 	return results, nil
 }
 
-// Max calculates the maximum value for each column in the DataFrame
-func (df *DataFrame) Max() (map[string]float64, error) {
+// Max calculates the maximum value for each column in the DataFrame.
+// NaN values are skipped unless AggOption.KeepNaN is set.
+func (df *DataFrame) Max(options ...AggOption) (map[string]float64, error) {
 	results := make(map[string]float64)
 	for name, col := range df.Columns {
-		series := &Series{Name: name, Data: col.Data}
-		max, err := series.Max()
+		if nums, ok := col.nativeFloats(); ok {
+			results[name] = floatMax(nums, keepNaN(options))
+			continue
+		}
+		series := &Series{Name: name, Data: col.Values()}
+		max, err := series.Max(options...)
 		if err != nil {
 			return nil, fmt.Errorf("error calculating max for column '%s': %w", name, err)
 		}
@@ -63,3 +86,79 @@ func (df *DataFrame) Max() (map[string]float64, error) {
 	}
 	return results, nil
 }
+
+// aggregateColumns applies a statistic to every column, reading natively stored numbers without boxing
+// and going through the Series method otherwise
+func (df *DataFrame) aggregateColumns(stat string, native func([]float64) float64, boxed func(*Series) (float64, error)) (map[string]float64, error) {
+	results := make(map[string]float64)
+	for name, col := range df.Columns {
+		if nums, ok := col.nativeFloats(); ok {
+			results[name] = native(nums)
+			continue
+		}
+		value, err := boxed(&Series{Name: name, Data: col.Values()})
+		if err != nil {
+			return nil, fmt.Errorf("error calculating %s for column '%s': %w", stat, name, err)
+		}
+		results[name] = value
+	}
+	return results, nil
+}
+
+// Median calculates the median of each column in the DataFrame, see Series.Median.
+// NaN values are skipped unless AggOption.KeepNaN is set, nil values are always skipped.
+func (df *DataFrame) Median(options ...AggOption) (map[string]float64, error) {
+	return df.aggregateColumns("median", func(nums []float64) float64 {
+		return floatMedian(nums, keepNaN(options))
+	}, func(s *Series) (float64, error) { return s.Median(options...) })
+}
+
+// Var calculates the variance of each column in the DataFrame, see Series.Var.
+// The sample variance is returned unless AggOption.Population is set.
+func (df *DataFrame) Var(options ...AggOption) (map[string]float64, error) {
+	return df.aggregateColumns("variance", func(nums []float64) float64 {
+		return floatVariance(nums, keepNaN(options), ddof(options))
+	}, func(s *Series) (float64, error) { return s.Var(options...) })
+}
+
+// Std calculates the standard deviation of each column in the DataFrame, see Series.Std.
+// The sample standard deviation is returned unless AggOption.Population is set.
+func (df *DataFrame) Std(options ...AggOption) (map[string]float64, error) {
+	return df.aggregateColumns("standard deviation", func(nums []float64) float64 {
+		return math.Sqrt(floatVariance(nums, keepNaN(options), ddof(options)))
+	}, func(s *Series) (float64, error) { return s.Std(options...) })
+}
+
+// Quantile calculates the q quantile (0 to 1) of each column in the DataFrame, see Series.Quantile.
+func (df *DataFrame) Quantile(q float64, options ...AggOption) (map[string]float64, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return nil, fmt.Errorf("quantile %v is outside [0, 1]", q)
+	}
+	return df.aggregateColumns("quantile", func(nums []float64) float64 {
+		return floatQuantileOf(nums, keepNaN(options), q)
+	}, func(s *Series) (float64, error) { return s.Quantile(q, options...) })
+}
+
+// Skew calculates the unbiased skewness of each column in the DataFrame, see Series.Skew.
+func (df *DataFrame) Skew(options ...AggOption) (map[string]float64, error) {
+	return df.aggregateColumns("skewness", func(nums []float64) float64 {
+		return floatSkew(nums, keepNaN(options))
+	}, func(s *Series) (float64, error) { return s.Skew(options...) })
+}
+
+// Kurtosis calculates the unbiased excess kurtosis of each column in the DataFrame, see Series.Kurtosis.
+func (df *DataFrame) Kurtosis(options ...AggOption) (map[string]float64, error) {
+	return df.aggregateColumns("kurtosis", func(nums []float64) float64 {
+		return floatKurtosis(nums, keepNaN(options))
+	}, func(s *Series) (float64, error) { return s.Kurtosis(options...) })
+}
+
+// Mode returns the most frequent values of each column in the DataFrame, see Series.Mode.
+// Unlike the numeric aggregations it accepts columns of any type.
+func (df *DataFrame) Mode() map[string][]any {
+	results := make(map[string][]any)
+	for name, col := range df.Columns {
+		results[name] = (&Series{Name: name, Data: col.Values()}).Mode()
+	}
+	return results
+}
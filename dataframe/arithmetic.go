@@ -0,0 +1,256 @@
+package dataframe
+
+/*
+
+	This is where vectorized Series arithmetic and comparisons are defined, so that derived
+	columns are computed without row loops:
+
+		df.WithColumn("total", df.Col("price").Mul(df.Col("qty")))
+
+	Operations return a new Series and can be chained. An error (missing column, length mismatch,
+	non-numeric value) is stored in the result and carried through the chain, Err and
+	WithColumn return it.
+
+*/
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Col returns a copy of a column as a Series, to be combined with the Series operations.
+// The Err method of the Series returns an error if the column does not exist.
+//
+// Parameters:
+//   - name: The name of the column.
+//
+// Returns:
+//   - *Series: The values of the column.
+func (df *DataFrame) Col(name string) *Series {
+	col, exists := df.Columns[name]
+	if !exists {
+		return failedSeries(name, fmt.Errorf("column '%s' does not exist", name))
+	}
+	return NewSeries(name, append([]any{}, col.Values()...))
+}
+
+// WithColumn returns a copy of the DataFrame with the series stored as a column, replacing the
+// column of the same name in place or adding it at the end.
+//
+// Parameters:
+//   - name: The name of the column.
+//   - series: The values, one per row.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame holding the column.
+//   - error: The error carried by the series, or an error if its length does not match the number of rows.
+func (df *DataFrame) WithColumn(name string, series *Series) (*DataFrame, error) {
+	if err := series.Err(); err != nil {
+		return nil, err
+	}
+	if len(df.Columns) > 0 && series.Len() != df.Nrows() {
+		return nil, fmt.Errorf("series '%s' has %d values, expected %d", name, series.Len(), df.Nrows())
+	}
+	result := df.clone()
+	col := &Column[any]{Name: name, Data: append([]any{}, series.Data...)}
+	if _, exists := result.Columns[name]; !exists {
+		result.order = append(result.order, name)
+	}
+	result.Columns[name] = col
+	result.storeNative(col)
+	return result, nil
+}
+
+// Add adds a series or a scalar element-wise. Two strings are concatenated.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: The float64 sums, nil where either value is nil.
+func (s *Series) Add(other any) *Series {
+	return s.arithmetic(other, "add", func(a, b float64) float64 { return a + b })
+}
+
+// Sub subtracts a series or a scalar element-wise.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: The float64 differences, nil where either value is nil.
+func (s *Series) Sub(other any) *Series {
+	return s.arithmetic(other, "subtract", func(a, b float64) float64 { return a - b })
+}
+
+// Mul multiplies by a series or a scalar element-wise.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: The float64 products, nil where either value is nil.
+func (s *Series) Mul(other any) *Series {
+	return s.arithmetic(other, "multiply", func(a, b float64) float64 { return a * b })
+}
+
+// Div divides by a series or a scalar element-wise. Dividing by zero gives an infinity or NaN.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: The float64 quotients, nil where either value is nil.
+func (s *Series) Div(other any) *Series {
+	return s.arithmetic(other, "divide", func(a, b float64) float64 { return a / b })
+}
+
+// Gt compares with a series or a scalar element-wise. Numbers are compared numerically, time.Time
+// values chronologically and strings lexically.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: A boolean Series, false where the values are nil or cannot be compared.
+func (s *Series) Gt(other any) *Series {
+	return s.comparison(other, func(c int) bool { return c > 0 })
+}
+
+// Ge compares with a series or a scalar element-wise, see Gt.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: A boolean Series, false where the values are nil or cannot be compared.
+func (s *Series) Ge(other any) *Series {
+	return s.comparison(other, func(c int) bool { return c >= 0 })
+}
+
+// Lt compares with a series or a scalar element-wise, see Gt.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: A boolean Series, false where the values are nil or cannot be compared.
+func (s *Series) Lt(other any) *Series {
+	return s.comparison(other, func(c int) bool { return c < 0 })
+}
+
+// Le compares with a series or a scalar element-wise, see Gt.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: A boolean Series, false where the values are nil or cannot be compared.
+func (s *Series) Le(other any) *Series {
+	return s.comparison(other, func(c int) bool { return c <= 0 })
+}
+
+// Eq tests equality with a series or a scalar element-wise. Numbers are equal regardless of their
+// type, so 1 equals 1.0. Use NullEq to treat two nils as equal.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: A boolean Series, false where either value is nil.
+func (s *Series) Eq(other any) *Series {
+	return s.elementWise(other, func(a, b any) (any, error) {
+		return valuesEqual(a, b), nil
+	})
+}
+
+// Ne tests inequality with a series or a scalar element-wise, see Eq.
+//
+// Parameters:
+//   - other: A *Series of the same length or a scalar applied to every row.
+//
+// Returns:
+//   - *Series: A boolean Series, false where either value is nil.
+func (s *Series) Ne(other any) *Series {
+	return s.elementWise(other, func(a, b any) (any, error) {
+		return a != nil && b != nil && !valuesEqual(a, b), nil
+	})
+}
+
+// elementWise applies op to the values of s and other (a *Series or a scalar), carrying errors
+func (s *Series) elementWise(other any, op func(a, b any) (any, error)) *Series {
+	if err := s.Err(); err != nil {
+		return failedSeries(s.Name, err)
+	}
+	value := func(int) any { return other }
+	if o, ok := other.(*Series); ok {
+		if err := o.Err(); err != nil {
+			return failedSeries(s.Name, err)
+		}
+		if o.Len() != s.Len() {
+			return failedSeries(s.Name, fmt.Errorf("series '%s' has %d values, expected %d", o.Name, o.Len(), s.Len()))
+		}
+		value = func(i int) any { return o.Data[i] }
+	}
+
+	result := make([]any, len(s.Data))
+	for i, a := range s.Data {
+		v, err := op(a, value(i))
+		if err != nil {
+			return failedSeries(s.Name, fmt.Errorf("row %d: %w", i, err))
+		}
+		result[i] = v
+	}
+	return NewSeries(s.Name, result)
+}
+
+// arithmetic applies a numeric operation, nil operands give nil
+func (s *Series) arithmetic(other any, verb string, op func(a, b float64) float64) *Series {
+	return s.elementWise(other, func(a, b any) (any, error) {
+		if a == nil || b == nil {
+			return nil, nil
+		}
+		if verb == "add" {
+			sa, aIsString := a.(string)
+			sb, bIsString := b.(string)
+			if aIsString && bIsString {
+				return sa + sb, nil
+			}
+		}
+		fa, okA := numericValue(a)
+		fb, okB := numericValue(b)
+		if !okA || !okB {
+			return nil, fmt.Errorf("cannot %s %v (%T) and %v (%T)", verb, a, a, b, b)
+		}
+		return op(fa, fb), nil
+	})
+}
+
+// comparison applies an ordering test to compareValues, values that cannot be compared give false
+func (s *Series) comparison(other any, test func(c int) bool) *Series {
+	return s.elementWise(other, func(a, b any) (any, error) {
+		c, ok := compareValues(a, b)
+		return ok && test(c), nil
+	})
+}
+
+// numericValue converts a number to float64, unlike toFloat it rejects strings and booleans
+func numericValue(v any) (float64, bool) {
+	switch v.(type) {
+	case string, bool:
+		return 0, false
+	}
+	return toFloat(v)
+}
+
+// valuesEqual compares two non-nil values with compareValues, falling back to reflect.DeepEqual
+// for values it cannot order such as booleans
+func valuesEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if c, ok := compareValues(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,320 @@
+package dataframe
+
+/*
+
+	This is where the Apache Arrow conversions are defined
+
+*/
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// ArrowOption configures how a DataFrame is converted to Arrow record batches.
+//
+// Fields:
+//   - Allocator: The memory allocator used for the Arrow buffers. Defaults to memory.DefaultAllocator.
+//   - BatchSize: The maximum number of rows per record batch in ToArrowRecords. Defaults to all rows in one batch.
+type ArrowOption struct {
+	Allocator memory.Allocator
+	BatchSize int
+}
+
+// ToArrowRecord converts the DataFrame to a single Arrow record batch. Column types follow the
+// typed storage rules (see NewTypedDataFrame): integers become int64, integers mixed with floats
+// become float64, strings, bools and time.Time values (UTC timestamps in nanoseconds) keep their type,
+// nil values become nulls and columns holding only nil values use the Arrow null type.
+//
+// The caller owns the record and must call Release when done with it.
+//
+// Parameters:
+//   - options (optional): The ArrowOption struct to configure the allocator.
+//
+// Returns:
+//   - arrow.Record: The record batch, columns follow the DataFrame column order.
+//   - error: An error if a column holds values of mixed or unsupported types.
+func (df *DataFrame) ToArrowRecord(options ...ArrowOption) (arrow.Record, error) {
+	mem := memory.Allocator(memory.DefaultAllocator)
+	if len(options) > 0 && options[0].Allocator != nil {
+		mem = options[0].Allocator
+	}
+
+	names := df.ColumnNames()
+	fields := make([]arrow.Field, len(names))
+	arrays := make([]arrow.Array, len(names))
+	defer func() {
+		for _, arr := range arrays {
+			if arr != nil {
+				arr.Release()
+			}
+		}
+	}()
+
+	for i, name := range names {
+		arr, err := arrowArray(mem, df.Columns[name])
+		if err != nil {
+			return nil, fmt.Errorf("error converting column '%s': %w", name, err)
+		}
+		arrays[i] = arr
+		fields[i] = arrow.Field{Name: name, Type: arr.DataType(), Nullable: true}
+	}
+
+	// NewRecord retains the arrays, the deferred Release drops our reference
+	return array.NewRecord(arrow.NewSchema(fields, nil), arrays, int64(df.Nrows())), nil
+}
+
+// ToArrowRecords converts the DataFrame to a sequence of Arrow record batches sharing one schema,
+// with at most BatchSize rows each (see ToArrowRecord for the type mapping).
+//
+// The caller owns the records and must call Release on each of them when done.
+//
+// Parameters:
+//   - options (optional): The ArrowOption struct to configure the allocator and the batch size.
+//
+// Returns:
+//   - []arrow.Record: The record batches, in row order.
+//   - error: An error if the batch size is negative or a column cannot be converted.
+func (df *DataFrame) ToArrowRecords(options ...ArrowOption) ([]arrow.Record, error) {
+	batchSize := 0
+	if len(options) > 0 {
+		batchSize = options[0].BatchSize
+	}
+	if batchSize < 0 {
+		return nil, fmt.Errorf("BatchSize must be greater than 0, got %d", batchSize)
+	}
+
+	record, err := df.ToArrowRecord(options...)
+	if err != nil {
+		return nil, err
+	}
+	nRows := record.NumRows()
+	if batchSize == 0 || int64(batchSize) >= nRows {
+		return []arrow.Record{record}, nil
+	}
+	defer record.Release()
+
+	records := make([]arrow.Record, 0, (nRows+int64(batchSize)-1)/int64(batchSize))
+	for start := int64(0); start < nRows; start += int64(batchSize) {
+		end := min(start+int64(batchSize), nRows)
+		records = append(records, record.NewSlice(start, end))
+	}
+	return records, nil
+}
+
+// arrowArray builds the Arrow array holding the values of a column
+func arrowArray(mem memory.Allocator, col *Column[any]) (arrow.Array, error) {
+	native := col.native
+	if native == nil {
+		native = toNative(col.Values())
+	}
+
+	switch n := native.(type) {
+	case *nativeColumn[int64]:
+		b := array.NewInt64Builder(mem)
+		defer b.Release()
+		b.AppendValues(n.data, arrowValidity(n.nulls, len(n.data)))
+		return b.NewArray(), nil
+	case *nativeColumn[float64]:
+		b := array.NewFloat64Builder(mem)
+		defer b.Release()
+		b.AppendValues(n.data, arrowValidity(n.nulls, len(n.data)))
+		return b.NewArray(), nil
+	case *nativeColumn[string]:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(n.data, arrowValidity(n.nulls, len(n.data)))
+		return b.NewArray(), nil
+	case *nativeColumn[bool]:
+		b := array.NewBooleanBuilder(mem)
+		defer b.Release()
+		b.AppendValues(n.data, arrowValidity(n.nulls, len(n.data)))
+		return b.NewArray(), nil
+	case *nativeColumn[time.Time]:
+		b := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"})
+		defer b.Release()
+		values := make([]arrow.Timestamp, len(n.data))
+		for i, t := range n.data {
+			values[i] = arrow.Timestamp(t.UnixNano())
+		}
+		b.AppendValues(values, arrowValidity(n.nulls, len(n.data)))
+		return b.NewArray(), nil
+	}
+
+	values := col.Values()
+	for _, v := range values {
+		if v != nil {
+			return nil, fmt.Errorf("values of mixed or unsupported types (found %T)", v)
+		}
+	}
+	b := array.NewNullBuilder(mem)
+	defer b.Release()
+	b.AppendNulls(len(values))
+	return b.NewArray(), nil
+}
+
+// arrowValidity converts a null bitmap to the validity slice used by the Arrow builders
+func arrowValidity(nulls nullBitmap, n int) []bool {
+	if !nulls.hasNulls() {
+		return nil
+	}
+	valid := make([]bool, n)
+	for i := range valid {
+		valid[i] = !nulls.isNull(i)
+	}
+	return valid
+}
+
+// FromArrowRecord creates a DataFrame from an Arrow record batch. Signed integers and unsigned
+// integers up to 32 bits become int64, uint64 stays uint64, floats become float64, strings stay
+// strings, booleans stay bool, timestamps and dates become time.Time and nulls become nil.
+// The record is not released.
+//
+// Parameters:
+//   - record: The Arrow record batch.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame, columns follow the schema order.
+//   - error: An error if a column has an unsupported Arrow type.
+func FromArrowRecord(record arrow.Record) (*DataFrame, error) {
+	return FromArrowRecords([]arrow.Record{record})
+}
+
+// FromArrowRecords creates a DataFrame from a sequence of Arrow record batches sharing one schema,
+// e.g. the chunks of an Arrow Flight stream. Rows keep the order of the batches. See FromArrowRecord
+// for the type mapping. The records are not released.
+//
+// Parameters:
+//   - records: The Arrow record batches.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame.
+//   - error: An error if no record is given, the schemas differ or a column has an unsupported Arrow type.
+func FromArrowRecords(records []arrow.Record) (*DataFrame, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no arrow records provided")
+	}
+
+	schema := records[0].Schema()
+	df := NewDataFrame()
+	for _, field := range schema.Fields() {
+		if _, exists := df.Columns[field.Name]; exists {
+			return nil, fmt.Errorf("column '%s' already exists", field.Name)
+		}
+		df.Columns[field.Name] = &Column[any]{Name: field.Name, Data: []any{}}
+		df.order = append(df.order, field.Name)
+	}
+
+	for r, record := range records {
+		if !record.Schema().Equal(schema) {
+			return nil, fmt.Errorf("record %d has schema %s, expected %s", r, record.Schema(), schema)
+		}
+		for i, field := range schema.Fields() {
+			col := df.Columns[field.Name]
+			values, err := arrowValues(record.Column(i))
+			if err != nil {
+				return nil, fmt.Errorf("error converting column '%s': %w", field.Name, err)
+			}
+			col.Data = append(col.Data, values...)
+		}
+	}
+
+	return df, nil
+}
+
+// FromArrowReader creates a DataFrame from every record batch of an Arrow record reader,
+// e.g. an Arrow IPC or Flight stream reader. See FromArrowRecord for the type mapping.
+//
+// Parameters:
+//   - reader: The Arrow record reader.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame, empty with the reader schema if the stream has no batches.
+//   - error: An error if the stream fails or a column has an unsupported Arrow type.
+func FromArrowReader(reader array.RecordReader) (*DataFrame, error) {
+	var records []arrow.Record
+	defer func() {
+		for _, record := range records {
+			record.Release()
+		}
+	}()
+
+	for reader.Next() {
+		record := reader.Record()
+		record.Retain()
+		records = append(records, record)
+	}
+	if err := reader.Err(); err != nil {
+		return nil, fmt.Errorf("error reading arrow stream: %w", err)
+	}
+
+	if len(records) == 0 {
+		df := NewDataFrame()
+		for _, field := range reader.Schema().Fields() {
+			df.Columns[field.Name] = &Column[any]{Name: field.Name, Data: []any{}}
+			df.order = append(df.order, field.Name)
+		}
+		return df, nil
+	}
+	return FromArrowRecords(records)
+}
+
+// arrowValues converts an Arrow array to boxed values
+func arrowValues(arr arrow.Array) ([]any, error) {
+	values := make([]any, arr.Len())
+	var value func(i int) any
+
+	switch a := arr.(type) {
+	case *array.Null:
+		return values, nil
+	case *array.Int8:
+		value = func(i int) any { return int64(a.Value(i)) }
+	case *array.Int16:
+		value = func(i int) any { return int64(a.Value(i)) }
+	case *array.Int32:
+		value = func(i int) any { return int64(a.Value(i)) }
+	case *array.Int64:
+		value = func(i int) any { return a.Value(i) }
+	case *array.Uint8:
+		value = func(i int) any { return int64(a.Value(i)) }
+	case *array.Uint16:
+		value = func(i int) any { return int64(a.Value(i)) }
+	case *array.Uint32:
+		value = func(i int) any { return int64(a.Value(i)) }
+	case *array.Uint64:
+		value = func(i int) any { return a.Value(i) }
+	case *array.Float32:
+		value = func(i int) any { return float64(a.Value(i)) }
+	case *array.Float64:
+		value = func(i int) any { return a.Value(i) }
+	case *array.String:
+		value = func(i int) any { return a.Value(i) }
+	case *array.LargeString:
+		value = func(i int) any { return a.Value(i) }
+	case *array.Boolean:
+		value = func(i int) any { return a.Value(i) }
+	case *array.Timestamp:
+		toTime, err := a.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return nil, err
+		}
+		value = func(i int) any { return toTime(a.Value(i)) }
+	case *array.Date32:
+		value = func(i int) any { return a.Value(i).ToTime() }
+	case *array.Date64:
+		value = func(i int) any { return a.Value(i).ToTime() }
+	default:
+		return nil, fmt.Errorf("unsupported arrow type %s", arr.DataType())
+	}
+
+	for i := range values {
+		if arr.IsValid(i) {
+			values[i] = value(i)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,261 @@
+package dataframe
+
+/*
+
+	This is where struct binding (DataFrame rows to Go structs) is defined
+
+*/
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// structField describes how a struct field is bound to a DataFrame column
+type structField struct {
+	index    int
+	column   string
+	required bool
+}
+
+// bindFields resolves the struct fields of t against the DataFrame columns.
+//
+// Fields are matched through the `goframe:"column"` tag, or by name (exact first, then case-insensitive)
+// when there is no tag. `goframe:"column,required"` marks a column that must exist and must not hold nil,
+// `goframe:"-"` skips the field. Unexported fields are ignored.
+func bindFields(df *DataFrame, t reflect.Type) ([]structField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot bind rows to %v: not a struct", t)
+	}
+
+	lowerNames := make(map[string]string)
+	for _, name := range df.ColumnNames() {
+		lowerNames[strings.ToLower(name)] = name
+	}
+
+	var fields []structField
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		column, required := f.Name, false
+		if tag, ok := f.Tag.Lookup("goframe"); ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				column = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if strings.TrimSpace(opt) == "required" {
+					required = true
+				}
+			}
+		}
+
+		if _, exists := df.Columns[column]; !exists {
+			if name, ok := lowerNames[strings.ToLower(column)]; ok {
+				column = name
+			} else if required {
+				errs = append(errs, fmt.Errorf("required column '%s' (field %s) does not exist", column, f.Name))
+				continue
+			} else {
+				continue // optional field without a column keeps its zero value
+			}
+		}
+
+		fields = append(fields, structField{index: i, column: column, required: required})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return fields, nil
+}
+
+// bindRow fills the struct value dst with the cells of row i
+func bindRow(df *DataFrame, fields []structField, i int, dst reflect.Value) []error {
+	var errs []error
+	for _, f := range fields {
+		value, _ := df.Columns[f.column].At(i)
+		if value == nil && f.required {
+			errs = append(errs, fmt.Errorf("row %d, column '%s': required value is nil", i, f.column))
+			continue
+		}
+		if err := assignValue(dst.Field(f.index), value); err != nil {
+			errs = append(errs, fmt.Errorf("row %d, column '%s': %w", i, f.column, err))
+		}
+	}
+	return errs
+}
+
+// BindAndValidate converts every row of the DataFrame into a struct of type T, validating the frame
+// against the struct definition. It is meant to turn loosely typed input (CSV uploads, HTTP forms)
+// into validated, typed rows.
+//
+// Fields are matched to columns through the `goframe:"column"` tag or by field name (case-insensitive).
+// Tag options:
+//   - `goframe:"column,required"`: the column must exist and its values must not be nil.
+//   - `goframe:"-"`: the field is skipped.
+//
+// Values are converted to the field type where it is lossless: any number to any numeric field if it fits,
+// numeric and boolean strings to number and bool fields, RFC3339 strings to time.Time fields.
+// Nil values leave the field at its zero value (or nil for pointer fields).
+//
+// Returns:
+//   - []T: One struct per row.
+//   - error: Every validation problem found, joined into a single error with row and column positions.
+func BindAndValidate[T any](df *DataFrame) ([]T, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	fields, err := bindFields(df, t)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, df.Nrows())
+	var errs []error
+	for i := range result {
+		errs = append(errs, bindRow(df, fields, i, reflect.ValueOf(&result[i]).Elem())...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// Rows returns an iterator over the rows of the DataFrame as structs of type T, bound like
+// BindAndValidate but one row at a time, without building a slice of every row:
+//
+//	for employee, err := range Rows[Employee](df) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Ranging with a single variable (for employee := range Rows[Employee](df)) ignores the errors.
+//
+// Returns:
+//   - iter.Seq2[T, error]: The rows in order. A row with invalid values is yielded with its validation
+//     errors joined and the values that could be converted. When T does not match the columns, a single
+//     zero T is yielded with the error.
+func Rows[T any](df *DataFrame) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		fields, err := bindFields(df, reflect.TypeOf(&zero).Elem())
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for i := range df.Nrows() {
+			var row T
+			errs := bindRow(df, fields, i, reflect.ValueOf(&row).Elem())
+			if !yield(row, errors.Join(errs...)) {
+				return
+			}
+		}
+	}
+}
+
+// assignValue converts value to the type of dst and stores it
+func assignValue(dst reflect.Value, value any) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	fail := func() error {
+		return fmt.Errorf("cannot convert %v (%T) to %v", value, value, dst.Type())
+	}
+
+	if dst.Type() == reflect.TypeOf(time.Time{}) {
+		s, ok := value.(string)
+		if !ok {
+			return fail()
+		}
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+		if err != nil {
+			return fail()
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	// numeric and boolean strings are parsed, other strings cannot be converted
+	if s, isString := value.(string); isString {
+		s = strings.TrimSpace(s)
+		switch dst.Kind() {
+		case reflect.Bool:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fail()
+			}
+			dst.SetBool(b)
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return fail()
+			}
+			value = f
+		default:
+			return fail()
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := toFloat(value)
+		if !ok || f != math.Trunc(f) || dst.OverflowInt(int64(f)) {
+			return fail()
+		}
+		dst.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := toFloat(value)
+		if !ok || f < 0 || f != math.Trunc(f) || dst.OverflowUint(uint64(f)) {
+			return fail()
+		}
+		dst.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat(value)
+		if !ok || dst.OverflowFloat(f) {
+			return fail()
+		}
+		dst.SetFloat(f)
+	default:
+		if src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind() {
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+		return fail()
+	}
+	return nil
+}
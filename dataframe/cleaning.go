@@ -1,9 +1,16 @@
 package dataframe
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
-	"sort"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Data Cleaning
@@ -11,79 +18,832 @@ import (
 // FillNa fills missing values in the DataFrame with a specified value
 func (df *DataFrame) FillNa(value any) {
 	for _, col := range df.Columns {
-		for i, v := range col.Data {
+		col.own()
+		data := col.Values()
+		for i, v := range data {
 			if v == nil {
-				col.Data[i] = value
+				data[i] = value
 			}
 		}
+		df.setColumnData(col, data)
 	}
 }
 
-// DropNa removes rows with missing values from the DataFrame
-func (df *DataFrame) DropNa() error {
-	rowsToKeep := []int{}
+// ReplaceInf replaces the +Inf and -Inf values of the DataFrame with a specified value, e.g. nil or math.NaN().
+func (df *DataFrame) ReplaceInf(value any) {
+	for _, col := range df.Columns {
+		col.own()
+		data := col.Values()
+		replaced := false
+		for i, v := range data {
+			if f, ok := v.(float64); ok && math.IsInf(f, 0) {
+				data[i] = value
+				replaced = true
+			} else if f, ok := v.(float32); ok && math.IsInf(float64(f), 0) {
+				data[i] = value
+				replaced = true
+			}
+		}
+		if replaced {
+			df.setColumnData(col, data)
+		}
+	}
+}
+
+// FillOption configures FillNaForward, FillNaBackward, FillNaMap and Interpolate.
+//
+// Fields:
+//   - Columns: The columns to fill. Defaults to every column except the index columns (see SetIndex),
+//     only the numeric ones for Interpolate.
+//   - Limit: The maximum number of consecutive missing values filled in each gap, 0 for no limit.
+//   - TimeColumn: The time.Time column giving the position of each row for Interpolate("time").
+//     Defaults to the index column. The grouped FFill and BFill fill each group in the order of this column.
+type FillOption struct {
+	Columns    []string
+	Limit      int
+	TimeColumn string
+}
+
+// isMissing reports whether a value is missing for the fill methods and DropNa: nil or NaN
+func isMissing(v any) bool {
+	return v == nil || isNaNValue(v)
+}
 
-	for i := 0; i < df.Nrows(); i++ {
-		row, err := df.Row(i)
-		if err != nil {
-			return fmt.Errorf("failed to select row:%v, %v", err, err)
+// fillOptions validates the options of a fill method and returns the columns to fill
+func (df *DataFrame) fillOptions(options []FillOption) (FillOption, []string, error) {
+	var opts FillOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Limit < 0 {
+		return opts, nil, fmt.Errorf("limit must not be negative, got %d", opts.Limit)
+	}
+	for _, name := range opts.Columns {
+		if _, exists := df.Columns[name]; !exists {
+			return opts, nil, fmt.Errorf("column '%s' does not exist", name)
 		}
-		hasNa := false
-		for _, v := range row {
-			if v == nil {
-				hasNa = true
+	}
+	if len(opts.Columns) > 0 {
+		return opts, opts.Columns, nil
+	}
+	index := df.IndexNames()
+	var columns []string
+	for _, name := range df.ColumnNames() {
+		if !slices.Contains(index, name) {
+			columns = append(columns, name)
+		}
+	}
+	return opts, columns, nil
+}
+
+// fillColumn replaces the values of a column with the result of fill, which gets a copy of the values
+func (df *DataFrame) fillColumn(name string, fill func(values []any)) {
+	col := df.Columns[name]
+	col.own()
+	data := col.Values()
+	fill(data)
+	df.setColumnData(col, data)
+}
+
+// FillNaForward fills the missing values (nil or NaN) of the DataFrame in place with the last value
+// before them (ffill). Missing values at the start of a column stay missing.
+//
+// Parameters:
+//   - options (optional): The FillOption struct with the columns to fill and the limit.
+//
+// Returns:
+//   - error: An error if a column does not exist or the limit is negative.
+func (df *DataFrame) FillNaForward(options ...FillOption) error {
+	opts, columns, err := df.fillOptions(options)
+	if err != nil {
+		return err
+	}
+	for _, name := range columns {
+		df.fillColumn(name, func(values []any) {
+			fillForward(values, opts.Limit, false)
+		})
+	}
+	return nil
+}
+
+// FillNaBackward fills the missing values (nil or NaN) of the DataFrame in place with the next value
+// after them (bfill). Missing values at the end of a column stay missing.
+//
+// Parameters:
+//   - options (optional): The FillOption struct with the columns to fill and the limit.
+//
+// Returns:
+//   - error: An error if a column does not exist or the limit is negative.
+func (df *DataFrame) FillNaBackward(options ...FillOption) error {
+	opts, columns, err := df.fillOptions(options)
+	if err != nil {
+		return err
+	}
+	for _, name := range columns {
+		df.fillColumn(name, func(values []any) {
+			fillForward(values, opts.Limit, true)
+		})
+	}
+	return nil
+}
+
+// fillForward propagates the last value over the missing values, from the end when backward is set
+func fillForward(values []any, limit int, backward bool) {
+	var last any
+	gap := 0
+	for k := range values {
+		i := k
+		if backward {
+			i = len(values) - 1 - k
+		}
+		if !isMissing(values[i]) {
+			last, gap = values[i], 0
+			continue
+		}
+		gap++
+		if last != nil && (limit == 0 || gap <= limit) {
+			values[i] = last
+		}
+	}
+}
+
+// FillNaMap fills the missing values (nil or NaN) of each column in place with its own value,
+// e.g. FillNaMap(map[string]any{"qty": 0, "region": "unknown"}).
+//
+// Parameters:
+//   - values: The fill value of each column, other columns are not modified.
+//   - options (optional): The FillOption struct with the limit, its Columns field is not used.
+//
+// Returns:
+//   - error: An error if a column does not exist or the limit is negative.
+func (df *DataFrame) FillNaMap(values map[string]any, options ...FillOption) error {
+	var opts FillOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	opts.Columns = slices.Sorted(maps.Keys(values))
+	opts, columns, err := df.fillOptions([]FillOption{opts})
+	if err != nil {
+		return err
+	}
+	for _, name := range columns {
+		df.fillColumn(name, func(data []any) {
+			gap := 0
+			for i, v := range data {
+				if !isMissing(v) {
+					gap = 0
+					continue
+				}
+				gap++
+				if opts.Limit == 0 || gap <= opts.Limit {
+					data[i] = values[name]
+				}
+			}
+		})
+	}
+	return nil
+}
+
+// Interpolate fills the missing values (nil or NaN) of numeric columns in place by linear
+// interpolation between the values around them. Only the gaps between two values are filled, the
+// missing values at the start and the end of a column stay missing. The filled values are float64.
+//
+// Parameters:
+//   - method: "linear" treats the rows as equally spaced, "time" spaces them by the time.Time values
+//     of FillOption.TimeColumn (the index column by default), for irregular time series.
+//   - options (optional): The FillOption struct with the columns to fill, the limit and the time column.
+//
+// Returns:
+//   - error: An error if the method is unknown, a column does not exist, a listed column is not
+//     numeric or the time column is missing or holds other values than time.Time.
+func (df *DataFrame) Interpolate(method string, options ...FillOption) error {
+	if method != "linear" && method != "time" {
+		return fmt.Errorf("unknown interpolation method: %s (must be 'linear' or 'time')", method)
+	}
+	opts, columns, err := df.fillOptions(options)
+	if err != nil {
+		return err
+	}
+
+	// positions of the rows, the row numbers or the times
+	positions := make([]float64, df.Nrows())
+	for i := range positions {
+		positions[i] = float64(i)
+	}
+	if method == "time" {
+		timeColumn := opts.TimeColumn
+		if timeColumn == "" {
+			if index := df.IndexNames(); len(index) == 1 {
+				timeColumn = index[0]
+			} else {
+				return fmt.Errorf("time interpolation needs a time column, set FillOption.TimeColumn or SetIndex")
+			}
+		}
+		col, exists := df.Columns[timeColumn]
+		if !exists {
+			return fmt.Errorf("column '%s' does not exist", timeColumn)
+		}
+		for i, v := range col.Values() {
+			t, ok := v.(time.Time)
+			if !ok {
+				return fmt.Errorf("time column '%s' has a value that is not a time.Time at row %d: %v", timeColumn, i, v)
+			}
+			positions[i] = float64(t.UnixNano())
+		}
+		columns = slices.DeleteFunc(slices.Clone(columns), func(name string) bool { return name == timeColumn })
+	}
+
+	numeric := make(map[string][]float64)
+	for _, name := range columns {
+		values := df.Columns[name].Values()
+		nums := make([]float64, len(values))
+		for i, v := range values {
+			f, ok := toFloat(v)
+			if isMissing(v) {
+				f, ok = math.NaN(), true
+			}
+			if !ok {
+				if len(opts.Columns) > 0 {
+					return fmt.Errorf("cannot interpolate column '%s': value %v at row %d is not numeric", name, v, i)
+				}
+				nums = nil
 				break
 			}
+			nums[i] = f
+		}
+		if nums != nil {
+			numeric[name] = nums
+		}
+	}
+
+	for _, name := range columns {
+		nums, ok := numeric[name]
+		if !ok {
+			continue
+		}
+		df.fillColumn(name, func(values []any) {
+			interpolate(nums, positions, opts.Limit)
+			for i, f := range nums {
+				if isMissing(values[i]) && !math.IsNaN(f) {
+					values[i] = f
+				}
+			}
+		})
+	}
+	return nil
+}
+
+// interpolate fills the NaN values between two numbers in place, at the given positions
+func interpolate(nums, positions []float64, limit int) {
+	previous := -1
+	for i, f := range nums {
+		if math.IsNaN(f) {
+			continue
+		}
+		if previous >= 0 && i-previous > 1 {
+			x0, x1 := positions[previous], positions[i]
+			y0, y1 := nums[previous], f
+			for k := previous + 1; k < i && (limit == 0 || k-previous <= limit); k++ {
+				nums[k] = y0 + (y1-y0)*(positions[k]-x0)/(x1-x0)
+			}
 		}
-		if !hasNa {
+		previous = i
+	}
+}
+
+// DropNaOption configures DropNa.
+//
+// Fields:
+//   - Axis: 0 (the default) drops rows, 1 drops columns.
+//   - How: "any" (the default) drops a row or column holding at least one missing value, "all" only
+//     drops the ones holding missing values only.
+//   - Subset: The columns to look at for missing values, every column by default. With Axis 1, the
+//     columns that may be dropped.
+//   - Thresh: When set, keeps the rows or columns with at least Thresh non-missing values instead of using How.
+type DropNaOption struct {
+	Axis   int
+	How    string
+	Subset []string
+	Thresh int
+}
+
+// DropNa removes the rows (or columns) with missing values from the DataFrame in place. A value
+// is missing if it is nil or a float NaN, as for FillNa and Info. Without options, every row
+// holding a missing value is removed.
+//
+// Parameters:
+//   - options (optional): The DropNaOption struct with the axis, the columns to look at and the
+//     number of missing values that drops a row or column.
+//
+// Returns:
+//   - error: An error if the axis or How is invalid, Thresh is negative or a column of Subset does not exist.
+func (df *DataFrame) DropNa(options ...DropNaOption) error {
+	var opts DropNaOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Axis != 0 && opts.Axis != 1 {
+		return fmt.Errorf("axis must be 0 or 1, got %d", opts.Axis)
+	}
+	if opts.How == "" {
+		opts.How = "any"
+	}
+	if opts.How != "any" && opts.How != "all" {
+		return fmt.Errorf("unknown how: %s (must be 'any' or 'all')", opts.How)
+	}
+	if opts.Thresh < 0 {
+		return fmt.Errorf("thresh must not be negative, got %d", opts.Thresh)
+	}
+	for _, name := range opts.Subset {
+		if _, exists := df.Columns[name]; !exists {
+			return fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+	columns := opts.Subset
+	if len(columns) == 0 {
+		columns = df.ColumnNames()
+	}
+
+	// keep reports whether a row or column with present non-missing values out of total is kept
+	keep := func(present, total int) bool {
+		switch {
+		case opts.Thresh > 0:
+			return present >= opts.Thresh
+		case opts.How == "all":
+			return present > 0 || total == 0
+		default:
+			return present == total
+		}
+	}
+
+	if opts.Axis == 1 {
+		for _, name := range columns {
+			present := 0
+			for _, v := range df.Columns[name].Values() {
+				if !isMissing(v) {
+					present++
+				}
+			}
+			if !keep(present, df.Nrows()) {
+				_ = df.DropColumn(name)
+			}
+		}
+		return nil
+	}
+
+	present := make([]int, df.Nrows())
+	for _, name := range columns {
+		for i, v := range df.Columns[name].Values() {
+			if !isMissing(v) {
+				present[i]++
+			}
+		}
+	}
+	rowsToKeep := []int{}
+	for i, count := range present {
+		if keep(count, len(columns)) {
 			rowsToKeep = append(rowsToKeep, i)
 		}
 	}
+	if len(rowsToKeep) == df.Nrows() {
+		return nil
+	}
 
 	for _, col := range df.Columns {
-		newData := []any{}
-		for _, idx := range rowsToKeep {
-			newData = append(newData, col.Data[idx])
+		values := col.Values()
+		newData := make([]any, len(rowsToKeep))
+		for i, idx := range rowsToKeep {
+			newData[i] = values[idx]
 		}
-		col.Data = newData
+		df.setColumnData(col, newData)
 	}
 
 	return nil
 }
 
-// Astype converts the data type of a column
-func (df *DataFrame) Astype(columnName string, targetType string) error {
+// EmptyColumns returns the columns holding only missing values (nil or NaN), including columns without rows.
+//
+// Returns:
+//   - []string: The names of the empty columns, in column order.
+func (df *DataFrame) EmptyColumns() []string {
+	empty := []string{}
+	for _, name := range df.ColumnNames() {
+		if distinctValues(df.Columns[name], 1) == 0 {
+			empty = append(empty, name)
+		}
+	}
+	return empty
+}
+
+// ConstantColumns returns the columns holding a single distinct value, missing values (nil or NaN)
+// are ignored. Numbers are compared numerically, so 1 and 1.0 are the same value.
+//
+// Returns:
+//   - []string: The names of the constant columns, in column order. Empty columns are not included (see EmptyColumns).
+func (df *DataFrame) ConstantColumns() []string {
+	constant := []string{}
+	for _, name := range df.ColumnNames() {
+		if distinctValues(df.Columns[name], 2) == 1 {
+			constant = append(constant, name)
+		}
+	}
+	return constant
+}
+
+// DropConstant removes the constant and the empty columns, which carry no information
+// for feature selection (see ConstantColumns and EmptyColumns).
+//
+// Returns:
+//   - []string: The names of the removed columns, in column order.
+func (df *DataFrame) DropConstant() []string {
+	return df.DropColumnsIf(func(_ string, col *Column[any]) bool {
+		return distinctValues(col, 2) <= 1
+	})
+}
+
+// distinctValues counts the distinct non-missing values of a column, stopping once limit is reached
+func distinctValues(col *Column[any], limit int) int {
+	var seen []any
+	for _, v := range col.Values() {
+		if v == nil || isNaNValue(v) {
+			continue
+		}
+		if !slices.ContainsFunc(seen, func(s any) bool { return sameValue(s, v) }) {
+			seen = append(seen, v)
+			if len(seen) >= limit {
+				break
+			}
+		}
+	}
+	return len(seen)
+}
+
+// sameValue reports whether two values are equal, numbers are compared numerically
+func sameValue(a, b any) bool {
+	if cmp, ok := compareValues(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// AstypeOption is the parameters we can set to the Astype method.
+//
+// Fields:
+//   - Errors: "raise" (the default) fails on the first value that cannot be converted and leaves the
+//     column unchanged, "coerce" converts such values to nil.
+//   - Layout: The time layout (see time.Parse) used to parse strings into "time" values and to format
+//     time values into strings. Strings are parsed with the built-in layouts of ReadCSV's ParseDates
+//     (RFC 3339, "2006-01-02", ...) when it is empty.
+type AstypeOption struct {
+	Errors string
+	Layout string
+}
+
+// Astype converts the data type of a column. Nil values stay nil, so any target type is nullable.
+//
+// The target types are:
+//   - "int", "int64": Integers are converted if they fit, floats are truncated toward zero, bools give
+//     0 or 1 and strings are parsed, e.g. "42".
+//   - "float64", "float32": Numbers are converted, bools give 0 or 1 and strings are parsed, e.g. "4.2".
+//   - "bool": Numbers give true when they are not 0 and strings are parsed like strconv.ParseBool ("true", "0", "F", ...).
+//   - "time": Strings are parsed with the Layout of the options and numbers are Unix timestamps, in UTC.
+//   - "string": Values are printed with fmt, time values with the Layout of the options when it is set.
+//
+// Parameters:
+//   - columnName: The column to convert.
+//   - targetType: The type to convert the values to.
+//   - options (optional): The AstypeOption struct to coerce invalid values to nil and set the time layout.
+//
+// Returns:
+//   - error: An error if the column does not exist, the target type or error mode is unknown,
+//     or a value cannot be converted when Errors is "raise".
+func (df *DataFrame) Astype(columnName string, targetType string, options ...AstypeOption) error {
+	var opts AstypeOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Errors == "" {
+		opts.Errors = "raise"
+	}
+	if opts.Errors != "raise" && opts.Errors != "coerce" {
+		return fmt.Errorf("unknown errors mode: %s (must be 'raise' or 'coerce')", opts.Errors)
+	}
 	col, exists := df.Columns[columnName]
 	if !exists {
 		return fmt.Errorf("column '%s' does not exist", columnName)
 	}
+	if !slices.Contains([]string{"int", "int64", "float64", "float32", "bool", "time", "string"}, targetType) {
+		return fmt.Errorf("unsupported target type '%s'", targetType)
+	}
 
-	newData := make([]any, len(col.Data))
-	for i, v := range col.Data {
-		switch targetType {
-		case "int":
-			if floatVal, ok := v.(float64); ok {
-				newData[i] = int(floatVal)
-			} else {
-				return fmt.Errorf("cannot convert value '%v' of type %T to int", v, v)
-			}
-		case "float64":
-			if intVal, ok := v.(int); ok {
-				newData[i] = float64(intVal)
-			} else {
-				return fmt.Errorf("cannot convert value '%v' of type %T to float64", v, v)
+	values := col.Values()
+	newData, failed := astypeValues(values, targetType, opts)
+	if len(failed) > 0 && opts.Errors == "raise" {
+		v := values[failed[0]]
+		return fmt.Errorf("cannot convert value '%v' of type %T to %s at row %d", v, v, targetType, failed[0])
+	}
+
+	df.setColumnData(col, newData)
+	return nil
+}
+
+// astypeValues converts the values of a column for Astype, the values that cannot be converted
+// become nil and their rows are returned
+func astypeValues(values []any, targetType string, opts AstypeOption) ([]any, []int) {
+	newData := make([]any, len(values))
+	var failed []int
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		converted, ok := astypeValue(v, targetType, opts.Layout)
+		if !ok {
+			failed = append(failed, i)
+			continue
+		}
+		newData[i] = converted
+	}
+	return newData, failed
+}
+
+// AstypeMap converts several columns at once, e.g. after loading a CSV file:
+//
+//	df.AstypeMap(map[string]string{"age": "int64", "price": "float64", "date": "time"})
+//
+// Every column is converted before any is replaced, so the DataFrame is left unchanged on error.
+// See Astype for the target types.
+//
+// Parameters:
+//   - types: The target type of each column to convert.
+//   - options (optional): The AstypeOption struct to coerce invalid values to nil and set the time layout,
+//     applied to every column.
+//
+// Returns:
+//   - error: An error listing every column that does not exist, has an unknown target type or, when
+//     Errors is "raise", a value that cannot be converted, with the first such value and the number of them.
+func (df *DataFrame) AstypeMap(types map[string]string, options ...AstypeOption) error {
+	var opts AstypeOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Errors == "" {
+		opts.Errors = "raise"
+	}
+	if opts.Errors != "raise" && opts.Errors != "coerce" {
+		return fmt.Errorf("unknown errors mode: %s (must be 'raise' or 'coerce')", opts.Errors)
+	}
+
+	// the columns in the order of the DataFrame, so the errors are reported in a stable order
+	names := slices.Sorted(maps.Keys(types))
+	slices.SortStableFunc(names, func(a, b string) int {
+		return cmp.Compare(df.columnPosition(a), df.columnPosition(b))
+	})
+
+	converted := make(map[string][]any, len(types))
+	var errs []error
+	for _, name := range names {
+		targetType := types[name]
+		col, exists := df.Columns[name]
+		if !exists {
+			errs = append(errs, fmt.Errorf("column '%s' does not exist", name))
+			continue
+		}
+		if !slices.Contains([]string{"int", "int64", "float64", "float32", "bool", "time", "string"}, targetType) {
+			errs = append(errs, fmt.Errorf("column '%s': unsupported target type '%s'", name, targetType))
+			continue
+		}
+		values := col.Values()
+		newData, failed := astypeValues(values, targetType, opts)
+		if len(failed) > 0 && opts.Errors == "raise" {
+			v := values[failed[0]]
+			err := fmt.Errorf("column '%s': cannot convert value '%v' of type %T to %s at row %d", name, v, v, targetType, failed[0])
+			if len(failed) > 1 {
+				err = fmt.Errorf("%w (and %d more values)", err, len(failed)-1)
 			}
-		case "string":
-			newData[i] = fmt.Sprintf("%v", v)
-		default:
-			return fmt.Errorf("unsupported target type '%s'", targetType)
+			errs = append(errs, err)
+			continue
 		}
+		converted[name] = newData
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
 
-	col.Data = newData
+	for _, name := range names {
+		df.setColumnData(df.Columns[name], converted[name])
+	}
 	return nil
 }
 
+// columnPosition returns the position of a column in ColumnNames, or the number of columns for a missing column
+func (df *DataFrame) columnPosition(name string) int {
+	if i := slices.Index(df.ColumnNames(), name); i >= 0 {
+		return i
+	}
+	return len(df.Columns)
+}
+
+// InferDtypes returns the narrowest type holding every value of each column, for the columns with
+// values of other types, e.g. {"age": "int64"} for a column of "42" strings read from a file.
+// The candidate types are tried in order:
+//   - "int64": integers of any size, floats without a fractional part and strings parsing as integers.
+//   - "float64": numbers and strings parsing as numbers.
+//   - "bool": booleans and strings parsing as booleans ("true", "F", ...).
+//   - "time": time.Time values and strings parsing as dates, see Astype.
+//   - "string": strings.
+//
+// Nil and NaN values are missing and fit every type. Columns with values of several kinds
+// (e.g. numbers and words), or no values at all, are left out.
+//
+// Returns:
+//   - map[string]string: The target type of each column to convert, for AstypeMap.
+func (df *DataFrame) InferDtypes() map[string]string {
+	plan := make(map[string]string)
+	for name, col := range df.Columns {
+		values := col.Values()
+		for _, dtype := range []string{"int64", "float64", "bool", "time", "string"} {
+			fits, changes, present := true, false, false
+			for _, v := range values {
+				if isMissing(v) {
+					// NaN is not an integer
+					changes = changes || (v != nil && dtype == "int64")
+					continue
+				}
+				present = true
+				if !fitsDtype(v, dtype) {
+					fits = false
+					break
+				}
+				changes = changes || !hasDtype(v, dtype)
+			}
+			if !present {
+				break
+			}
+			if fits {
+				if changes {
+					plan[name] = dtype
+				}
+				break
+			}
+		}
+	}
+	return plan
+}
+
+// fitsDtype reports whether a non-missing value can be converted to a type of InferDtypes without loss
+func fitsDtype(v any, dtype string) bool {
+	if s, ok := v.(string); ok {
+		s = strings.TrimSpace(s)
+		var err error
+		switch dtype {
+		case "int64":
+			_, err = strconv.ParseInt(s, 10, 64)
+		case "float64":
+			_, err = strconv.ParseFloat(s, 64)
+		case "bool":
+			_, err = strconv.ParseBool(s)
+		case "time":
+			_, err = parseDateValue(s, defaultDateLayouts, time.UTC)
+		}
+		return err == nil
+	}
+
+	switch dtype {
+	case "int64":
+		f, ok := astypeNumber(v)
+		return ok && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+	case "float64":
+		_, ok := astypeNumber(v)
+		return ok
+	default:
+		return hasDtype(v, dtype)
+	}
+}
+
+// hasDtype reports whether a value already has the Go type of a type of InferDtypes
+func hasDtype(v any, dtype string) bool {
+	switch v.(type) {
+	case int64:
+		return dtype == "int64"
+	case float64:
+		return dtype == "float64"
+	case bool:
+		return dtype == "bool"
+	case time.Time:
+		return dtype == "time"
+	case string:
+		return dtype == "string"
+	}
+	return false
+}
+
+// ConvertDtypes converts the columns to the narrowest types holding their values, see InferDtypes,
+// e.g. to fix the schema of a DataFrame loaded from text in one call. NaN values of columns converted
+// to "int64" become nil.
+//
+// Returns:
+//   - map[string]string: The conversions applied, the target type of each converted column.
+//   - error: An error if a column cannot be converted, then the DataFrame is not modified.
+func (df *DataFrame) ConvertDtypes() (map[string]string, error) {
+	plan := df.InferDtypes()
+	// every value fits its type, only the NaN values of integer columns are coerced
+	if err := df.AstypeMap(plan, AstypeOption{Errors: "coerce"}); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// astypeValue converts a non-nil value for Astype, it returns false if the value cannot be converted
+func astypeValue(v any, targetType string, layout string) (any, bool) {
+	switch targetType {
+	case "string":
+		if t, ok := v.(time.Time); ok && layout != "" {
+			return t.Format(layout), true
+		}
+		return fmt.Sprintf("%v", v), true
+
+	case "time":
+		layouts := defaultDateLayouts
+		if layout != "" {
+			layouts = []string{layout}
+		}
+		if s, ok := v.(string); ok {
+			v = strings.TrimSpace(s)
+		}
+		t, err := parseDateValue(v, layouts, time.UTC)
+		return t, err == nil
+
+	case "bool":
+		switch value := v.(type) {
+		case bool:
+			return value, true
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(value))
+			return b, err == nil
+		}
+		if f, ok := astypeNumber(v); ok {
+			return f != 0, true
+		}
+		return nil, false
+	}
+
+	// numeric targets
+	if s, ok := v.(string); ok {
+		s = strings.TrimSpace(s)
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			v = n
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+			v = f
+		} else {
+			return nil, false
+		}
+		// "4.2" is not an integer
+		if f, isFloat := v.(float64); isFloat && (targetType == "int" || targetType == "int64") && f != math.Trunc(f) {
+			return nil, false
+		}
+	}
+	if b, ok := v.(bool); ok {
+		v = 0
+		if b {
+			v = 1
+		}
+	}
+	value := reflect.ValueOf(v)
+	if !isNumericKind(value.Kind()) {
+		return nil, false
+	}
+	switch targetType {
+	case "float64":
+		return value.Convert(reflect.TypeFor[float64]()).Interface(), true
+	case "float32":
+		return value.Convert(reflect.TypeFor[float32]()).Interface(), true
+	}
+	if value.CanFloat() {
+		f := math.Trunc(value.Float())
+		if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return nil, false
+		}
+		value = reflect.ValueOf(int64(f))
+	}
+	if value.CanUint() && value.Uint() > math.MaxInt64 {
+		return nil, false
+	}
+	n := value.Convert(reflect.TypeFor[int64]()).Int()
+	if targetType == "int" {
+		return int(n), true
+	}
+	return n, true
+}
+
+// astypeNumber returns a number of any type as a float64, without parsing strings like toFloat
+func astypeNumber(v any) (float64, bool) {
+	value := reflect.ValueOf(v)
+	if !isNumericKind(value.Kind()) {
+		return 0, false
+	}
+	return value.Convert(reflect.TypeFor[float64]()).Float(), true
+}
+
 // DropDuplicatesOption is the parameters we can set to the DropDuplicates method.
 //
 // Fields:
@@ -100,6 +860,7 @@ type DropDuplicatesOption struct {
 
 // DropDuplicates is the method where users can drop duplicate values in the dataframe.
 // It will return a cloned DataFrame by default and only keep the FIRST occurence of the duplicate value.
+// Rows are compared like Duplicated does.
 //
 // Parameters:
 //   - options: The DropDuplicatesOption struct to optionally add parameters to this method.
@@ -107,164 +868,122 @@ type DropDuplicatesOption struct {
 // Returns:
 //   - *DataFrame: The original dataframe with no duplicates if the inplace option in DropDuplicatesOption is true.
 //   - *DataFrame (cloned): A cloned DataFrame if the inplace option in DropDuplicatesOption is false.
+//   - error: An error if a column of the subset does not exist or keep is unknown.
 func (df *DataFrame) DropDuplicates(options ...DropDuplicatesOption) (*DataFrame, error) {
-	var colNames []string
-	finalOptions := DropDuplicatesOption{Keep: "first", Inplace: false}
-	newDf := NewDataFrame()
-
+	var opts DropDuplicatesOption
 	if len(options) > 0 {
-		userOpt := options[0]
+		opts = options[0]
+	}
 
-		// only overwrite Subset if user provided one
-		if len(userOpt.Subset) > 0 {
-			finalOptions.Subset = userOpt.Subset
+	duplicated, err := df.Duplicated(opts.Subset, opts.Keep)
+	if err != nil {
+		return nil, err
+	}
+	indexesToKeep := []int{}
+	for i, dup := range duplicated.Data {
+		if !dup.(bool) {
+			indexesToKeep = append(indexesToKeep, i)
 		}
+	}
 
-		// only overwrite Keep if user provided one (not empty)
-		if userOpt.Keep != "" {
-			finalOptions.Keep = userOpt.Keep
+	if !opts.Inplace {
+		return df.takeRows(indexesToKeep), nil
+	}
+	if len(indexesToKeep) == df.Nrows() {
+		return df, nil
+	}
+	for _, col := range df.Columns {
+		values := col.Values()
+		newData := make([]any, len(indexesToKeep))
+		for i, index := range indexesToKeep {
+			newData[i] = values[index]
 		}
-
-		finalOptions.Inplace = userOpt.Inplace
+		df.setColumnData(col, newData)
 	}
+	return df, nil
+}
 
-	if len(finalOptions.Subset) > 0 {
-		// if the options has columns to specifically target
-		colNames = finalOptions.Subset
-	} else {
-		// else just do it for all columns
-		colNames = df.ColumnNames()
+// Duplicated marks the rows that repeat an other row on the given columns. Values are compared like
+// ValueCounts does: numbers regardless of their type (1 equals 1.0), NaN values equal each other and
+// nil values equal each other, but 1 and "1" differ. Rows are hashed, so this runs in linear time.
+//
+// Parameters:
+//   - subset: The columns to compare, every column if empty.
+//   - keep: The occurence that is not marked: "first" (the default if empty), "last", or "none"
+//     to mark every row that has a duplicate.
+//
+// Returns:
+//   - *Series: A boolean Series named "duplicated", true for the duplicate rows.
+//   - error: An error if a column of the subset does not exist or keep is unknown.
+func (df *DataFrame) Duplicated(subset []string, keep string) (*Series, error) {
+	if keep == "" {
+		keep = "first"
+	}
+	if keep != "first" && keep != "last" && keep != "none" {
+		return nil, fmt.Errorf("unknown keep: %s (must be 'first', 'last' or 'none')", keep)
+	}
+	if len(subset) == 0 {
+		subset = df.ColumnNames()
+	}
+	codes, err := df.rowCodes(subset)
+	if err != nil {
+		return nil, err
 	}
 
-	// find which indexes in a column to keep (which rows to keep), prob create another helper function to do this
-	seen := make(map[string]bool)
-	indexesToKeep := []int{}
-
-	switch finalOptions.Keep {
+	duplicated := make([]any, len(codes))
+	switch keep {
 	case "first":
-		// logic for finalOptions.keep = "first"
-		for i := 0; i < df.Nrows(); i++ {
-			rowKey, err := df.getRowKey(i, colNames)
-			if err != nil {
-				return nil, fmt.Errorf("Error, could not get row key: %v", err)
-			}
-			if !seen[rowKey] {
-				seen[rowKey] = true
-				indexesToKeep = append(indexesToKeep, i)
-			}
+		seen := make(map[int]bool, len(codes))
+		for i, code := range codes {
+			duplicated[i] = seen[code]
+			seen[code] = true
 		}
-
 	case "last":
-		// logic for finalOptions.keep = "last"
-		for i := df.Nrows() - 1; i >= 0; i-- {
-			rowKey, err := df.getRowKey(i, colNames)
-			if err != nil {
-				return nil, fmt.Errorf("Error, could not get row key: %v", err)
-			}
-			if !seen[rowKey] {
-				seen[rowKey] = true
-				indexesToKeep = append(indexesToKeep, i)
-			}
+		seen := make(map[int]bool, len(codes))
+		for i := len(codes) - 1; i >= 0; i-- {
+			duplicated[i] = seen[codes[i]]
+			seen[codes[i]] = true
 		}
-		sort.Ints(indexesToKeep)
-
 	case "none":
-		// logic for finalOptions.keep = "none"
-
-		// count all the times a specific row has occured before
-		counts := make(map[string]int)
-		for i := 0; i < df.Nrows(); i++ {
-			key, err := df.getRowKey(i, colNames)
-			if err != nil {
-				return nil, fmt.Errorf("Error, could not get row key: %v", err)
-			}
-			counts[key]++
-		}
-
-		// only keep the indexes where the count == 1
-		for i := 0; i < df.Nrows(); i++ {
-			key, err := df.getRowKey(i, colNames)
-			if err != nil {
-				return nil, fmt.Errorf("Error, could not get row key: %v", err)
-			}
-			if counts[key] == 1 {
-				indexesToKeep = append(indexesToKeep, i)
-			}
-		}
-
-	}
-
-	for _, colName := range df.ColumnNames() {
-		// iterate through each column and get the indexes
-		// then directly change it in the current dataframe
-		// or add the new data to a new dataframe to return.
-		newData, err := df.getSubSlice(colName, indexesToKeep)
-
-		if err != nil {
-			return nil, fmt.Errorf("Error, could not get sub slice: %v", err)
+		counts := make(map[int]int, len(codes))
+		for _, code := range codes {
+			counts[code]++
 		}
-
-		// use inplace option to check whether we want to clone or modify the current dataframe
-		if finalOptions.Inplace {
-			// replace the existing data with the new data
-			df.Columns[colName].Data = newData
-		} else {
-			err := newDf.AddColumn(ConvertToAnyColumn(NewColumn(colName, newData)))
-			if err != nil {
-				return nil, fmt.Errorf("Error trying to add column to new DataFrame: %v", err)
-			}
+		for i, code := range codes {
+			duplicated[i] = counts[code] > 1
 		}
 	}
-
-	if finalOptions.Inplace {
-		return df, nil
-	}
-
-	return newDf, nil
-	// return the DataFrame
+	return NewSeries("duplicated", duplicated), nil
 }
 
-// getRowKey is a helper function to return a unique key for a row
-func (df *DataFrame) getRowKey(rowIndex int, colNames []string) (string, error) {
-
-	var builder strings.Builder
-
-	for _, name := range colNames {
+// rowCodes numbers the distinct combinations of values of the columns, equal rows get the same code.
+// The codes of each column are combined with the codes of the previous ones, so no row key is built.
+func (df *DataFrame) rowCodes(colNames []string) ([]int, error) {
+	codes := make([]int, df.Nrows())
+	for c, name := range colNames {
 		col, ok := df.Columns[name]
 		if !ok {
-			return "", fmt.Errorf("Column %s not found", name)
+			return nil, fmt.Errorf("column '%s' does not exist", name)
 		}
-		value := col.Data[rowIndex]
-
-		// add the col name to prevent similar values but different column cases
-		builder.WriteString(name)
-		builder.WriteString(":")
-
-		if value == nil {
-			builder.WriteString("nil")
-		} else {
-			builder.WriteString(fmt.Sprintf("%v", value))
+		values := make(map[any]int)
+		pairs := make(map[[2]int]int)
+		for i, v := range col.Values() {
+			code := factorize(values, valueKey(v))
+			if c > 0 {
+				code = factorize(pairs, [2]int{codes[i], code})
+			}
+			codes[i] = code
 		}
-
-		builder.WriteString("|")
 	}
-
-	return builder.String(), nil
+	return codes, nil
 }
 
-// getSubSlice is a method get a portion of an existing column and returns a slice
-func (df *DataFrame) getSubSlice(colName string, indexesToKeep []int) ([]any, error) {
-	col, ok := df.Columns[colName]
+// factorize returns the code of a key, numbering keys in the order they are first seen
+func factorize[K comparable](codes map[K]int, key K) int {
+	code, ok := codes[key]
 	if !ok {
-		return nil, fmt.Errorf("Column %s not found", colName)
+		code = len(codes)
+		codes[key] = code
 	}
-
-	finalRows := make([]any, len(indexesToKeep))
-
-	for i, index := range indexesToKeep {
-		// use direct assignment instead of appending because appending costs more
-		finalRows[i] = col.Data[index]
-	}
-
-	return finalRows, nil
+	return code
 }
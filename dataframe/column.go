@@ -8,6 +8,8 @@ package dataframe
 
 import (
 	"fmt"
+	"slices"
+	"sync/atomic"
 )
 
 // Column represents a typed column in the DataFrame
@@ -15,6 +17,10 @@ import (
 type Column[T any] struct {
 	Name string
 	Data []T
+
+	encoded *columnEncoding[T] // set while the column is compressed, see Compress
+	native  nativeStorage      // set while the column is stored natively, see NewTypedDataFrame
+	shared  atomic.Bool        // set while the rows may be shared with a view, see Slice
 }
 
 // AddTypedColumn adds a typed column to the DataFrame.
@@ -33,22 +39,103 @@ func AddTypedColumn[T any](df *DataFrame, col *Column[T]) error {
 
 // Len returns the length of the column
 func (c *Column[T]) Len() int {
+	if c.encoded != nil {
+		return c.encoded.length
+	}
+	if c.native != nil {
+		return c.native.length()
+	}
 	return len(c.Data)
 }
 
 // At returns the value at the given index
 func (c *Column[T]) At(index int) (T, error) {
-	if index < 0 || index >= len(c.Data) {
+	if index < 0 || index >= c.Len() {
 		var zero T
 		return zero, fmt.Errorf("index out of bounds")
 	}
+	if c.encoded != nil {
+		return c.encoded.at(index), nil
+	}
+	if c.native != nil {
+		value, _ := c.native.at(index).(T)
+		return value, nil
+	}
 	return c.Data[index], nil
 }
 
+// Slice returns a view of the rows [start, end) of the column. The view shares the backing array
+// of the column instead of copying it, the rows are copied on the first write made by a DataFrame
+// method (FillNa, ReplaceInf...) to either column. Appending to either column never writes into the other.
+// Compressed columns are decoded into a new slice.
+//
+// Assigning to the elements of the Data field directly writes through to the other column.
+//
+// Parameters:
+//   - start: The first row of the view.
+//   - end: The row after the last row of the view.
+//
+// Returns:
+//   - *Column[T]: The view, with the same name.
+//   - error: An error if the bounds are out of range.
+func (c *Column[T]) Slice(start, end int) (*Column[T], error) {
+	if start < 0 || end < start || end > c.Len() {
+		return nil, fmt.Errorf("slice bounds [%d:%d] out of range for %d rows", start, end, c.Len())
+	}
+	view := c.slice(start, end)
+	if c.encoded == nil {
+		c.shared.Store(true)
+		view.shared.Store(true)
+	}
+	return view, nil
+}
+
+// slice returns the rows [start, end) of the column, sharing its storage unless it is compressed
+func (c *Column[T]) slice(start, end int) *Column[T] {
+	view := &Column[T]{Name: c.Name}
+	switch {
+	case c.encoded != nil:
+		view.Data = c.encoded.decode()[start:end:end]
+	case c.native != nil:
+		view.native = c.native.slice(start, end)
+	default:
+		// the capacity ends with the view, so appending to it reallocates
+		view.Data = c.Data[start:end:end]
+	}
+	return view
+}
+
+// copyRows returns a copy of the rows [start, end) of the column, with their own storage
+func (c *Column[T]) copyRows(start, end int) *Column[T] {
+	rows := c.slice(start, end)
+	switch {
+	case c.encoded != nil:
+		// already decoded into a new slice
+	case c.native != nil:
+		rows.native = rows.native.copy()
+	default:
+		rows.Data = slices.Clone(rows.Data)
+	}
+	return rows
+}
+
+// own copies the rows of a column shared with a view before they are modified in place, see Slice
+func (c *Column[T]) own() {
+	if !c.shared.Load() {
+		return
+	}
+	c.Data = slices.Clone(c.Data)
+	if c.native != nil {
+		c.native = c.native.copy()
+	}
+	c.shared.Store(false)
+}
+
 // ConvertToAnyColumn converts a typed column to a generic column of type `any`
 func ConvertToAnyColumn[T any](col *Column[T]) *Column[any] {
-	genericData := make([]any, len(col.Data))
-	for i, v := range col.Data {
+	values := col.Values()
+	genericData := make([]any, len(values))
+	for i, v := range values {
 		genericData[i] = v
 	}
 	return &Column[any]{
@@ -0,0 +1,393 @@
+package dataframe
+
+/*
+
+	This is where DataFrame comparison is defined: Compare lists the differences between two
+	DataFrames cell by cell, Equals and Hash check them for equality, and AssertFrameEqual
+	reports them from tests as a readable diff showing the differing cells among the rows
+	around them.
+
+*/
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// maxListedCells is the number of differing cells listed by FrameDiff.String, the others are counted
+const maxListedCells = 20
+
+// CompareOption configures Compare and AssertFrameEqual.
+//
+// Fields:
+//   - Tolerance: The largest absolute difference between two numbers that are considered equal.
+//   - StrictTypes: Requires equal values to have the same type, otherwise numbers are compared
+//     numerically (1 equals 1.0).
+//   - IgnoreColumnOrder: Does not report columns that are in a different order.
+//   - Context: The number of rows shown around each differing row by FrameDiff.String. Defaults to 2,
+//     negative to show only the differing rows.
+//   - IgnoreRowOrder: Sorts the rows of both DataFrames before comparing them, by the Keys columns or
+//     by every column when Keys is empty. The rows of the differences are then the sorted positions.
+//   - Keys: The columns identifying a row when IgnoreRowOrder is set, e.g. an id column.
+type CompareOption struct {
+	Tolerance         float64
+	StrictTypes       bool
+	IgnoreColumnOrder bool
+	Context           int
+	IgnoreRowOrder    bool
+	Keys              []string
+}
+
+// CellDiff is a cell holding different values in the compared DataFrames.
+//
+// Fields:
+//   - Row: The position of the row.
+//   - Column: The name of the column.
+//   - Expected: The value in the DataFrame Compare is called on.
+//   - Actual: The value in the other DataFrame.
+type CellDiff struct {
+	Row      int
+	Column   string
+	Expected any
+	Actual   any
+}
+
+// FrameDiff lists the differences between two DataFrames, see Compare.
+//
+// Fields:
+//   - ExpectedRows: The number of rows of the DataFrame Compare is called on.
+//   - ActualRows: The number of rows of the other DataFrame. Only the rows both have are compared.
+//   - MissingColumns: The columns the other DataFrame does not have.
+//   - ExtraColumns: The columns only the other DataFrame has.
+//   - ColumnOrder: True if the shared columns are in a different order.
+//   - Cells: The differing cells of the shared columns, by row then column.
+type FrameDiff struct {
+	ExpectedRows   int
+	ActualRows     int
+	MissingColumns []string
+	ExtraColumns   []string
+	ColumnOrder    bool
+	Cells          []CellDiff
+
+	expected, actual *DataFrame
+	columns          []string
+	context          int
+}
+
+// Compare lists the differences between the DataFrame and another one, cell by cell.
+// Nil values equal nil values and NaN values equal NaN values.
+//
+// Parameters:
+//   - other: The DataFrame to compare to, the "actual" side of the diff.
+//   - options (optional): The CompareOption struct with the number tolerance and the type and column order checks.
+//
+// Returns:
+//   - *FrameDiff: The differences, FrameDiff.Equal reports whether there are none and
+//     FrameDiff.String prints them.
+func (df *DataFrame) Compare(other *DataFrame, options ...CompareOption) *FrameDiff {
+	opts := CompareOption{Context: 2}
+	if len(options) > 0 {
+		opts = options[0]
+		if opts.Context == 0 {
+			opts.Context = 2
+		}
+	}
+
+	if opts.IgnoreRowOrder {
+		keys := opts.Keys
+		if len(keys) == 0 {
+			keys = df.ColumnNames()
+		}
+		df, other = sortedRows(df, keys), sortedRows(other, keys)
+	}
+
+	diff := &FrameDiff{
+		ExpectedRows: df.Nrows(),
+		ActualRows:   other.Nrows(),
+		expected:     df,
+		actual:       other,
+		context:      max(opts.Context, 0),
+	}
+	var otherShared []string
+	for _, name := range other.ColumnNames() {
+		if _, exists := df.Columns[name]; exists {
+			otherShared = append(otherShared, name)
+		} else {
+			diff.ExtraColumns = append(diff.ExtraColumns, name)
+		}
+	}
+	for _, name := range df.ColumnNames() {
+		if _, exists := other.Columns[name]; exists {
+			diff.columns = append(diff.columns, name)
+		} else {
+			diff.MissingColumns = append(diff.MissingColumns, name)
+		}
+	}
+	diff.ColumnOrder = !opts.IgnoreColumnOrder && !slices.Equal(diff.columns, otherShared)
+
+	for i := range min(diff.ExpectedRows, diff.ActualRows) {
+		for _, name := range diff.columns {
+			expected, _ := df.Columns[name].At(i)
+			actual, _ := other.Columns[name].At(i)
+			if !cellsEqual(expected, actual, opts) {
+				diff.Cells = append(diff.Cells, CellDiff{Row: i, Column: name, Expected: expected, Actual: actual})
+			}
+		}
+	}
+	return diff
+}
+
+// sortedRows sorts the rows by the key columns it has, for CompareOption.IgnoreRowOrder
+func sortedRows(df *DataFrame, keys []string) *DataFrame {
+	var columns [][]any
+	for _, key := range keys {
+		if col, exists := df.Columns[key]; exists {
+			columns = append(columns, col.Values())
+		}
+	}
+	positions := make([]int, df.Nrows())
+	for i := range positions {
+		positions[i] = i
+	}
+	slices.SortStableFunc(positions, func(a, b int) int {
+		for _, values := range columns {
+			if c := compareSortValues(values[a], values[b], true, false); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return df.takeRows(positions)
+}
+
+// Equals reports whether the DataFrame equals another one, see Compare.
+//
+// Parameters:
+//   - other: The DataFrame to compare to.
+//   - options (optional): The CompareOption struct with the number tolerance, the type checks and the
+//     column and row orders to ignore.
+//
+// Returns:
+//   - bool: True if the DataFrames have the same shape, columns and values.
+//   - *FrameDiff: The differences, FrameDiff.String prints them.
+func (df *DataFrame) Equals(other *DataFrame, options ...CompareOption) (bool, *FrameDiff) {
+	diff := df.Compare(other, options...)
+	return diff.Equal(), diff
+}
+
+// Hash returns a hash of the column names and values of the DataFrame, e.g. to detect changes or
+// deduplicate DataFrames. DataFrames equal for Equals without options have the same hash: numbers are
+// hashed by value (1 and 1.0 hash the same), NaN values hash the same and times by their instant.
+//
+// Returns:
+//   - uint64: The FNV-1a hash.
+func (df *DataFrame) Hash() uint64 {
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	writeUint := func(n uint64) {
+		binary.LittleEndian.PutUint64(buf, n)
+		h.Write(buf)
+	}
+	writeString := func(s string) {
+		writeUint(uint64(len(s)))
+		h.Write([]byte(s))
+	}
+
+	writeUint(uint64(df.Nrows()))
+	for _, name := range df.ColumnNames() {
+		writeString(name)
+		for _, v := range df.Columns[name].Values() {
+			switch value := v.(type) {
+			case nil:
+				h.Write([]byte{0})
+			case string:
+				h.Write([]byte{1})
+				writeString(value)
+			case time.Time:
+				h.Write([]byte{2})
+				writeUint(uint64(value.UnixNano()))
+			default:
+				if f, ok := toFloat(v); ok {
+					if math.IsNaN(f) {
+						f = math.NaN()
+					}
+					if f == 0 {
+						f = 0 // -0 equals 0
+					}
+					h.Write([]byte{3})
+					writeUint(math.Float64bits(f))
+					continue
+				}
+				h.Write([]byte{4})
+				writeString(fmt.Sprintf("%T:%v", v, v))
+			}
+		}
+	}
+	return h.Sum64()
+}
+
+// cellsEqual compares two cells for Compare
+func cellsEqual(a, b any, opts CompareOption) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if opts.StrictTypes && reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	if isNaNValue(a) || isNaNValue(b) {
+		return isNaNValue(a) && isNaNValue(b)
+	}
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	_, stringA := a.(string)
+	_, stringB := b.(string)
+	if stringA || stringB {
+		return a == b
+	}
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if okA && okB {
+		return fa == fb || math.Abs(fa-fb) <= opts.Tolerance
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Equal reports whether the DataFrames have the same shape, columns and values.
+func (d *FrameDiff) Equal() bool {
+	return d.ExpectedRows == d.ActualRows && len(d.MissingColumns) == 0 && len(d.ExtraColumns) == 0 &&
+		!d.ColumnOrder && len(d.Cells) == 0
+}
+
+// String prints the differences: the shape and column differences, the list of differing cells
+// and a table of the shared columns where the differing rows are marked with ">" and the
+// differing cells show "expected -> actual", among the rows around them.
+func (d *FrameDiff) String() string {
+	if d.Equal() {
+		return "DataFrames are equal"
+	}
+
+	var b strings.Builder
+	b.WriteString("DataFrames differ:\n")
+	if d.ExpectedRows != d.ActualRows {
+		fmt.Fprintf(&b, "  rows: expected %d, got %d\n", d.ExpectedRows, d.ActualRows)
+	}
+	if len(d.MissingColumns) > 0 {
+		fmt.Fprintf(&b, "  missing columns: %s\n", strings.Join(d.MissingColumns, ", "))
+	}
+	if len(d.ExtraColumns) > 0 {
+		fmt.Fprintf(&b, "  extra columns: %s\n", strings.Join(d.ExtraColumns, ", "))
+	}
+	if d.ColumnOrder {
+		fmt.Fprintf(&b, "  column order: expected %s, got %s\n", strings.Join(d.expected.ColumnNames(), ", "),
+			strings.Join(d.actual.ColumnNames(), ", "))
+	}
+	if len(d.Cells) == 0 {
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "  %d different cell(s):\n", len(d.Cells))
+	for _, cell := range d.Cells[:min(len(d.Cells), maxListedCells)] {
+		fmt.Fprintf(&b, "    row %d, column '%s': expected %s, got %s\n", cell.Row, cell.Column,
+			formatDiffValue(cell.Expected), formatDiffValue(cell.Actual))
+	}
+	if len(d.Cells) > maxListedCells {
+		fmt.Fprintf(&b, "    ... and %d more\n", len(d.Cells)-maxListedCells)
+	}
+	b.WriteString("\n")
+	d.writeTable(&b)
+	return b.String()
+}
+
+// writeTable prints the differing rows of the first listed cells and the rows around them
+func (d *FrameDiff) writeTable(b *strings.Builder) {
+	cells := make(map[int]map[string]CellDiff)
+	for _, cell := range d.Cells[:min(len(d.Cells), maxListedCells)] {
+		if cells[cell.Row] == nil {
+			cells[cell.Row] = make(map[string]CellDiff)
+		}
+		cells[cell.Row][cell.Column] = cell
+	}
+	rows := min(d.ExpectedRows, d.ActualRows)
+	shown := make([]bool, rows)
+	for row := range cells {
+		for i := max(0, row-d.context); i <= min(rows-1, row+d.context); i++ {
+			shown[i] = true
+		}
+	}
+
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  \trow\t%s\n", strings.Join(d.columns, "\t"))
+	previous := -1
+	for i, show := range shown {
+		if !show {
+			continue
+		}
+		if previous >= 0 && i > previous+1 {
+			// as many cells as the other lines, so the columns stay aligned
+			fmt.Fprintf(w, "  \t...%s\n", strings.Repeat("\t", len(d.columns)))
+		}
+		previous = i
+
+		marker := " "
+		if cells[i] != nil {
+			marker = ">"
+		}
+		values := make([]string, len(d.columns))
+		for c, name := range d.columns {
+			if cell, differs := cells[i][name]; differs {
+				values[c] = formatDiffValue(cell.Expected) + " -> " + formatDiffValue(cell.Actual)
+				continue
+			}
+			value, _ := d.expected.Columns[name].At(i)
+			values[c] = fmt.Sprintf("%v", value)
+		}
+		fmt.Fprintf(w, "%s \t%d\t%s\n", marker, i, strings.Join(values, "\t"))
+	}
+	w.Flush()
+}
+
+// formatDiffValue prints a value with its type, quoting strings so "1" and 1 can be told apart
+func formatDiffValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprintf("%v (%T)", v, v)
+}
+
+// TestingT is the part of *testing.T used by AssertFrameEqual, so the package does not depend on testing.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertFrameEqual fails the test with a readable diff (see FrameDiff.String) when the DataFrames differ.
+//
+// Parameters:
+//   - t: The test, e.g. a *testing.T.
+//   - expected: The expected DataFrame.
+//   - actual: The DataFrame under test.
+//   - options (optional): The CompareOption struct, see Compare.
+//
+// Returns:
+//   - bool: True if the DataFrames are equal.
+func AssertFrameEqual(t TestingT, expected, actual *DataFrame, options ...CompareOption) bool {
+	t.Helper()
+	diff := expected.Compare(actual, options...)
+	if !diff.Equal() {
+		t.Errorf("%s", diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package dataframe
+
+/*
+
+	This is where in-memory column compression is defined
+
+*/
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// columnEncoding holds the compressed representation of a column.
+//
+// Both encodings store every distinct value once in dict:
+//   - "dict": codes holds one dictionary code per row.
+//   - "rle": codes holds one dictionary code per run and runEnds the exclusive end row of each run.
+type columnEncoding[T any] struct {
+	kind    string
+	dict    []T
+	codes   []uint32
+	runEnds []int
+	length  int
+}
+
+// at returns the value stored at the given row
+func (e *columnEncoding[T]) at(index int) T {
+	if e.kind == "rle" {
+		run := sort.Search(len(e.runEnds), func(k int) bool { return e.runEnds[k] > index })
+		return e.dict[e.codes[run]]
+	}
+	return e.dict[e.codes[index]]
+}
+
+// decode expands the encoding back to a plain slice
+func (e *columnEncoding[T]) decode() []T {
+	data := make([]T, e.length)
+	if e.kind == "rle" {
+		start := 0
+		for run, end := range e.runEnds {
+			value := e.dict[e.codes[run]]
+			for i := start; i < end; i++ {
+				data[i] = value
+			}
+			start = end
+		}
+		return data
+	}
+	for i, code := range e.codes {
+		data[i] = e.dict[code]
+	}
+	return data
+}
+
+// Compress compresses the column data in memory using run-length ("rle") or dictionary ("dict") encoding.
+// Dictionary encoding suits low-cardinality columns (e.g. categories), run-length encoding suits columns
+// where equal values are stored next to each other (e.g. sorted data).
+//
+// While a column is compressed its Data field is nil. The values stay readable through Len, At and
+// Values, and the DataFrame methods handing out columns (Select, MultiSelect, ApplyColumns...) return
+// them decoded; only the Columns map holds the compressed column itself. Call Decompress before
+// modifying the column data.
+//
+// Parameters:
+//   - kind: The encoding to use, "rle" or "dict".
+//
+// Returns:
+//   - error: An error if the kind is unknown or the values cannot be used as dictionary keys.
+func (c *Column[T]) Compress(kind string) error {
+	if kind != "rle" && kind != "dict" {
+		return fmt.Errorf("unknown compression kind: %s (must be 'rle' or 'dict')", kind)
+	}
+	if c.encoded != nil {
+		if c.encoded.kind == kind {
+			return nil
+		}
+		c.Decompress()
+	}
+	c.materialize()
+
+	enc := &columnEncoding[T]{kind: kind, length: len(c.Data)}
+	lookup := make(map[any]uint32)
+
+	for i, v := range c.Data {
+		key := any(v)
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return fmt.Errorf("cannot compress column '%s': value at row %d of type %T is not comparable", c.Name, i, key)
+		}
+
+		code, seen := lookup[key]
+		if !seen {
+			code = uint32(len(enc.dict))
+			lookup[key] = code
+			enc.dict = append(enc.dict, v)
+		}
+
+		if kind == "rle" {
+			last := len(enc.codes) - 1
+			if last >= 0 && enc.codes[last] == code {
+				enc.runEnds[last] = i + 1
+				continue
+			}
+			enc.codes = append(enc.codes, code)
+			enc.runEnds = append(enc.runEnds, i+1)
+			continue
+		}
+		enc.codes = append(enc.codes, code)
+	}
+
+	c.encoded = enc
+	c.Data = nil
+	return nil
+}
+
+// Decompress restores the plain Data slice of a compressed column. It is a no-op for uncompressed columns.
+func (c *Column[T]) Decompress() {
+	if c.encoded == nil {
+		return
+	}
+	c.Data = c.encoded.decode()
+	c.encoded = nil
+}
+
+// IsCompressed reports whether the column data is currently compressed.
+func (c *Column[T]) IsCompressed() bool {
+	return c.encoded != nil
+}
+
+// Values returns the column data as a plain slice, decoding it if the column is compressed.
+// For plain columns the returned slice is the Data field itself.
+func (c *Column[T]) Values() []T {
+	if c.encoded != nil {
+		return c.encoded.decode()
+	}
+	if c.native != nil {
+		return fromAny[T](c.native.boxed())
+	}
+	return c.Data
+}
+
+// CompressColumns compresses the given columns of the DataFrame in memory.
+//
+// Parameters:
+//   - kind: The encoding to use, "rle" or "dict".
+//   - names (optional): The columns to compress. Every string column is compressed if left empty.
+//
+// Returns:
+//   - error: An error if a column does not exist or cannot be compressed.
+func (df *DataFrame) CompressColumns(kind string, names ...string) error {
+	if len(names) == 0 {
+		for _, name := range df.ColumnNames() {
+			if inferGoTypeFromColumn(df.Columns[name]).Kind() == reflect.String {
+				names = append(names, name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		col, exists := df.Columns[name]
+		if !exists {
+			return fmt.Errorf("column '%s' does not exist", name)
+		}
+		if err := col.Compress(kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecompressColumns decompresses every compressed column of the DataFrame.
+func (df *DataFrame) DecompressColumns() {
+	for _, col := range df.Columns {
+		col.Decompress()
+	}
+}
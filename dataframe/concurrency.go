@@ -0,0 +1,140 @@
+package dataframe
+
+/*
+
+	This is where concurrent access is defined.
+
+	A DataFrame is safe for any number of concurrent readers as long as no goroutine modifies it:
+	Select, Row, Filter, Head, Tail, Loc, At, GroupBy, the aggregations and the exports only read it.
+	Methods that modify the DataFrame in place (AddColumn, Append, DropRow, SetIndex, Rename, Compress,
+	...) need exclusive access. ConcurrentDataFrame provides that locking when readers and writers
+	share a DataFrame.
+
+*/
+
+import (
+	"sync"
+)
+
+// ConcurrentDataFrame guards a DataFrame with a read-write lock so that goroutines can read it while
+// others append rows or add columns. Readers run concurrently, writers run alone.
+type ConcurrentDataFrame struct {
+	mu sync.RWMutex
+	df *DataFrame
+}
+
+// NewConcurrentDataFrame wraps a DataFrame, which must not be used directly afterwards.
+//
+// Parameters:
+//   - df: The DataFrame to guard.
+//
+// Returns:
+//   - *ConcurrentDataFrame: The guarded DataFrame.
+func NewConcurrentDataFrame(df *DataFrame) *ConcurrentDataFrame {
+	return &ConcurrentDataFrame{df: df}
+}
+
+// Read calls fn with the DataFrame while holding the read lock. fn must not modify the DataFrame nor
+// keep references to its columns after returning.
+//
+// Parameters:
+//   - fn: The function reading the DataFrame.
+//
+// Returns:
+//   - error: The error returned by fn.
+func (c *ConcurrentDataFrame) Read(fn func(df *DataFrame) error) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return fn(c.df)
+}
+
+// Write calls fn with the DataFrame while holding the write lock.
+//
+// Parameters:
+//   - fn: The function modifying the DataFrame.
+//
+// Returns:
+//   - error: The error returned by fn.
+func (c *ConcurrentDataFrame) Write(fn func(df *DataFrame) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fn(c.df)
+}
+
+// Snapshot returns a copy of the DataFrame that can be used freely without the lock.
+//
+// Returns:
+//   - *DataFrame: A copy with its own column storage.
+func (c *ConcurrentDataFrame) Snapshot() *DataFrame {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.df.clone()
+}
+
+// Select returns a copy of a column, unaffected by later writes.
+//
+// Parameters:
+//   - name: The name of the column to select.
+//
+// Returns:
+//   - *Column[any]: A copy of the column.
+//   - error: An error if the column does not exist.
+func (c *ConcurrentDataFrame) Select(name string) (*Column[any], error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	col, err := c.df.Select(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Column[any]{Name: col.Name, Data: append([]any{}, col.Values()...)}, nil
+}
+
+// Row returns a row by index, see DataFrame.Row.
+//
+// Parameters:
+//   - index: The index of the row to retrieve.
+//
+// Returns:
+//   - map[string]any: A map representing the row, with column names as keys.
+//   - error: An error if the index is out of bounds.
+func (c *ConcurrentDataFrame) Row(index int) (map[string]any, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.df.Row(index)
+}
+
+// Filter returns a new DataFrame with the rows that satisfy the condition, see DataFrame.Filter.
+//
+// Parameters:
+//   - condition: A function that takes a row and returns true if the row should be included.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the filtered rows.
+func (c *ConcurrentDataFrame) Filter(condition func(row map[string]any) bool) *DataFrame {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.df.Filter(condition)
+}
+
+// Nrows returns the number of rows.
+//
+// Returns:
+//   - int: The number of rows.
+func (c *ConcurrentDataFrame) Nrows() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.df.Nrows()
+}
+
+// Append adds a row at the end of the DataFrame, see DataFrame.Append.
+//
+// Parameters:
+//   - row: A map of column names to values.
+//
+// Returns:
+//   - error: An error if a new column cannot be added.
+func (c *ConcurrentDataFrame) Append(row map[string]any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.df.Append(row)
+}
@@ -0,0 +1,211 @@
+package dataframe
+
+/*
+
+	This is where column handles are defined, to build row conditions with method calls instead of
+	the string expressions of Query, so that a misspelled operator fails to compile:
+
+		df.Where(df.C("salary").Gt(100).And(df.C("dept").Eq("IT")))
+
+	Like the Series operations, an error (missing column, length mismatch) is stored in the Err field
+	of the Mask and carried through the chain, Where returns it.
+
+*/
+
+import (
+	"fmt"
+)
+
+// ColumnRef is a handle on a column of a DataFrame, whose comparisons return a Mask. See DataFrame.C.
+type ColumnRef struct {
+	df   *DataFrame
+	name string
+}
+
+// Mask is a condition on the rows of a DataFrame, built from the comparisons of a ColumnRef and combined
+// with And, Or and Not.
+//
+// Fields:
+//   - Series: The boolean Series, true for the rows meeting the condition, e.g. for FilterByMask. Nil if Err is set.
+//   - Err: The first error of the chain.
+type Mask struct {
+	Series *Series
+	Err    error
+}
+
+// C returns a handle on a column to build row conditions, e.g. df.C("age").Ge(18). The column is read when
+// a comparison is called, a missing column gives a Mask carrying the error.
+//
+// Parameters:
+//   - name: The name of the column.
+//
+// Returns:
+//   - ColumnRef: The handle on the column.
+func (df *DataFrame) C(name string) ColumnRef {
+	return ColumnRef{df: df, name: name}
+}
+
+// Series returns the values of the column, see DataFrame.Col.
+//
+// Returns:
+//   - *Series: A copy of the values of the column, Err returns an error if the column does not exist.
+func (c ColumnRef) Series() *Series {
+	return c.df.Col(c.name)
+}
+
+// Gt tests the values of the column against a value or another column, see Series.Gt.
+//
+// Parameters:
+//   - other: A scalar applied to every row, a ColumnRef or a *Series of the same length.
+//
+// Returns:
+//   - *Mask: True where the value is greater, false where the values are nil or cannot be compared.
+func (c ColumnRef) Gt(other any) *Mask {
+	return c.compare(other, (*Series).Gt)
+}
+
+// Ge tests the values of the column against a value or another column, see Gt.
+func (c ColumnRef) Ge(other any) *Mask {
+	return c.compare(other, (*Series).Ge)
+}
+
+// Lt tests the values of the column against a value or another column, see Gt.
+func (c ColumnRef) Lt(other any) *Mask {
+	return c.compare(other, (*Series).Lt)
+}
+
+// Le tests the values of the column against a value or another column, see Gt.
+func (c ColumnRef) Le(other any) *Mask {
+	return c.compare(other, (*Series).Le)
+}
+
+// Eq tests the values of the column for equality with a value or another column, see Series.Eq.
+func (c ColumnRef) Eq(other any) *Mask {
+	return c.compare(other, (*Series).Eq)
+}
+
+// Ne tests the values of the column for inequality with a value or another column, see Series.Ne.
+func (c ColumnRef) Ne(other any) *Mask {
+	return c.compare(other, (*Series).Ne)
+}
+
+// compare applies a comparison of Series to the column, other is a scalar, a ColumnRef or a *Series
+func (c ColumnRef) compare(other any, op func(s *Series, other any) *Series) *Mask {
+	if ref, ok := other.(ColumnRef); ok {
+		other = ref.Series()
+	}
+	return newMask(op(c.Series(), other))
+}
+
+// Between tests whether the values of the column lie between lo and hi, both inclusive, see Series.Between.
+func (c ColumnRef) Between(lo, hi any) *Mask {
+	return c.apply(func(s *Series) *Series { return s.Between(lo, hi) })
+}
+
+// IsIn tests whether the values of the column are one of the given values, see Series.IsIn.
+func (c ColumnRef) IsIn(values ...any) *Mask {
+	return c.apply(func(s *Series) *Series { return s.IsIn(values...) })
+}
+
+// IsNull tests whether the values of the column are missing (nil or NaN).
+func (c ColumnRef) IsNull() *Mask {
+	return c.apply(func(s *Series) *Series {
+		mask := make([]any, s.Len())
+		for i, v := range s.Data {
+			mask[i] = isMissing(v)
+		}
+		return NewSeries(s.Name, mask)
+	})
+}
+
+// NotNull tests whether the values of the column are present (neither nil nor NaN).
+func (c ColumnRef) NotNull() *Mask {
+	return c.IsNull().Not()
+}
+
+// apply builds a Mask from the values of the column
+func (c ColumnRef) apply(fn func(s *Series) *Series) *Mask {
+	s := c.Series()
+	if err := s.Err(); err != nil {
+		return &Mask{Err: err}
+	}
+	return newMask(fn(s))
+}
+
+// newMask wraps a boolean Series, moving its error to the Mask
+func newMask(s *Series) *Mask {
+	if err := s.Err(); err != nil {
+		return &Mask{Err: err}
+	}
+	return &Mask{Series: s}
+}
+
+// And combines two masks, true where both are true.
+//
+// Parameters:
+//   - other: The mask to combine with.
+//
+// Returns:
+//   - *Mask: The combined mask, carrying the error of either mask or of a length mismatch.
+func (m *Mask) And(other *Mask) *Mask {
+	return m.combine(other, (*Series).And)
+}
+
+// Or combines two masks, true where either is true.
+//
+// Parameters:
+//   - other: The mask to combine with.
+//
+// Returns:
+//   - *Mask: The combined mask, carrying the error of either mask or of a length mismatch.
+func (m *Mask) Or(other *Mask) *Mask {
+	return m.combine(other, (*Series).Or)
+}
+
+// Not negates the mask.
+//
+// Returns:
+//   - *Mask: The negated mask, carrying the error of m.
+func (m *Mask) Not() *Mask {
+	if m.Err != nil {
+		return &Mask{Err: m.Err}
+	}
+	s, err := m.Series.Not()
+	if err != nil {
+		return &Mask{Err: err}
+	}
+	return &Mask{Series: s}
+}
+
+// combine applies a logical operator of Series to two masks
+func (m *Mask) combine(other *Mask, op func(s, other *Series) (*Series, error)) *Mask {
+	if m.Err != nil {
+		return &Mask{Err: m.Err}
+	}
+	if other.Err != nil {
+		return &Mask{Err: other.Err}
+	}
+	s, err := op(m.Series, other.Series)
+	if err != nil {
+		return &Mask{Err: err}
+	}
+	return &Mask{Series: s}
+}
+
+// Where returns a new DataFrame with the rows meeting a condition built with C, see FilterByMask.
+//
+// Parameters:
+//   - mask: The condition, e.g. df.C("salary").Gt(100).And(df.C("dept").Eq("IT")).
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the matching rows, in order.
+//   - error: The error carried by the mask, or an error if its length does not match the number of rows.
+func (df *DataFrame) Where(mask *Mask) (*DataFrame, error) {
+	if mask.Err != nil {
+		return nil, mask.Err
+	}
+	if mask.Series == nil {
+		return nil, fmt.Errorf("mask has no values")
+	}
+	return df.FilterByMask(mask.Series)
+}
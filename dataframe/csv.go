@@ -1,41 +1,128 @@
 package dataframe
 
 import (
+	"bytes"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// CSVGlobOption configures how FromCSVGlob loads multiple files.
+//
+// Fields:
+//   - SourceColumn: The name of the column holding the path of the file each row came from.
+//     Defaults to "source_file".
+//   - Workers: The number of files read in parallel. Defaults to the number of CPUs.
+type CSVGlobOption struct {
+	SourceColumn string
+	Workers      int
+}
+
+// CSVReadOption configures how FromCSVReader infers the column types.
+//
+// Fields:
+//   - DTypes: The type of the columns that are parsed directly instead of being inferred (see Schema),
+//     e.g. {"id": {Kind: "string"}} keeps identifiers such as "007" intact.
+//   - DisableInference: Reads every column that is not in DTypes as raw strings.
+//   - ParseBools: Reads a column as bool when all its non-empty cells are boolean values
+//     (see BoolOption), empty cells become nil. Disabled by default.
+//   - ParseDates: Reads a column as time.Time when all its non-empty cells are dates in one of the
+//     CustomDateLayouts or the built-in layouts (RFC 3339, "2006-01-02", "2006-01-02 15:04:05", ...),
+//     empty cells become nil. Disabled by default.
+//   - CustomDateLayouts: Additional layouts (see time.Parse) tried before the built-in ones when ParseDates is set.
+//   - NAValues: The cells read as nil in every column, e.g. []string{"NA", "N/A", "-"}. Cells are trimmed before matching.
+//   - Strict: Returns an error when an inferred column mixes numeric and non-numeric cells
+//     instead of storing both as float64 and string values.
+//   - BoolOption: The strings recognized as true and false when ParseBools is set.
+//   - NumberOption: The locale of numeric cells (decimal and thousands separators, currency symbols, percentages).
+//   - ReadSchema: Reads the type of each column on the line after the header, as written by
+//     CSVWriteOption.WriteSchema, instead of inferring it: one of the targets of Astype, "time:<layout>"
+//     for times, or empty to infer it. Empty cells become nil, except in string columns where they are
+//     empty strings and the cells \N are nil. DTypes take precedence.
+type CSVReadOption struct {
+	DTypes            Schema
+	DisableInference  bool
+	ParseBools        bool
+	ParseDates        bool
+	CustomDateLayouts []string
+	NAValues          []string
+	Strict            bool
+	BoolOption
+	NumberOption
+	ReadSchema bool
+}
+
+// DType describes the type a CSV column is parsed into.
+//
+// Fields:
+//   - Kind: The target type, one of "int64", "float64", "bool", "string" or "time".
+//   - Layout: The time layout used when Kind is "time" (see time.Parse). Defaults to time.RFC3339.
+type DType struct {
+	Kind   string
+	Layout string
+}
+
+// Schema maps column names to the type their values are parsed into.
+type Schema map[string]DType
+
 // FromCSV creates a DataFrame from a CSV file.
 //
 // Parameters:
 //   - filename: The path to the CSV file.
+//   - options (optional): The CSVReadOption struct to configure type inference.
 //
 // Returns:
 //   - *DataFrame: The created DataFrame.
 //   - error: An error if the file cannot be read.
-func (df *DataFrame) FromCSV(filename string) (*DataFrame, error) {
+func (df *DataFrame) FromCSV(filename string, options ...CSVReadOption) (*DataFrame, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("error opening file: %w", err)
 	}
 	defer file.Close()
 
-	return FromCSVReader(file)
+	return FromCSVReader(file, options...)
 }
 
 // FromCSVReader creates a DataFrame from a CSV reader.
 //
+// Columns listed in DTypes are parsed into their declared type. The other columns are inferred:
+// boolean columns (with ParseBools), then columns holding only numbers become float64, then date
+// columns (with ParseDates), the remaining cells keep their numeric or trimmed string value.
+//
 // Parameters:
 //   - reader: An io.Reader for the CSV data.
+//   - options (optional): The CSVReadOption struct to configure type inference.
 //
 // Returns:
 //   - *DataFrame: The created DataFrame.
-//   - error: An error if the data cannot be read.
-func FromCSVReader(reader io.Reader) (*DataFrame, error) {
+//   - error: An error if the data cannot be read, the header repeats a column, a DTypes column is missing from the header or a type
+//     is unknown, or every cell that cannot be parsed (or mixes types in Strict mode) with its row, line and column position.
+func FromCSVReader(reader io.Reader, options ...CSVReadOption) (*DataFrame, error) {
+	var opts CSVReadOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := opts.NumberOption.validate(); err != nil {
+		return nil, err
+	}
+	for name, dtype := range opts.DTypes {
+		switch dtype.Kind {
+		case "int64", "float64", "bool", "string", "time":
+		default:
+			return nil, fmt.Errorf("unsupported type '%s' for column '%s'", dtype.Kind, name)
+		}
+	}
+
 	csvReader := csv.NewReader(reader)
 
 	// Read header
@@ -43,17 +130,32 @@ func FromCSVReader(reader io.Reader) (*DataFrame, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error reading header: %w", err)
 	}
-
-	// Initialize DataFrame with columns
-	df := NewDataFrame()
-	for _, colName := range header {
-		df.Columns[colName] = &Column[any]{
-			Name: colName,
-			Data: []any{},
+	for i, name := range header {
+		if slices.Contains(header[:i], name) {
+			return nil, fmt.Errorf("duplicate column '%s' in header", name)
+		}
+	}
+	for name := range opts.DTypes {
+		if !slices.Contains(header, name) {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+	var schema []string
+	if opts.ReadSchema {
+		if schema, err = csvReader.Read(); err != nil {
+			return nil, fmt.Errorf("error reading schema: %w", err)
+		}
+		for i, kind := range schema {
+			kind, _, _ = strings.Cut(kind, ":")
+			if !slices.Contains([]string{"", "int", "int64", "float64", "float32", "bool", "time", "string"}, kind) {
+				return nil, fmt.Errorf("unsupported type '%s' for column '%s'", kind, header[i])
+			}
 		}
 	}
 
-	// Read data rows
+	// Read the raw cells, the types are decided once each column is complete
+	raw := make([][]string, len(header))
+	var lines []int
 	for {
 		record, err := csvReader.Read()
 		if err == io.EOF {
@@ -62,69 +164,820 @@ func FromCSVReader(reader io.Reader) (*DataFrame, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error reading row: %w", err)
 		}
-
-		// Add data to each column, trying to parse as number if possible
+		line, _ := csvReader.FieldPos(0)
+		lines = append(lines, line)
 		for i, value := range record {
-			col := df.Columns[header[i]]
-			if floatVal, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
-				col.Data = append(col.Data, floatVal)
-			} else {
-				col.Data = append(col.Data, strings.TrimSpace(value))
-			}
+			raw[i] = append(raw[i], value)
+		}
+	}
+
+	// Initialize DataFrame with columns
+	df := NewDataFrame()
+	for _, colName := range header {
+		df.Columns[colName] = &Column[any]{Name: colName}
+	}
+	df.order = header
+
+	naValues := make(map[string]bool, len(opts.NAValues))
+	for _, na := range opts.NAValues {
+		naValues[strings.TrimSpace(na)] = true
+	}
+	isNA := func(value string) bool {
+		return len(naValues) > 0 && naValues[strings.TrimSpace(value)]
+	}
+
+	var errs []error
+	for i, name := range header {
+		var values []any
+		var cellErrs []csvCellError
+		if _, typed := opts.DTypes[name]; !typed && schema != nil && schema[i] != "" {
+			values, cellErrs = csvSchemaValues(raw[i], schema[i], opts.BoolOption, isNA)
+		} else {
+			values, cellErrs = csvColumnValues(name, raw[i], opts, isNA)
 		}
+		for _, cellErr := range cellErrs {
+			errs = append(errs, fmt.Errorf("row %d (line %d), column '%s': %w", cellErr.row, lines[cellErr.row], name, cellErr.err))
+		}
+		df.Columns[name].Data = values
 	}
 
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 	return df, nil
 }
 
+// csvCellError is a cell that cannot be read into the type of its column
+type csvCellError struct {
+	row int
+	err error
+}
+
+// csvSchemaValues converts the raw cells of a column to a type of CSVReadOption.ReadSchema, empty and NA cells become nil
+func csvSchemaValues(raw []string, schemaType string, boolOpts BoolOption, isNA func(string) bool) ([]any, []csvCellError) {
+	kind, layout, _ := strings.Cut(schemaType, ":")
+	parseBool := boolOpts.parser()
+	values := make([]any, len(raw))
+	var errs []csvCellError
+	for j, value := range raw {
+		if kind == "string" {
+			// empty cells are empty strings, nil values are written as csvNullToken
+			if value != csvNullToken && !isNA(value) {
+				values[j] = unquoteCSVNull(value)
+			}
+			continue
+		}
+		if value == "" || isNA(value) {
+			continue
+		}
+		var converted any
+		ok := true
+		switch kind {
+		case "bool":
+			converted, ok = parseBool(value)
+		default:
+			converted, ok = astypeValue(value, kind, layout)
+		}
+		if !ok {
+			errs = append(errs, csvCellError{j, fmt.Errorf("cannot parse '%s' as %s", value, kind)})
+			continue
+		}
+		values[j] = converted
+	}
+	return values, errs
+}
+
+// csvColumnValues converts the raw cells of a column following the options, NA cells become nil
+func csvColumnValues(name string, raw []string, opts CSVReadOption, isNA func(string) bool) ([]any, []csvCellError) {
+	values := make([]any, len(raw))
+	var errs []csvCellError
+
+	if dtype, typed := opts.DTypes[name]; typed {
+		for j, value := range raw {
+			if isNA(value) {
+				continue
+			}
+			parsed, err := parseTypedCell(value, dtype)
+			if err != nil {
+				errs = append(errs, csvCellError{j, err})
+			}
+			values[j] = parsed
+		}
+		return values, errs
+	}
+
+	if opts.DisableInference {
+		for j, value := range raw {
+			if !isNA(value) {
+				values[j] = value
+			}
+		}
+		return values, nil
+	}
+
+	// missing reports the cells that do not decide the type of the column
+	missing := func(value string) bool {
+		return isNA(value) || strings.TrimSpace(value) == ""
+	}
+	// convertAll stores the converted cells if every non-missing cell converts and at least one does
+	convertAll := func(convert func(string) (any, bool)) bool {
+		found := false
+		for _, value := range raw {
+			if missing(value) {
+				continue
+			}
+			if _, ok := convert(value); !ok {
+				return false
+			}
+			found = true
+		}
+		if !found {
+			return false
+		}
+		for j, value := range raw {
+			if !missing(value) {
+				values[j], _ = convert(value)
+			}
+		}
+		return true
+	}
+
+	if opts.ParseBools {
+		parseBool := opts.BoolOption.parser()
+		if convertAll(func(s string) (any, bool) { return parseBool(s) }) {
+			return values, nil
+		}
+	}
+	if opts.ParseDates && !csvAllNumeric(raw, opts.NumberOption, missing) {
+		layouts := append(append([]string{}, opts.CustomDateLayouts...), defaultDateLayouts...)
+		parseDate := func(s string) (any, bool) {
+			t, err := parseDateValue(strings.TrimSpace(s), layouts, nil)
+			return t, err == nil
+		}
+		if convertAll(parseDate) {
+			return values, nil
+		}
+	}
+
+	numeric, text := -1, -1
+	for j, value := range raw {
+		if isNA(value) {
+			continue
+		}
+		if floatVal, ok := opts.NumberOption.parse(value); ok {
+			values[j] = floatVal
+			if numeric < 0 {
+				numeric = j
+			}
+			continue
+		}
+		values[j] = strings.TrimSpace(value)
+		if text < 0 && values[j] != "" {
+			text = j
+		}
+	}
+	if opts.Strict && numeric >= 0 && text >= 0 {
+		errs = append(errs, csvCellError{max(numeric, text), fmt.Errorf("mixed types: %q is not numeric like %q",
+			strings.TrimSpace(raw[text]), strings.TrimSpace(raw[numeric]))})
+	}
+	return values, errs
+}
+
+// csvAllNumeric reports whether every non-missing cell is a number
+func csvAllNumeric(raw []string, number NumberOption, missing func(string) bool) bool {
+	for _, value := range raw {
+		if missing(value) {
+			continue
+		}
+		if _, ok := number.parse(value); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FromCSVWithSchema creates a DataFrame from a CSV reader, parsing every column listed in the schema
+// directly into its declared type instead of guessing it. Columns that are not in the schema are
+// read the same way as FromCSVReader. It is a shorthand for FromCSVReader with CSVReadOption.DTypes.
+//
+// Parameters:
+//   - reader: An io.Reader for the CSV data.
+//   - schema: The Schema holding the type of each column.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame, empty cells are stored as nil.
+//   - error: An error if the data cannot be read, a schema column is missing from the header
+//     or a type is unknown, or every cell that cannot be parsed with its row, line and column position.
+func FromCSVWithSchema(reader io.Reader, schema Schema) (*DataFrame, error) {
+	return FromCSVReader(reader, CSVReadOption{DTypes: schema})
+}
+
+// parseTypedCell parses a CSV cell into the given type, empty cells become nil
+func parseTypedCell(value string, dtype DType) (any, error) {
+	if dtype.Kind == "string" {
+		return value, nil
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	switch dtype.Kind {
+	case "int64":
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as int64", value)
+		}
+		return v, nil
+	case "float64":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as float64", value)
+		}
+		return v, nil
+	case "bool":
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as bool", value)
+		}
+		return v, nil
+	case "time":
+		layout := dtype.Layout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		v, err := time.Parse(layout, value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as time with layout %q", value, layout)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("unsupported type '%s'", dtype.Kind)
+}
+
+// FromCSVGlob loads every CSV file matching a glob pattern in parallel and concatenates them
+// into a single DataFrame, e.g. a directory of daily extracts ("exports/sales_*.csv").
+//
+// Parameters:
+//   - pattern: The glob pattern of the files to load (see filepath.Glob).
+//   - options (optional): The CSVGlobOption struct to configure the source column and parallelism.
+//
+// Returns:
+//   - *DataFrame: The concatenated DataFrame, files are appended in lexical order of their paths.
+//   - error: An error if the pattern is invalid, matches no file, or a file cannot be read.
+//
+// Note:
+//   - Columns are aligned by name, rows of files missing a column get nil values.
+func FromCSVGlob(pattern string, options ...CSVGlobOption) (*DataFrame, error) {
+	opts := CSVGlobOption{
+		SourceColumn: "source_file",
+		Workers:      runtime.NumCPU(),
+	}
+	if len(options) > 0 {
+		userOpt := options[0]
+		if userOpt.SourceColumn != "" {
+			opts.SourceColumn = userOpt.SourceColumn
+		}
+		if userOpt.Workers > 0 {
+			opts.Workers = userOpt.Workers
+		}
+	}
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match pattern %q", pattern)
+	}
+
+	frames := make([]*DataFrame, len(files))
+	errs := make([]error, len(files))
+
+	var wg sync.WaitGroup
+	indices := make(chan int, len(files))
+	for range min(opts.Workers, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				frame, err := NewDataFrame().FromCSV(files[i])
+				if err != nil {
+					errs[i] = fmt.Errorf("error loading %s: %w", files[i], err)
+					continue
+				}
+
+				source := make([]any, frame.Nrows())
+				for j := range source {
+					source[j] = files[i]
+				}
+				if err := frame.AddColumn(NewColumn(opts.SourceColumn, source)); err != nil {
+					errs[i] = fmt.Errorf("error loading %s: %w", files[i], err)
+					continue
+				}
+				frames[i] = frame
+			}
+		}()
+	}
+	for i := range files {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return concatRows(frames), nil
+}
+
+// CSVWriteOption configures how ToCSV writes a file.
+//
+// Fields:
+//   - AtomicWrite: Writes to a temporary file in the same directory and renames it over the output
+//     file once complete, so readers never see a partially written file.
+//   - FloatFormat: The fmt format of float values, e.g. "%.2f". Defaults to the shortest representation
+//     that reads back to the same value.
+//   - TimeLayout: The layout of time.Time values (see time.Format). Defaults to their String form, or to
+//     time.RFC3339Nano with WriteSchema.
+//   - TrueValue, FalseValue: The text of bool values. Default to "true" and "false".
+//   - Formatters: The functions printing the non-nil values of some columns, by column name, used
+//     instead of the formats above.
+//   - WriteSchema: Writes the type of each column on the line after the header, so FromCSVReader with
+//     CSVReadOption.ReadSchema reads the columns back with their types instead of inferring them. The nil
+//     values of string columns are written as \N to tell them from empty strings, and the strings \N,
+//     \\N, ... get one more backslash.
+type CSVWriteOption struct {
+	AtomicWrite bool
+	FloatFormat string
+	TimeLayout  string
+	TrueValue   string
+	FalseValue  string
+	Formatters  map[string]func(value any) string
+	WriteSchema bool
+}
+
+// csvWriteOptions applies the defaults to the user options
+func csvWriteOptions(options []CSVWriteOption) CSVWriteOption {
+	var opts CSVWriteOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.WriteSchema && opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339Nano
+	}
+	return opts
+}
+
+// format prints a non-nil value of a column
+func (o CSVWriteOption) format(name string, value any) string {
+	if formatter, ok := o.Formatters[name]; ok {
+		return formatter(value)
+	}
+	switch v := value.(type) {
+	case float64, float32:
+		if o.FloatFormat != "" {
+			return fmt.Sprintf(o.FloatFormat, v)
+		}
+	case bool:
+		if v && o.TrueValue != "" {
+			return o.TrueValue
+		}
+		if !v && o.FalseValue != "" {
+			return o.FalseValue
+		}
+	case time.Time:
+		if o.TimeLayout != "" {
+			return v.Format(o.TimeLayout)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// csvSchema returns the types of the columns written by CSVWriteOption.WriteSchema
+func (df *DataFrame) csvSchema(columns []string, timeLayout string) []string {
+	types := make([]string, len(columns))
+	for i, name := range columns {
+		types[i] = csvSchemaType(df.Columns[name].Values(), timeLayout)
+	}
+	return types
+}
+
+// csvSchemaType returns the type of a column written by CSVWriteOption.WriteSchema, one of the targets
+// of Astype ("time:<layout>" for times), empty when the values have several types or none
+func csvSchemaType(values []any, timeLayout string) string {
+	kind := ""
+	for _, v := range values {
+		var valueKind string
+		switch v.(type) {
+		case nil:
+			continue
+		case int:
+			valueKind = "int"
+		case int64:
+			valueKind = "int64"
+		case float64:
+			valueKind = "float64"
+		case float32:
+			valueKind = "float32"
+		case bool:
+			valueKind = "bool"
+		case string:
+			valueKind = "string"
+		case time.Time:
+			valueKind = "time:" + timeLayout
+		default:
+			return ""
+		}
+		if kind != "" && valueKind != kind {
+			return ""
+		}
+		kind = valueKind
+	}
+	return kind
+}
+
 // ToCSV exports the DataFrame to a CSV file.
 //
 // Parameters:
 //   - filename: The path to the output CSV file.
+//   - options (optional): The CSVWriteOption struct to write the file atomically and format the values.
 //
 // Returns:
 //   - error: An error if the file cannot be written.
-func (df *DataFrame) ToCSV(filename string) error {
+func (df *DataFrame) ToCSV(filename string, options ...CSVWriteOption) error {
+	if len(options) > 0 && options[0].AtomicWrite {
+		return writeFileAtomic(filename, func(writer io.Writer) error { return df.ToCSVWriter(writer, options...) })
+	}
+
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("error creating file: %w", err)
 	}
-	defer file.Close()
+	if err := df.ToCSVWriter(file, options...); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing file: %w", err)
+	}
+	return nil
+}
+
+// AppendCSV appends the rows of the DataFrame to a CSV file, without rewriting its header. The file is
+// created with a header if it does not exist or is empty.
+//
+// Parameters:
+//   - filename: The path to the CSV file.
+//   - options (optional): The CSVWriteOption struct to format the values. AtomicWrite does not apply and
+//     WriteSchema writes the schema line only when the file is created, set it to append to a file
+//     with a schema.
+//
+// Returns:
+//   - error: An error if the file cannot be read or written, or its header differs from the columns
+//     of the DataFrame.
+func (df *DataFrame) AppendCSV(filename string, options ...CSVWriteOption) error {
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+	if err := df.appendCSV(file, filename, options); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing file: %w", err)
+	}
+	return nil
+}
+
+// appendCSV writes the rows of AppendCSV to the open file, after a header if the file is empty
+func (df *DataFrame) appendCSV(file *os.File, filename string, options []CSVWriteOption) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+	if info.Size() == 0 {
+		return df.ToCSVWriter(file, options...)
+	}
+
+	header, err := csv.NewReader(file).Read()
+	if err != nil {
+		return fmt.Errorf("error reading header: %w", err)
+	}
+	if columns := df.ColumnNames(); !slices.Equal(header, columns) {
+		return fmt.Errorf("columns [%s] do not match the header [%s] of '%s'",
+			strings.Join(columns, ", "), strings.Join(header, ", "), filename)
+	}
 
-	return df.ToCSVWriter(file)
+	// a last line without a line break would be joined with the first appended row
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("error seeking file: %w", err)
+	}
+	if last[0] != '\n' {
+		if _, err := file.WriteString("\n"); err != nil {
+			return fmt.Errorf("error writing row: %w", err)
+		}
+	}
+
+	// the schema line is not written again, but the nil values of its string columns are still \N
+	opts := csvWriteOptions(options)
+	var schema []string
+	if opts.WriteSchema {
+		schema = df.csvSchema(header, opts.TimeLayout)
+	}
+	csvWriter := csv.NewWriter(file)
+	if err := df.writeCSVRows(csvWriter, file, header, opts, schema); err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("error writing row: %w", err)
+	}
+	return nil
 }
 
-// ToCSVWriter exports the DataFrame to a CSV writer.
+// ToCSVWriter exports the DataFrame to a CSV writer. Missing values are written as empty cells, or
+// as \N in the string columns with WriteSchema.
 //
 // Parameters:
 //   - writer: An io.Writer for the CSV data.
+//   - options (optional): The CSVWriteOption struct to format the values and write the schema. AtomicWrite does not apply.
 //
 // Returns:
 //   - error: An error if the data cannot be written.
-func (df *DataFrame) ToCSVWriter(writer io.Writer) error {
+func (df *DataFrame) ToCSVWriter(writer io.Writer, options ...CSVWriteOption) error {
+	opts := csvWriteOptions(options)
 	csvWriter := csv.NewWriter(writer)
-	defer csvWriter.Flush()
 
 	// Write header
 	header := df.ColumnNames()
-	if err := csvWriter.Write(header); err != nil {
+	if err := writeCSVRecord(csvWriter, writer, header); err != nil {
 		return fmt.Errorf("error writing header: %w", err)
 	}
+	var schema []string
+	if opts.WriteSchema {
+		schema = df.csvSchema(header, opts.TimeLayout)
+		if err := writeCSVRecord(csvWriter, writer, schema); err != nil {
+			return fmt.Errorf("error writing schema: %w", err)
+		}
+	}
+	if err := df.writeCSVRows(csvWriter, writer, header, opts, schema); err != nil {
+		return err
+	}
 
-	// Write rows
+	// the rows are buffered, the error of the last write is only known after the flush
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("error writing row: %w", err)
+	}
+	return nil
+}
+
+// writeCSVRows writes the rows of the columns, without header, see csvRecord for the schema
+func (df *DataFrame) writeCSVRows(csvWriter *csv.Writer, writer io.Writer, columns []string, opts CSVWriteOption, schema []string) error {
 	for i := 0; i < df.Nrows(); i++ {
-		row := make([]string, len(header))
-		for idx, colName := range header {
-			value, err := df.Columns[colName].At(i)
-			if err != nil {
-				return fmt.Errorf("error accessing value: %w", err)
-			}
-			row[idx] = fmt.Sprintf("%v", value)
+		row, err := df.csvRecord(i, columns, opts, schema)
+		if err != nil {
+			return err
 		}
-		if err := csvWriter.Write(row); err != nil {
+		if err := writeCSVRecord(csvWriter, writer, row); err != nil {
 			return fmt.Errorf("error writing row: %w", err)
 		}
 	}
 
 	return nil
 }
+
+// csvRecord returns the cells of a row, missing values are empty cells. With the schema written by
+// CSVWriteOption.WriteSchema, nil values of string columns are csvNullToken instead.
+func (df *DataFrame) csvRecord(i int, columns []string, opts CSVWriteOption, schema []string) ([]string, error) {
+	row := make([]string, len(columns))
+	for idx, colName := range columns {
+		value, err := df.Columns[colName].At(i)
+		if err != nil {
+			return nil, fmt.Errorf("error accessing value: %w", err)
+		}
+		if value != nil {
+			row[idx] = opts.format(colName, value)
+		}
+		if schema != nil && schema[idx] == "string" {
+			row[idx] = quoteCSVNull(row[idx], value == nil)
+		}
+	}
+	return row, nil
+}
+
+// csvNullToken is the cell of a nil value in a string column written with a schema, an empty cell
+// being an empty string
+const csvNullToken = `\N`
+
+// quoteCSVNull returns the cell of a string column written with a schema: csvNullToken for nil, and
+// the strings that would be read as csvNullToken with one more backslash
+func quoteCSVNull(text string, null bool) string {
+	if null {
+		return csvNullToken
+	}
+	if isCSVNullToken(text) {
+		return `\` + text
+	}
+	return text
+}
+
+// unquoteCSVNull reverses quoteCSVNull for a cell that is not csvNullToken
+func unquoteCSVNull(cell string) string {
+	if isCSVNullToken(cell) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// isCSVNullToken reports whether a cell is csvNullToken preceded by any number of backslashes
+func isCSVNullToken(cell string) bool {
+	return strings.HasPrefix(cell, `\`) && strings.TrimLeft(cell, `\`) == "N"
+}
+
+// CSVSplitOption configures how ToCSVSplit splits a DataFrame. At least one of MaxRows and MaxBytes must be set.
+//
+// Fields:
+//   - MaxRows: The largest number of rows in a file, 0 for no limit.
+//   - MaxBytes: The largest size of a file in bytes, header included, 0 for no limit. A row larger than
+//     the limit on its own still gets a file.
+//   - Prefix: The name of the files before their number, e.g. "orders" for "orders-00001.csv". Defaults to "part".
+//   - CSVWriteOption: The formats of the values and the schema line of each file. AtomicWrite does not apply.
+type CSVSplitOption struct {
+	MaxRows  int
+	MaxBytes int64
+	Prefix   string
+	CSVWriteOption
+}
+
+// ToCSVSplit exports the DataFrame to several CSV files of limited size in a directory, each with the
+// header, for systems that cap upload sizes. The files are numbered from 1 in the order of the rows.
+//
+// Parameters:
+//   - dir: The directory of the files, created if it does not exist.
+//   - options: The CSVSplitOption struct with the limits of the files.
+//
+// Returns:
+//   - []string: The paths of the files, in order. A DataFrame without rows gives one file with the header.
+//   - error: An error if no limit is set or a file cannot be written.
+func (df *DataFrame) ToCSVSplit(dir string, options CSVSplitOption) ([]string, error) {
+	if options.MaxRows <= 0 && options.MaxBytes <= 0 {
+		return nil, fmt.Errorf("MaxRows or MaxBytes must be set")
+	}
+	if options.Prefix == "" {
+		options.Prefix = "part"
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating directory: %w", err)
+	}
+
+	columns := df.ColumnNames()
+	writeOpts := csvWriteOptions([]CSVWriteOption{options.CSVWriteOption})
+	header, err := encodeCSVRecord(columns)
+	if err != nil {
+		return nil, fmt.Errorf("error writing header: %w", err)
+	}
+	var schema []string
+	if writeOpts.WriteSchema {
+		schema = df.csvSchema(columns, writeOpts.TimeLayout)
+		line, err := encodeCSVRecord(schema)
+		if err != nil {
+			return nil, fmt.Errorf("error writing schema: %w", err)
+		}
+		header = append(header, line...)
+	}
+
+	var paths []string
+	var file *os.File
+	var size int64
+	rows := 0
+	next := func() error {
+		if file != nil {
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("error closing file: %w", err)
+			}
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%s-%05d.csv", options.Prefix, len(paths)+1))
+		var err error
+		if file, err = os.Create(path); err != nil {
+			return fmt.Errorf("error creating file: %w", err)
+		}
+		paths = append(paths, path)
+		size, rows = int64(len(header)), 0
+		if _, err := file.Write(header); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+		return nil
+	}
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	if err := next(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < df.Nrows(); i++ {
+		record, err := df.csvRecord(i, columns, writeOpts, schema)
+		if err != nil {
+			return nil, err
+		}
+		row, err := encodeCSVRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("error writing row: %w", err)
+		}
+		full := (options.MaxRows > 0 && rows == options.MaxRows) ||
+			(options.MaxBytes > 0 && rows > 0 && size+int64(len(row)) > options.MaxBytes)
+		if full {
+			if err := next(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := file.Write(row); err != nil {
+			return nil, fmt.Errorf("error writing row: %w", err)
+		}
+		size += int64(len(row))
+		rows++
+	}
+
+	err = file.Close()
+	file = nil
+	if err != nil {
+		return nil, fmt.Errorf("error closing file: %w", err)
+	}
+	return paths, nil
+}
+
+// encodeCSVRecord returns a record as a line of CSV
+func encodeCSVRecord(record []string) ([]byte, error) {
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+	if err := writeCSVRecord(csvWriter, &buf, record); err != nil {
+		return nil, err
+	}
+	csvWriter.Flush()
+	return buf.Bytes(), csvWriter.Error()
+}
+
+// writeFileAtomic writes a file through a temporary file in the same directory, renamed over the
+// file once written and synced, so the file is either the old one or the complete new one. write must
+// flush its buffers and return their errors, the file is kept only if it returns nil.
+func writeFileAtomic(filename string, write func(io.Writer) error) (err error) {
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	// the new file keeps the permissions of the one it replaces
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := file.Chmod(mode); err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	if err := write(file); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("error syncing file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing file: %w", err)
+	}
+	if err := os.Rename(file.Name(), filename); err != nil {
+		return fmt.Errorf("error renaming file: %w", err)
+	}
+	return nil
+}
+
+// writeCSVRecord writes a record, quoting a lone empty field that csv.Writer would write as a
+// blank line, which readers skip
+func writeCSVRecord(csvWriter *csv.Writer, writer io.Writer, record []string) error {
+	if len(record) != 1 || record[0] != "" {
+		return csvWriter.Write(record)
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\"\"\n")
+	return err
+}
@@ -0,0 +1,261 @@
+package dataframe
+
+/*
+
+	This is where the cumulative operations are defined: running sums, products, maximums and
+	minimums of a Series, of the numeric columns of a DataFrame, or within the groups of a
+	GroupedDataFrame, e.g. a running total per customer or the running peak used for drawdowns.
+
+	Missing values (nil or NaN) give nil and are skipped, the following rows go on from the
+	values before them.
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// cumulativeOps are the running aggregations, by name
+var cumulativeOps = map[string]func(acc, x float64) float64{
+	"sum":  func(acc, x float64) float64 { return acc + x },
+	"prod": func(acc, x float64) float64 { return acc * x },
+	"max":  math.Max,
+	"min":  math.Min,
+}
+
+// nanFloats converts the values to floats, missing values become NaN. It reports false if a value is not numeric.
+func nanFloats(values []any) ([]float64, bool) {
+	nums := make([]float64, len(values))
+	for i, v := range values {
+		if isMissing(v) {
+			nums[i] = math.NaN()
+			continue
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, false
+		}
+		nums[i] = f
+	}
+	return nums, true
+}
+
+// cumulate returns the running aggregation of the values, missing values give nil
+func cumulate(nums []float64, op string) []any {
+	fn := cumulativeOps[op]
+	out := make([]any, len(nums))
+	started := false
+	var acc float64
+	for i, x := range nums {
+		if math.IsNaN(x) {
+			continue
+		}
+		if started {
+			acc = fn(acc, x)
+		} else {
+			acc, started = x, true
+		}
+		out[i] = acc
+	}
+	return out
+}
+
+// CumSum returns the running total of the values.
+//
+// Returns:
+//   - *Series: The float64 totals, nil where the value is missing. Err returns an error if a value is not numeric.
+func (s *Series) CumSum() *Series {
+	return s.cumulative("sum")
+}
+
+// CumProd returns the running product of the values.
+//
+// Returns:
+//   - *Series: The float64 products, nil where the value is missing. Err returns an error if a value is not numeric.
+func (s *Series) CumProd() *Series {
+	return s.cumulative("prod")
+}
+
+// CumMax returns the running maximum of the values, e.g. the peak for drawdown calculations.
+//
+// Returns:
+//   - *Series: The float64 maximums, nil where the value is missing. Err returns an error if a value is not numeric.
+func (s *Series) CumMax() *Series {
+	return s.cumulative("max")
+}
+
+// CumMin returns the running minimum of the values.
+//
+// Returns:
+//   - *Series: The float64 minimums, nil where the value is missing. Err returns an error if a value is not numeric.
+func (s *Series) CumMin() *Series {
+	return s.cumulative("min")
+}
+
+// cumulative applies a running aggregation to the values of the series
+func (s *Series) cumulative(op string) *Series {
+	if err := s.Err(); err != nil {
+		return failedSeries(s.Name, err)
+	}
+	nums, ok := nanFloats(s.Data)
+	if !ok {
+		return failedSeries(s.Name, fmt.Errorf("column '%s' is not numeric", s.Name))
+	}
+	return NewSeries(s.Name, cumulate(nums, op))
+}
+
+// CumSum returns the running totals of the columns.
+//
+// Parameters:
+//   - columns (optional): The columns to use. Defaults to every numeric column.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the same columns, the other columns are copied as is.
+//   - error: An error if a column does not exist or holds non-numeric values.
+func (df *DataFrame) CumSum(columns ...string) (*DataFrame, error) {
+	return df.cumulative(columns, "sum")
+}
+
+// CumProd returns the running products of the columns, see CumSum.
+func (df *DataFrame) CumProd(columns ...string) (*DataFrame, error) {
+	return df.cumulative(columns, "prod")
+}
+
+// CumMax returns the running maximums of the columns, see CumSum.
+func (df *DataFrame) CumMax(columns ...string) (*DataFrame, error) {
+	return df.cumulative(columns, "max")
+}
+
+// CumMin returns the running minimums of the columns, see CumSum.
+func (df *DataFrame) CumMin(columns ...string) (*DataFrame, error) {
+	return df.cumulative(columns, "min")
+}
+
+// cumulative applies a running aggregation to the selected columns and copies the others
+func (df *DataFrame) cumulative(columns []string, op string) (*DataFrame, error) {
+	return df.mapNumericColumns(columns, func(name string, values []any) ([]any, error) {
+		nums, ok := nanFloats(values)
+		if !ok {
+			return nil, fmt.Errorf("column '%s' is not numeric", name)
+		}
+		return cumulate(nums, op), nil
+	})
+}
+
+// mapNumericColumns replaces the values of the selected columns, every numeric column by default, by
+// fn of them and copies the others
+func (df *DataFrame) mapNumericColumns(columns []string, fn func(name string, values []any) ([]any, error)) (*DataFrame, error) {
+	for _, name := range columns {
+		if _, exists := df.Columns[name]; !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+
+	result := NewDataFrame()
+	for _, name := range df.ColumnNames() {
+		values := df.Columns[name].Values()
+		data := append([]any{}, values...)
+		_, numeric := nanFloats(values)
+		if slices.Contains(columns, name) || (len(columns) == 0 && numeric) {
+			var err error
+			if data, err = fn(name, values); err != nil {
+				return nil, err
+			}
+		}
+		result.Columns[name] = &Column[any]{Name: name, Data: data}
+		result.order = append(result.order, name)
+	}
+	result.indexNames = df.indexNames
+	return result, nil
+}
+
+// CumSum returns the running totals of the columns within each group, e.g. the running spend of each customer.
+//
+// Parameters:
+//   - colNames (optional): The columns to use. Defaults to every numeric column but the group key.
+//
+// Returns:
+//   - *DataFrame: A copy of the grouped DataFrame with the running totals, its rows in their original order.
+//   - error: An error if the data cannot be grouped, or a column does not exist or holds non-numeric values.
+func (gdf *GroupedDataFrame) CumSum(colNames ...string) (*DataFrame, error) {
+	return gdf.cumulative(colNames, "sum")
+}
+
+// CumProd returns the running products of the columns within each group, see CumSum.
+func (gdf *GroupedDataFrame) CumProd(colNames ...string) (*DataFrame, error) {
+	return gdf.cumulative(colNames, "prod")
+}
+
+// CumMax returns the running maximums of the columns within each group, see CumSum.
+func (gdf *GroupedDataFrame) CumMax(colNames ...string) (*DataFrame, error) {
+	return gdf.cumulative(colNames, "max")
+}
+
+// CumMin returns the running minimums of the columns within each group, see CumSum.
+func (gdf *GroupedDataFrame) CumMin(colNames ...string) (*DataFrame, error) {
+	return gdf.cumulative(colNames, "min")
+}
+
+// cumulative applies a running aggregation to the columns of each group
+func (gdf *GroupedDataFrame) cumulative(colNames []string, op string) (*DataFrame, error) {
+	if gdf.Err != nil {
+		return nil, gdf.Err
+	}
+	if gdf.positions == nil {
+		return nil, fmt.Errorf("row positions are unknown, cumulative operations require a GroupedDataFrame created by Groupby")
+	}
+	for _, name := range colNames {
+		if !slices.Contains(gdf.columns, name) {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+
+	// by default a column is only aggregated if it is numeric in every group
+	data := make([][]any, len(gdf.columns))
+	cumulated := make([][]any, len(gdf.columns))
+	numeric := make([]bool, len(gdf.columns))
+	for c, name := range gdf.columns {
+		data[c] = make([]any, gdf.nRows)
+		selected := slices.Contains(colNames, name) || (len(colNames) == 0 && name != gdf.Key)
+		if selected {
+			cumulated[c] = make([]any, gdf.nRows)
+			numeric[c] = true
+		}
+	}
+	err := gdf.eachGroup(func(_ int, groupKey any, rows []map[string]any) error {
+		positions := gdf.positions[groupKey]
+		for c, name := range gdf.columns {
+			values := make([]any, len(rows))
+			for i, row := range rows {
+				values[i] = row[name]
+				data[c][positions[i]] = row[name]
+			}
+			if !numeric[c] {
+				continue
+			}
+			nums, ok := nanFloats(values)
+			if !ok {
+				if len(colNames) > 0 {
+					return fmt.Errorf("column '%s' is not numeric", name)
+				}
+				numeric[c] = false
+				continue
+			}
+			for i, v := range cumulate(nums, op) {
+				cumulated[c][positions[i]] = v
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for c := range data {
+		if numeric[c] {
+			data[c] = cumulated[c]
+		}
+	}
+	return fromColumnData(gdf.columns, data), nil
+}
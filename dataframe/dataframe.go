@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 	"math"
 )
 
@@ -22,6 +24,14 @@ import (
 // It provides methods for adding, removing, and manipulating columns and rows.
 type DataFrame struct {
 	Columns map[string]*Column[any] // Map column name to generic Column
+
+	order        []string                   // Column names in insertion order, see ColumnNames
+	columnLevels map[string][]string        // Multi-level header labels per column, see Pivot
+	indexNames   []string                   // Columns used as the row index, see SetIndex and SetMultiIndex
+	labelIndex   atomic.Pointer[labelIndex] // Cached label -> row position map of the index column, shared by concurrent readers
+	version      atomic.Uint64              // Incremented by the methods writing rows in place, invalidates labelIndex
+	typed        bool                       // Columns are stored natively, see NewTypedDataFrame
+	parallelism  int                        // Goroutines of the row-wise operations, see WithParallelism
 }
 
 // NewDataFrame creates a new empty DataFrame.
@@ -34,15 +44,54 @@ func NewDataFrame() *DataFrame {
 	}
 }
 
+// Copy returns a deep copy of the DataFrame, with the same columns, index and typed storage. Writing to
+// the copy, including to the Data of its columns, never changes df and the other way around.
+//
+// Most methods already return new DataFrames with their own data: Filter, Head, Tail, SortValues,
+// joins... The exceptions are the views, which share the storage of df to avoid copying it. The
+// rows of SliceRows, WithParallelism and Column.Slice are copied by the first DataFrame method
+// writing in place (FillNa, Append, DropRow...) to either side (copy-on-write), but assigning to
+// the elements of their Data writes through. Use Copy before such writes.
+//
+// Returns:
+//   - *DataFrame: The copy. Compressed columns are copied decoded.
+func (df *DataFrame) Copy() *DataFrame {
+	return df.clone()
+}
+
+// clone returns a deep copy of the DataFrame where every column has its own data slice.
+func (df *DataFrame) clone() *DataFrame {
+	newDf := NewDataFrame()
+	for name, col := range df.Columns {
+		newDf.Columns[name] = &Column[any]{
+			Name: col.Name,
+			Data: append([]any{}, col.Values()...),
+		}
+	}
+	newDf.order = df.ColumnNames()
+	for name, levels := range df.columnLevels {
+		newDf.setColumnLevels(name, levels)
+	}
+	newDf.indexNames = df.indexNames
+	newDf.typed = df.typed
+	newDf.parallelism = df.parallelism
+	for _, col := range newDf.Columns {
+		newDf.storeNative(col)
+	}
+	return newDf
+}
+
 // Nrows returns the number of rows in the DataFrame.
 //
 // Returns:
-//   - int: The number of rows in the DataFrame.
+//   - int: The number of rows in the DataFrame, the length of the longest column if columns were given
+//     different lengths by modifying their Data directly.
 func (df *DataFrame) Nrows() int {
+	nRows := 0 // 0 if there are no columns
 	for _, col := range df.Columns {
-		return col.Len() // Return the length of the first column
+		nRows = max(nRows, col.Len())
 	}
-	return 0 // Return 0 if there are no columns
+	return nRows
 }
 
 // Ncols returns the number of columns in the DataFrame.
@@ -53,6 +102,31 @@ func (df *DataFrame) Ncols() int {
 	return len(df.Columns)
 }
 
+// Shape returns the number of rows and columns in the DataFrame.
+//
+// Returns:
+//   - int: The number of rows, see Nrows.
+//   - int: The number of columns.
+func (df *DataFrame) Shape() (int, int) {
+	return df.Nrows(), df.Ncols()
+}
+
+// Empty reports whether the DataFrame has no cells, i.e. no rows or no columns.
+//
+// Returns:
+//   - bool: True if the DataFrame has no rows or no columns.
+func (df *DataFrame) Empty() bool {
+	return df.Size() == 0
+}
+
+// Size returns the number of cells in the DataFrame.
+//
+// Returns:
+//   - int: The number of rows times the number of columns.
+func (df *DataFrame) Size() int {
+	return df.Nrows() * df.Ncols()
+}
+
 // Select returns a column by name.
 //
 // Parameters:
@@ -61,11 +135,18 @@ func (df *DataFrame) Ncols() int {
 // Returns:
 //   - *Column[any]: The selected column.
 //   - error: An error if the column does not exist.
+//
+// Note:
+//   - Compressed and natively stored (typed) columns are returned decoded, as a copy whose Data can be
+//     read like that of any column: writing to it does not change df.
 func (df *DataFrame) Select(name string) (*Column[any], error) {
 	col, exists := df.Columns[name]
 	if !exists {
 		return nil, fmt.Errorf("column '%s' does not exist", name)
 	}
+	if col.encoded != nil || col.native != nil {
+		return &Column[any]{Name: col.Name, Data: col.Values()}, nil
+	}
 	return col, nil
 }
 
@@ -134,6 +215,7 @@ func (df *DataFrame) RowSlice(startIndex, endIndex int) *DataFrame {
 			Data: []any{},
 		}
 	}
+	newDf.order = df.ColumnNames()
 
 	if startIndex < 0 {
 		startIndex = 0
@@ -150,13 +232,14 @@ func (df *DataFrame) RowSlice(startIndex, endIndex int) *DataFrame {
 		if err != nil {
 			continue
 		}
-		_ = newDf.AppendRow(newDf, row)
+		_ = newDf.Append(row)
 	}
 
 	return newDf
 }
 
 // Filter returns a new DataFrame with rows that satisfy the given condition.
+// It builds a map per row for the condition, FilterRows is faster on large DataFrames.
 //
 // Parameters:
 //   - condition: A function that takes a row and returns true if the row should be included.
@@ -164,30 +247,78 @@ func (df *DataFrame) RowSlice(startIndex, endIndex int) *DataFrame {
 // Returns:
 //   - *DataFrame: A new DataFrame containing the filtered rows.
 func (df *DataFrame) Filter(condition func(row map[string]any) bool) *DataFrame {
-	filtered := NewDataFrame()
-
-	// Initialize new columns
-	for name := range df.Columns {
-		filtered.Columns[name] = &Column[any]{
-			Name: name,
-			Data: []any{},
+	names := df.ColumnNames()
+	return df.FilterRows(func(get func(col string) any) bool {
+		row := make(map[string]any, len(names))
+		for _, name := range names {
+			row[name] = get(name)
 		}
+		return condition(row)
+	})
+}
+
+// FilterRows returns a new DataFrame with the rows that satisfy the predicate. Unlike Filter, the
+// predicate reads the cells it needs through an accessor instead of receiving a map of the whole
+// row, which avoids an allocation per row on large DataFrames.
+//
+// Parameters:
+//   - predicate: A function that takes an accessor returning the value of a column in the current
+//     row (nil for unknown columns) and returns true if the row should be included. The accessor is
+//     only valid during the call. It is called concurrently with WithParallelism.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the filtered rows.
+func (df *DataFrame) FilterRows(predicate func(get func(col string) any) bool) *DataFrame {
+	values := make(map[string][]any, len(df.Columns))
+	for name, col := range df.Columns {
+		values[name] = col.Values()
 	}
 
-	// Iterate through rows and apply the condition
+	// each range of rows has its own accessor
+	ranges := parallelMap(df.parallelism, df.Nrows(), func(start, end int) []int {
+		row := start
+		get := func(col string) any {
+			if data, exists := values[col]; exists {
+				return data[row]
+			}
+			return nil
+		}
+		rowsToKeep := []int{}
+		for ; row < end; row++ {
+			if predicate(get) {
+				rowsToKeep = append(rowsToKeep, row)
+			}
+		}
+		return rowsToKeep
+	})
+	return df.takeRows(slices.Concat(ranges...))
+}
+
+// FilterSafe works like Filter but recovers from panics raised by the condition (e.g. a type
+// assertion on a nil cell) and returns them as an error mentioning the offending row.
+//
+// Parameters:
+//   - condition: A function that takes a row and returns true if the row should be included.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the filtered rows.
+//   - error: An error if the condition panicked on a row.
+func (df *DataFrame) FilterSafe(condition func(row map[string]any) bool) (*DataFrame, error) {
+	rowsToKeep := []int{}
 	for i := 0; i < df.Nrows(); i++ {
 		row, err := df.Row(i)
 		if err != nil {
-			continue
+			return nil, err
 		}
-		if condition(row) {
-			for name, value := range row {
-				filtered.Columns[name].Data = append(filtered.Columns[name].Data, value)
-			}
+		keep := false
+		if err := recoverRow(i, func() { keep = condition(row) }); err != nil {
+			return nil, err
+		}
+		if keep {
+			rowsToKeep = append(rowsToKeep, i)
 		}
 	}
-
-	return filtered
+	return df.takeRows(rowsToKeep), nil
 }
 
 // String returns a string representation of the DataFrame.
@@ -242,21 +373,10 @@ func (df *DataFrame) String() string {
 //   - n: The number of rows to return.
 //
 // Returns:
-//   - *DataFrame: A new DataFrame containing the first n rows.
+//   - *DataFrame: A new DataFrame containing the first n rows, a copy safe to modify (SliceRows returns a view instead).
 func (df *DataFrame) Head(n int) *DataFrame {
-	if n > df.Nrows() {
-		n = df.Nrows()
-	}
-
-	head := NewDataFrame()
-	for name, col := range df.Columns {
-		newCol := &Column[any]{
-			Name: name,
-			Data: col.Data[:n],
-		}
-		head.Columns[name] = newCol
-	}
-	return head
+	n = max(0, min(n, df.Nrows()))
+	return df.copyRows(0, n)
 }
 
 // Tail returns the last n rows of the DataFrame.
@@ -265,22 +385,62 @@ func (df *DataFrame) Head(n int) *DataFrame {
 //   - n: The number of rows to return.
 //
 // Returns:
-//   - *DataFrame: A new DataFrame containing the last n rows.
+//   - *DataFrame: A new DataFrame containing the last n rows, a copy safe to modify (SliceRows returns a view instead).
 func (df *DataFrame) Tail(n int) *DataFrame {
 	totalRows := df.Nrows()
-	if n > totalRows {
-		n = totalRows
+	n = max(0, min(n, totalRows))
+	return df.copyRows(totalRows-n, totalRows)
+}
+
+// SliceRows returns the rows [start, end) of the DataFrame without copying them: every column is a
+// view over the storage of df, see Column.Slice. The rows are copied on the first in-place write
+// to either DataFrame and appending to either one never writes into the other.
+// Unlike RowSlice, the bounds are not clamped.
+//
+// Parameters:
+//   - start: The position of the first row.
+//   - end: The position after the last row.
+//
+// Returns:
+//   - *DataFrame: The view, with the columns, index and typed storage of df.
+//   - error: An error if the bounds are out of range.
+func (df *DataFrame) SliceRows(start, end int) (*DataFrame, error) {
+	if start < 0 || end < start || end > df.Nrows() {
+		return nil, fmt.Errorf("slice bounds [%d:%d] out of range for %d rows", start, end, df.Nrows())
 	}
 
-	tail := NewDataFrame()
+	view := NewDataFrame()
 	for name, col := range df.Columns {
-		newCol := &Column[any]{
-			Name: name,
-			Data: col.Data[totalRows-n:],
+		newCol, err := col.Slice(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", name, err)
 		}
-		tail.Columns[name] = newCol
+		view.Columns[name] = newCol
+	}
+	view.order = df.ColumnNames()
+	for name, levels := range df.columnLevels {
+		view.setColumnLevels(name, levels)
+	}
+	view.indexNames = df.indexNames
+	view.typed = df.typed
+	return view, nil
+}
+
+// copyRows returns a copy of the rows [start, end) of the DataFrame, keeping its columns, index and typed storage
+func (df *DataFrame) copyRows(start, end int) *DataFrame {
+	result := NewDataFrame()
+	for name, col := range df.Columns {
+		// columns shorter than the DataFrame keep the rows they have
+		result.Columns[name] = col.copyRows(min(start, col.Len()), min(end, col.Len()))
+	}
+	result.order = df.ColumnNames()
+	for name, levels := range df.columnLevels {
+		result.setColumnLevels(name, levels)
 	}
-	return tail
+	result.indexNames = df.indexNames
+	result.typed = df.typed
+	result.parallelism = df.parallelism
+	return result
 }
 
 // DropRow removes a row by index from the DataFrame
@@ -290,7 +450,9 @@ func (df *DataFrame) DropRow(i int) error {
 	}
 
 	for _, col := range df.Columns {
-		col.Data = append(col.Data[:i], col.Data[i+1:]...)
+		data := col.Values()
+		// a new slice, the rows may be shared with views returned by SliceRows
+		df.setColumnData(col, slices.Concat(data[:i], data[i+1:]))
 	}
 	return nil
 }
@@ -307,19 +469,21 @@ func checkExists(df *DataFrame, other *DataFrame, key string) error {
 }
 
 func appendCols(df *DataFrame, other *DataFrame, result *DataFrame) error {
-	// Add columns from both DataFrames to the result
-	for name := range df.Columns {
+	// Add columns from both DataFrames to the result, the columns of df come first
+	for _, name := range df.ColumnNames() {
 		result.Columns[name] = &Column[any]{
 			Name: name,
 			Data: []any{},
 		}
+		result.order = append(result.order, name)
 	}
-	for name := range other.Columns {
+	for _, name := range other.ColumnNames() {
 		if _, exists := result.Columns[name]; !exists {
 			result.Columns[name] = &Column[any]{
 				Name: name,
 				Data: []any{},
 			}
+			result.order = append(result.order, name)
 		}
 	}
 
@@ -338,14 +502,29 @@ func mergeRows(rowA, rowB map[string]any) map[string]any {
 	return merged
 }
 
+// AppendRow appends a row to result, the receiver is not used.
+//
+// Deprecated: AppendRow appends to its argument instead of the receiver, use result.Append(row).
 func (df *DataFrame) AppendRow(result *DataFrame, row map[string]any) error {
+	return result.Append(row)
+}
 
-	// Add new columns if they don't exist.
+// Append adds a row at the end of the DataFrame. Columns of the row that the DataFrame does not have
+// are added and filled with nil for the earlier rows, columns missing from the row get a nil value.
+//
+// Parameters:
+//   - row: A map of column names to values.
+//
+// Returns:
+//   - error: An error if a new column cannot be added.
+func (df *DataFrame) Append(row map[string]any) error {
+	// Add new columns if they don't exist, with nil values for the earlier rows.
+	n := df.Nrows()
 	for name := range row {
-		if _, exists := result.Columns[name]; !exists {
-			newCol := NewColumn(name, make([]any, 0))
-			// add the new column to the result dataframe
-			err := result.AddColumn(ConvertToAnyColumn(newCol))
+		if _, exists := df.Columns[name]; !exists {
+			newCol := NewColumn(name, make([]any, n))
+			// add the new column to the dataframe
+			err := df.AddColumn(ConvertToAnyColumn(newCol))
 			if err != nil {
 				return fmt.Errorf("error adding column: %v", err)
 			}
@@ -353,36 +532,368 @@ func (df *DataFrame) AppendRow(result *DataFrame, row map[string]any) error {
 	}
 
 	// In the new Columns, put nil placeholders
-	for name, col := range result.Columns {
+	for name, col := range df.Columns {
 		if _, exists := row[name]; !exists {
 			// Append a nil value if the new row doesn't have data for this column.
-			col.Data = append(col.Data, nil)
+			df.appendValue(col, nil)
 		}
 	}
 
 	// Append the new row's data.
 	for name, value := range row {
-		result.Columns[name].Data = append(result.Columns[name].Data, value)
+		df.appendValue(df.Columns[name], value)
 	}
 
 	return nil
+}
+
+// AppendRows adds several rows at the end of the DataFrame, like calling Append for each row but
+// growing every column once. Columns of the rows that the DataFrame does not have are added in the
+// order the rows introduce them (sorted within a row) and filled with nil for the earlier rows.
+//
+// Parameters:
+//   - rows: The rows to append, maps of column names to values.
+//
+// Returns:
+//   - error: An error if a new column cannot be added.
+func (df *DataFrame) AppendRows(rows []map[string]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	n := df.Nrows()
+	for _, row := range rows {
+		var added []string
+		for name := range row {
+			if _, exists := df.Columns[name]; !exists {
+				added = append(added, name)
+			}
+		}
+		slices.Sort(added)
+		for _, name := range added {
+			if err := df.AddColumn(&Column[any]{Name: name, Data: make([]any, n, n+len(rows))}); err != nil {
+				return fmt.Errorf("error adding column: %v", err)
+			}
+		}
+	}
+
+	for name, col := range df.Columns {
+		// natively stored columns stay typed while the values fit
+		if col.native != nil {
+			for _, row := range rows {
+				df.appendValue(col, row[name])
+			}
+			continue
+		}
+		data := slices.Grow(col.Values(), len(rows))
+		for _, row := range rows {
+			data = append(data, row[name])
+		}
+		df.setColumnData(col, data)
+	}
+	return nil
+}
+
+// FromRows creates a DataFrame from rows given as maps of column names to values.
+// Columns missing from a row get a nil value.
+//
+// Parameters:
+//   - rows: The rows of the DataFrame.
+//   - columnOrder: The columns of the DataFrame, in order. Every column of the rows must be listed.
+//     When empty, the columns of all the rows are used in alphabetical order.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with one column per name of columnOrder.
+//   - error: An error if a column is listed twice or a row has a column that is not listed.
+func FromRows(rows []map[string]any, columnOrder []string) (*DataFrame, error) {
+	if len(columnOrder) == 0 {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			for name := range row {
+				seen[name] = true
+			}
+		}
+		columnOrder = slices.Sorted(maps.Keys(seen))
+	}
+
+	positions := make(map[string]int, len(columnOrder))
+	for i, name := range columnOrder {
+		if _, exists := positions[name]; exists {
+			return nil, fmt.Errorf("duplicate column '%s'", name)
+		}
+		positions[name] = i
+	}
+
+	data := make([][]any, len(columnOrder))
+	for i := range data {
+		data[i] = make([]any, len(rows))
+	}
+	for r, row := range rows {
+		for name, value := range row {
+			i, exists := positions[name]
+			if !exists {
+				return nil, fmt.Errorf("row %d has column '%s' which is not in the column order", r, name)
+			}
+			data[i][r] = value
+		}
+	}
+	return fromColumnData(columnOrder, data), nil
+}
+
+// FromRecords creates a DataFrame from records given as slices of values, e.g. rows read from a database or a CSV file.
+//
+// Parameters:
+//   - records: The rows of the DataFrame, each with one value per column of header.
+//   - header: The column names, in order.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with one column per name of header.
+//   - error: An error if a column name is listed twice or a record does not have one value per column.
+func FromRecords(records [][]any, header []string) (*DataFrame, error) {
+	seen := make(map[string]bool, len(header))
+	for _, name := range header {
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column '%s'", name)
+		}
+		seen[name] = true
+	}
+
+	data := make([][]any, len(header))
+	for i := range data {
+		data[i] = make([]any, len(records))
+	}
+	for r, record := range records {
+		if len(record) != len(header) {
+			return nil, fmt.Errorf("record %d has %d values, expected %d", r, len(record), len(header))
+		}
+		for i, value := range record {
+			data[i][r] = value
+		}
+	}
+	return fromColumnData(header, data), nil
+}
+
+// FromMap creates a DataFrame from the values of each column. Map keys are unordered, so the columns
+// are sorted alphabetically, use FromColumns to choose their order.
+//
+// Parameters:
+//   - data: The values of each column, by column name.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame sharing the value slices of data.
+//   - error: An error if the columns do not all have the same length.
+func FromMap(data map[string][]any) (*DataFrame, error) {
+	names := slices.Sorted(maps.Keys(data))
+	values := make([][]any, len(names))
+	for i, name := range names {
+		if len(data[name]) != len(data[names[0]]) {
+			return nil, fmt.Errorf("column '%s' has %d rows, expected %d", name, len(data[name]), len(data[names[0]]))
+		}
+		values[i] = data[name]
+	}
+	return fromColumnData(names, values), nil
+}
+
+// FromColumns creates a DataFrame from columns, in the given order, e.g.
+// FromColumns(NewColumn[any]("id", []any{1, 2}), NewColumn[any]("name", []any{"a", "b"})).
+//
+// Parameters:
+//   - cols: The columns of the DataFrame, added as is without copying them.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame.
+//   - error: An error if a column is nil, two columns have the same name or the columns do not all have the same length.
+func FromColumns(cols ...*Column[any]) (*DataFrame, error) {
+	df := NewDataFrame()
+	for i, col := range cols {
+		if col == nil {
+			return nil, fmt.Errorf("column %d is nil", i)
+		}
+		if _, exists := df.Columns[col.Name]; exists {
+			return nil, fmt.Errorf("duplicate column '%s'", col.Name)
+		}
+		if col.Len() != cols[0].Len() {
+			return nil, fmt.Errorf("column '%s' has %d rows, expected %d", col.Name, col.Len(), cols[0].Len())
+		}
+		df.Columns[col.Name] = col
+		df.order = append(df.order, col.Name)
+	}
+	return df, nil
+}
+
+// fromColumnData creates a DataFrame from the data of each column, in order
+func fromColumnData(names []string, data [][]any) *DataFrame {
+	df := NewDataFrame()
+	for i, name := range names {
+		df.Columns[name] = &Column[any]{Name: name, Data: data[i]}
+	}
+	df.order = slices.Clone(names)
+	return df
+}
+
+// Concat combines several DataFrames into one, replacing loops of Append.
+//
+// Parameters:
+//   - dfs: The DataFrames to combine, in order.
+//   - axis: 0 stacks the rows, aligning the columns by name and filling the columns missing from a
+//     DataFrame with nil. 1 puts the columns side by side, the DataFrames must have the same number of rows
+//     and distinct column names.
+//   - ignoreIndex: Drops the index of the result, the index columns are kept as regular columns. Otherwise
+//     all the DataFrames must have the same index columns, which the result keeps; with axis 1 their
+//     values must match row by row and the index columns appear once.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with its own copy of the data.
+//   - error: An error if there is no DataFrame, the axis is invalid, or the lengths, column names or indexes do not line up.
+func Concat(dfs []*DataFrame, axis int, ignoreIndex bool) (*DataFrame, error) {
+	if len(dfs) == 0 {
+		return nil, fmt.Errorf("no DataFrames to concatenate")
+	}
+	for i, frame := range dfs {
+		if frame == nil {
+			return nil, fmt.Errorf("DataFrame %d is nil", i)
+		}
+	}
+	if axis != 0 && axis != 1 {
+		return nil, fmt.Errorf("invalid axis %d (must be 0 or 1)", axis)
+	}
+
+	var indexNames []string
+	if !ignoreIndex {
+		indexNames = dfs[0].indexNames
+		for i, frame := range dfs[1:] {
+			if !slices.Equal(frame.IndexNames(), dfs[0].IndexNames()) {
+				return nil, fmt.Errorf("DataFrame %d is indexed by %v, expected %v (use ignoreIndex to drop the indexes)",
+					i+1, frame.IndexNames(), dfs[0].IndexNames())
+			}
+		}
+	}
+
+	if axis == 0 {
+		result := concatRows(dfs)
+		result.indexNames = indexNames
+		return result, nil
+	}
+
+	result := NewDataFrame()
+	rows := dfs[0].Nrows()
+	for i, frame := range dfs {
+		if frame.Nrows() != rows {
+			return nil, fmt.Errorf("DataFrame %d has %d rows, expected %d", i, frame.Nrows(), rows)
+		}
+		for _, name := range frame.ColumnNames() {
+			values := frame.Columns[name].Values()
+			if i > 0 && slices.Contains(indexNames, name) {
+				first := dfs[0].Columns[name].Values()
+				for row := range values {
+					if !reflect.DeepEqual(values[row], first[row]) {
+						return nil, fmt.Errorf("DataFrame %d has index value %v at row %d, expected %v", i, values[row], row, first[row])
+					}
+				}
+				continue
+			}
+			if _, exists := result.Columns[name]; exists {
+				return nil, fmt.Errorf("column '%s' exists in several DataFrames", name)
+			}
+			result.Columns[name] = &Column[any]{Name: name, Data: append([]any{}, values...)}
+			result.order = append(result.order, name)
+		}
+	}
+	result.indexNames = indexNames
+	return result, nil
+}
+
+// concatRows stacks the rows of several DataFrames, aligning columns by name.
+// Rows of a DataFrame that is missing a column get nil values in that column.
+func concatRows(frames []*DataFrame) *DataFrame {
+	result := NewDataFrame()
+	total := 0
+	for _, frame := range frames {
+		for _, name := range frame.ColumnNames() {
+			if _, exists := result.Columns[name]; !exists {
+				result.Columns[name] = &Column[any]{Name: name, Data: []any{}}
+				result.order = append(result.order, name)
+			}
+		}
+		total += frame.Nrows()
+	}
 
+	for name, col := range result.Columns {
+		data := make([]any, 0, total)
+		for _, frame := range frames {
+			if src, exists := frame.Columns[name]; exists {
+				data = append(data, src.Values()...)
+			} else {
+				data = append(data, make([]any, frame.Nrows())...)
+			}
+		}
+		col.Data = data
+	}
+
+	return result
 }
 
 // ColumnNames returns the names of all columns in the DataFrame.
 //
 // Returns:
-//   - []string: A sorted list of column names.
+//   - []string: The column names in insertion order. Columns assigned directly to the Columns map
+//     come last, sorted alphabetically.
 func (df *DataFrame) ColumnNames() []string {
 	names := make([]string, 0, len(df.Columns))
+	seen := make(map[string]bool, len(df.Columns))
+	for _, name := range df.order {
+		if _, exists := df.Columns[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	extra := []string{}
 	for name := range df.Columns {
-		names = append(names, name)
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra) // Ensure consistent order
+	return append(names, extra...)
+}
+
+// ReorderColumns changes the order of the columns.
+//
+// Parameters:
+//   - names: The column names in their new order. Columns that are not listed keep their
+//     relative order after the listed ones.
+//
+// Returns:
+//   - error: An error if a column does not exist or is listed twice.
+func (df *DataFrame) ReorderColumns(names []string) error {
+	listed := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, exists := df.Columns[name]; !exists {
+			return fmt.Errorf("column '%s' does not exist", name)
+		}
+		if listed[name] {
+			return fmt.Errorf("column '%s' is listed more than once", name)
+		}
+		listed[name] = true
+	}
+
+	order := append([]string{}, names...)
+	for _, name := range df.ColumnNames() {
+		if !listed[name] {
+			order = append(order, name)
+		}
 	}
-	sort.Strings(names) // Ensure consistent order
-	return names
+	df.order = order
+	return nil
 }
 
-// RenameColumn renames a column in the DataFrame
+// removeFromOrder removes a column name from the column order
+func (df *DataFrame) removeFromOrder(name string) {
+	df.order = slices.DeleteFunc(df.order, func(n string) bool { return n == name })
+}
+
+// RenameColumn renames a column in the DataFrame, a renamed index column stays in the index
 func (df *DataFrame) RenameColumn(oldName, newName string) error {
 	col, exists := df.Columns[oldName]
 	if !exists {
@@ -392,12 +903,132 @@ func (df *DataFrame) RenameColumn(oldName, newName string) error {
 		return fmt.Errorf("column '%s' already exists", newName)
 	}
 
+	df.order = df.ColumnNames()
+	df.order[slices.Index(df.order, oldName)] = newName
+
 	col.Name = newName
 	df.Columns[newName] = col
 	delete(df.Columns, oldName)
+	delete(df.columnLevels, oldName)
+	df.renameIndex(map[string]string{oldName: newName})
+	return nil
+}
+
+// renameIndex follows the renaming of the index columns, see SetIndex
+func (df *DataFrame) renameIndex(mapping map[string]string) {
+	// a new slice, the index names may be shared with other DataFrames
+	names := slices.Clone(df.indexNames)
+	changed := false
+	for i, name := range names {
+		if newName, renamed := mapping[name]; renamed && newName != name {
+			names[i] = newName
+			changed = true
+		}
+	}
+	if changed {
+		df.indexNames = names
+		df.labelIndex.Store(nil)
+	}
+}
+
+// RenameColumns renames several columns at once. All renames are applied together, so names
+// can be swapped (e.g. {"a": "b", "b": "a"}), and the DataFrame is left unchanged on error. Renamed
+// index columns stay in the index.
+//
+// Parameters:
+//   - mapping: The new name of each renamed column, keyed by its current name.
+//
+// Returns:
+//   - error: An error if a column does not exist or two columns would end up with the same name.
+func (df *DataFrame) RenameColumns(mapping map[string]string) error {
+	for oldName := range mapping {
+		if _, exists := df.Columns[oldName]; !exists {
+			return fmt.Errorf("column '%s' does not exist", oldName)
+		}
+	}
+
+	order := df.ColumnNames()
+	seen := make(map[string]bool, len(order))
+	for i, name := range order {
+		if newName, renamed := mapping[name]; renamed {
+			order[i] = newName
+		}
+		if seen[order[i]] {
+			return fmt.Errorf("column '%s' already exists", order[i])
+		}
+		seen[order[i]] = true
+	}
+
+	columns := make(map[string]*Column[any], len(order))
+	for oldName, col := range df.Columns {
+		newName, renamed := mapping[oldName]
+		if !renamed || newName == oldName {
+			columns[oldName] = col
+			continue
+		}
+		col.Name = newName
+		columns[newName] = col
+		delete(df.columnLevels, oldName)
+	}
+	df.Columns = columns
+	df.order = order
+	df.renameIndex(mapping)
 	return nil
 }
 
+// RenameColumnsFunc renames every column with a function, e.g. strings.ToLower or a snake_case converter.
+//
+// Parameters:
+//   - fn: The function returning the new name of a column from its current name.
+//
+// Returns:
+//   - error: An error if two columns would end up with the same name, the DataFrame is then left unchanged.
+func (df *DataFrame) RenameColumnsFunc(fn func(string) string) error {
+	mapping := make(map[string]string, len(df.Columns))
+	for _, name := range df.ColumnNames() {
+		mapping[name] = fn(name)
+	}
+	return df.RenameColumns(mapping)
+}
+
+// AddPrefix prepends a prefix to the names of the given columns, e.g. before concatenating
+// similarly named DataFrames side by side.
+//
+// Parameters:
+//   - prefix: The prefix to prepend.
+//   - columns (optional): The columns to rename, all columns by default.
+//
+// Returns:
+//   - error: An error if a column does not exist or a new name is already taken.
+func (df *DataFrame) AddPrefix(prefix string, columns ...string) error {
+	return df.renameSelected(columns, func(name string) string { return prefix + name })
+}
+
+// AddSuffix appends a suffix to the names of the given columns, e.g. before concatenating
+// similarly named DataFrames side by side.
+//
+// Parameters:
+//   - suffix: The suffix to append.
+//   - columns (optional): The columns to rename, all columns by default.
+//
+// Returns:
+//   - error: An error if a column does not exist or a new name is already taken.
+func (df *DataFrame) AddSuffix(suffix string, columns ...string) error {
+	return df.renameSelected(columns, func(name string) string { return name + suffix })
+}
+
+// renameSelected renames the given columns, or all columns when none is given, with a function
+func (df *DataFrame) renameSelected(columns []string, fn func(string) string) error {
+	if len(columns) == 0 {
+		return df.RenameColumnsFunc(fn)
+	}
+	mapping := make(map[string]string, len(columns))
+	for _, name := range columns {
+		mapping[name] = fn(name)
+	}
+	return df.RenameColumns(mapping)
+}
+
 // AddColumn adds a generic column to the DataFrame.
 //
 // Parameters:
@@ -412,6 +1043,9 @@ func (df *DataFrame) AddColumn(col *Column[any]) error {
 	}
 
 	df.Columns[col.Name] = col
+	df.removeFromOrder(col.Name)
+	df.order = append(df.order, col.Name)
+	df.storeNative(col)
 	return nil
 }
 
@@ -428,9 +1062,69 @@ func (df *DataFrame) DropColumn(name string) error {
 	}
 
 	delete(df.Columns, name)
+	delete(df.columnLevels, name)
+	df.removeFromOrder(name)
 	return nil
 }
 
+// DropColumns removes several columns from the DataFrame. Nothing is removed if a column does not exist.
+//
+// Parameters:
+//   - names: The names of the columns to remove.
+//
+// Returns:
+//   - error: An error if a column does not exist.
+func (df *DataFrame) DropColumns(names ...string) error {
+	for _, name := range names {
+		if _, exists := df.Columns[name]; !exists {
+			return fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+	for _, name := range names {
+		if _, exists := df.Columns[name]; exists {
+			df.DropColumn(name)
+		}
+	}
+	return nil
+}
+
+// DropColumnsMatching removes the columns whose name matches a regular expression, e.g. "^tmp_" or "_id$".
+//
+// Parameters:
+//   - pattern: The regular expression matched against the column names (see regexp.MatchString).
+//
+// Returns:
+//   - []string: The names of the removed columns, in column order.
+//   - error: An error if the pattern is invalid.
+func (df *DataFrame) DropColumnsMatching(pattern string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return df.DropColumnsIf(func(name string, _ *Column[any]) bool { return re.MatchString(name) }), nil
+}
+
+// DropColumnsIf removes the columns for which a predicate returns true, e.g. columns holding only
+// nil values or a single constant value.
+//
+// Parameters:
+//   - predicate: The function called with the name and the column of every column.
+//
+// Returns:
+//   - []string: The names of the removed columns, in column order.
+func (df *DataFrame) DropColumnsIf(predicate func(name string, col *Column[any]) bool) []string {
+	dropped := []string{}
+	for _, name := range df.ColumnNames() {
+		if predicate(name, df.Columns[name]) {
+			dropped = append(dropped, name)
+		}
+	}
+	for _, name := range dropped {
+		df.DropColumn(name)
+	}
+	return dropped
+}
+
 // NewColumn creates a new typed column
 func NewColumn[T any](name string, data []T) *Column[T] {
 	return &Column[T]{
@@ -454,9 +1148,10 @@ type FuncType func([]any) any
 //
 // Note:
 //   - The method signature of the custom function needs to match the FuncType type: 'func(x any) any'
+//
+// Deprecated: a single value returned by function is repeated over the whole column or row and errors
+// cannot be reported. Use ApplyRows or ApplyColumns instead.
 func (df *DataFrame) Apply(function FuncType, axis ...int) (any, error) {
-	startNow := time.Now()
-
 	// default to 0 if user did not pass 'axis' parameter
 	if axis == nil {
 		axis = []int{0}
@@ -464,29 +1159,114 @@ func (df *DataFrame) Apply(function FuncType, axis ...int) (any, error) {
 	// =============== Creation of Result from function ===============
 	// column wise operation (basically operate on all the numbers in the current column only)
 	if axis[0] == 0 {
-		fmt.Printf("Apply operation took: %v \n", time.Since(startNow))
 		return df.applyColumnWise(function)
+	}
+	return df.applyRowWise(function)
+}
 
-	} else {
-		fmt.Printf("Apply operation took: %v \n", time.Since(startNow))
-		return df.applyRowWise(function)
+// ApplyRows calls a function with every row of the DataFrame, e.g. to compute a value from several columns.
+//
+// Parameters:
+//   - fn: The function returning the value of a row, or an error that stops ApplyRows. A panic in fn is
+//     returned as an error too. The row must not be modified. It is called concurrently with WithParallelism.
+//
+// Returns:
+//   - *Series: The values returned by fn, one per row, e.g. to add with WithColumn.
+//   - error: An error with the row position if fn fails.
+func (df *DataFrame) ApplyRows(fn func(row Row) (any, error)) (*Series, error) {
+	names := df.ColumnNames()
+	columns := make([][]any, len(names))
+	for c, name := range names {
+		columns[c] = df.Columns[name].Values()
 	}
 
+	result := make([]any, df.Nrows())
+	// the first error of each range of rows
+	errs := parallelMap(df.parallelism, len(result), func(start, end int) error {
+		for i := start; i < end; i++ {
+			row := make(Row, len(names))
+			for c, name := range names {
+				row[name] = columns[c][i]
+			}
+			var err error
+			if panicErr := recoverRow(i, func() { result[i], err = fn(row) }); panicErr != nil {
+				return fmt.Errorf("error applying function: %w", panicErr)
+			}
+			if err != nil {
+				return fmt.Errorf("error applying function at row %d: %w", i, err)
+			}
+		}
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewSeries("", result), nil
+}
+
+// ApplyColumns calls a function with every column of the DataFrame and builds a new DataFrame from the
+// returned columns, e.g. to scale or aggregate each column.
+//
+// Parameters:
+//   - fn: The function returning the new column, or an error that stops ApplyColumns. It receives a copy of
+//     each column, in column order, so it may modify it. The returned column keeps the name of the original
+//     one if its Name is empty. A panic in fn is returned as an error too.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the returned columns, which may all have fewer rows than df (e.g. one
+//     row of totals) but must have the same length.
+//   - error: An error if fn fails or returns nil, the returned columns have different lengths or the same name.
+func (df *DataFrame) ApplyColumns(fn func(col *Column[any]) (*Column[any], error)) (*DataFrame, error) {
+	result := NewDataFrame()
+	nRows := -1
+	for _, name := range df.ColumnNames() {
+		col := &Column[any]{Name: name, Data: append([]any{}, df.Columns[name].Values()...)}
+		var applied *Column[any]
+		var err error
+		if panicErr := recoverPanic(func() { applied, err = fn(col) }); panicErr != nil {
+			return nil, fmt.Errorf("error applying function to column '%s': %w", name, panicErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error applying function to column '%s': %w", name, err)
+		}
+		if applied == nil {
+			return nil, fmt.Errorf("function returned no column for column '%s'", name)
+		}
+
+		newName := applied.Name
+		if newName == "" {
+			newName = name
+		}
+		if _, exists := result.Columns[newName]; exists {
+			return nil, fmt.Errorf("duplicate column name '%s'", newName)
+		}
+		values := applied.Values()
+		if nRows >= 0 && len(values) != nRows {
+			return nil, fmt.Errorf("column '%s' has %d rows, expected %d", newName, len(values), nRows)
+		}
+		nRows = len(values)
+		result.Columns[newName] = &Column[any]{Name: newName, Data: values}
+		result.order = append(result.order, newName)
+	}
+	return result, nil
 }
 
 func (df *DataFrame) applyColumnWise(fn FuncType) (any, error) {
 
 	results := make(map[string][]any)
 
-	for colName, colValue := range df.Columns {
+	for _, colName := range df.ColumnNames() {
+		colValue := df.Columns[colName]
 
 		// initialize the slice if it doesn't exist yet
 		if _, exists := results[colName]; !exists {
-			results[colName] = make([]any, len(colValue.Data)) // create a slice with the same length as the column
+			results[colName] = make([]any, colValue.Len()) // create a slice with the same length as the column
 		}
 
 		// here, the function is applied once per column
-		result := fn(colValue.Data) // Pass the entire column data to fn
+		result := fn(colValue.Values()) // Pass the entire column data to fn
 
 		switch value := result.(type) {
 		case []any:
@@ -519,7 +1299,7 @@ func (df *DataFrame) applyColumnWise(fn FuncType) (any, error) {
 
 		case any:
 			// if the function returns a single value, repeat it for every element in the column
-			repeated := make([]any, len(colValue.Data))
+			repeated := make([]any, colValue.Len())
 			for i := range repeated {
 				repeated[i] = value
 			}
@@ -532,7 +1312,7 @@ func (df *DataFrame) applyColumnWise(fn FuncType) (any, error) {
 
 	}
 
-	return consolidateResults(results)
+	return consolidateResults(results, df.ColumnNames())
 }
 
 type rowResult struct {
@@ -576,8 +1356,12 @@ func (df *DataFrame) applyRowWise(fn FuncType) (any, error) {
 					rowData[j] = row[colName]
 				}
 
-				// execute the custom function
-				res := fn(rowData)
+				// execute the custom function, a panic is reported as an error instead of crashing the worker
+				var res any
+				if err := recoverRow(i, func() { res = fn(rowData) }); err != nil {
+					resultsChan <- rowResult{index: i, err: err}
+					continue
+				}
 				resultsChan <- rowResult{index: i, data: res}
 
 			}
@@ -626,18 +1410,18 @@ func (df *DataFrame) applyRowWise(fn FuncType) (any, error) {
 		}
 	}
 
-	return consolidateResults(finalResults)
+	return consolidateResults(finalResults, nCols)
 }
 
-func consolidateResults(results map[string][]any) (*DataFrame, error) {
+func consolidateResults(results map[string][]any, order []string) (*DataFrame, error) {
 
 	if len(results) == 0 {
 		return NewDataFrame(), fmt.Errorf("function returns no data")
 	}
 	finalDf := NewDataFrame()
 
-	for key, value := range results {
-		col := NewColumn(key, value)
+	for _, key := range order {
+		col := NewColumn(key, results[key])
 		finalDf.AddColumn(col)
 	}
 
@@ -645,6 +1429,44 @@ func consolidateResults(results map[string][]any) (*DataFrame, error) {
 
 }
 
+// ApplyTo applies a function to every value of one column, e.g. to normalize or parse it, without
+// the all-columns Apply machinery.
+//
+// Parameters:
+//   - colName: The name of the column.
+//   - fn: The function returning the new value of each cell, or an error that stops ApplyTo.
+//     A panic in fn is returned as an error too.
+//   - inplace: Replaces the column of the DataFrame itself instead of returning a copy.
+//
+// Returns:
+//   - *DataFrame: The DataFrame with the new column, df itself when inplace is set. The other columns
+//     and the column order are kept.
+//   - error: An error if the column does not exist or fn fails, then df is not modified.
+func (df *DataFrame) ApplyTo(colName string, fn func(value any) (any, error), inplace bool) (*DataFrame, error) {
+	col, exists := df.Columns[colName]
+	if !exists {
+		return nil, fmt.Errorf("column '%s' does not exist", colName)
+	}
+
+	values := col.Values()
+	result := make([]any, len(values))
+	for i, value := range values {
+		var err error
+		if panicErr := recoverRow(i, func() { result[i], err = fn(value) }); panicErr != nil {
+			return nil, fmt.Errorf("error applying function to column '%s': %w", colName, panicErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error applying function to column '%s' at row %d: %w", colName, i, err)
+		}
+	}
+
+	if inplace {
+		df.setColumnData(col, result)
+		return df, nil
+	}
+	return df.WithColumn(colName, NewSeries(colName, result))
+}
+
 // Add sums 2 dataframes together.
 //
 // Parameters:
@@ -664,14 +1486,15 @@ func (df *DataFrame) Add(other *DataFrame, fillValue ...any) (*DataFrame, error)
 		return &newDf, fmt.Errorf("the number of columns does not match for both dataframes. First dataframe has: %v while second dataframe has: %v", df.Ncols(), other.Ncols())
 	}
 
-	for colName, col := range df.Columns {
+	for _, colName := range df.ColumnNames() {
+		col := df.Columns[colName]
 
 		// create the new column in newDf
 		colToAdd := NewColumn(colName, []any{})
 
 		// get the other column's row data
-		otherRows := other.Columns[colName].Data
-		dfRows := col.Data
+		otherRows := other.Columns[colName].Values()
+		dfRows := col.Values()
 
 		// get the max number of rows between the 2
 		maxNoRows := max(len(dfRows), len(otherRows))
@@ -765,10 +1588,41 @@ func toFloat(v any) (float64, bool) {
 	return 0, false
 }
 
-// Describe returns summary statistics for numeric columns.
-func (df *DataFrame) Describe() (*DataFrame, error) {
+// DescribeOption configures Describe.
+//
+// Fields:
+//   - Include: "" (the default) summarizes the numeric columns, "all" also summarizes the other
+//     columns with the count, unique, top and freq statistics, leaving the statistics that do not
+//     apply to a column nil.
+type DescribeOption struct {
+	Include string
+}
 
-	stats := []string{"count", "mean", "min", "max"}
+// Describe returns summary statistics for numeric columns: the count of numeric values, mean, min,
+// max, sample standard deviation and the 25%, 50% and 75% percentiles (linear interpolation),
+// NaN values are skipped. With Include "all" the other columns report the count of non-nil values,
+// the number of unique values, the most frequent value (top, the first one on ties) and its frequency.
+//
+// Parameters:
+//   - options (optional): The DescribeOption struct to include non-numeric columns.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with a "stat" column naming the statistic of each row, followed by one
+//     column per summarized column.
+//   - error: An error if Include is not "" or "all".
+func (df *DataFrame) Describe(options ...DescribeOption) (*DataFrame, error) {
+	opts := DescribeOption{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Include != "" && opts.Include != "all" {
+		return nil, fmt.Errorf("unknown include '%s', expected \"\" or \"all\"", opts.Include)
+	}
+
+	stats := []string{"count", "mean", "min", "max", "std", "25%", "50%", "75%"}
+	if opts.Include == "all" {
+		stats = append(stats, "unique", "top", "freq")
+	}
 	result := NewDataFrame()
 
 	statCol := NewColumn("stat", make([]any, len(stats)))
@@ -777,36 +1631,54 @@ func (df *DataFrame) Describe() (*DataFrame, error) {
 	}
 	result.AddColumn(statCol)
 
-	for name, col := range df.Columns {
+	for _, name := range df.ColumnNames() {
+		col := df.Columns[name]
+		values := col.Values()
 		var nums []float64
 
-		for _, v := range col.Data {
-			if f, ok := toFloat(v); ok {
+		for _, v := range values {
+			if f, ok := toFloat(v); ok && !math.IsNaN(f) {
 				nums = append(nums, f)
 			}
 		}
 
-		if len(nums) == 0 {
+		summary := make([]any, len(stats))
+		if len(nums) > 0 {
+			sorted := slices.Clone(nums)
+			slices.Sort(sorted)
+			copy(summary, []any{
+				float64(len(nums)), floatMean(nums, false), sorted[0], sorted[len(sorted)-1], floatStd(nums, false),
+				floatQuantile(sorted, 0.25), floatQuantile(sorted, 0.5), floatQuantile(sorted, 0.75),
+			})
+		} else if opts.Include == "all" {
+			count, unique, top, freq := describeCategorical(values)
+			summary[0] = float64(count)
+			if count > 0 {
+				summary[8], summary[9], summary[10] = float64(unique), top, float64(freq)
+			}
+		} else {
 			continue
 		}
+		result.AddColumn(NewColumn(name, summary))
+	}
 
-		sum := 0.0
-		min, max := nums[0], nums[0]
+	return result, nil
+}
 
-		for _, v := range nums {
-			sum += v
-			min = math.Min(min, v)
-			max = math.Max(max, v)
+// describeCategorical counts the non-nil values of a column, its unique values and the most
+// frequent one, the first to reach the highest frequency on ties
+func describeCategorical(values []any) (count, unique int, top any, freq int) {
+	counts := make(map[any]int)
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		count++
+		key := valueKey(v)
+		counts[key]++
+		if counts[key] > freq {
+			top, freq = v, counts[key]
 		}
-
-
-		count := float64(len(nums))
-		mean := sum / count
-
-		result.AddColumn(NewColumn(name, []any{
-			count, mean, min, max,
-		}))
 	}
-
-	return result, nil
+	return count, len(counts), top, freq
 }
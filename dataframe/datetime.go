@@ -0,0 +1,133 @@
+package dataframe
+
+/*
+
+	This is where the datetime accessor is defined: Dt groups the vectorized methods of a Series or a
+	column of time.Time values, to derive calendar columns without Apply boilerplate:
+
+		df.WithColumn("month", df.Col("date").Dt().Month())
+
+	The methods return a new Series. Nil values stay nil and values that are not time.Time give nil
+	too, parse strings first with AddDatetimeIndex or Astype(column, "time").
+
+*/
+
+import (
+	"fmt"
+	"time"
+)
+
+// DatetimeAccessor holds the vectorized time methods of a Series, see Series.Dt.
+type DatetimeAccessor struct {
+	s *Series
+}
+
+// Dt returns the time methods of the series.
+//
+// Returns:
+//   - *DatetimeAccessor: The time methods, each returns a new Series.
+func (s *Series) Dt() *DatetimeAccessor {
+	return &DatetimeAccessor{s: s}
+}
+
+// Dt returns the time methods of the values of the column, as a Series named after the column.
+//
+// Returns:
+//   - *DatetimeAccessor: The time methods, each returns a new Series.
+func (c *Column[T]) Dt() *DatetimeAccessor {
+	values := c.Values()
+	data := make([]any, len(values))
+	for i, v := range values {
+		data[i] = v
+	}
+	return NewSeries(c.Name, data).Dt()
+}
+
+// mapTimes applies fn to the time.Time values, other values give nil
+func (a *DatetimeAccessor) mapTimes(fn func(t time.Time) any) *Series {
+	if err := a.s.Err(); err != nil {
+		return failedSeries(a.s.Name, err)
+	}
+	result := make([]any, len(a.s.Data))
+	for i, v := range a.s.Data {
+		if t, ok := v.(time.Time); ok {
+			result[i] = fn(t)
+		}
+	}
+	return NewSeries(a.s.Name, result)
+}
+
+// Year returns the year of the values.
+//
+// Returns:
+//   - *Series: The int years.
+func (a *DatetimeAccessor) Year() *Series {
+	return a.mapTimes(func(t time.Time) any { return t.Year() })
+}
+
+// Month returns the month of the values, 1 for January to 12 for December.
+//
+// Returns:
+//   - *Series: The int months.
+func (a *DatetimeAccessor) Month() *Series {
+	return a.mapTimes(func(t time.Time) any { return int(t.Month()) })
+}
+
+// Day returns the day of the month of the values.
+//
+// Returns:
+//   - *Series: The int days, from 1 to 31.
+func (a *DatetimeAccessor) Day() *Series {
+	return a.mapTimes(func(t time.Time) any { return t.Day() })
+}
+
+// Weekday returns the day of the week of the values, numbered like time.Weekday: 0 for Sunday to 6 for Saturday.
+//
+// Returns:
+//   - *Series: The int days of the week.
+func (a *DatetimeAccessor) Weekday() *Series {
+	return a.mapTimes(func(t time.Time) any { return int(t.Weekday()) })
+}
+
+// Hour returns the hour of the values.
+//
+// Returns:
+//   - *Series: The int hours, from 0 to 23.
+func (a *DatetimeAccessor) Hour() *Series {
+	return a.mapTimes(func(t time.Time) any { return t.Hour() })
+}
+
+// Date returns the values at midnight, in their location.
+//
+// Returns:
+//   - *Series: The time.Time dates.
+func (a *DatetimeAccessor) Date() *Series {
+	return a.mapTimes(func(t time.Time) any { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()) })
+}
+
+// Floor rounds the values down to a frequency of Resample, e.g. "H" for the start of the hour or
+// "W-MON" for the last Monday. The values stay in their location.
+//
+// Parameters:
+//   - freq: The frequency, see Resample.
+//
+// Returns:
+//   - *Series: The time.Time values, Err returns an error if the frequency is unknown.
+func (a *DatetimeAccessor) Floor(freq string) *Series {
+	frequency, ok := parseFrequency(freq)
+	if !ok {
+		return failedSeries(a.s.Name, fmt.Errorf("unknown frequency '%s'", freq))
+	}
+	return a.mapTimes(func(t time.Time) any { return frequency.floor(t) })
+}
+
+// Format prints the values with a layout.
+//
+// Parameters:
+//   - layout: The layout (see time.Format), e.g. "2006-01-02".
+//
+// Returns:
+//   - *Series: The formatted strings.
+func (a *DatetimeAccessor) Format(layout string) *Series {
+	return a.mapTimes(func(t time.Time) any { return t.Format(layout) })
+}
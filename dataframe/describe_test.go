@@ -1,6 +1,10 @@
 package dataframe
 
-import "testing"
+import (
+	"math"
+	"reflect"
+	"testing"
+)
 
 func TestDescribe(t *testing.T) {
 	df := NewDataFrame()
@@ -56,4 +60,51 @@ func TestDescribe(t *testing.T) {
 	if maxSalary.(float64) != 3000 {
 		t.Errorf("expected salary max 3000, got %v", maxSalary)
 	}
-}
\ No newline at end of file
+}
+
+func TestDescribeExtended(t *testing.T) {
+	df := NewDataFrame()
+	df.AddColumn(NewColumn[any]("score", []any{1.0, 2, 3.0, 4, math.NaN(), nil}))
+	df.AddColumn(NewColumn[any]("city", []any{"Paris", "Oslo", "Paris", nil, "Oslo", "Rome"}))
+	df.AddColumn(NewColumn[any]("empty", []any{nil, nil, nil, nil, nil, nil}))
+
+	desc, err := df.Describe()
+	if err != nil {
+		t.Fatalf("Describe returned error: %v", err)
+	}
+	if !reflect.DeepEqual(desc.ColumnNames(), []string{"stat", "score"}) {
+		t.Errorf("expected the numeric columns only, got %v", desc.ColumnNames())
+	}
+	expected := []any{4.0, 2.5, 1.0, 4.0, math.Sqrt(5.0 / 3), 1.75, 2.5, 3.25}
+	if got := desc.Columns["score"].Data; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := desc.Columns["stat"].Data[4:]; !reflect.DeepEqual(got, []any{"std", "25%", "50%", "75%"}) {
+		t.Errorf("expected the new statistics after max, got %v", got)
+	}
+
+	all, err := df.Describe(DescribeOption{Include: "all"})
+	if err != nil {
+		t.Fatalf("Describe returned error: %v", err)
+	}
+	if !reflect.DeepEqual(all.ColumnNames(), []string{"stat", "score", "city", "empty"}) {
+		t.Errorf("expected every column, got %v", all.ColumnNames())
+	}
+	if got := all.Columns["stat"].Data[8:]; !reflect.DeepEqual(got, []any{"unique", "top", "freq"}) {
+		t.Errorf("expected unique, top and freq rows, got %v", got)
+	}
+	city := []any{5.0, nil, nil, nil, nil, nil, nil, nil, 3.0, "Paris", 2.0}
+	if got := all.Columns["city"].Data; !reflect.DeepEqual(got, city) {
+		t.Errorf("expected %v, got %v", city, got)
+	}
+	if got := all.Columns["score"].Data[8:]; !reflect.DeepEqual(got, []any{nil, nil, nil}) {
+		t.Errorf("expected nil categorical statistics for a numeric column, got %v", got)
+	}
+	if got := all.Columns["empty"].Data[0]; got != 0.0 {
+		t.Errorf("expected a count of 0 for an empty column, got %v", got)
+	}
+
+	if _, err := df.Describe(DescribeOption{Include: "object"}); err == nil {
+		t.Errorf("expected an error for an unknown include")
+	}
+}
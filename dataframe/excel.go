@@ -0,0 +1,217 @@
+package dataframe
+
+/*
+
+	This is where Excel (xlsx) export methods for the DataFrame struct are defined
+
+*/
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelOption configures how a DataFrame is written to an Excel sheet.
+//
+// Fields:
+//   - SheetName: The name of the sheet. Defaults to "Sheet1".
+//   - HeaderBold: Writes the header row in bold.
+//   - HeaderFill: The background color of the header row as a hex string (e.g. "#DDEBF7").
+//   - HeaderFontColor: The font color of the header row as a hex string.
+//   - ColumnWidths: The width of specific columns, keyed by column name.
+//   - AutoWidth: Sizes every column without an explicit width to fit its content.
+//   - NumberFormats: Excel number formats per column name (e.g. "#,##0.00", "0%", "yyyy-mm-dd").
+//   - FreezeRows: The number of top rows kept visible while scrolling (1 freezes the header).
+//   - FreezeColumns: The number of leftmost columns kept visible while scrolling.
+//   - AutoFilter: Adds filter buttons to the header row.
+type ExcelOption struct {
+	SheetName       string
+	HeaderBold      bool
+	HeaderFill      string
+	HeaderFontColor string
+	ColumnWidths    map[string]float64
+	AutoWidth       bool
+	NumberFormats   map[string]string
+	FreezeRows      int
+	FreezeColumns   int
+	AutoFilter      bool
+}
+
+// maxAutoWidth caps the width computed by ExcelOption.AutoWidth
+const maxAutoWidth = 60
+
+// ToExcel exports the DataFrame to an Excel (xlsx) file.
+//
+// Parameters:
+//   - filename: The path to the output xlsx file.
+//   - options (optional): The ExcelOption struct to configure the sheet name, styling and layout.
+//
+// Returns:
+//   - error: An error if the workbook cannot be built or written.
+func (df *DataFrame) ToExcel(filename string, options ...ExcelOption) error {
+	file, err := df.excelFile(options...)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := file.SaveAs(filename); err != nil {
+		return fmt.Errorf("error writing workbook: %w", err)
+	}
+	return nil
+}
+
+// ToExcelWriter exports the DataFrame as an Excel (xlsx) workbook to a writer.
+//
+// Parameters:
+//   - writer: An io.Writer for the xlsx data.
+//   - options (optional): The ExcelOption struct to configure the sheet name, styling and layout.
+//
+// Returns:
+//   - error: An error if the workbook cannot be built or written.
+func (df *DataFrame) ToExcelWriter(writer io.Writer, options ...ExcelOption) error {
+	file, err := df.excelFile(options...)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteTo(writer); err != nil {
+		return fmt.Errorf("error writing workbook: %w", err)
+	}
+	return nil
+}
+
+// excelFile builds a workbook holding the DataFrame in a single sheet
+func (df *DataFrame) excelFile(options ...ExcelOption) (*excelize.File, error) {
+	opts := ExcelOption{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.SheetName == "" {
+		opts.SheetName = "Sheet1"
+	}
+
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", opts.SheetName); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error naming sheet: %w", err)
+	}
+	if err := df.writeExcelSheet(file, opts); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// writeExcelSheet writes the DataFrame into the existing sheet opts.SheetName of a workbook
+func (df *DataFrame) writeExcelSheet(file *excelize.File, opts ExcelOption) error {
+	sheet := opts.SheetName
+	header := df.ColumnNames()
+	nrows := df.Nrows()
+
+	for name := range opts.NumberFormats {
+		if _, exists := df.Columns[name]; !exists {
+			return fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+	for name := range opts.ColumnWidths {
+		if _, exists := df.Columns[name]; !exists {
+			return fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+
+	for c, name := range header {
+		cell, _ := excelize.CoordinatesToCellName(c+1, 1)
+		if err := file.SetCellValue(sheet, cell, name); err != nil {
+			return fmt.Errorf("error writing header: %w", err)
+		}
+
+		width := utf8.RuneCountInString(name)
+		for r, value := range df.Columns[name].Values() {
+			if value == nil {
+				continue // nil values stay empty cells
+			}
+			cell, _ := excelize.CoordinatesToCellName(c+1, r+2)
+			if err := file.SetCellValue(sheet, cell, value); err != nil {
+				return fmt.Errorf("error writing value at row %d, column '%s': %w", r, name, err)
+			}
+			width = max(width, utf8.RuneCountInString(fmt.Sprintf("%v", value)))
+		}
+
+		colName, _ := excelize.ColumnNumberToName(c + 1)
+		if w, ok := opts.ColumnWidths[name]; ok {
+			if err := file.SetColWidth(sheet, colName, colName, w); err != nil {
+				return fmt.Errorf("error setting width of column '%s': %w", name, err)
+			}
+		} else if opts.AutoWidth {
+			if err := file.SetColWidth(sheet, colName, colName, float64(min(width+2, maxAutoWidth))); err != nil {
+				return fmt.Errorf("error setting width of column '%s': %w", name, err)
+			}
+		}
+
+		if format, ok := opts.NumberFormats[name]; ok && nrows > 0 {
+			style, err := file.NewStyle(&excelize.Style{CustomNumFmt: &format})
+			if err != nil {
+				return fmt.Errorf("error creating number format for column '%s': %w", name, err)
+			}
+			top, _ := excelize.CoordinatesToCellName(c+1, 2)
+			bottom, _ := excelize.CoordinatesToCellName(c+1, nrows+1)
+			if err := file.SetCellStyle(sheet, top, bottom, style); err != nil {
+				return fmt.Errorf("error applying number format to column '%s': %w", name, err)
+			}
+		}
+	}
+
+	if len(header) == 0 {
+		return nil
+	}
+	lastHeader, _ := excelize.CoordinatesToCellName(len(header), 1)
+
+	if opts.HeaderBold || opts.HeaderFill != "" || opts.HeaderFontColor != "" {
+		style := &excelize.Style{Font: &excelize.Font{Bold: opts.HeaderBold, Color: opts.HeaderFontColor}}
+		if opts.HeaderFill != "" {
+			style.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{opts.HeaderFill}}
+		}
+		styleID, err := file.NewStyle(style)
+		if err != nil {
+			return fmt.Errorf("error creating header style: %w", err)
+		}
+		if err := file.SetCellStyle(sheet, "A1", lastHeader, styleID); err != nil {
+			return fmt.Errorf("error applying header style: %w", err)
+		}
+	}
+
+	if opts.FreezeRows > 0 || opts.FreezeColumns > 0 {
+		topLeft, _ := excelize.CoordinatesToCellName(opts.FreezeColumns+1, opts.FreezeRows+1)
+		pane := "bottomRight"
+		switch {
+		case opts.FreezeColumns == 0:
+			pane = "bottomLeft"
+		case opts.FreezeRows == 0:
+			pane = "topRight"
+		}
+		err := file.SetPanes(sheet, &excelize.Panes{
+			Freeze:      true,
+			XSplit:      opts.FreezeColumns,
+			YSplit:      opts.FreezeRows,
+			TopLeftCell: topLeft,
+			ActivePane:  pane,
+		})
+		if err != nil {
+			return fmt.Errorf("error freezing panes: %w", err)
+		}
+	}
+
+	if opts.AutoFilter {
+		lastCell, _ := excelize.CoordinatesToCellName(len(header), nrows+1)
+		if err := file.AutoFilter(sheet, "A1:"+lastCell, nil); err != nil {
+			return fmt.Errorf("error adding auto filter: %w", err)
+		}
+	}
+
+	return nil
+}
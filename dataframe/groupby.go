@@ -2,7 +2,11 @@ package dataframe
 
 import (
 	"fmt"
+	"maps"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 )
 
 type GroupedDataFrame struct {
@@ -10,6 +14,28 @@ type GroupedDataFrame struct {
 	KeyOrder []any // This is to preserve the order of the data
 	Key      string
 	Err      error
+
+	columns   []string      // column order of the grouped DataFrame
+	positions map[any][]int // row positions of each group in the grouped DataFrame, see Transform
+	nRows     int           // number of rows of the grouped DataFrame
+	spill     *groupSpill   // rows written to disk instead of Groups, see SetSpillOption
+	keyIndex  []int         // position in KeyOrder of each spilled group once SortKeys reordered them
+}
+
+// GroupbyOption is the parameters we can set to the Groupby method, to guard against grouping by a
+// near-unique key by mistake, e.g. an id instead of a category, which holds a row map per row and a
+// group per key.
+//
+// Fields:
+//   - MaxGroups: The maximum number of groups, none if 0.
+//   - MaxMemory: The maximum estimated bytes of the grouped rows, none if 0.
+//   - Spill: Instead of failing when a limit is exceeded, groups the rows on disk like SetSpillOption does,
+//     in chunks of MaxMemory bytes (or of the SetSpillOption budget), and aggregates them one chunk at a
+//     time. The rows stay in memory if no budget is set or they fit in it.
+type GroupbyOption struct {
+	MaxGroups int
+	MaxMemory int64
+	Spill     bool
 }
 
 // The Groupby method is a powerful method used for data aggregation, it involves a DataFrame to be split into groups
@@ -17,27 +43,50 @@ type GroupedDataFrame struct {
 //
 // Parameters:
 //   - key(s): The key(s) to group the data by.
+//   - options (optional): The GroupbyOption struct to limit the number of groups and the memory.
 //
 // Returns:
 //   - *GroupedDataFrame: The grouped DataFrame, returns empty dataframe if error.
-//   - error: An error if the data cannot be grouped.
+//   - error: An error if the data cannot be grouped or a limit is exceeded.
 
-func (df *DataFrame) Groupby(key any) *GroupedDataFrame {
+func (df *DataFrame) Groupby(key any, options ...GroupbyOption) *GroupedDataFrame {
 	groups := make(map[any][]map[string]any) // GroupKey: { row[key] : value} where key is the column name
 	var err error
 	keyName := ""
 	keyOrder := []any{}
+	var positions map[any][]int
+
+	var opts GroupbyOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	spill := CurrentSpillOption()
+	if err := checkGroupbyLimits(df, key, opts); err != nil {
+		if !opts.Spill {
+			return &GroupedDataFrame{Err: fmt.Errorf("unable to group: %w", err)}
+		}
+		if opts.MaxMemory > 0 {
+			spill.MemoryBudget = opts.MaxMemory
+		}
+	}
+
+	if spilled, ok, err := spillGroupby(df, key, spill); ok {
+		if err != nil {
+			return &GroupedDataFrame{Err: fmt.Errorf("unable to group: %w", err)}
+		}
+		return spilled
+	}
 
 	switch key := key.(type) {
 	case string:
 		keyName = key
-		groups, keyOrder, err = groupByString(df, keyName, groups)
+		groups, keyOrder, positions, err = groupByString(df, keyName, groups)
 		if err != nil {
 			return &GroupedDataFrame{Err: fmt.Errorf("unable to group by string: %v", err)}
 		}
 
 	case []string:
-		groups, keyOrder, err = groupByList(df, key, groups)
+		groups, keyOrder, positions, err = groupByList(df, key, groups)
 		if err != nil {
 			return &GroupedDataFrame{Err: fmt.Errorf("unable to group by string: %v", err)}
 		}
@@ -52,22 +101,64 @@ func (df *DataFrame) Groupby(key any) *GroupedDataFrame {
 		return &GroupedDataFrame{Err: fmt.Errorf("unsupported groupby key type: %T", key)}
 	}
 
-	return &GroupedDataFrame{Groups: groups, Key: keyName, KeyOrder: keyOrder, Err: nil}
+	return &GroupedDataFrame{
+		Groups:    groups,
+		Key:       keyName,
+		KeyOrder:  keyOrder,
+		Err:       nil,
+		columns:   df.ColumnNames(),
+		positions: positions,
+		nRows:     df.Nrows(),
+	}
+}
+
+// GroupbyLevel groups the rows by levels of the index (see SetIndex and SetMultiIndex), like Groupby on the index columns.
+//
+// Parameters:
+//   - levels (optional): The positions of the index levels to group by, outermost first. Defaults to every level.
+//
+// Returns:
+//   - *GroupedDataFrame: The grouped DataFrame, check Error for a missing index or an out of range level.
+//
+// Experimental: the multi-level index API may change in a minor release.
+func (df *DataFrame) GroupbyLevel(levels ...int) *GroupedDataFrame {
+	names := df.IndexNames()
+	if len(names) == 0 {
+		return &GroupedDataFrame{Err: fmt.Errorf("DataFrame has no index, use SetIndex or SetMultiIndex first")}
+	}
+	if len(levels) == 0 {
+		for l := range names {
+			levels = append(levels, l)
+		}
+	}
+
+	keys := make([]string, len(levels))
+	for i, level := range levels {
+		if level < 0 || level >= len(names) {
+			return &GroupedDataFrame{Err: fmt.Errorf("level %d is out of range for an index with %d levels", level, len(names))}
+		}
+		keys[i] = names[level]
+	}
+	if len(keys) == 1 {
+		return df.Groupby(keys[0])
+	}
+	return df.Groupby(keys)
 }
 
-func groupByString(df *DataFrame, colName string, groups map[any][]map[string]any) (map[any][]map[string]any, []any, error) {
+func groupByString(df *DataFrame, colName string, groups map[any][]map[string]any) (map[any][]map[string]any, []any, map[any][]int, error) {
 	_, exists := df.Columns[colName]
 	keys := []any{}
+	positions := make(map[any][]int)
 
 	if !exists {
-		return nil, nil, fmt.Errorf("Column '%s' does not exist", colName)
+		return nil, nil, nil, fmt.Errorf("Column '%s' does not exist", colName)
 	}
 
-	for i := 0; i < df.Nrows(); i++ {
-		row, err := df.Row(i) //access each row in the dataframe
-		if err != nil {
-			return groups, nil, fmt.Errorf("unable to access row %v in the dataframe: %v", i, err)
-		}
+	rows, err := df.rowMaps() // access each row in the dataframe
+	if err != nil {
+		return groups, nil, nil, err
+	}
+	for i, row := range rows {
 		groupKey := row[colName] // access the column name's value, it is called groupkey because it is the identifier of that row
 		_, ok := groups[groupKey]
 		if !ok {
@@ -75,35 +166,37 @@ func groupByString(df *DataFrame, colName string, groups map[any][]map[string]an
 			keys = append(keys, groupKey)
 		}
 		groups[groupKey] = append(groups[groupKey], row) // append the row to the map of maps
+		positions[groupKey] = append(positions[groupKey], i)
 	}
 
-	return groups, keys, nil
+	return groups, keys, positions, nil
 
 }
 
-func groupByList(df *DataFrame, colNames []string, groups map[any][]map[string]any) (map[any][]map[string]any, []any, error) {
+func groupByList(df *DataFrame, colNames []string, groups map[any][]map[string]any) (map[any][]map[string]any, []any, map[any][]int, error) {
 	keys := []any{}
+	positions := make(map[any][]int)
 
 	// Validate all columns exist
 	for _, col := range colNames {
 		if _, exists := df.Columns[col]; !exists {
-			return nil, nil, fmt.Errorf("column '%s' does not exist", col)
+			return nil, nil, nil, fmt.Errorf("column '%s' does not exist", col)
 		}
 	}
 
 	// Iterate over all rows
-	for i := 0; i < df.Nrows(); i++ {
-		row, err := df.Row(i)
-		if err != nil {
-			return groups, nil, fmt.Errorf("unable to access row %v in the dataframe: %v", i, err)
-		}
+	rows, err := df.rowMaps()
+	if err != nil {
+		return groups, nil, nil, err
+	}
+	for i, row := range rows {
 
 		// Build composite key using all specified columns
 		keyParts := make([]string, len(colNames))
 		for j, col := range colNames {
 			val, ok := row[col]
 			if !ok {
-				return nil, nil, fmt.Errorf("column '%s' missing in row %d", col, i)
+				return nil, nil, nil, fmt.Errorf("column '%s' missing in row %d", col, i)
 			}
 			keyParts[j] = fmt.Sprintf("%v", val)
 		}
@@ -116,95 +209,109 @@ func groupByList(df *DataFrame, colNames []string, groups map[any][]map[string]a
 
 		// Append row to group
 		groups[groupKey] = append(groups[groupKey], row)
+		positions[groupKey] = append(positions[groupKey], i)
 	}
 
-	return groups, keys, nil
+	return groups, keys, positions, nil
 }
 
-// The Sum method for the grouped data frame struct is to sum the column values by their column names
-// that is provided in the arguments.
+// SortKeys sorts the groups by their key in place, so the results of the aggregations, Apply and
+// Transform follow the sorted keys instead of the order the keys first appear in. Keys are compared
+// like SortValues does, the keys of a list of columns column by column. Nil and NaN keys come last.
 //
 // Parameters:
-//   - column name(s): The column(s) to sum all its stored values.
+//   - ascending (optional): The order of the keys, ascending by default.
 //
 // Returns:
-//   - *DataFrame: The grouped DataFrame, returns empty dataframe if error.
-//   - error: An error if the data cannot be grouped.
-
-func (gdf *GroupedDataFrame) Sum(colNames ...string) (*DataFrame, error) {
+//   - *GroupedDataFrame: The grouped DataFrame, check Error if more than one direction is given.
+func (gdf *GroupedDataFrame) SortKeys(ascending ...bool) *GroupedDataFrame {
 	if gdf.Err != nil {
-		return nil, gdf.Err
+		return gdf
 	}
-	resultDf := NewDataFrame()
-
-	groupKeys := make([]any, 0, len(gdf.KeyOrder))
-	sumsPerCol := make(map[string][]float64)
-	if len(colNames) == 0 {
-		colNames = gdf.GetAllColumnNames()
+	if len(ascending) > 1 {
+		gdf.Err = fmt.Errorf("got %d sort directions for the group keys", len(ascending))
+		return gdf
 	}
+	direction := len(ascending) == 0 || ascending[0]
 
-	// Build the column values first
-	for _, groupKey := range gdf.KeyOrder {
-		rows := gdf.Groups[groupKey]
-		groupKeys = append(groupKeys, groupKey)
-
-		for _, colName := range colNames {
-			sum := sumColumn(rows, colName)
-			sumsPerCol[colName] = append(sumsPerCol[colName], sum)
+	// keys of a list of columns are the values joined with "|", compared part by part
+	parts := func(key any) []any {
+		if gdf.Key != "" {
+			return []any{key}
 		}
-
+		values := []any{}
+		for _, part := range strings.Split(fmt.Sprintf("%v", key), "|") {
+			values = append(values, part)
+		}
+		return values
 	}
+	perm := make([]int, len(gdf.KeyOrder))
+	for i := range perm {
+		perm[i] = i
+	}
+	slices.SortStableFunc(perm, func(a, b int) int {
+		partsA, partsB := parts(gdf.KeyOrder[a]), parts(gdf.KeyOrder[b])
+		for k := range min(len(partsA), len(partsB)) {
+			if c := compareSortValues(partsA[k], partsB[k], direction, false); c != 0 {
+				return c
+			}
+		}
+		return len(partsA) - len(partsB)
+	})
 
-	// Build GroupKey column
-	groupCol := NewColumn("GroupKey", groupKeys)
-
-	// Construct DataFrame
-	_ = AddTypedColumn(resultDf, groupCol)
-
-	for _, colName := range colNames {
-		values := sumsPerCol[colName]
-		newcol := NewColumn(colName, values)
-		err := AddTypedColumn(resultDf, newcol)
-		if err != nil {
-			return nil, fmt.Errorf("Error trying to add type column: %v", err)
+	keyOrder := make([]any, len(perm))
+	moved := make([]int, len(perm)) // new position of each group
+	for i, index := range perm {
+		keyOrder[i] = gdf.KeyOrder[index]
+		moved[index] = i
+	}
+	gdf.KeyOrder = keyOrder
+	if gdf.spill != nil {
+		// the spilled records hold the positions of the groups when they were written
+		if gdf.keyIndex == nil {
+			gdf.keyIndex = make([]int, len(perm))
+			for i := range gdf.keyIndex {
+				gdf.keyIndex[i] = i
+			}
+		}
+		for original, current := range gdf.keyIndex {
+			gdf.keyIndex[original] = moved[current]
 		}
 	}
+	return gdf
+}
 
-	return resultDf, gdf.Err
+// The Sum method for the grouped data frame struct is to sum the column values by their column names
+// that is provided in the arguments.
+//
+// Parameters:
+//   - column name(s): The column(s) to sum all its stored values.
+//
+// Returns:
+//   - *DataFrame: The grouped DataFrame, returns empty dataframe if error.
+//   - error: An error if the data cannot be grouped.
 
+func (gdf *GroupedDataFrame) Sum(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["sum"])
 }
 
 func (gdf *GroupedDataFrame) Error() error {
 	return gdf.Err
 }
 
-/*
-The sumColumn is a helper function to help sum the specific column, this is done to separate
-code to make it more readable.
-*/
-func sumColumn(rows []map[string]any, colName string) float64 {
-	sum := 0.0
-	for _, rowData := range rows {
-		val, ok := rowData[colName]
-		if ok {
-			switch v := val.(type) {
-			case int:
-				sum += float64(v)
-			case float64:
-				sum += v
-			case float32:
-				sum += float64(v)
-			}
-		}
-	}
-
-	return sum
-}
-
 func (gdf *GroupedDataFrame) GetAllColumnNames() []string {
 	columnNames := []string{}
 	seen := map[string]string{}
 
+	if gdf.columns != nil {
+		for _, name := range gdf.columns {
+			if name != gdf.Key {
+				columnNames = append(columnNames, name)
+			}
+		}
+		return columnNames
+	}
+
 	for _, groupVal := range gdf.Groups {
 		for _, rowValue := range groupVal {
 			for key := range rowValue {
@@ -225,76 +332,333 @@ func (gdf *GroupedDataFrame) GetAllColumnNames() []string {
 	return columnNames
 }
 
+// Mean averages the numeric values of the given columns for each group, NaN values are skipped.
+//
+// Parameters:
+//   - colNames (optional): The columns to average, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per averaged column,
+//     NaN for groups without numeric values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
 func (gdf *GroupedDataFrame) Mean(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["mean"])
+}
+
+// Min returns the smallest numeric value of the given columns for each group, NaN values are skipped.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     NaN for groups without numeric values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) Min(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["min"])
+}
+
+// Max returns the largest numeric value of the given columns for each group, NaN values are skipped.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     NaN for groups without numeric values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) Max(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["max"])
+}
+
+// Median returns the median of the numeric values of the given columns for each group, NaN values are skipped.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     NaN for groups without numeric values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) Median(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["median"])
+}
+
+// Var returns the sample variance (n-1 denominator) of the numeric values of the given columns
+// for each group, NaN values are skipped.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     NaN for groups with fewer than two numeric values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) Var(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["var"])
+}
+
+// Std returns the sample standard deviation (n-1 denominator) of the numeric values of the given
+// columns for each group, NaN values are skipped.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     NaN for groups with fewer than two numeric values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) Std(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["std"])
+}
+
+// First returns the first non-nil, non-NaN value of the given columns for each group, in row order.
+// Values keep their type, so non-numeric columns are supported.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     nil for groups without values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) First(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["first"])
+}
+
+// Last returns the last non-nil, non-NaN value of the given columns for each group, in row order.
+// Values keep their type, so non-numeric columns are supported.
+//
+// Parameters:
+//   - colNames (optional): The columns to aggregate, all columns except the key by default.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column and one column per aggregated column,
+//     nil for groups without values.
+//   - error: An error if the data cannot be grouped or a column does not exist.
+func (gdf *GroupedDataFrame) Last(colNames ...string) (*DataFrame, error) {
+	return gdf.aggregateValues(colNames, groupAggregations["last"])
+}
+
+// GroupAggOption configures GroupedDataFrame.Agg.
+//
+// Fields:
+//   - Funcs: Custom aggregations referenced by name in the spec, next to the built-in ones. Each function
+//     receives the values of a column for the rows of one group, e.g. {"range": func(v []any) any {...}}.
+//   - Separator: The separator between the column name and the aggregation name in the output columns. Defaults to "_".
+//   - SortBy: The output column to sort the result by, e.g. "GroupKey" or "salary_sum". Empty keeps the order of the groups.
+//   - Descending: Sorts the result in descending order of SortBy.
+type GroupAggOption struct {
+	Funcs      map[string]func(values []any) any
+	Separator  string
+	SortBy     string
+	Descending bool
+}
+
+// Agg applies several aggregations per column in one pass over the groups, e.g.
+// {"salary": {"sum", "mean"}, "age": {"max"}} produces the columns salary_sum, salary_mean and age_max.
+//
+// Built-in aggregations are "sum", "mean", "min", "max", "median", "var", "std" (see the methods of the
+// same name), "first", "last", "count" (the non-nil, non-NaN values) and "size" (the rows of the group).
+// Custom aggregations are added with GroupAggOption.Funcs and take precedence over built-in ones of the same name.
+//
+// Parameters:
+//   - spec: The aggregations to apply, keyed by column name.
+//   - options (optional): The GroupAggOption struct to add custom aggregations, set the name separator and sort the result.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the GroupKey column followed by one column per aggregation, the columns
+//     follow the column order of the grouped DataFrame and the order of the aggregations in the spec.
+//   - error: An error if the data cannot be grouped, a column does not exist, an aggregation is unknown
+//     or two output columns have the same name, or SortBy is not an output column.
+func (gdf *GroupedDataFrame) Agg(spec map[string][]string, options ...GroupAggOption) (*DataFrame, error) {
 	if gdf.Err != nil {
 		return nil, gdf.Err
 	}
+	opts := GroupAggOption{Separator: "_"}
+	if len(options) > 0 {
+		opts = options[0]
+		if opts.Separator == "" {
+			opts.Separator = "_"
+		}
+	}
+
+	// Columns follow the grouped DataFrame order, then the remaining spec columns sorted by name
+	columns := []string{}
+	for _, colName := range gdf.GetAllColumnNames() {
+		if _, listed := spec[colName]; listed {
+			columns = append(columns, colName)
+		}
+	}
+	remaining := []string{}
+	for colName := range spec {
+		if !slices.Contains(columns, colName) {
+			if gdf.columns != nil && colName != gdf.Key {
+				return nil, fmt.Errorf("column '%s' does not exist", colName)
+			}
+			remaining = append(remaining, colName)
+		}
+	}
+	slices.Sort(remaining)
+	columns = append(columns, remaining...)
+
+	type outputColumn struct {
+		name   string
+		column string
+		agg    func(rows []map[string]any, colName string) any
+	}
+	outputs := []outputColumn{}
+	seen := map[string]bool{"GroupKey": true}
+	for _, colName := range columns {
+		for _, aggName := range spec[colName] {
+			agg, known := groupAggregations[aggName]
+			if custom, ok := opts.Funcs[aggName]; ok && custom != nil {
+				agg = func(rows []map[string]any, colName string) any {
+					values := make([]any, len(rows))
+					for i, row := range rows {
+						values[i] = row[colName]
+					}
+					return custom(values)
+				}
+			} else if !known {
+				return nil, fmt.Errorf("unknown aggregation '%s' for column '%s'", aggName, colName)
+			}
+
+			name := colName + opts.Separator + aggName
+			if seen[name] {
+				return nil, fmt.Errorf("duplicate output column '%s'", name)
+			}
+			seen[name] = true
+			outputs = append(outputs, outputColumn{name, colName, agg})
+		}
+	}
+
+	values := make([][]any, len(outputs))
+	for i := range values {
+		values[i] = make([]any, len(gdf.KeyOrder))
+	}
+	err := gdf.eachGroup(func(index int, _ any, rows []map[string]any) error {
+		for i, output := range outputs {
+			values[i][index] = output.agg(rows, output.column)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	resultDf := NewDataFrame()
+	_ = resultDf.AddColumn(NewColumn("GroupKey", append([]any{}, gdf.KeyOrder...)))
+	for i, output := range outputs {
+		_ = resultDf.AddColumn(NewColumn(output.name, values[i]))
+	}
+	if opts.SortBy != "" {
+		// rows with equal values keep the order of the groups
+		return resultDf.SortValues([]string{opts.SortBy}, !opts.Descending)
+	}
+	return resultDf, nil
+}
+
+// groupAggregations are the built-in aggregations of Agg, shared with the aggregation methods
+var groupAggregations = map[string]func(rows []map[string]any, colName string) any{
+	"sum":    numericGroupAgg(floatSum),
+	"mean":   numericGroupAgg(floatMean),
+	"min":    numericGroupAgg(floatMin),
+	"max":    numericGroupAgg(floatMax),
+	"median": numericGroupAgg(floatMedian),
+	"var":    numericGroupAgg(floatVar),
+	"std":    numericGroupAgg(floatStd),
+	"first": func(rows []map[string]any, colName string) any {
+		for _, row := range rows {
+			if v := row[colName]; v != nil && !isNaNValue(v) {
+				return v
+			}
+		}
+		return nil
+	},
+	"last": func(rows []map[string]any, colName string) any {
+		for i := len(rows) - 1; i >= 0; i-- {
+			if v := rows[i][colName]; v != nil && !isNaNValue(v) {
+				return v
+			}
+		}
+		return nil
+	},
+	"count": func(rows []map[string]any, colName string) any {
+		count := 0
+		for _, row := range rows {
+			if v := row[colName]; v != nil && !isNaNValue(v) {
+				count++
+			}
+		}
+		return count
+	},
+	"size": func(rows []map[string]any, _ string) any {
+		return len(rows)
+	},
+}
 
-	groupKeys := make([]any, 0, len(gdf.KeyOrder))
-	meansPerCol := make(map[string][]float64)
+// numericGroupAgg applies a numeric aggregation to the values of a column in the rows of a group. Values
+// are coerced like Describe: numbers of any type and numeric strings count, other values are skipped.
+func numericGroupAgg(agg func(nums []float64, keep bool) float64) func(rows []map[string]any, colName string) any {
+	return func(rows []map[string]any, colName string) any {
+		return agg(groupFloats(rows, colName), false)
+	}
+}
+
+// aggregateValues builds the result of a grouped aggregation: the GroupKey column followed by
+// one column per aggregated column, agg computes the value of a column for the rows of one group
+func (gdf *GroupedDataFrame) aggregateValues(colNames []string, agg func(rows []map[string]any, colName string) any) (*DataFrame, error) {
+	if gdf.Err != nil {
+		return nil, gdf.Err
+	}
 	if len(colNames) == 0 {
 		colNames = gdf.GetAllColumnNames()
+	} else if gdf.columns != nil {
+		for _, colName := range colNames {
+			if !slices.Contains(gdf.columns, colName) {
+				return nil, fmt.Errorf("column '%s' does not exist", colName)
+			}
+		}
 	}
 
-	// Build the column values first
-	for _, groupKey := range gdf.KeyOrder {
-		rows := gdf.Groups[groupKey]
-		groupKeys = append(groupKeys, groupKey)
+	valuesPerCol := make(map[string][]any)
+	for _, colName := range colNames {
+		valuesPerCol[colName] = make([]any, len(gdf.KeyOrder))
+	}
 
+	// Build the column values first
+	err := gdf.eachGroup(func(index int, _ any, rows []map[string]any) error {
 		for _, colName := range colNames {
-			mean := averageColumn(rows, colName)
-			meansPerCol[colName] = append(meansPerCol[colName], mean)
+			valuesPerCol[colName][index] = agg(rows, colName)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Build GroupKey column
-	groupCol := NewColumn("GroupKey", groupKeys)
-
 	// Construct DataFrame
-	_ = AddTypedColumn(resultDf, groupCol)
-
+	resultDf := NewDataFrame()
+	_ = resultDf.AddColumn(NewColumn("GroupKey", append([]any{}, gdf.KeyOrder...)))
 	for _, colName := range colNames {
-		values := meansPerCol[colName]
-		newcol := NewColumn(colName, values)
-		err := AddTypedColumn(resultDf, newcol)
-		if err != nil {
+		if err := resultDf.AddColumn(NewColumn(colName, valuesPerCol[colName])); err != nil {
 			return nil, fmt.Errorf("Error trying to add type column: %v", err)
 		}
 	}
 
-	return resultDf, gdf.Err
+	return resultDf, nil
 }
 
-func averageColumn(rows []map[string]any, colName string) float64 {
-	sum := 0.0
-	var count float64
-
+// groupFloats returns the numeric values of a column in the rows of a group
+func groupFloats(rows []map[string]any, colName string) []float64 {
+	nums := make([]float64, 0, len(rows))
 	for _, rowData := range rows {
-		val, ok := rowData[colName] // access the row data
-		if ok {
-			switch v := val.(type) {
-			case int:
-				sum += float64(v)
-				count++
-			case float64:
-				sum += v
-				count++
-			case float32:
-				sum += float64(v)
-				count++
-			default:
-				continue
-			}
+		if f, ok := toFloat(rowData[colName]); ok {
+			nums = append(nums, f)
 		}
 	}
-
-	if count == 0 {
-		return 0
-	}
-
-	return sum / count
+	return nums
 }
 
 func (gdf *GroupedDataFrame) Count(colNames ...string) (*DataFrame, error) {
@@ -304,22 +668,24 @@ func (gdf *GroupedDataFrame) Count(colNames ...string) (*DataFrame, error) {
 
 	resultDf := NewDataFrame()
 
-	groupKeys := make([]any, 0, len(gdf.KeyOrder))
 	countPerCol := make(map[string][]int)
+	for _, colName := range colNames {
+		countPerCol[colName] = make([]int, len(gdf.KeyOrder))
+	}
 
 	// Build the column values first
-	for _, groupKey := range gdf.KeyOrder {
-		rows := gdf.Groups[groupKey]
-		groupKeys = append(groupKeys, groupKey)
-
+	err := gdf.eachGroup(func(index int, _ any, rows []map[string]any) error {
 		for _, colName := range colNames {
-			count := len(rows)
-			countPerCol[colName] = append(countPerCol[colName], count)
+			countPerCol[colName][index] = len(rows)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Build GroupKey column
-	groupCol := NewColumn("GroupKey", groupKeys)
+	groupCol := NewColumn("GroupKey", append([]any{}, gdf.KeyOrder...))
 
 	// Construct DataFrame
 	_ = AddTypedColumn(resultDf, groupCol)
@@ -335,3 +701,385 @@ func (gdf *GroupedDataFrame) Count(colNames ...string) (*DataFrame, error) {
 
 	return resultDf, gdf.Err
 }
+
+// Apply runs a function on each group, passed as a DataFrame holding the rows of the group, and
+// concatenates the returned DataFrames in group order. Columns missing from some results are filled with nil.
+//
+// Parameters:
+//   - fn: The function applied to each group, returning nil skips the group.
+//
+// Returns:
+//   - *DataFrame: The concatenated results.
+//   - error: An error if the data cannot be grouped or the function panics on a group.
+func (gdf *GroupedDataFrame) Apply(fn func(group *DataFrame) *DataFrame) (*DataFrame, error) {
+	if gdf.Err != nil {
+		return nil, gdf.Err
+	}
+
+	results := make([]*DataFrame, len(gdf.KeyOrder))
+	err := gdf.eachGroup(func(index int, groupKey any, rows []map[string]any) error {
+		group := gdf.groupFrame(rows)
+		if err := recoverPanic(func() { results[index] = fn(group) }); err != nil {
+			return fmt.Errorf("error applying function to group %v: %w", groupKey, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return concatRows(slices.DeleteFunc(results, func(result *DataFrame) bool { return result == nil })), nil
+}
+
+// Transform runs a function on the values of a column in each group and returns the results aligned
+// to the row order of the grouped DataFrame, e.g. a group-wise z-score that can be added back as a column.
+//
+// Parameters:
+//   - colName: The column whose values are passed to the function.
+//   - fn: The function applied to the values of each group, it must return as many values as it receives.
+//
+// Returns:
+//   - *Series: The transformed values, one per row of the grouped DataFrame, named after the column.
+//   - error: An error if the data cannot be grouped, the column does not exist, the function panics
+//     or returns a different number of values.
+func (gdf *GroupedDataFrame) Transform(colName string, fn func(values *Series) *Series) (*Series, error) {
+	if gdf.Err != nil {
+		return nil, gdf.Err
+	}
+	if gdf.positions == nil {
+		return nil, fmt.Errorf("row positions are unknown, Transform requires a GroupedDataFrame created by Groupby")
+	}
+	if !slices.Contains(gdf.columns, colName) {
+		return nil, fmt.Errorf("column '%s' does not exist", colName)
+	}
+
+	result := make([]any, gdf.nRows)
+	err := gdf.eachGroup(func(_ int, groupKey any, rows []map[string]any) error {
+		values := make([]any, len(rows))
+		for i, row := range rows {
+			values[i] = row[colName]
+		}
+
+		var transformed *Series
+		if err := recoverPanic(func() { transformed = fn(NewSeries(colName, values)) }); err != nil {
+			return fmt.Errorf("error transforming group %v: %w", groupKey, err)
+		}
+		if transformed == nil || transformed.Len() != len(rows) {
+			got := 0
+			if transformed != nil {
+				got = transformed.Len()
+			}
+			return fmt.Errorf("function returned %d values for group %v, expected %d", got, groupKey, len(rows))
+		}
+		for i, pos := range gdf.positions[groupKey] {
+			result[pos] = transformed.Data[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewSeries(colName, result), nil
+}
+
+// FFill fills the missing values (nil or NaN) of each group with the last value before them in the
+// group, so values never leak from one group to another. This is the usual preparation of panel data,
+// e.g. df.Groupby("sensor").FFill([]string{"reading"}, FillOption{TimeColumn: "time"}).
+//
+// Parameters:
+//   - colNames: The columns to fill, every column but the group key and the time column if empty.
+//   - options (optional): The FillOption struct with the limit and the time column ordering the rows of
+//     each group (rows without a time come last), its Columns field is not used. Without a time column
+//     the rows are filled in their order in the grouped DataFrame.
+//
+// Returns:
+//   - *DataFrame: A copy of the grouped DataFrame with the filled columns, its rows in their original order.
+//   - error: An error if the data cannot be grouped, a column does not exist or the limit is negative.
+func (gdf *GroupedDataFrame) FFill(colNames []string, options ...FillOption) (*DataFrame, error) {
+	return gdf.fill(colNames, options, false)
+}
+
+// BFill fills the missing values (nil or NaN) of each group with the next value after them in the
+// group, see FFill.
+//
+// Parameters:
+//   - colNames: The columns to fill, every column but the group key and the time column if empty.
+//   - options (optional): The FillOption struct with the limit and the time column ordering the rows of each group.
+//
+// Returns:
+//   - *DataFrame: A copy of the grouped DataFrame with the filled columns, its rows in their original order.
+//   - error: An error if the data cannot be grouped, a column does not exist or the limit is negative.
+func (gdf *GroupedDataFrame) BFill(colNames []string, options ...FillOption) (*DataFrame, error) {
+	return gdf.fill(colNames, options, true)
+}
+
+// fill fills the columns of each group for FFill and BFill
+func (gdf *GroupedDataFrame) fill(colNames []string, options []FillOption, backward bool) (*DataFrame, error) {
+	if gdf.Err != nil {
+		return nil, gdf.Err
+	}
+	if gdf.positions == nil {
+		return nil, fmt.Errorf("row positions are unknown, filling requires a GroupedDataFrame created by Groupby")
+	}
+	var opts FillOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative, got %d", opts.Limit)
+	}
+	for _, name := range append(slices.Clone(colNames), opts.TimeColumn) {
+		if name != "" && !slices.Contains(gdf.columns, name) {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+	if len(colNames) == 0 {
+		for _, name := range gdf.columns {
+			if name != gdf.Key && name != opts.TimeColumn {
+				colNames = append(colNames, name)
+			}
+		}
+	}
+
+	data := make([][]any, len(gdf.columns))
+	for c := range data {
+		data[c] = make([]any, gdf.nRows)
+	}
+	err := gdf.eachGroup(func(_ int, groupKey any, rows []map[string]any) error {
+		order := make([]int, len(rows))
+		for i := range order {
+			order[i] = i
+		}
+		if opts.TimeColumn != "" {
+			slices.SortStableFunc(order, func(a, b int) int {
+				return compareSortValues(rows[a][opts.TimeColumn], rows[b][opts.TimeColumn], true, false)
+			})
+		}
+		positions := gdf.positions[groupKey]
+		for c, name := range gdf.columns {
+			values := make([]any, len(rows))
+			for i, row := range order {
+				values[i] = rows[row][name]
+			}
+			if slices.Contains(colNames, name) {
+				fillForward(values, opts.Limit, backward)
+			}
+			for i, row := range order {
+				data[c][positions[row]] = values[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromColumnData(gdf.columns, data), nil
+}
+
+// groupFrame builds a DataFrame from the rows of a group
+func (gdf *GroupedDataFrame) groupFrame(rows []map[string]any) *DataFrame {
+	names := gdf.columns
+	if names == nil {
+		seen := map[string]bool{}
+		for _, row := range rows {
+			for name := range row {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+		slices.Sort(names)
+	}
+
+	group := NewDataFrame()
+	for _, name := range names {
+		data := make([]any, len(rows))
+		for i, row := range rows {
+			data[i] = row[name]
+		}
+		group.Columns[name] = &Column[any]{Name: name, Data: data}
+	}
+	group.order = append([]string{}, names...)
+	return group
+}
+
+// eachGroup calls fn with the position in KeyOrder, the key and the rows of every group, in
+// KeyOrder for in-memory groups and partition by partition for spilled ones
+func (gdf *GroupedDataFrame) eachGroup(fn func(index int, groupKey any, rows []map[string]any) error) error {
+	if gdf.spill == nil {
+		for index, groupKey := range gdf.KeyOrder {
+			if err := fn(index, groupKey, gdf.Groups[groupKey]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	gdf.spill.mu.Lock()
+	defer gdf.spill.mu.Unlock()
+	for _, file := range gdf.spill.files {
+		groups := make(map[int][]map[string]any)
+		err := readSpillRecords(file, func(record []any) error {
+			row := make(map[string]any, len(gdf.columns))
+			for c, name := range gdf.columns {
+				row[name] = record[c+1]
+			}
+			index := record[0].(int)
+			if gdf.keyIndex != nil {
+				index = gdf.keyIndex[index]
+			}
+			groups[index] = append(groups[index], row)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		indexes := slices.Sorted(maps.Keys(groups))
+		for _, index := range indexes {
+			if err := fn(index, gdf.KeyOrder[index], groups[index]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// groupSpill holds the rows of a spilled Groupby, partitioned by group. A record is the position of
+// the group in KeyOrder followed by the values of the row in column order.
+type groupSpill struct {
+	mu    sync.Mutex // the files are read sequentially
+	files []*spillFile
+}
+
+// groupbyKeyColumns returns the key columns of a Groupby key, nil if the key is not a column name or list
+func groupbyKeyColumns(key any) []string {
+	switch key := key.(type) {
+	case string:
+		return []string{key}
+	case []string:
+		return key
+	}
+	return nil
+}
+
+// groupbyMemory estimates the bytes held by the row maps of a Groupby, a row map costs about 32 bytes
+// per entry on top of the values
+func groupbyMemory(df *DataFrame) int64 {
+	columns := df.ColumnNames()
+	return estimateMemory(df, columns) + int64(df.Nrows()*len(columns))*32
+}
+
+// groupbyKeyValues returns the values of the key columns of a Groupby
+func groupbyKeyValues(df *DataFrame, keyCols []string) ([][]any, error) {
+	keyValues := make([][]any, len(keyCols))
+	for i, col := range keyCols {
+		if _, exists := df.Columns[col]; !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", col)
+		}
+		keyValues[i] = df.Columns[col].Values()
+	}
+	return keyValues, nil
+}
+
+// groupbyKey returns the group key of a row, the same as groupByString and groupByList
+func groupbyKey(keyValues [][]any, list bool, row int) any {
+	if !list {
+		return keyValues[0][row]
+	}
+	parts := make([]string, len(keyValues))
+	for k := range keyValues {
+		parts[k] = fmt.Sprintf("%v", keyValues[k][row])
+	}
+	return strings.Join(parts, "|")
+}
+
+// checkGroupbyLimits returns an error if grouping df by key would exceed the limits of opts. Keys that
+// cannot be checked are left to Groupby to report.
+func checkGroupbyLimits(df *DataFrame, key any, opts GroupbyOption) error {
+	keyCols := groupbyKeyColumns(key)
+	if keyCols == nil {
+		return nil
+	}
+	if opts.MaxMemory > 0 {
+		if estimate := groupbyMemory(df); estimate > opts.MaxMemory {
+			return fmt.Errorf("grouping by %v needs about %d bytes, more than MaxMemory (%d)", key, estimate, opts.MaxMemory)
+		}
+	}
+	if opts.MaxGroups > 0 {
+		keyValues, err := groupbyKeyValues(df, keyCols)
+		if err != nil {
+			return nil
+		}
+		_, list := key.([]string)
+		seen := make(map[any]struct{})
+		for i := range df.Nrows() {
+			seen[groupbyKey(keyValues, list, i)] = struct{}{}
+			if len(seen) > opts.MaxGroups {
+				return fmt.Errorf("grouping by %v gives more than MaxGroups (%d) groups", key, opts.MaxGroups)
+			}
+		}
+	}
+	return nil
+}
+
+// spillGroupby groups the rows on disk when the row maps of Groupby would exceed the budget of opts.
+// It reports false when the rows fit in memory or the key is not a column name or list.
+func spillGroupby(df *DataFrame, key any, opts SpillOption) (*GroupedDataFrame, bool, error) {
+	keyCols := groupbyKeyColumns(key)
+	if keyCols == nil {
+		return nil, false, nil
+	}
+	columns := df.ColumnNames()
+	partitions := spillChunks(opts, groupbyMemory(df))
+	if partitions < 2 {
+		return nil, false, nil
+	}
+
+	keyValues, err := groupbyKeyValues(df, keyCols)
+	if err != nil {
+		return nil, true, err
+	}
+	values := make([][]any, len(columns))
+	for c, name := range columns {
+		values[c] = df.Columns[name].Values()
+	}
+
+	files, err := newSpillFiles(opts, partitions)
+	if err != nil {
+		return nil, true, err
+	}
+	gdf := &GroupedDataFrame{
+		columns:   columns,
+		positions: make(map[any][]int),
+		nRows:     df.Nrows(),
+		spill:     &groupSpill{files: files},
+	}
+	if _, single := key.(string); single {
+		gdf.Key = keyCols[0]
+	}
+	// the files are removed once the GroupedDataFrame is garbage collected
+	runtime.AddCleanup(gdf, removeSpillFiles, files)
+
+	_, list := key.([]string)
+	indexes := make(map[any]int)
+	for i := range df.Nrows() {
+		groupKey := groupbyKey(keyValues, list, i)
+		index, seen := indexes[groupKey]
+		if !seen {
+			index = len(gdf.KeyOrder)
+			indexes[groupKey] = index
+			gdf.KeyOrder = append(gdf.KeyOrder, groupKey)
+		}
+		gdf.positions[groupKey] = append(gdf.positions[groupKey], i)
+
+		record := make([]any, len(columns)+1)
+		record[0] = index
+		for c := range columns {
+			record[c+1] = values[c][i]
+		}
+		if err := files[index%partitions].write(record); err != nil {
+			return nil, true, err
+		}
+	}
+	return gdf, true, nil
+}
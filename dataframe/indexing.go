@@ -1,11 +1,21 @@
 package dataframe
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // Advanced Indexing
 
-// MultiIndex represents hierarchical indexing for rows
+// MultiIndex represents hierarchical indexing for rows, see SetMultiIndex.
+//
+// Fields:
+//   - Names: The index columns, outermost level first.
+//   - Levels: The distinct values of each level, in order of appearance.
+//   - Labels: The position in Levels of the value of every row, per level.
 type MultiIndex struct {
+	Names  []string
 	Levels [][]any
 	Labels [][]int
 }
@@ -15,9 +25,81 @@ func (df *DataFrame) BooleanIndex(condition func(row map[string]any) bool) *Data
 	return df.Filter(condition)
 }
 
-// Loc selects rows and columns by labels
+// FilterByMask returns a new DataFrame with the rows where the boolean mask is true.
+//
+// Parameters:
+//   - mask: A boolean Series with one value per row, e.g. the result of Series.Between or Series.IsIn.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the selected rows.
+//   - error: An error if the mask length does not match or the mask holds non boolean values.
+func (df *DataFrame) FilterByMask(mask *Series) (*DataFrame, error) {
+	if mask.Len() != df.Nrows() {
+		return nil, fmt.Errorf("mask length %d does not match the number of rows %d", mask.Len(), df.Nrows())
+	}
+
+	rowsToKeep := []int{}
+	for i, v := range mask.Data {
+		keep, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("mask value at row %d is not a bool: %v (%T)", i, v, v)
+		}
+		if keep {
+			rowsToKeep = append(rowsToKeep, i)
+		}
+	}
+
+	return df.takeRows(rowsToKeep), nil
+}
+
+// FilterIn returns a new DataFrame with the rows where the column value is one of the given values.
+//
+// Parameters:
+//   - column: The name of the column to test.
+//   - values: The values to keep.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the matching rows.
+//   - error: An error if the column does not exist.
+func (df *DataFrame) FilterIn(column string, values ...any) (*DataFrame, error) {
+	col, exists := df.Columns[column]
+	if !exists {
+		return nil, fmt.Errorf("column '%s' does not exist", column)
+	}
+	return df.FilterByMask(NewSeries(column, col.Values()).IsIn(values...))
+}
+
+// takeRows returns a new DataFrame with the rows at the given positions, in the given order
+func (df *DataFrame) takeRows(positions []int) *DataFrame {
+	result := NewDataFrame()
+	for name, col := range df.Columns {
+		data := make([]any, len(positions))
+		if col.native != nil {
+			// avoid boxing the whole column for a few rows
+			for i, pos := range positions {
+				data[i] = col.native.at(pos)
+			}
+		} else {
+			values := col.Values()
+			for i, pos := range positions {
+				data[i] = values[pos]
+			}
+		}
+		result.Columns[name] = &Column[any]{Name: name, Data: data}
+	}
+	result.order = df.ColumnNames()
+	result.indexNames = df.indexNames
+	result.parallelism = df.parallelism
+	return result
+}
+
+// Loc selects rows and columns by labels. Rows are matched on the index (see SetIndex), or on their
+// positions when the DataFrame has a range index, and keep their order in the DataFrame. With a
+// multi-level index the labels are []any tuples, partial tuples (or single labels) select every row
+// starting with them.
 func (df *DataFrame) Loc(rowLabels []any, colLabels []string) (*DataFrame, error) {
 	result := NewDataFrame()
+	result.indexNames = df.indexNames
 
 	for _, col := range colLabels {
 		if _, exists := df.Columns[col]; !exists {
@@ -27,20 +109,27 @@ func (df *DataFrame) Loc(rowLabels []any, colLabels []string) (*DataFrame, error
 			Name: col,
 			Data: []any{},
 		}
+		result.order = append(result.order, col)
 	}
 
-	indexCol, indexExists := df.Columns["index"]
-	if !indexExists {
-		return nil, fmt.Errorf("'index' column does not exist")
-	}
-
+	labels := df.Index()
+	multi := len(df.IndexNames()) > 1
 	for i := 0; i < df.Nrows(); i++ {
 		row, _ := df.Row(i)
 		for _, label := range rowLabels {
-			if indexCol.Data[i] == label {
-				for _, col := range colLabels {
-					result.Columns[col].Data = append(result.Columns[col].Data, row[col])
+			if multi {
+				tuple, ok := label.([]any)
+				if !ok {
+					tuple = []any{label} // a single label selects on the outermost level
+				}
+				if !matchesLabel(labels.Data[i].([]any), tuple) {
+					continue
 				}
+			} else if labelKey(labels.Data[i]) != labelKey(label) {
+				continue
+			}
+			for _, col := range colLabels {
+				result.Columns[col].Data = append(result.Columns[col].Data, row[col])
 			}
 		}
 	}
@@ -62,6 +151,7 @@ func (df *DataFrame) Iloc(rowIndices []int, colIndices []int) (*DataFrame, error
 			Name: colName,
 			Data: []any{},
 		}
+		result.order = append(result.order, colName)
 	}
 
 	for _, rowIdx := range rowIndices {
@@ -77,3 +167,279 @@ func (df *DataFrame) Iloc(rowIndices []int, colIndices []int) (*DataFrame, error
 
 	return result, nil
 }
+
+// labelIndex maps the labels of the index column to their row positions
+type labelIndex struct {
+	column    *Column[any]
+	rows      int    // the length of the column when the map was built
+	version   uint64 // the version of the DataFrame when the map was built
+	positions map[any]int
+}
+
+// SetIndex sets the column used to look up rows by label with At, LocRow and Loc.
+// The column stays in the DataFrame, lookups go through a label -> row map that is
+// built on first use, making single-row access O(1) instead of a linear scan.
+// The index is kept by Filter, Head, Tail, the sorts and the joins, Shift does not shift it.
+//
+// Parameters:
+//   - column: The name of the column holding the row labels.
+//
+// Returns:
+//   - error: An error if the column does not exist.
+//
+// Note:
+//   - If a label appears more than once, lookups return its first row.
+//   - The label map is rebuilt after the DataFrame methods modifying rows, but not after writes to
+//     the Data of the index column: call SetIndex again after such writes.
+//   - Without SetIndex, lookups use the "index" column if the DataFrame has one, otherwise the
+//     DataFrame has a range index: the labels are the row positions 0..n-1.
+func (df *DataFrame) SetIndex(column string) error {
+	if _, exists := df.Columns[column]; !exists {
+		return fmt.Errorf("column '%s' does not exist", column)
+	}
+	df.indexNames = []string{column}
+	df.labelIndex.Store(nil)
+	return nil
+}
+
+// SetMultiIndex sets a hierarchical index over several columns. Rows are then addressed by tuples
+// of labels, e.g. At([]any{"EU", 2024}, "sales"), and Loc accepts partial tuples selecting every row
+// that starts with the given labels.
+//
+// Parameters:
+//   - columns: The index columns, outermost level first.
+//
+// Returns:
+//   - error: An error if no column is given or a column does not exist.
+//
+// Experimental: the multi-level index API may change in a minor release.
+func (df *DataFrame) SetMultiIndex(columns ...string) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("please enter 1 or more index column name(s)")
+	}
+	for _, column := range columns {
+		if _, exists := df.Columns[column]; !exists {
+			return fmt.Errorf("column '%s' does not exist", column)
+		}
+	}
+	df.indexNames = append([]string{}, columns...)
+	df.labelIndex.Store(nil)
+	return nil
+}
+
+// MultiIndex returns the structure of the hierarchical index set with SetMultiIndex.
+//
+// Returns:
+//   - *MultiIndex: The levels and the per-row labels of the index, nil if the DataFrame has no multi-level index.
+//
+// Experimental: the multi-level index API may change in a minor release.
+func (df *DataFrame) MultiIndex() *MultiIndex {
+	names := df.IndexNames()
+	if len(names) < 2 {
+		return nil
+	}
+	mi := &MultiIndex{Names: names, Levels: make([][]any, len(names)), Labels: make([][]int, len(names))}
+	for l, name := range names {
+		positions := make(map[any]int)
+		for _, value := range df.Columns[name].Values() {
+			key := labelKey(value)
+			pos, seen := positions[key]
+			if !seen {
+				pos = len(mi.Levels[l])
+				positions[key] = pos
+				mi.Levels[l] = append(mi.Levels[l], value)
+			}
+			mi.Labels[l] = append(mi.Labels[l], pos)
+		}
+	}
+	return mi
+}
+
+// ResetIndex goes back to the range index, where the labels are the row positions.
+//
+// Parameters:
+//   - drop: Removes the index column from the DataFrame. Otherwise it stays as a regular column,
+//     except for a column named "index", which is always used as the index (see SetIndex).
+func (df *DataFrame) ResetIndex(drop bool) {
+	names := df.IndexNames()
+	df.indexNames = nil
+	df.labelIndex.Store(nil)
+	if drop && len(names) > 0 {
+		_ = df.DropColumns(names...)
+	}
+}
+
+// Index returns the row labels: the values of the index column, []any tuples for a multi-level
+// index, or the row positions 0..n-1 for a range index.
+//
+// Returns:
+//   - *Series: A new Series named after the index column(s), or "index" for a range index.
+func (df *DataFrame) Index() *Series {
+	if names := df.IndexNames(); len(names) > 1 {
+		tuples := make([]any, df.Nrows())
+		for i := range tuples {
+			tuples[i] = df.indexTuple(names, i)
+		}
+		return NewSeries(strings.Join(names, levelSeparator), tuples)
+	} else if len(names) == 1 {
+		return NewSeries(names[0], append([]any{}, df.Columns[names[0]].Values()...))
+	}
+	labels := make([]any, df.Nrows())
+	for i := range labels {
+		labels[i] = i
+	}
+	return NewSeries("index", labels)
+}
+
+// IndexName returns the name of the column used as the row index, or an empty string if there is
+// none or the index has several levels (see IndexNames).
+func (df *DataFrame) IndexName() string {
+	if names := df.IndexNames(); len(names) == 1 {
+		return names[0]
+	}
+	return ""
+}
+
+// IndexNames returns the columns of the row index, outermost level first, or nil for a range index.
+func (df *DataFrame) IndexNames() []string {
+	valid := len(df.indexNames) > 0
+	for _, name := range df.indexNames {
+		if _, exists := df.Columns[name]; !exists {
+			valid = false
+		}
+	}
+	if valid {
+		return append([]string{}, df.indexNames...)
+	}
+	if _, exists := df.Columns["index"]; exists {
+		return []string{"index"}
+	}
+	return nil
+}
+
+// indexTuple returns the labels of a row for the given index columns
+func (df *DataFrame) indexTuple(names []string, row int) []any {
+	tuple := make([]any, len(names))
+	for l, name := range names {
+		tuple[l], _ = df.Columns[name].At(row)
+	}
+	return tuple
+}
+
+// matchesLabel reports whether the row labels of a multi-level index start with the given labels
+func matchesLabel(tuple []any, label []any) bool {
+	if len(label) > len(tuple) {
+		return false
+	}
+	for l := range label {
+		if !isComparable(labelKey(label[l])) || labelKey(tuple[l]) != labelKey(label[l]) {
+			return false
+		}
+	}
+	return true
+}
+
+// LocRow returns the row whose index label equals label.
+//
+// Parameters:
+//   - label: The index label of the row.
+//
+// Returns:
+//   - map[string]any: A map representing the row, with column names as keys.
+//   - error: An error if the DataFrame has no index or the label does not exist.
+func (df *DataFrame) LocRow(label any) (map[string]any, error) {
+	pos, err := df.labelPosition(label)
+	if err != nil {
+		return nil, err
+	}
+	return df.Row(pos)
+}
+
+// At returns a single value by index label and column name.
+//
+// Parameters:
+//   - label: The index label of the row.
+//   - column: The name of the column.
+//
+// Returns:
+//   - any: The value stored in the cell.
+//   - error: An error if the column, the index or the label does not exist.
+func (df *DataFrame) At(label any, column string) (any, error) {
+	col, exists := df.Columns[column]
+	if !exists {
+		return nil, fmt.Errorf("column '%s' does not exist", column)
+	}
+	pos, err := df.labelPosition(label)
+	if err != nil {
+		return nil, err
+	}
+	return col.At(pos)
+}
+
+// labelPosition returns the row position of an index label using the cached label map.
+// The map is rebuilt when the index column was replaced, resized or written by a method of the
+// DataFrame since it was built, so a missing label is reported without scanning the column.
+func (df *DataFrame) labelPosition(label any) (int, error) {
+	names := df.IndexNames()
+	if len(names) > 1 {
+		tuple, ok := label.([]any)
+		if !ok || len(tuple) != len(names) {
+			return 0, fmt.Errorf("label '%v' must be a tuple of %d labels for the multi-level index", label, len(names))
+		}
+		for i := 0; i < df.Nrows(); i++ {
+			if matchesLabel(df.indexTuple(names, i), tuple) {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("label '%v' does not exist in index '%s'", label, strings.Join(names, levelSeparator))
+	}
+	name := df.IndexName()
+	if name == "" {
+		// range index, the labels are the row positions
+		if pos, ok := labelKey(label).(float64); ok && pos == math.Trunc(pos) && pos >= 0 && pos < float64(df.Nrows()) {
+			return int(pos), nil
+		}
+		return 0, fmt.Errorf("label '%v' does not exist in the range index, use SetIndex to look up rows by a column", label)
+	}
+	col := df.Columns[name]
+	key := labelKey(label)
+
+	if !isComparable(key) {
+		return 0, fmt.Errorf("label of type %T cannot be used for lookups", label)
+	}
+
+	version := df.version.Load()
+	idx := df.labelIndex.Load()
+	if idx == nil || idx.column != col || idx.rows != col.Len() || idx.version != version {
+		// no map yet or stale map, rebuild it
+		idx = &labelIndex{column: col, rows: col.Len(), version: version, positions: make(map[any]int, col.Len())}
+		for i, value := range col.Values() {
+			k := labelKey(value)
+			if !isComparable(k) {
+				continue
+			}
+			if _, seen := idx.positions[k]; !seen {
+				idx.positions[k] = i
+			}
+		}
+		df.labelIndex.Store(idx)
+	}
+
+	pos, ok := idx.positions[key]
+	if !ok {
+		return 0, fmt.Errorf("label '%v' does not exist in index '%s'", label, name)
+	}
+	return pos, nil
+}
+
+// labelKey normalizes numeric labels to float64 so that 1 and 1.0 address the same row
+func labelKey(label any) any {
+	switch label.(type) {
+	case string, bool, nil:
+		return label
+	}
+	if f, ok := toFloat(label); ok {
+		return f
+	}
+	return label
+}
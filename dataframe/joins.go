@@ -1,137 +1,375 @@
 package dataframe
 
-import "reflect"
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
 
 // Join combines two DataFrames based on a key column and join type (inner, left, right, outer).
+// An empty key joins on the index column shared by both DataFrames (see SetIndex), the result keeps
+// the index of the first DataFrame.
+//
+// Joins are hash joins: the rows of one DataFrame are grouped by key, then the other one is scanned
+// once, so the cost grows with the sum of the sizes rather than their product. Keys match when they
+// are equal with ==, so nil matches nil, NaN matches nothing and 1 (int) does not match 1.0 (float64).
+// When both DataFrames have a column besides the key, the value of the first one is kept in matched rows,
+// use Join to keep both under suffixed names or to join on several keys.
 
 func (df *DataFrame) InnerJoin(other *DataFrame, key string) (*DataFrame, error) {
-	err := checkExists(df, other, key)
+	return df.hashJoin(other, key, "inner")
+}
+
+func (df *DataFrame) LeftJoin(other *DataFrame, key string) (*DataFrame, error) {
+	return df.hashJoin(other, key, "left")
+}
+
+func (df *DataFrame) RightJoin(other *DataFrame, key string) (*DataFrame, error) {
+	return df.hashJoin(other, key, "right")
+}
+
+func (df *DataFrame) OuterJoin(other *DataFrame, key string) (*DataFrame, error) {
+	return df.hashJoin(other, key, "outer")
+}
+
+// Join combines two DataFrames on one or more key columns, like pandas merge. Rows match when all
+// their keys are equal with ==. The key columns appear once in the result, other columns present in
+// both DataFrames are kept twice, renamed with the suffixes.
+//
+// Parameters:
+//   - other: The DataFrame to join with.
+//   - keys: The key columns, present in both DataFrames. Empty joins on the index columns shared by
+//     both DataFrames (see SetIndex and SetMultiIndex).
+//   - how: The join type, "inner", "left", "right" or "outer".
+//   - suffixes: The suffixes appended to colliding columns of df and other. Defaults to "_x" and "_y"
+//     when both are empty.
+//
+// Returns:
+//   - *DataFrame: The joined DataFrame, keeping the index of df. The rows of a right join follow other,
+//     the rows of the other joins follow df, and the unmatched rows of other come last in an outer join.
+//   - error: An error if a key is missing, the join type is unknown or a renamed column collides with another column.
+func (df *DataFrame) Join(other *DataFrame, keys []string, how string, suffixes [2]string) (*DataFrame, error) {
+	switch how {
+	case "inner", "left", "right", "outer":
+	default:
+		return nil, fmt.Errorf("invalid join type '%s' (must be 'inner', 'left', 'right' or 'outer')", how)
+	}
+	if suffixes == [2]string{} {
+		suffixes = [2]string{"_x", "_y"}
+	}
+	if suffixes[0] == suffixes[1] {
+		return nil, fmt.Errorf("suffixes must differ, got '%s' twice", suffixes[0])
+	}
+	keys, err := joinKeys(df, other, keys)
 	if err != nil {
 		return nil, err
 	}
+	leftRows, rightRows := joinRows(joinKeyValues(df, keys), joinKeyValues(other, keys), how, df.parallelism)
+	return suffixedJoin(df, other, keys, suffixes, leftRows, rightRows)
+}
 
+// suffixedJoin gathers the joined rows of df and other: the key columns once, the other columns
+// present in both DataFrames twice, renamed with the suffixes
+func suffixedJoin(df, other *DataFrame, keys []string, suffixes [2]string, leftRows, rightRows []int) (*DataFrame, error) {
 	result := NewDataFrame()
-	err = appendCols(df, other, result)
-	if err != nil {
-		return nil, err
+	result.indexNames = df.indexNames
+	add := func(name string, left, right *Column[any]) error {
+		if _, exists := result.Columns[name]; exists {
+			return fmt.Errorf("column '%s' already exists in the joined DataFrame", name)
+		}
+		result.Columns[name] = &Column[any]{Name: name, Data: joinColumn(left, right, leftRows, rightRows)}
+		result.order = append(result.order, name)
+		return nil
 	}
-
-	for i := 0; i < df.Nrows(); i++ {
-		rowA, _ := df.Row(i)
-		for j := 0; j < other.Nrows(); j++ {
-			rowB, _ := other.Row(j)
-			if rowA[key] == rowB[key] {
-				mergedRow := mergeRows(rowA, rowB)
-				df.AppendRow(result, mergedRow)
-			}
+	for _, name := range df.ColumnNames() {
+		left := df.Columns[name]
+		right, shared := other.Columns[name]
+		var err error
+		switch {
+		case slices.Contains(keys, name):
+			err = add(name, left, right)
+		case shared:
+			err = add(name+suffixes[0], left, nil)
+		default:
+			err = add(name, left, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range other.ColumnNames() {
+		if slices.Contains(keys, name) {
+			continue
+		}
+		target := name
+		if _, shared := df.Columns[name]; shared {
+			target = name + suffixes[1]
+		}
+		if err := add(target, nil, other.Columns[name]); err != nil {
+			return nil, err
 		}
 	}
-
 	return result, nil
-
 }
 
-func (df *DataFrame) LeftJoin(other *DataFrame, key string) (*DataFrame, error) {
-	err := checkExists(df, other, key)
+// MergeAsOf joins every row of df with the row of other whose timestamp is the closest in the given
+// direction, like pandas merge_asof: trades with the last quote before them, events with the nearest
+// sensor reading. other does not need to be sorted. The timestamp column appears once, with the values
+// of df; other columns present in both DataFrames get the "_x" and "_y" suffixes.
+//
+// Parameters:
+//   - other: The DataFrame to look up.
+//   - on: The time.Time column present in both DataFrames. Nil timestamps never match.
+//   - tolerance: The largest distance between matched timestamps, 0 to match equal timestamps only and a
+//     negative duration for no limit.
+//   - direction: "backward" matches the last row of other at or before the timestamp, "forward" the
+//     first row at or after it and "nearest" the closest one, preferring the earlier on ties.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the rows of df in order, the columns of other are nil when no row matches.
+//   - error: An error if the column is missing or holds other values than time.Time, or the direction is unknown.
+func (df *DataFrame) MergeAsOf(other *DataFrame, on string, tolerance time.Duration, direction string) (*DataFrame, error) {
+	switch direction {
+	case "backward", "forward", "nearest":
+	default:
+		return nil, fmt.Errorf("invalid direction '%s' (must be 'backward', 'forward' or 'nearest')", direction)
+	}
+	if err := checkExists(df, other, on); err != nil {
+		return nil, err
+	}
+	leftTimes, err := asOfTimes(df, on)
 	if err != nil {
 		return nil, err
 	}
-
-	result := NewDataFrame()
-	err = appendCols(df, other, result)
+	rightTimes, err := asOfTimes(other, on)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := 0; i < df.Nrows(); i++ {
-		rowA, _ := df.Row(i)
-		matched := false
-		for j := 0; j < other.Nrows(); j++ {
-			rowB, _ := other.Row(j)
-			if rowA[key] == rowB[key] {
-				mergedRow := mergeRows(rowA, rowB)
-				df.AppendRow(result, mergedRow)
-				matched = true
+	// the rows of other holding a timestamp, in chronological order (stable for equal timestamps)
+	var sorted []int
+	for j, t := range rightTimes {
+		if t != nil {
+			sorted = append(sorted, j)
+		}
+	}
+	sort.SliceStable(sorted, func(a, b int) bool { return rightTimes[sorted[a]].Before(*rightTimes[sorted[b]]) })
+
+	leftRows := make([]int, len(leftTimes))
+	rightRows := make([]int, len(leftTimes))
+	for i, t := range leftTimes {
+		leftRows[i], rightRows[i] = i, -1
+		if t == nil {
+			continue
+		}
+		// before: the last row at or before t, after: the first row at or after t
+		before := sort.Search(len(sorted), func(k int) bool { return rightTimes[sorted[k]].After(*t) }) - 1
+		after := sort.Search(len(sorted), func(k int) bool { return !rightTimes[sorted[k]].Before(*t) })
+		candidate := -1
+		switch direction {
+		case "backward":
+			candidate = before
+		case "forward":
+			candidate = after
+		case "nearest":
+			candidate = before
+			if after < len(sorted) && (before < 0 || rightTimes[sorted[after]].Sub(*t) < t.Sub(*rightTimes[sorted[before]])) {
+				candidate = after
 			}
 		}
-		if !matched {
-			df.AppendRow(result, rowA)
+		if candidate < 0 || candidate >= len(sorted) {
+			continue
+		}
+		match := sorted[candidate]
+		distance := rightTimes[match].Sub(*t).Abs()
+		if tolerance < 0 || distance <= tolerance {
+			rightRows[i] = match
 		}
 	}
+	return suffixedJoin(df, other, []string{on}, [2]string{"_x", "_y"}, leftRows, rightRows)
+}
 
-	return result, nil
+// asOfTimes returns the timestamps of a column, nil for missing values
+func asOfTimes(df *DataFrame, on string) ([]*time.Time, error) {
+	values := df.Columns[on].Values()
+	times := make([]*time.Time, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case time.Time:
+			times[i] = &v
+		default:
+			return nil, fmt.Errorf("column '%s' holds %T values, expected time.Time", on, value)
+		}
+	}
+	return times, nil
 }
 
-func (df *DataFrame) RightJoin(other *DataFrame, key string) (*DataFrame, error) {
-	err := checkExists(df, other, key)
+// hashJoin joins on a single key, keeping the value of df for the columns present in both DataFrames
+func (df *DataFrame) hashJoin(other *DataFrame, key string, how string) (*DataFrame, error) {
+	key, err := joinKey(df, other, key)
 	if err != nil {
 		return nil, err
 	}
+	keys := []string{key}
+	leftRows, rightRows := joinRows(joinKeyValues(df, keys), joinKeyValues(other, keys), how, df.parallelism)
 
 	result := NewDataFrame()
-	err = appendCols(df, other, result)
-	if err != nil {
+	result.indexNames = df.indexNames
+	if err := appendCols(df, other, result); err != nil {
 		return nil, err
 	}
+	for _, name := range result.order {
+		result.Columns[name].Data = joinColumn(df.Columns[name], other.Columns[name], leftRows, rightRows)
+	}
+	return result, nil
+}
 
-	for i := 0; i < other.Nrows(); i++ {
-		rowB, _ := other.Row(i)
-		matched := false
-		for j := 0; j < df.Nrows(); j++ {
-			rowA, _ := df.Row(j)
-			if rowB[key] == rowA[key] {
-				mergedRow := mergeRows(rowA, rowB)
-				df.AppendRow(result, mergedRow)
-				matched = true
+// joinRows pairs the rows with equal keys, returning the positions of the joined rows in the left and
+// right DataFrames, -1 when a row has no counterpart. The rows of one side are grouped by key in a hash
+// map, then the other side is scanned once, in ranges of rows on up to workers goroutines. The pairs of
+// the ranges are concatenated in order.
+func joinRows(leftKeys, rightKeys [][]any, how string, workers int) ([]int, []int) {
+	indexed, probed := rightKeys, leftKeys
+	if how == "right" {
+		indexed, probed = leftKeys, rightKeys
+	}
+	index := joinIndex(indexed)
+	type pairs struct{ probed, indexed []int }
+	ranges := parallelMap(workers, len(probed[0]), func(start, end int) pairs {
+		var p pairs
+		for i := start; i < end; i++ {
+			matches := lookupJoinKey(index, probed, i)
+			for _, j := range matches {
+				p.probed, p.indexed = append(p.probed, i), append(p.indexed, j)
+			}
+			if len(matches) == 0 && how != "inner" {
+				p.probed, p.indexed = append(p.probed, i), append(p.indexed, -1)
 			}
 		}
-		if !matched {
-			df.AppendRow(result, rowB)
-		}
+		return p
+	})
+	var probedRows, indexedRows []int
+	for _, p := range ranges {
+		probedRows, indexedRows = append(probedRows, p.probed...), append(indexedRows, p.indexed...)
+	}
+	if how == "right" {
+		return indexedRows, probedRows
 	}
 
-	return result, nil
+	leftRows, rightRows := probedRows, indexedRows
+	if how == "outer" {
+		matched := make([]bool, len(rightKeys[0]))
+		for _, j := range rightRows {
+			if j >= 0 {
+				matched[j] = true
+			}
+		}
+		for j, found := range matched {
+			if !found {
+				leftRows, rightRows = append(leftRows, -1), append(rightRows, j)
+			}
+		}
+	}
+	return leftRows, rightRows
 }
 
-func (df *DataFrame) OuterJoin(other *DataFrame, key string) (*DataFrame, error) {
-	err := checkExists(df, other, key)
-	if err != nil {
-		return nil, err
+// joinColumn gathers the values of a joined column, taken from left when the row has a left
+// counterpart and from right otherwise. Either column may be nil.
+func joinColumn(left, right *Column[any], leftRows, rightRows []int) []any {
+	var leftValues, rightValues []any
+	if left != nil {
+		leftValues = left.Values()
+	}
+	if right != nil {
+		rightValues = right.Values()
+	}
+	data := make([]any, len(leftRows))
+	for r, i := range leftRows {
+		if left != nil && i >= 0 {
+			data[r] = leftValues[i]
+		} else if right != nil && rightRows[r] >= 0 {
+			data[r] = rightValues[rightRows[r]]
+		}
 	}
+	return data
+}
 
-	result := NewDataFrame()
-	err = appendCols(df, other, result)
-	if err != nil {
-		return nil, err
+// joinKeyValues returns the values of the key columns
+func joinKeyValues(df *DataFrame, keys []string) [][]any {
+	values := make([][]any, len(keys))
+	for i, key := range keys {
+		values[i] = df.Columns[key].Values()
 	}
+	return values
+}
 
-	matchedRows := make(map[any]bool)
-	for i := 0; i < df.Nrows(); i++ {
-		rowA, _ := df.Row(i)
-		matched := false
-		for j := 0; j < other.Nrows(); j++ {
-			rowB, _ := other.Row(j) // Ensure rowB is defined
-			if reflect.DeepEqual(rowA[key], rowB[key]) {
-				mergedRow := mergeRows(rowA, rowB)
-				df.AppendRow(result, mergedRow)
-				matchedRows[rowA[key]] = true
-				matched = true
-			}
+// rowJoinKey returns the hash key of a row: the key itself for a single key column, an encoding of
+// the typed values otherwise. Keys that cannot be hashed or hold NaN never match.
+func rowJoinKey(keys [][]any, row int) (any, bool) {
+	if len(keys) == 1 {
+		key := keys[0][row]
+		return key, isComparable(key)
+	}
+	var b strings.Builder
+	for _, column := range keys {
+		key := column[row]
+		if !isComparable(key) || isNaNValue(key) {
+			return nil, false
 		}
-		if !matched {
-			df.AppendRow(result, rowA)
+		fmt.Fprintf(&b, "%T:%#v\x00", key, key)
+	}
+	return b.String(), true
+}
+
+// joinIndex groups the row positions by key
+func joinIndex(keys [][]any) map[any][]int {
+	index := make(map[any][]int, len(keys[0]))
+	for i := range keys[0] {
+		if key, ok := rowJoinKey(keys, i); ok {
+			index[key] = append(index[key], i)
 		}
+	}
+	return index
+}
 
+// lookupJoinKey returns the positions of the key of a row in a join index
+func lookupJoinKey(index map[any][]int, keys [][]any, row int) []int {
+	key, ok := rowJoinKey(keys, row)
+	if !ok {
+		return nil
 	}
+	return index[key]
+}
 
-	// Now append the rows that were not matched in the first for loop
-	// this is to also add the other dataframe into the result
-	for i := 0; i < other.Nrows(); i++ {
-		rowB, _ := other.Row(i)
-		if _, exists := matchedRows[rowB[key]]; !exists {
-			df.AppendRow(result, rowB)
+// joinKey returns the key column of a join, the index column shared by both DataFrames when key is empty
+func joinKey(df, other *DataFrame, key string) (string, error) {
+	if key == "" {
+		key = df.IndexName()
+		if key == "" || other.IndexName() != key {
+			return "", fmt.Errorf("both DataFrames need the same index column to join on the index, got '%s' and '%s'", key, other.IndexName())
 		}
 	}
+	return key, checkExists(df, other, key)
+}
 
-	return result, nil
+// joinKeys returns the key columns of a join, the index columns shared by both DataFrames when keys is empty
+func joinKeys(df, other *DataFrame, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		keys = df.IndexNames()
+		if len(keys) == 0 || !slices.Equal(keys, other.IndexNames()) {
+			return nil, fmt.Errorf("both DataFrames need the same index columns to join on the index, got %v and %v", keys, other.IndexNames())
+		}
+	}
+	for i, key := range keys {
+		if slices.Contains(keys[:i], key) {
+			return nil, fmt.Errorf("duplicate key column '%s'", key)
+		}
+		if err := checkExists(df, other, key); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
 }
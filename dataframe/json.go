@@ -0,0 +1,485 @@
+package dataframe
+
+/*
+
+	This is where JSON import and export is defined
+
+*/
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// JSONOption configures how a DataFrame is read from or written to JSON.
+//
+// Fields:
+//   - Orient: The layout of the JSON document. "records" (default) is an array of objects, one per row
+//     ([{"col": value}, ...]). "columns" is an object holding one array per column ({"col": [values]}).
+//     "lines" is JSON Lines (NDJSON), one object per line.
+//   - Separator: The separator used to flatten nested objects into column names when reading,
+//     e.g. {"user": {"id": 1}} becomes the column "user.id". Defaults to ".".
+//   - StrictFields: Returns an error when reading records that do not all have the fields of the first one.
+//     By default the columns are the union of the fields of every record, nil where a record misses a field.
+type JSONOption struct {
+	Orient       string
+	Separator    string
+	StrictFields bool
+}
+
+// JSONField is a field of the records of a JSON Lines document, see JSONLinesReport.
+//
+// Fields:
+//   - Name: The name of the column, nested fields joined with the separator.
+//   - FirstRecord: The position of the first record with the field.
+//   - LastRecord: The position of the last record with the field.
+//   - Count: The number of records with the field.
+type JSONField struct {
+	Name        string
+	FirstRecord int
+	LastRecord  int
+	Count       int
+}
+
+// JSONLinesReport describes how the fields of the records of a JSON Lines document evolve, see FromJSONLinesReader.
+//
+// Fields:
+//   - Records: The number of records.
+//   - Fields: The fields, in column order.
+type JSONLinesReport struct {
+	Records int
+	Fields  []JSONField
+}
+
+// Added returns the fields that the first record does not have, first seen in a later record.
+func (r *JSONLinesReport) Added() []string {
+	var names []string
+	for _, field := range r.Fields {
+		if field.FirstRecord > 0 {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// Removed returns the fields that the last record does not have, last seen in an earlier record.
+func (r *JSONLinesReport) Removed() []string {
+	var names []string
+	for _, field := range r.Fields {
+		if field.LastRecord < r.Records-1 {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// jsonObject is a decoded JSON object that keeps the order of its keys
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+// jsonOptions validates the user options and applies the defaults
+func jsonOptions(options []JSONOption) (JSONOption, error) {
+	opts := JSONOption{Orient: "records", Separator: "."}
+	if len(options) > 0 {
+		if options[0].Orient != "" {
+			opts.Orient = options[0].Orient
+		}
+		if options[0].Separator != "" {
+			opts.Separator = options[0].Separator
+		}
+		opts.StrictFields = options[0].StrictFields
+	}
+	if opts.Orient != "records" && opts.Orient != "columns" && opts.Orient != "lines" {
+		return opts, fmt.Errorf("invalid orient '%s' (must be 'records', 'columns' or 'lines')", opts.Orient)
+	}
+	return opts, nil
+}
+
+// FromJSON creates a DataFrame from a JSON file.
+//
+// Parameters:
+//   - filename: The path to the JSON file.
+//   - options (optional): The JSONOption struct to configure the orientation and the nested field separator.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame.
+//   - error: An error if the file cannot be read.
+func FromJSON(filename string, options ...JSONOption) (*DataFrame, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	return FromJSONReader(file, options...)
+}
+
+// FromJSONReader creates a DataFrame from a JSON reader.
+//
+// Nested objects are flattened into one column per leaf field, joined with the separator. Columns keep
+// the order in which their fields first appear. Values are typed the same way as FromCSVReader: numbers
+// become float64 and strings stay strings, booleans stay bool, and null or missing fields become nil.
+// Arrays are stored as []any values.
+//
+// Parameters:
+//   - reader: An io.Reader for the JSON data.
+//   - options (optional): The JSONOption struct to configure the orientation and the nested field separator.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame.
+//   - error: An error if the data cannot be read or does not match the orientation.
+func FromJSONReader(reader io.Reader, options ...JSONOption) (*DataFrame, error) {
+	opts, err := jsonOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Orient == "lines" {
+		df, _, err := FromJSONLinesReader(reader, opts)
+		return df, err
+	}
+
+	dec := json.NewDecoder(reader)
+	value, err := readJSONValue(dec)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("error reading JSON: unexpected data after the document")
+	}
+
+	if opts.Orient == "columns" {
+		return jsonColumnsFrame(value, opts.Separator)
+	}
+	records, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("records orientation requires a JSON array")
+	}
+	df, _, err := jsonRecordsFrame(records, opts)
+	return df, err
+}
+
+// FromJSONLinesReader creates a DataFrame from JSON Lines (NDJSON), one object per line, and reports
+// the fields that appear or disappear along the records, e.g. when the producer of a log changes its schema.
+// The columns are the union of the fields of every record, nil where a record misses a field, unless
+// JSONOption.StrictFields is set. Values are typed like FromJSONReader.
+//
+// Parameters:
+//   - reader: An io.Reader for the JSON Lines data.
+//   - options (optional): The JSONOption struct to configure the nested field separator and the strict fields check.
+//
+// Returns:
+//   - *DataFrame: The created DataFrame.
+//   - *JSONLinesReport: The fields of the records, see JSONLinesReport.Added and JSONLinesReport.Removed.
+//   - error: An error if the data cannot be read or a line is not a JSON object.
+func FromJSONLinesReader(reader io.Reader, options ...JSONOption) (*DataFrame, *JSONLinesReport, error) {
+	opts, err := jsonOptions(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dec := json.NewDecoder(reader)
+	var records []any
+	for {
+		value, err := readJSONValue(dec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading JSON: record %d: %w", len(records), err)
+		}
+		records = append(records, value)
+	}
+	return jsonRecordsFrame(records, opts)
+}
+
+// readJSONValue decodes the next JSON value, objects are returned as *jsonObject to keep their key order
+func readJSONValue(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := &jsonObject{values: make(map[string]any)}
+		for dec.More() {
+			keyToken, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyToken.(string)
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := obj.values[key]; !exists {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		_, err = dec.Token() // closing brace
+		return obj, err
+	case json.Delim('['):
+		values := []any{}
+		for dec.More() {
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		_, err = dec.Token() // closing bracket
+		return values, err
+	}
+	return token, nil
+}
+
+// plainJSONValue converts the objects nested in a cell value to map[string]any
+func plainJSONValue(value any) any {
+	switch v := value.(type) {
+	case *jsonObject:
+		result := make(map[string]any, len(v.values))
+		for key, item := range v.values {
+			result[key] = plainJSONValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = plainJSONValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// flattenJSON calls emit for every leaf field of an object, nested object keys are joined with sep
+func flattenJSON(prefix string, obj *jsonObject, sep string, emit func(name string, value any) error) error {
+	for _, key := range obj.keys {
+		name := key
+		if prefix != "" {
+			name = prefix + sep + key
+		}
+		if nested, ok := obj.values[key].(*jsonObject); ok && len(nested.keys) > 0 {
+			if err := flattenJSON(name, nested, sep, emit); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := emit(name, plainJSONValue(obj.values[key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonRecordsFrame builds a DataFrame from decoded records and reports their fields
+func jsonRecordsFrame(records []any, opts JSONOption) (*DataFrame, *JSONLinesReport, error) {
+	df := NewDataFrame()
+	report := &JSONLinesReport{Records: len(records)}
+	fields := make(map[string]int)
+	for i, item := range records {
+		record, ok := item.(*jsonObject)
+		if !ok {
+			return nil, nil, fmt.Errorf("record %d is not a JSON object", i)
+		}
+
+		err := flattenJSON("", record, opts.Separator, func(name string, value any) error {
+			col, exists := df.Columns[name]
+			if !exists {
+				if opts.StrictFields && i > 0 {
+					return fmt.Errorf("record %d: unexpected field '%s'", i, name)
+				}
+				// a field first seen in a later record is nil in the previous ones
+				col = &Column[any]{Name: name, Data: make([]any, i, len(records))}
+				df.Columns[name] = col
+				df.order = append(df.order, name)
+				fields[name] = len(report.Fields)
+				report.Fields = append(report.Fields, JSONField{Name: name, FirstRecord: i})
+			}
+			if len(col.Data) > i {
+				return fmt.Errorf("record %d: duplicate field '%s'", i, name)
+			}
+			col.Data = append(col.Data, value)
+			field := &report.Fields[fields[name]]
+			field.LastRecord = i
+			field.Count++
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// fields missing from this record are nil
+		for _, name := range df.order {
+			if col := df.Columns[name]; len(col.Data) == i {
+				if opts.StrictFields {
+					return nil, nil, fmt.Errorf("record %d: missing field '%s'", i, name)
+				}
+				col.Data = append(col.Data, nil)
+			}
+		}
+	}
+
+	return df, report, nil
+}
+
+// jsonColumnsFrame builds a DataFrame from a decoded object of column arrays
+func jsonColumnsFrame(value any, sep string) (*DataFrame, error) {
+	obj, ok := value.(*jsonObject)
+	if !ok {
+		return nil, fmt.Errorf("columns orientation requires a JSON object")
+	}
+
+	df := NewDataFrame()
+	nRows := -1
+	err := flattenJSON("", obj, sep, func(name string, value any) error {
+		values, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("column '%s' is not a JSON array", name)
+		}
+		if nRows >= 0 && len(values) != nRows {
+			return fmt.Errorf("column '%s' has %d values, expected %d", name, len(values), nRows)
+		}
+		if _, exists := df.Columns[name]; exists {
+			return fmt.Errorf("duplicate column '%s'", name)
+		}
+		nRows = len(values)
+		df.Columns[name] = &Column[any]{Name: name, Data: values}
+		df.order = append(df.order, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return df, nil
+}
+
+// ToJSON exports the DataFrame to a JSON file.
+//
+// Parameters:
+//   - filename: The path to the output JSON file.
+//   - options (optional): The JSONOption struct to configure the orientation.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func (df *DataFrame) ToJSON(filename string, options ...JSONOption) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	return df.ToJSONWriter(file, options...)
+}
+
+// ToJSONWriter exports the DataFrame to a JSON writer. Fields are written in column order,
+// NaN and infinite floats are written as null and time.Time values as RFC 3339 strings.
+//
+// Parameters:
+//   - writer: An io.Writer for the JSON data.
+//   - options (optional): The JSONOption struct to configure the orientation.
+//
+// Returns:
+//   - error: An error if a value cannot be encoded or the data cannot be written.
+func (df *DataFrame) ToJSONWriter(writer io.Writer, options ...JSONOption) error {
+	opts, err := jsonOptions(options)
+	if err != nil {
+		return err
+	}
+
+	names := df.ColumnNames()
+	keys := make([][]byte, len(names))
+	for i, name := range names {
+		if keys[i], err = json.Marshal(name); err != nil {
+			return fmt.Errorf("error encoding column name '%s': %w", name, err)
+		}
+	}
+
+	w := bufio.NewWriter(writer)
+	writeValue := func(name string, row int) error {
+		value, _ := df.Columns[name].At(row)
+		encoded, err := encodeJSONValue(value)
+		if err != nil {
+			return fmt.Errorf("error encoding column '%s' row %d: %w", name, row, err)
+		}
+		w.Write(encoded)
+		return nil
+	}
+
+	if opts.Orient == "columns" {
+		w.WriteByte('{')
+		for c, name := range names {
+			if c > 0 {
+				w.WriteByte(',')
+			}
+			w.Write(keys[c])
+			w.WriteString(":[")
+			for i := 0; i < df.Columns[name].Len(); i++ {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				if err := writeValue(name, i); err != nil {
+					return err
+				}
+			}
+			w.WriteByte(']')
+		}
+		w.WriteByte('}')
+	} else {
+		lines := opts.Orient == "lines"
+		if !lines {
+			w.WriteByte('[')
+		}
+		for i := 0; i < df.Nrows(); i++ {
+			if i > 0 && !lines {
+				w.WriteByte(',')
+			}
+			w.WriteByte('{')
+			for c, name := range names {
+				if c > 0 {
+					w.WriteByte(',')
+				}
+				w.Write(keys[c])
+				w.WriteByte(':')
+				if err := writeValue(name, i); err != nil {
+					return err
+				}
+			}
+			w.WriteByte('}')
+			if lines {
+				w.WriteByte('\n')
+			}
+		}
+		if !lines {
+			w.WriteByte(']')
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("error writing JSON: %w", err)
+	}
+	return nil
+}
+
+// encodeJSONValue encodes a single cell, NaN and infinite floats become null
+func encodeJSONValue(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return []byte("null"), nil
+		}
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return []byte("null"), nil
+		}
+	}
+	return json.Marshal(value)
+}
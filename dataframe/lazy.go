@@ -0,0 +1,411 @@
+package dataframe
+
+/*
+
+	This is where lazy pipelines are defined. A LazyFrame records the operations called on it and
+	runs them when Collect is called, after rewriting them:
+
+		df.Lazy().Filter("salary > 100").Select("dept", "salary").Filter("dept != 'HR'").
+			GroupBy("dept").Agg(map[string][]string{"salary": {"sum"}}).Collect()
+
+	- the filters are moved before the selections (predicate pushdown) and combined into one
+	  expression, evaluated in a single pass over the rows;
+	- only the columns used by the filters, the selections and the aggregations are read
+	  (projection pruning), the other columns are never copied.
+
+	The operations after an aggregation work on its result and are rewritten the same way. Explain
+	prints the rewritten plan. A pipeline started with LazyFromSQLTable reads a database table and
+	pushes its first filters and columns into the SELECT statement, see lazy_sql.go.
+
+*/
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LazyFrame is a pipeline of operations on a DataFrame, run by Collect. See DataFrame.Lazy.
+//
+// The methods return a new LazyFrame and leave the receiver unchanged, so a pipeline can be
+// extended in several ways. An invalid operation (e.g. an expression that does not parse) is
+// reported by Collect.
+type LazyFrame struct {
+	source *DataFrame
+	sql    *lazySQL // table read instead of source, see LazyFromSQLTable
+	steps  []lazyStep
+	err    error
+}
+
+// LazyGroupBy is a grouping of a LazyFrame, waiting for its aggregations. See LazyFrame.GroupBy.
+type LazyGroupBy struct {
+	lf   *LazyFrame
+	keys []string
+}
+
+// lazyStep is a recorded operation of a LazyFrame
+type lazyStep struct {
+	kind    string              // "filter", "select" or "agg"
+	expr    string              // filter expression
+	node    queryNode           // parsed filter expression
+	columns []string            // selected columns, or the keys of agg
+	spec    map[string][]string // aggregations of agg
+	options []GroupAggOption    // options of agg
+}
+
+// lazyStage is a part of the plan run in one pass over its input: the filters, then the
+// selection, then the aggregation. A new stage starts after each aggregation.
+type lazyStage struct {
+	filters []lazyStep
+	where   []queryCondition // filters evaluated by the database, see LazyFromSQLTable
+	scan    []string         // columns read from the input, nil for all of them
+	columns []string         // columns of the result, nil for all of them
+	agg     *lazyStep        // aggregation ending the stage, if any
+}
+
+// Lazy starts a pipeline of operations on the DataFrame, run when Collect is called. The DataFrame
+// must not be modified until then.
+//
+// Returns:
+//   - *LazyFrame: An empty pipeline reading the DataFrame.
+func (df *DataFrame) Lazy() *LazyFrame {
+	return &LazyFrame{source: df}
+}
+
+// with returns a copy of the pipeline with one more step
+func (lf *LazyFrame) with(step lazyStep) *LazyFrame {
+	next := *lf
+	next.steps = append(slices.Clip(lf.steps), step)
+	return &next
+}
+
+// withErr returns a copy of the pipeline failing with err, unless it already fails
+func (lf *LazyFrame) withErr(err error) *LazyFrame {
+	failed := *lf
+	if failed.err == nil {
+		failed.err = err
+	}
+	return &failed
+}
+
+// Filter keeps the rows for which an expression holds, see DataFrame.Query.
+//
+// Parameters:
+//   - expr: The expression, e.g. "age > 30 && dept == 'IT'".
+//
+// Returns:
+//   - *LazyFrame: The pipeline with the filter, Collect returns the parse error of expr.
+func (lf *LazyFrame) Filter(expr string) *LazyFrame {
+	node, err := parseQuery(expr)
+	if err != nil {
+		return lf.withErr(err)
+	}
+	return lf.with(lazyStep{kind: "filter", expr: expr, node: node})
+}
+
+// Select keeps the given columns, in the given order.
+//
+// Parameters:
+//   - columns: The names of the columns.
+//
+// Returns:
+//   - *LazyFrame: The pipeline with the selection.
+func (lf *LazyFrame) Select(columns ...string) *LazyFrame {
+	return lf.with(lazyStep{kind: "select", columns: slices.Clone(columns)})
+}
+
+// GroupBy groups the rows by the values of one or more columns, see DataFrame.Groupby.
+//
+// Parameters:
+//   - keys: The names of the key columns.
+//
+// Returns:
+//   - *LazyGroupBy: The grouping, Agg adds its aggregations to the pipeline.
+func (lf *LazyFrame) GroupBy(keys ...string) *LazyGroupBy {
+	return &LazyGroupBy{lf: lf, keys: slices.Clone(keys)}
+}
+
+// Agg aggregates the groups, see GroupedDataFrame.Agg. The operations added after it work on the
+// aggregated DataFrame, e.g. a Filter on "salary_sum".
+//
+// Parameters:
+//   - spec: The aggregations to apply, keyed by column name, e.g. {"salary": {"sum", "mean"}}.
+//   - options (optional): The GroupAggOption struct to add custom aggregations, set the name separator and sort the result.
+//
+// Returns:
+//   - *LazyFrame: The pipeline with the aggregation.
+func (g *LazyGroupBy) Agg(spec map[string][]string, options ...GroupAggOption) *LazyFrame {
+	if len(g.keys) == 0 {
+		return g.lf.withErr(fmt.Errorf("GroupBy needs at least one key column"))
+	}
+	return g.lf.with(lazyStep{kind: "agg", columns: g.keys, spec: spec, options: options})
+}
+
+// Collect runs the pipeline.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the result, sharing no data with the source DataFrame.
+//   - error: An error if an expression does not parse or fails on a row, a column does not exist
+//     (or is used after a Select that dropped it), an aggregation fails or the database query
+//     fails, see LazyFromSQLTable.
+func (lf *LazyFrame) Collect() (*DataFrame, error) {
+	stages, err := lf.plan()
+	if err != nil {
+		return nil, err
+	}
+	result := lf.source
+	if lf.sql != nil {
+		// the first stage reads only the columns it needs and the rows its pushed filters keep
+		if result, err = lf.sql.read(stages[0]); err != nil {
+			return nil, err
+		}
+	}
+	for _, stage := range stages {
+		if result, err = stage.run(result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Explain describes the plan run by Collect, one operation per line, after the filters are
+// combined and moved before the selections and the columns read are pruned.
+//
+// Returns:
+//   - string: The plan, e.g. "scan [dept salary]\nfilter (salary > 100) && (dept != 'HR')\n...".
+//   - error: An error if the pipeline is invalid, see Collect.
+func (lf *LazyFrame) Explain() (string, error) {
+	stages, err := lf.plan()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, stage := range stages {
+		switch {
+		case i > 0:
+			b.WriteString("scan aggregate\n")
+		case lf.sql != nil:
+			sqlText, args, err := lf.sql.query(stage).Build(lf.sql.dialect)
+			if err != nil {
+				return "", err
+			}
+			if len(args) > 0 {
+				sqlText = fmt.Sprintf("%s %v", sqlText, args)
+			}
+			fmt.Fprintf(&b, "sql %s\n", sqlText)
+		case stage.scan == nil:
+			b.WriteString("scan *\n")
+		default:
+			fmt.Fprintf(&b, "scan %v\n", stage.scan)
+		}
+		if len(stage.filters) > 0 {
+			_, expr := stage.fusedFilter()
+			fmt.Fprintf(&b, "filter %s\n", expr)
+		}
+		if stage.columns != nil && !slices.Equal(stage.columns, stage.scan) {
+			fmt.Fprintf(&b, "select %v\n", stage.columns)
+		}
+		if stage.agg != nil {
+			fmt.Fprintf(&b, "aggregate %v by %v\n", stage.agg.spec, stage.agg.columns)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// plan splits the steps into stages, moving the filters before the selections and listing the
+// columns each stage reads
+func (lf *LazyFrame) plan() ([]lazyStage, error) {
+	if lf.err != nil {
+		return nil, lf.err
+	}
+
+	// a column used after a Select must have been selected
+	var stages []lazyStage
+	var stage lazyStage
+	available := func(columns []string) error {
+		for _, name := range columns {
+			if stage.columns != nil && !slices.Contains(stage.columns, name) {
+				return fmt.Errorf("column '%s' does not exist", name)
+			}
+		}
+		return nil
+	}
+	for _, step := range lf.steps {
+		switch step.kind {
+		case "filter":
+			if err := available(filterColumns(step.node)); err != nil {
+				return nil, err
+			}
+			stage.filters = append(stage.filters, step)
+		case "select":
+			if err := available(step.columns); err != nil {
+				return nil, err
+			}
+			for i, name := range step.columns {
+				if slices.Contains(step.columns[:i], name) {
+					return nil, fmt.Errorf("duplicate column name '%s'", name)
+				}
+			}
+			stage.columns = step.columns
+		case "agg":
+			aggregated := slices.Clone(step.columns)
+			for name := range step.spec {
+				aggregated = append(aggregated, name)
+			}
+			if err := available(aggregated); err != nil {
+				return nil, err
+			}
+			stage.agg = &step
+			stages = append(stages, stage)
+			stage = lazyStage{}
+		}
+	}
+	if len(stages) == 0 || len(stage.filters) > 0 || stage.columns != nil {
+		stages = append(stages, stage)
+	}
+	if lf.sql != nil {
+		stages[0].pushFilters()
+	}
+
+	// the columns to read: those of the result (or the aggregation) and of the filters
+	for i := range stages {
+		s := &stages[i]
+		var needed []string
+		switch {
+		case s.agg != nil:
+			needed = slices.Clone(s.agg.columns)
+			for name := range s.agg.spec {
+				needed = append(needed, name)
+			}
+			// the spec is a map, keep the plan stable
+			slices.Sort(needed[len(s.agg.columns):])
+		case s.columns != nil:
+			needed = slices.Clone(s.columns)
+		default:
+			continue
+		}
+		for _, filter := range s.filters {
+			needed = append(needed, filterColumns(filter.node)...)
+		}
+		s.scan = uniqueStrings(needed)
+	}
+	return stages, nil
+}
+
+// run executes the stage on its input, where the filters pushed to a database are already applied
+func (s lazyStage) run(df *DataFrame) (*DataFrame, error) {
+	result, err := df.project(s.scan)
+	if err != nil {
+		return nil, err
+	}
+	filtered := false
+	if len(s.filters) > 0 {
+		node, expr := s.fusedFilter()
+		if err := node.check(result); err != nil {
+			return nil, err
+		}
+		if result, err = result.queryRows(node, expr); err != nil {
+			return nil, err
+		}
+		filtered = true
+	}
+	if result, err = result.project(s.columns); err != nil {
+		return nil, err
+	}
+
+	if s.agg != nil {
+		var key any = s.agg.columns
+		if len(s.agg.columns) == 1 {
+			key = s.agg.columns[0]
+		}
+		return result.Groupby(key).Agg(s.agg.spec, s.agg.options...)
+	}
+	if !filtered {
+		// the projection shares the columns of the input
+		result = result.clone()
+	}
+	return result, nil
+}
+
+// fusedFilter combines the filters of the stage into one expression
+func (s lazyStage) fusedFilter() (queryNode, string) {
+	if len(s.filters) == 1 {
+		return s.filters[0].node, s.filters[0].expr
+	}
+	node := s.filters[0].node
+	exprs := []string{"(" + s.filters[0].expr + ")"}
+	for _, filter := range s.filters[1:] {
+		node = queryBinary{op: "&&", left: node, right: filter.node}
+		exprs = append(exprs, "("+filter.expr+")")
+	}
+	return node, strings.Join(exprs, " && ")
+}
+
+// project returns a DataFrame with some of the columns of df, sharing their data, or df itself for
+// nil columns. The index is kept if its columns are projected.
+func (df *DataFrame) project(columns []string) (*DataFrame, error) {
+	if columns == nil {
+		return df, nil
+	}
+	result := NewDataFrame()
+	for _, name := range columns {
+		col, exists := df.Columns[name]
+		if !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+		result.Columns[name] = col
+		if levels, ok := df.columnLevels[name]; ok {
+			result.setColumnLevels(name, levels)
+		}
+	}
+	result.order = slices.Clone(columns)
+	if len(df.indexNames) > 0 && !slices.ContainsFunc(df.indexNames, func(name string) bool {
+		return !slices.Contains(columns, name)
+	}) {
+		result.indexNames = df.indexNames
+	}
+	result.typed = df.typed
+	result.parallelism = df.parallelism
+	return result, nil
+}
+
+// filterColumns lists the columns referenced by an expression, in order of appearance
+func filterColumns(node queryNode) []string {
+	var columns []string
+	var walk func(node queryNode)
+	walk = func(node queryNode) {
+		switch n := node.(type) {
+		case queryColumn:
+			columns = append(columns, n.name)
+		case queryUnary:
+			walk(n.operand)
+		case queryBinary:
+			walk(n.left)
+			walk(n.right)
+		case queryCall:
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		case queryIsNull:
+			walk(n.operand)
+		case queryIn:
+			walk(n.operand)
+			for _, value := range n.values {
+				walk(value)
+			}
+		}
+	}
+	walk(node)
+	return uniqueStrings(columns)
+}
+
+// uniqueStrings removes the repeated strings of a slice, keeping the first occurrences in order
+func uniqueStrings(values []string) []string {
+	result := []string{}
+	for _, v := range values {
+		if !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package dataframe
+
+/*
+
+	This is where lazy pipelines reading a database table are defined. LazyFromSQLTable starts a
+	LazyFrame whose first stage is turned into a SELECT statement built with Table:
+
+		LazyFromSQLTable(db, "emp", "postgres").Filter("salary > 100").Select("dept", "salary").Collect()
+
+	runs SELECT "dept", "salary" FROM "emp" WHERE "salary" > $1, so only the needed columns and the
+	matching rows are transferred.
+
+	A filter is pushed into the WHERE clause when each of its conditions joined by && compares a
+	column with a number or a string (==, !=, <, <=, >, >=), tests it for null ("x == null") or
+	for membership in a list of literals (in, not in). The other filters, and every operation
+	after an aggregation, run in memory on the rows read. Parquet sources are out of scope, the
+	package has no Parquet reader.
+
+*/
+
+import (
+	"context"
+	"database/sql"
+)
+
+// lazySQL is the table read by a LazyFrame, see LazyFromSQLTable
+type lazySQL struct {
+	db      *sql.DB
+	table   string
+	dialect string
+	options []SQLReadOption
+}
+
+// sqlOperators maps the comparison operators of expressions to those of QueryBuilder.Where
+var sqlOperators = map[string]string{"==": "=", "!=": "<>", "<": "<", "<=": "<=", ">": ">", ">=": ">="}
+
+// sqlFlipped mirrors the comparison operators, for a literal on the left ("100 < salary")
+var sqlFlipped = map[string]string{"=": "=", "<>": "<>", "<": ">", "<=": ">=", ">": "<", ">=": "<="}
+
+// LazyFromSQLTable starts a pipeline reading a database table. Collect reads the table with a
+// query built with Table (see FromSQLQuery): the filters that the database can evaluate become
+// the WHERE clause, and only the columns used by the pipeline are selected. Explain prints the
+// query.
+//
+// The filters pushed into the query follow the SQL comparison rules of the database (e.g. for
+// the collation of strings), a null value never matches a comparison, as in DataFrame.Query.
+//
+// Parameters:
+//   - db: The database connection.
+//   - table: The name of the table, optionally qualified by a schema ("sales.orders").
+//   - dialect: The SQL dialect: "sqlite", "postgres" or "mysql".
+//   - options (optional): The SQLReadOption struct to configure NULL handling and date parsing.
+//
+// Returns:
+//   - *LazyFrame: An empty pipeline reading the table.
+func LazyFromSQLTable(db *sql.DB, table, dialect string, options ...SQLReadOption) *LazyFrame {
+	return &LazyFrame{sql: &lazySQL{db: db, table: table, dialect: dialect, options: options}}
+}
+
+// query builds the SELECT statement reading the input of the first stage
+func (s *lazySQL) query(stage lazyStage) *QueryBuilder {
+	query := Table(s.table).Select(stage.scan...)
+	query.conditions = append(query.conditions, stage.where...)
+	return query
+}
+
+// read runs the query of the first stage
+func (s *lazySQL) read(stage lazyStage) (*DataFrame, error) {
+	return FromSQLQueryContext(context.Background(), s.db, s.query(stage), s.dialect, s.options...)
+}
+
+// pushFilters moves the filters the database can evaluate to the WHERE clause of the stage
+func (s *lazyStage) pushFilters() {
+	var kept []lazyStep
+	for _, filter := range s.filters {
+		conditions, ok := sqlConditions(filter.node)
+		if !ok {
+			kept = append(kept, filter)
+			continue
+		}
+		s.where = append(s.where, conditions...)
+	}
+	s.filters = kept
+}
+
+// sqlConditions translates an expression into WHERE conditions combined with AND, ok is false if
+// a part of it cannot be translated
+func sqlConditions(node queryNode) ([]queryCondition, bool) {
+	switch n := node.(type) {
+	case queryBinary:
+		if n.op == "&&" {
+			left, ok := sqlConditions(n.left)
+			if !ok {
+				return nil, false
+			}
+			right, ok := sqlConditions(n.right)
+			if !ok {
+				return nil, false
+			}
+			return append(left, right...), true
+		}
+		op, ok := sqlOperators[n.op]
+		if !ok {
+			return nil, false
+		}
+		if column, ok := n.left.(queryColumn); ok {
+			if value, ok := sqlValue(n.right); ok {
+				return []queryCondition{{column: column.name, op: op, value: value}}, true
+			}
+		}
+		if column, ok := n.right.(queryColumn); ok {
+			if value, ok := sqlValue(n.left); ok {
+				return []queryCondition{{column: column.name, op: sqlFlipped[op], value: value}}, true
+			}
+		}
+	case queryIsNull:
+		if column, ok := n.operand.(queryColumn); ok {
+			op := "IS NULL"
+			if n.negate {
+				op = "IS NOT NULL"
+			}
+			return []queryCondition{{column: column.name, op: op}}, true
+		}
+	case queryIn:
+		column, ok := n.operand.(queryColumn)
+		if !ok || len(n.values) == 0 {
+			return nil, false
+		}
+		values := make([]any, len(n.values))
+		for i, candidate := range n.values {
+			if values[i], ok = sqlValue(candidate); !ok {
+				return nil, false
+			}
+		}
+		op := "IN"
+		if n.negate {
+			op = "NOT IN"
+		}
+		return []queryCondition{{column: column.name, op: op, value: values}}, true
+	}
+	return nil, false
+}
+
+// sqlValue returns the value of a number or string literal, whole numbers as int64
+func sqlValue(node queryNode) (any, bool) {
+	negate := false
+	if unary, ok := node.(queryUnary); ok && unary.op == "-" {
+		negate = true
+		node = unary.operand
+	}
+	literal, ok := node.(queryLiteral)
+	if !ok {
+		return nil, false
+	}
+	switch v := literal.value.(type) {
+	case float64:
+		if negate {
+			v = -v
+		}
+		if v == float64(int64(v)) {
+			return int64(v), true
+		}
+		return v, true
+	case string:
+		return v, !negate
+	}
+	return nil, false
+}
@@ -0,0 +1,142 @@
+package dataframe
+
+/*
+
+	This is where masking/anonymization methods for the DataFrame struct are defined
+
+*/
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// MaskOption is the parameters we can set to the MaskColumns method.
+//
+// Fields:
+//   - Salt: A secret prepended to every value before hashing, required by the "hash" strategy: unsalted
+//     hashes of emails or phone numbers are reversed by hashing a dictionary of candidates.
+//   - Replacement: The text used by the "redact" strategy. Defaults to "[REDACTED]".
+//   - Prefix: The pseudonym prefix used by the "fake" strategy. Defaults to the column name.
+//   - VisibleChars: The number of trailing characters left visible by the "partial" strategy, nil for 4.
+//     A pointer to 0 hides every character.
+//   - MaskChar: The character used to hide characters in the "partial" strategy. Defaults to "*".
+type MaskOption struct {
+	Salt         string
+	Replacement  string
+	Prefix       string
+	VisibleChars *int
+	MaskChar     string
+}
+
+// MaskColumns returns a copy of the DataFrame where the given columns are masked so the frame
+// can be shared without exposing personally identifiable information.
+//
+// Parameters:
+//   - cols: The names of the columns to mask.
+//   - strategy: The masking strategy to use:
+//     "hash" replaces each value with its salted SHA-256 hex digest,
+//     "redact" replaces each value with a fixed replacement text,
+//     "fake" replaces each value with a consistent pseudonym (e.g. "name_1"), equal values get equal pseudonyms,
+//     "partial" hides every character except the last few (e.g. "************1234").
+//   - options (optional): The MaskOption struct to tune the strategy.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the masked columns, the original DataFrame is left untouched.
+//   - error: An error if a column does not exist, the strategy is unknown or "hash" has no salt.
+//
+// Note:
+//   - nil values are never masked and stay nil.
+func (df *DataFrame) MaskColumns(cols []string, strategy string, options ...MaskOption) (*DataFrame, error) {
+	visibleChars := 4
+	opts := MaskOption{
+		Replacement:  "[REDACTED]",
+		VisibleChars: &visibleChars,
+		MaskChar:     "*",
+	}
+	if len(options) > 0 {
+		userOpt := options[0]
+		opts.Salt = userOpt.Salt
+		opts.Prefix = userOpt.Prefix
+		if userOpt.Replacement != "" {
+			opts.Replacement = userOpt.Replacement
+		}
+		if userOpt.VisibleChars != nil {
+			if *userOpt.VisibleChars < 0 {
+				return nil, fmt.Errorf("visible characters must not be negative, got %d", *userOpt.VisibleChars)
+			}
+			opts.VisibleChars = userOpt.VisibleChars
+		}
+		if userOpt.MaskChar != "" {
+			opts.MaskChar = userOpt.MaskChar
+		}
+	}
+
+	switch strategy {
+	case "hash", "redact", "fake", "partial":
+		// Valid
+	default:
+		return nil, fmt.Errorf("unknown masking strategy: %s (must be 'hash', 'redact', 'fake', or 'partial')", strategy)
+	}
+	if strategy == "hash" && opts.Salt == "" {
+		return nil, fmt.Errorf("the hash strategy requires a salt (MaskOption.Salt)")
+	}
+
+	for _, name := range cols {
+		if _, exists := df.Columns[name]; !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+
+	masked := df.clone()
+	for _, name := range cols {
+		col := masked.Columns[name]
+
+		// pseudonyms are only consistent within a column
+		pseudonyms := make(map[string]string)
+		prefix := opts.Prefix
+		if prefix == "" {
+			prefix = name
+		}
+
+		data := col.Values()
+		for i, v := range data {
+			if v == nil {
+				continue
+			}
+			value := fmt.Sprintf("%v", v)
+
+			switch strategy {
+			case "hash":
+				sum := sha256.Sum256([]byte(opts.Salt + value))
+				data[i] = hex.EncodeToString(sum[:])
+			case "redact":
+				data[i] = opts.Replacement
+			case "fake":
+				pseudonym, seen := pseudonyms[value]
+				if !seen {
+					pseudonym = fmt.Sprintf("%s_%d", prefix, len(pseudonyms)+1)
+					pseudonyms[value] = pseudonym
+				}
+				data[i] = pseudonym
+			case "partial":
+				data[i] = maskPartial(value, *opts.VisibleChars, opts.MaskChar)
+			}
+		}
+		masked.setColumnData(col, data)
+	}
+
+	return masked, nil
+}
+
+// maskPartial hides every character of value except the last visible ones
+func maskPartial(value string, visible int, maskChar string) string {
+	runes := []rune(value)
+	if len(runes) <= visible {
+		return value
+	}
+	hidden := len(runes) - visible
+	return strings.Repeat(maskChar, hidden) + string(runes[hidden:])
+}
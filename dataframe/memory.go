@@ -0,0 +1,135 @@
+package dataframe
+
+/*
+
+	This is where the memory introspection of a DataFrame is defined: MemoryUsage counts the bytes
+	held by each column and Info prints them with the type and the number of values of the columns.
+
+	A boxed column ([]any) costs an interface header of 16 bytes per row plus the value it points
+	to, e.g. 8 bytes for an int or 16 bytes and the characters for a string, which is why a CSV
+	file can take several times its size in memory. Typed storage (ToTyped) and compression
+	(CompressColumns) reduce it.
+
+*/
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// MemoryUsage returns the approximate number of bytes held by each column: the interface header
+// and the boxed value of every row for boxed columns, the native slice and the null bitmap for
+// typed storage (see NewTypedDataFrame) and the dictionary and codes of compressed columns.
+// Strings count their characters. Memory shared between columns, e.g. with the views of SliceRows,
+// is counted for each of them.
+//
+// Returns:
+//   - map[string]int64: The bytes of each column.
+func (df *DataFrame) MemoryUsage() map[string]int64 {
+	usage := make(map[string]int64, len(df.Columns))
+	for name, col := range df.Columns {
+		usage[name] = columnMemory(col)
+	}
+	return usage
+}
+
+// columnMemory returns the bytes held by a column, see MemoryUsage
+func columnMemory(col *Column[any]) int64 {
+	switch {
+	case col.encoded != nil:
+		bytes := int64(cap(col.encoded.codes))*4 + int64(cap(col.encoded.runEnds))*8
+		return bytes + boxedMemory(col.encoded.dict)
+	case col.native != nil:
+		return col.native.memory()
+	default:
+		return boxedMemory(col.Data)
+	}
+}
+
+// boxedMemory returns the bytes of a []any: an interface header per element and the values they point to
+func boxedMemory(values []any) int64 {
+	bytes := int64(cap(values)) * 16
+	for _, v := range values {
+		switch value := v.(type) {
+		case nil, bool:
+			// not allocated
+		case string:
+			bytes += 16 + int64(len(value))
+		default:
+			bytes += int64(reflect.TypeOf(v).Size())
+		}
+	}
+	return bytes
+}
+
+// Info summarizes the DataFrame: its shape and index, then for each column the number of values
+// that are not missing (nil or NaN), the type of the values, the storage and the memory, see
+// MemoryUsage, and the total memory.
+//
+// The type is the Go type shared by the values, "mixed" when they have several types and "null"
+// when there are none. The storage is "native" for typed storage, "rle" or "dict" for compressed
+// columns and "boxed" otherwise.
+//
+// Returns:
+//   - string: The summary, e.g. for fmt.Println.
+func (df *DataFrame) Info() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DataFrame: %d rows, %d columns\n", df.Nrows(), df.Ncols())
+	if index := df.IndexNames(); len(index) > 0 {
+		fmt.Fprintf(&b, "Index: %s\n", strings.Join(index, ", "))
+	}
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tColumn\tNon-Null\tDtype\tStorage\tMemory")
+	var total int64
+	for i, name := range df.ColumnNames() {
+		col := df.Columns[name]
+		values := col.Values()
+		nonNull := 0
+		dtype := "null"
+		for _, v := range values {
+			if isMissing(v) {
+				continue
+			}
+			nonNull++
+			switch t := fmt.Sprintf("%T", v); dtype {
+			case "null":
+				dtype = t
+			case t, "mixed":
+			default:
+				dtype = "mixed"
+			}
+		}
+		storage := "boxed"
+		if col.encoded != nil {
+			storage = col.encoded.kind
+		} else if col.native != nil {
+			storage = "native"
+		}
+		bytes := columnMemory(col)
+		total += bytes
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\n", i, name, nonNull, dtype, storage, formatBytes(bytes))
+	}
+	w.Flush()
+	fmt.Fprintf(&b, "Memory: %s\n", formatBytes(total))
+	return b.String()
+}
+
+// formatBytes prints a number of bytes with a binary unit, e.g. "1.5 KiB"
+func formatBytes(bytes int64) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	unit := ""
+	for _, u := range []string{"KiB", "MiB", "GiB", "TiB"} {
+		value /= 1024
+		unit = u
+		if value < 1024 {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", value, unit)
+}
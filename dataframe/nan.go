@@ -0,0 +1,232 @@
+package dataframe
+
+/*
+
+	This is where the NaN and infinity policy is defined
+
+	  - Aggregations (Mean, Sum, Min, Max, Median, Std, Var, Quantile, Skew, Kurtosis and Describe) skip NaN
+	    values by default, Mode and TimeWeightedMean always skip them.
+	    With AggOption.KeepNaN a NaN value makes the result NaN. When every value is NaN, Sum returns 0
+	    and the other aggregations NaN.
+	  - ±Inf values are regular numbers: Min and Max return them and Sum and Mean follow IEEE 754
+	    (+Inf + -Inf is NaN).
+	  - Sorts place NaN values last, together with nil values, in both directions. ±Inf sort as the smallest and largest numbers.
+	  - Joins never match NaN keys, since NaN is not equal to itself. ±Inf keys match each other.
+	  - SQL writes store NaN as NULL. ±Inf are written as is, MySQL rejects them: use ReplaceInf first.
+	  - JSON writes store NaN and ±Inf as null.
+	  - FillNaForward, FillNaBackward, FillNaMap and Interpolate fill NaN values like nil values, FillNa only fills nil values.
+
+*/
+
+import (
+	"math"
+	"slices"
+)
+
+// AggOption configures how an aggregation treats NaN values.
+//
+// Fields:
+//   - KeepNaN: Propagates NaN values to the result instead of skipping them (skipna=false).
+//   - Population: Std and Var divide by n (population statistics) instead of n-1 (sample statistics).
+type AggOption struct {
+	KeepNaN    bool
+	Population bool
+}
+
+// ddof returns the delta degrees of freedom of Std and Var: 0 for population statistics, 1 otherwise
+func ddof(options []AggOption) int {
+	if len(options) > 0 && options[0].Population {
+		return 0
+	}
+	return 1
+}
+
+// keepNaN reports whether the aggregation options ask to propagate NaN values
+func keepNaN(options []AggOption) bool {
+	return len(options) > 0 && options[0].KeepNaN
+}
+
+// skipNaN returns the values without NaN, nums is never modified. With keep set it leaves the
+// values untouched and reports whether a NaN value must propagate to the result.
+func skipNaN(nums []float64, keep bool) ([]float64, bool) {
+	for i, v := range nums {
+		if !math.IsNaN(v) {
+			continue
+		}
+		if keep {
+			return nums, true
+		}
+		filtered := append(make([]float64, 0, len(nums)-1), nums[:i]...)
+		for _, v := range nums[i+1:] {
+			if !math.IsNaN(v) {
+				filtered = append(filtered, v)
+			}
+		}
+		return filtered, false
+	}
+	return nums, false
+}
+
+// isNaNValue reports whether a value is a float NaN
+func isNaNValue(v any) bool {
+	switch f := v.(type) {
+	case float64:
+		return math.IsNaN(f)
+	case float32:
+		return math.IsNaN(float64(f))
+	}
+	return false
+}
+
+// floatMean, floatSum, floatMin, floatMax, floatMedian, floatVar and floatStd aggregate numeric
+// values following the NaN policy. Callers make sure nums is not empty.
+
+func floatMean(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) == 0 {
+		return math.NaN()
+	}
+	return floatSum(nums, keep) / float64(len(nums))
+}
+
+func floatSum(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range nums {
+		sum += v
+	}
+	return sum
+}
+
+func floatMin(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) == 0 {
+		return math.NaN()
+	}
+	result := nums[0]
+	for _, v := range nums[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+func floatMax(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) == 0 {
+		return math.NaN()
+	}
+	result := nums[0]
+	for _, v := range nums[1:] {
+		if v > result {
+			result = v
+		}
+	}
+	return result
+}
+
+func floatMedian(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) == 0 {
+		return math.NaN()
+	}
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// floatQuantile returns the q quantile (0 to 1) of sorted values with linear interpolation between
+// the closest ranks, like pandas. Callers make sure sorted is not empty and holds no NaN.
+func floatQuantile(sorted []float64, q float64) float64 {
+	rank := q * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// floatQuantileOf returns the q quantile of unsorted values, NaN if there is no value
+func floatQuantileOf(nums []float64, keep bool, q float64) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) == 0 {
+		return math.NaN()
+	}
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	return floatQuantile(sorted, q)
+}
+
+// floatVar returns the sample variance (n-1 denominator), NaN with fewer than two values
+func floatVar(nums []float64, keep bool) float64 {
+	return floatVariance(nums, keep, 1)
+}
+
+// floatVariance returns the variance with a n-ddof denominator, NaN when n <= ddof
+func floatVariance(nums []float64, keep bool, ddof int) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) <= ddof {
+		return math.NaN()
+	}
+	mean := floatSum(nums, keep) / float64(len(nums))
+	sq := 0.0
+	for _, v := range nums {
+		sq += (v - mean) * (v - mean)
+	}
+	return sq / float64(len(nums)-ddof)
+}
+
+func floatStd(nums []float64, keep bool) float64 {
+	return math.Sqrt(floatVar(nums, keep))
+}
+
+// centralMoments returns the sums of the squared, cubed and fourth powers of the deviations from the mean
+func centralMoments(nums []float64) (m2, m3, m4 float64) {
+	mean := floatSum(nums, false) / float64(len(nums))
+	for _, v := range nums {
+		d := v - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	return m2, m3, m4
+}
+
+// floatSkew returns the unbiased skewness (adjusted Fisher-Pearson, like pandas), NaN with fewer
+// than three values and 0 when every value is equal
+func floatSkew(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) < 3 {
+		return math.NaN()
+	}
+	n := float64(len(nums))
+	m2, m3, _ := centralMoments(nums)
+	if m2 == 0 {
+		return 0
+	}
+	m2, m3 = m2/n, m3/n
+	return m3 / math.Pow(m2, 1.5) * math.Sqrt(n*(n-1)) / (n - 2)
+}
+
+// floatKurtosis returns the unbiased excess kurtosis (Fisher, like pandas), NaN with fewer than
+// four values and 0 when every value is equal
+func floatKurtosis(nums []float64, keep bool) float64 {
+	nums, nan := skipNaN(nums, keep)
+	if nan || len(nums) < 4 {
+		return math.NaN()
+	}
+	n := float64(len(nums))
+	m2, _, m4 := centralMoments(nums)
+	if m2 == 0 {
+		return 0
+	}
+	return n*(n+1)*(n-1)*m4/((n-2)*(n-3)*m2*m2) - 3*(n-1)*(n-1)/((n-2)*(n-3))
+}
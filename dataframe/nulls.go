@@ -0,0 +1,212 @@
+package dataframe
+
+/*
+
+	This is where null-safe comparison and three-valued logic utilities are defined
+
+*/
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NullEq compares two series element-wise where two nil values are considered equal.
+// Numbers are compared by value, so 1 equals 1.0.
+//
+// Parameters:
+//   - other: The series to compare with.
+//
+// Returns:
+//   - *Series: A boolean Series, never containing nil.
+//   - error: An error if the series lengths do not match.
+func (s *Series) NullEq(other *Series) (*Series, error) {
+	if s.Len() != other.Len() {
+		return nil, fmt.Errorf("series lengths do not match: %d and %d", s.Len(), other.Len())
+	}
+
+	result := make([]any, s.Len())
+	for i := range s.Data {
+		result[i] = nullSafeEqual(s.Data[i], other.Data[i])
+	}
+	return NewSeries(s.Name, result), nil
+}
+
+// nullSafeEqual reports whether two values are equal, treating nil as equal to nil
+func nullSafeEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if cmp, ok := compareValues(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Coalesce returns, for every position, the first non-nil value among the given series.
+//
+// Parameters:
+//   - series: The series to coalesce, in order of priority.
+//
+// Returns:
+//   - *Series: A Series named after the first series, nil where every series is nil.
+//   - error: An error if no series is given or the lengths do not match.
+func Coalesce(series ...*Series) (*Series, error) {
+	if len(series) == 0 {
+		return nil, fmt.Errorf("please enter 1 or more series")
+	}
+	n := series[0].Len()
+	for _, s := range series[1:] {
+		if s.Len() != n {
+			return nil, fmt.Errorf("series lengths do not match: %d and %d", n, s.Len())
+		}
+	}
+
+	result := make([]any, n)
+	for i := range result {
+		for _, s := range series {
+			if s.Data[i] != nil {
+				result[i] = s.Data[i]
+				break
+			}
+		}
+	}
+	return NewSeries(series[0].Name, result), nil
+}
+
+// IfNull returns a copy of the series where nil values are replaced by a default value.
+//
+// Parameters:
+//   - value: The value used in place of nil.
+//
+// Returns:
+//   - *Series: A new Series without nil values.
+func (s *Series) IfNull(value any) *Series {
+	result := make([]any, s.Len())
+	for i, v := range s.Data {
+		if v == nil {
+			result[i] = value
+		} else {
+			result[i] = v
+		}
+	}
+	return NewSeries(s.Name, result)
+}
+
+// IfNull returns the values of a column where nil values are replaced by a default value.
+// Unlike FillNa the DataFrame is not modified.
+//
+// Parameters:
+//   - column: The name of the column.
+//   - value: The value used in place of nil.
+//
+// Returns:
+//   - *Series: A new Series without nil values.
+//   - error: An error if the column does not exist.
+func (df *DataFrame) IfNull(column string, value any) (*Series, error) {
+	col, exists := df.Columns[column]
+	if !exists {
+		return nil, fmt.Errorf("column '%s' does not exist", column)
+	}
+	return NewSeries(column, col.Values()).IfNull(value), nil
+}
+
+// And combines two boolean series element-wise using three-valued (SQL) logic where nil means unknown:
+// false AND anything is false, true AND nil is nil.
+//
+// Parameters:
+//   - other: The boolean series to combine with.
+//
+// Returns:
+//   - *Series: The combined Series holding true, false or nil.
+//   - error: An error if the lengths do not match or a value is neither a bool nor nil.
+func (s *Series) And(other *Series) (*Series, error) {
+	return s.combineLogical(other, func(a, b *bool) *bool {
+		if (a != nil && !*a) || (b != nil && !*b) {
+			return boolPtr(false)
+		}
+		if a == nil || b == nil {
+			return nil
+		}
+		return boolPtr(true)
+	})
+}
+
+// Or combines two boolean series element-wise using three-valued (SQL) logic where nil means unknown:
+// true OR anything is true, false OR nil is nil.
+//
+// Parameters:
+//   - other: The boolean series to combine with.
+//
+// Returns:
+//   - *Series: The combined Series holding true, false or nil.
+//   - error: An error if the lengths do not match or a value is neither a bool nor nil.
+func (s *Series) Or(other *Series) (*Series, error) {
+	return s.combineLogical(other, func(a, b *bool) *bool {
+		if (a != nil && *a) || (b != nil && *b) {
+			return boolPtr(true)
+		}
+		if a == nil || b == nil {
+			return nil
+		}
+		return boolPtr(false)
+	})
+}
+
+// Not negates a boolean series element-wise, nil stays nil (unknown).
+//
+// Returns:
+//   - *Series: The negated Series.
+//   - error: An error if a value is neither a bool nor nil.
+func (s *Series) Not() (*Series, error) {
+	result := make([]any, s.Len())
+	for i, v := range s.Data {
+		b, err := toNullableBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		if b != nil {
+			result[i] = !*b
+		}
+	}
+	return NewSeries(s.Name, result), nil
+}
+
+// combineLogical applies a three-valued logic operator to two boolean series
+func (s *Series) combineLogical(other *Series, op func(a, b *bool) *bool) (*Series, error) {
+	if s.Len() != other.Len() {
+		return nil, fmt.Errorf("series lengths do not match: %d and %d", s.Len(), other.Len())
+	}
+
+	result := make([]any, s.Len())
+	for i := range s.Data {
+		a, err := toNullableBool(s.Data[i])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		b, err := toNullableBool(other.Data[i])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		if value := op(a, b); value != nil {
+			result[i] = *value
+		}
+	}
+	return NewSeries(s.Name, result), nil
+}
+
+// toNullableBool converts a value to a bool pointer, nil for unknown values
+func toNullableBool(v any) (*bool, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("value %v of type %T is not a bool", v, v)
+	}
+	return &b, nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,105 @@
+package dataframe
+
+/*
+
+	This is where the parallel execution of row-wise operations is defined. A DataFrame opts in with
+	WithParallelism: Filter, FilterRows, ApplyRows, Groupby and the joins then split its rows into
+	one contiguous range per goroutine and merge the partial results in row order, so the results
+	are the same as with sequential execution. The functions passed to Filter, FilterRows and
+	ApplyRows must then be safe to call concurrently.
+
+*/
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WithParallelism returns a DataFrame running its row-wise operations on up to n goroutines, e.g.
+// df.WithParallelism(runtime.NumCPU()).Filter(...). It is a view of df, see Copy. The rows selected
+// from it by Filter, FilterRows or Sample keep the setting.
+//
+// Parameters:
+//   - n: The number of goroutines. 0 or 1 runs sequentially, the default.
+//
+// Returns:
+//   - *DataFrame: The DataFrame with the parallelism set.
+func (df *DataFrame) WithParallelism(n int) *DataFrame {
+	result := NewDataFrame()
+	for name, col := range df.Columns {
+		// the bounds of the column itself cannot be out of range
+		result.Columns[name], _ = col.Slice(0, col.Len())
+	}
+	result.order = df.ColumnNames()
+	for name, levels := range df.columnLevels {
+		result.setColumnLevels(name, levels)
+	}
+	result.indexNames = df.indexNames
+	result.typed = df.typed
+	result.parallelism = max(n, 1)
+	return result
+}
+
+// Parallelism returns the number of goroutines set with WithParallelism.
+//
+// Returns:
+//   - int: The number of goroutines of the row-wise operations, 1 when they run sequentially.
+func (df *DataFrame) Parallelism() int {
+	return max(df.parallelism, 1)
+}
+
+// parallelMap splits the rows 0..n into contiguous ranges, one per goroutine up to workers, calls fn
+// with each range concurrently and returns the results in row order. A panic in fn is raised again
+// in the calling goroutine once every range is done, like it would be sequentially.
+func parallelMap[T any](workers, n int, fn func(start, end int) T) []T {
+	workers = max(1, min(workers, n))
+	if workers == 1 {
+		return []T{fn(0, n)}
+	}
+
+	results := make([]T, workers)
+	panics := make([]any, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		start, end := w*n/workers, (w+1)*n/workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { panics[w] = recover() }()
+			results[w] = fn(start, end)
+		}()
+	}
+	wg.Wait()
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+	return results
+}
+
+// rowMaps returns every row as a map, built concurrently with the parallelism of df
+func (df *DataFrame) rowMaps() ([]map[string]any, error) {
+	n := df.Nrows()
+	names := df.ColumnNames()
+	values := make([][]any, len(names))
+	for c, name := range names {
+		values[c] = df.Columns[name].Values()
+		if len(values[c]) < n {
+			return nil, fmt.Errorf("unable to access row %d in the dataframe: column '%s' has %d rows", len(values[c]), name, len(values[c]))
+		}
+	}
+
+	rows := make([]map[string]any, n)
+	parallelMap(df.parallelism, n, func(start, end int) struct{} {
+		for i := start; i < end; i++ {
+			row := make(map[string]any, len(names))
+			for c, name := range names {
+				row[name] = values[c][i]
+			}
+			rows[i] = row
+		}
+		return struct{}{}
+	})
+	return rows, nil
+}
@@ -0,0 +1,214 @@
+package dataframe
+
+/*
+
+	This is where the charts of a GroupedDataFrame are defined: the groups are aggregated and drawn
+	in one call, a bar per group or a line per group over an x column such as a date, without
+	building and reshaping the aggregate DataFrame first.
+
+*/
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wcharczuk/go-chart/v2"
+)
+
+// Plot generates a chart of the groups and saves it to a file, see PlotWriter. With PlotOption.ExportData the
+// plotted aggregates are saved next to it: the GroupKey and value columns, preceded by the x column for "line".
+func (gdf *GroupedDataFrame) Plot(kind, valueCol, outputFile string, options ...PlotOption) error {
+	var opts PlotOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return savePlot(outputFile, options, func(w io.Writer) error {
+		return gdf.PlotWriter(kind, valueCol, w, options...)
+	}, func() (*DataFrame, error) {
+		return gdf.plotFrame(kind, valueCol, opts)
+	})
+}
+
+// PlotWriter aggregates the values of a column in each group and writes a chart of them as PNG to a writer.
+//
+// Parameters:
+//   - kind: "bar" for one bar per group, labelled with the group key, or "line" for one line per group over
+//     PlotOption.XColumn (numbers or time.Time values), the rows of a group sharing an x value being aggregated.
+//   - valueCol: The column to aggregate.
+//   - writer: An io.Writer for the PNG data.
+//   - options (optional): The PlotOption struct with the aggregation, the x column of "line", and the labels,
+//     Horizontal (bar), reference lines, regions and annotations (line) and Theme of the chart.
+//
+// Returns:
+//   - error: An error if the kind or aggregation is unknown, a column does not exist, a group has no numeric
+//     aggregate for "bar", the x values are not numbers or times, or the chart cannot be rendered.
+func (gdf *GroupedDataFrame) PlotWriter(kind, valueCol string, writer io.Writer, options ...PlotOption) error {
+	var opts PlotOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	plotted, err := gdf.plotFrame(kind, valueCol, opts)
+	if err != nil {
+		return err
+	}
+	theme := plotTheme(opts)
+
+	if kind == "bar" {
+		keys, aggregates := plotted.Columns["GroupKey"].Values(), plotted.Columns[valueCol].Values()
+		labels := make([]string, len(keys))
+		values := make([]float64, len(keys))
+		for i := range keys {
+			labels[i] = fmt.Sprintf("%v", keys[i])
+			values[i], _ = toFloat(aggregates[i])
+		}
+		if opts.Horizontal {
+			return horizontalBarChart(labels, values, theme).Render(chart.PNG, writer)
+		}
+		graph := chart.BarChart{YAxis: chart.YAxis{Name: opts.YLabel}}
+		applyBarTheme(&graph, theme)
+		for i, val := range values {
+			graph.Bars = append(graph.Bars, chart.Value{Value: val, Label: labels[i]})
+		}
+		return graph.Render(chart.PNG, writer)
+	}
+
+	// one series per group, in the order of the groups
+	xRows, keys, yRows := plotted.Columns[opts.XColumn].Values(), plotted.Columns["GroupKey"].Values(), plotted.Columns[valueCol].Values()
+	type line struct {
+		times   []time.Time
+		xValues []float64
+		yValues []float64
+	}
+	var order []any
+	lines := make(map[any]*line)
+	var allX, allY []float64
+	timeAxis, numberAxis := false, false
+	for i, x := range xRows {
+		y, ok := toFloat(yRows[i])
+		if !ok || math.IsNaN(y) {
+			continue
+		}
+		var xValue float64
+		t, isTime := x.(time.Time)
+		f, isNumber := toFloat(x)
+		switch {
+		case !isTime && !isNumber:
+			return fmt.Errorf("non-numeric data found in column '%s'", opts.XColumn)
+		case (isTime && numberAxis) || (isNumber && timeAxis):
+			return fmt.Errorf("column '%s' mixes numbers and times", opts.XColumn)
+		case isTime:
+			timeAxis, xValue = true, chart.TimeToFloat64(t)
+		default:
+			numberAxis, xValue = true, f
+		}
+
+		key := labelKey(keys[i])
+		l, exists := lines[key]
+		if !exists {
+			l = &line{}
+			lines[key] = l
+			order = append(order, keys[i])
+		}
+		if isTime {
+			l.times = append(l.times, t)
+		}
+		l.xValues = append(l.xValues, xValue)
+		l.yValues = append(l.yValues, y)
+		allX, allY = append(allX, xValue), append(allY, y)
+	}
+	if len(order) == 0 {
+		return fmt.Errorf("column '%s' has no numeric values to plot", valueCol)
+	}
+
+	graph := chart.Chart{
+		XAxis: chart.XAxis{Name: opts.XLabel},
+		YAxis: chart.YAxis{Name: opts.YLabel},
+	}
+	for _, key := range order {
+		l := lines[labelKey(key)]
+		name := fmt.Sprintf("%v", key)
+		if timeAxis {
+			graph.Series = append(graph.Series, chart.TimeSeries{Name: name, XValues: l.times, YValues: l.yValues})
+		} else {
+			graph.Series = append(graph.Series, chart.ContinuousSeries{Name: name, XValues: l.xValues, YValues: l.yValues})
+		}
+	}
+	// tell the groups apart
+	graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+	applyTheme(&graph, theme)
+	addPlotDecorations(&graph, opts, allX, allY)
+	return graph.Render(chart.PNG, writer)
+}
+
+// plotFrame returns the aggregates drawn by PlotWriter: the GroupKey and value columns for "bar", preceded
+// by the x column for "line" with one row per group and x value, sorted by x within each group
+func (gdf *GroupedDataFrame) plotFrame(kind, valueCol string, opts PlotOption) (*DataFrame, error) {
+	if gdf.Err != nil {
+		return nil, gdf.Err
+	}
+	if kind != "bar" && kind != "line" {
+		return nil, fmt.Errorf("unknown plot kind: %s (must be 'bar' or 'line')", kind)
+	}
+	if gdf.columns != nil && !slices.Contains(gdf.columns, valueCol) {
+		return nil, fmt.Errorf("column '%s' does not exist", valueCol)
+	}
+	aggregation := opts.Aggregation
+	if aggregation == "" {
+		aggregation = "sum"
+	}
+	agg, known := groupAggregations[aggregation]
+	if !known {
+		return nil, fmt.Errorf("unknown aggregation '%s' for column '%s'", aggregation, valueCol)
+	}
+
+	if kind == "bar" {
+		plotted, err := gdf.aggregateValues([]string{valueCol}, agg)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range plotted.Columns[valueCol].Values() {
+			if f, ok := toFloat(v); !ok || math.IsNaN(f) {
+				return nil, fmt.Errorf("group '%v' has no numeric values in column '%s'", gdf.KeyOrder[i], valueCol)
+			}
+		}
+		return plotted, nil
+	}
+
+	if opts.XColumn == "" {
+		return nil, fmt.Errorf("PlotOption.XColumn must be set for a line plot")
+	}
+	if opts.XColumn == valueCol || (gdf.columns != nil && !slices.Contains(gdf.columns, opts.XColumn)) {
+		return nil, fmt.Errorf("column '%s' does not exist or is the value column", opts.XColumn)
+	}
+	data := make([][]any, 3)
+	err := gdf.eachGroup(func(_ int, groupKey any, rows []map[string]any) error {
+		// the rows of the group at each x value, rows without x are skipped
+		var xs []any
+		atX := make(map[any][]map[string]any)
+		for _, row := range rows {
+			x := row[opts.XColumn]
+			if isMissing(x) {
+				continue
+			}
+			key := labelKey(x)
+			if _, seen := atX[key]; !seen {
+				xs = append(xs, x)
+			}
+			atX[key] = append(atX[key], row)
+		}
+		slices.SortStableFunc(xs, func(a, b any) int { return compareSortValues(a, b, true, false) })
+		for _, x := range xs {
+			data[0] = append(data[0], x)
+			data[1] = append(data[1], groupKey)
+			data[2] = append(data[2], agg(atX[labelKey(x)], valueCol))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromColumnData([]string{opts.XColumn, "GroupKey", valueCol}, data), nil
+}
@@ -0,0 +1,172 @@
+package dataframe
+
+/*
+
+	This is where plot themes are defined: the fonts, colors and gridlines shared by every chart,
+	registered once with SetDefaultTheme or per plot with PlotOption.Theme
+
+*/
+
+import (
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+)
+
+// Theme is the style of the generated charts, so that they match an organization's look without
+// repeating options on every call. Colors are written as "#rrggbb", "rgba(r,g,b,0.5)" or a known
+// name such as "blue", empty fields keep the go-chart defaults.
+//
+// Fields:
+//   - Font: The font of every text, e.g. parsed with truetype.Parse from a TTF file.
+//   - FontSize: The font size of the axis labels and names.
+//   - TextColor: The color of every text.
+//   - Background: The background color of the image and of the plot area.
+//   - AxisColor: The color of the axis lines and ticks.
+//   - Palette: The colors of the series and bars, in order, repeated when there are more series.
+//   - GridLines: Draws major gridlines on line, horizontal bar and Pareto plots.
+//   - GridColor: The color of the gridlines. Defaults to light gray.
+//
+// Experimental: the fields of Theme may change in a minor release.
+type Theme struct {
+	Font       *truetype.Font
+	FontSize   float64
+	TextColor  string
+	Background string
+	AxisColor  string
+	Palette    []string
+	GridLines  bool
+	GridColor  string
+}
+
+var (
+	themeMu      sync.RWMutex
+	defaultTheme *Theme
+)
+
+// SetDefaultTheme registers the theme of every plot generated without PlotOption.Theme.
+// It is safe to call concurrently with plotting.
+//
+// Parameters:
+//   - theme: The theme to use, nil restores the default look.
+//
+// Experimental: the fields of Theme may change in a minor release.
+func SetDefaultTheme(theme *Theme) {
+	themeMu.Lock()
+	defer themeMu.Unlock()
+	defaultTheme = theme
+}
+
+// DefaultTheme returns the theme registered with SetDefaultTheme, or nil if there is none.
+func DefaultTheme() *Theme {
+	themeMu.RLock()
+	defer themeMu.RUnlock()
+	return defaultTheme
+}
+
+// plotTheme returns the theme of a plot, the one of the options before the registered one
+func plotTheme(opts PlotOption) *Theme {
+	if opts.Theme != nil {
+		return opts.Theme
+	}
+	return DefaultTheme()
+}
+
+// palette returns the colors of the theme, falling back to base for the fields that are not set
+func (t *Theme) palette(base chart.ColorPalette) chart.ColorPalette {
+	if t == nil {
+		return base
+	}
+	return themePalette{theme: t, base: base}
+}
+
+// textStyle returns the style of the axis texts
+func (t *Theme) textStyle() chart.Style {
+	if t == nil {
+		return chart.Style{}
+	}
+	return chart.Style{FontSize: t.FontSize}
+}
+
+// gridStyle returns the style of the major gridlines, hidden unless the theme asks for them
+func (t *Theme) gridStyle() chart.Style {
+	if t == nil || !t.GridLines {
+		return chart.Style{Hidden: true}
+	}
+	return chart.Style{StrokeColor: themeColor(t.GridColor, drawing.Color{R: 220, G: 220, B: 220, A: 255}), StrokeWidth: 1}
+}
+
+// applyTheme styles a chart: fonts, palette, axis texts and gridlines
+func applyTheme(graph *chart.Chart, theme *Theme) {
+	graph.ColorPalette = theme.palette(chart.DefaultColorPalette)
+	if theme == nil {
+		return
+	}
+	graph.Font = theme.Font
+	for _, axis := range []*chart.YAxis{&graph.YAxis, &graph.YAxisSecondary} {
+		axis.Style = axis.Style.InheritFrom(theme.textStyle())
+		axis.NameStyle = axis.NameStyle.InheritFrom(theme.textStyle())
+	}
+	graph.XAxis.Style = graph.XAxis.Style.InheritFrom(theme.textStyle())
+	graph.XAxis.NameStyle = graph.XAxis.NameStyle.InheritFrom(theme.textStyle())
+	graph.XAxis.GridMajorStyle = theme.gridStyle()
+	graph.YAxis.GridMajorStyle = theme.gridStyle()
+}
+
+// applyBarTheme styles a vertical bar chart, which has no gridlines
+func applyBarTheme(graph *chart.BarChart, theme *Theme) {
+	graph.ColorPalette = theme.palette(chart.AlternateColorPalette)
+	if theme == nil {
+		return
+	}
+	graph.Font = theme.Font
+	graph.XAxis = graph.XAxis.InheritFrom(theme.textStyle())
+	graph.YAxis.Style = graph.YAxis.Style.InheritFrom(theme.textStyle())
+}
+
+// themeColor parses a theme color, returning fallback when it is not set
+func themeColor(value string, fallback drawing.Color) drawing.Color {
+	if value == "" {
+		return fallback
+	}
+	return drawing.ParseColor(value)
+}
+
+// themePalette is the chart.ColorPalette of a theme
+type themePalette struct {
+	theme *Theme
+	base  chart.ColorPalette
+}
+
+func (p themePalette) BackgroundColor() drawing.Color {
+	return themeColor(p.theme.Background, p.base.BackgroundColor())
+}
+
+func (p themePalette) BackgroundStrokeColor() drawing.Color {
+	return themeColor(p.theme.Background, p.base.BackgroundStrokeColor())
+}
+
+func (p themePalette) CanvasColor() drawing.Color {
+	return themeColor(p.theme.Background, p.base.CanvasColor())
+}
+
+func (p themePalette) CanvasStrokeColor() drawing.Color {
+	return themeColor(p.theme.Background, p.base.CanvasStrokeColor())
+}
+
+func (p themePalette) AxisStrokeColor() drawing.Color {
+	return themeColor(p.theme.AxisColor, p.base.AxisStrokeColor())
+}
+
+func (p themePalette) TextColor() drawing.Color {
+	return themeColor(p.theme.TextColor, p.base.TextColor())
+}
+
+func (p themePalette) GetSeriesColor(index int) drawing.Color {
+	if len(p.theme.Palette) == 0 {
+		return p.base.GetSeriesColor(index)
+	}
+	return drawing.ParseColor(p.theme.Palette[index%len(p.theme.Palette)])
+}
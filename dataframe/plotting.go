@@ -1,28 +1,174 @@
 package dataframe
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
 )
 
 // Visualization Support
 
-// LinePlot generates a line plot for the specified columns and saves it to a file
-func (df *DataFrame) LinePlot(xCol, yCol, outputFile string) error {
+// PlotOption configures how a plot is rendered.
+//
+// Fields:
+//   - Horizontal: Draws the bars of BarPlot horizontally, the first row at the top.
+//   - SecondaryColumn: A second column drawn by LinePlot against a secondary y-axis with its own scale.
+//   - XLabel: The name of the x-axis.
+//   - YLabel: The name of the (primary) y-axis.
+//   - SecondaryYLabel: The name of the secondary y-axis.
+//   - HLines: Horizontal reference lines of LinePlot, e.g. SLO thresholds.
+//   - VLines: Vertical reference lines of LinePlot, e.g. event markers.
+//   - XRegions: Shaded vertical bands of LinePlot between two x values, e.g. incident windows.
+//   - YRegions: Shaded horizontal bands of LinePlot between two y values, e.g. acceptable ranges.
+//   - Annotations: Text labels drawn at points of LinePlot.
+//   - Theme: The style of the plot, overriding the one registered with SetDefaultTheme.
+//   - ExportData: Also saves the plotted data next to the image, as "csv" or "json" (records). The file methods
+//     (LinePlot, BarPlot, ParetoPlot) write it to the image path with the extension replaced, e.g. sales.png -> sales.csv.
+//   - XColumn: The column on the x-axis of a "line" GroupedDataFrame.Plot, e.g. a date, with one line per group.
+//   - Aggregation: How GroupedDataFrame.Plot combines the values of a group, or of the rows of a group sharing an
+//     x value for "line", as a name accepted by Agg such as "mean" or "count". Defaults to "sum".
+type PlotOption struct {
+	Horizontal      bool
+	SecondaryColumn string
+	XLabel          string
+	YLabel          string
+	SecondaryYLabel string
+	HLines          []ReferenceLine
+	VLines          []ReferenceLine
+	XRegions        []PlotRegion
+	YRegions        []PlotRegion
+	Annotations     []PlotAnnotation
+	Theme           *Theme
+	ExportData      string
+	XColumn         string
+	Aggregation     string
+}
+
+// ReferenceLine is a straight line drawn across a plot.
+//
+// Fields:
+//   - Value: The y value of a horizontal line or the x value of a vertical line.
+//   - Label: The text drawn at the end of the line, none if empty.
+//   - Color: The color of the line, as "#rrggbb", "rgb(r,g,b)" or a known name such as "red". Defaults to red.
+type ReferenceLine struct {
+	Value float64
+	Label string
+	Color string
+}
+
+// PlotRegion is a shaded band of a plot, drawn behind the data.
+//
+// Fields:
+//   - From: The start of the band.
+//   - To: The end of the band.
+//   - Color: The fill color, as "#rrggbb", "rgba(r,g,b,0.2)" or a known name. Defaults to a translucent gray,
+//     opaque colors are made translucent so that the grid stays visible.
+type PlotRegion struct {
+	From  float64
+	To    float64
+	Color string
+}
+
+// PlotAnnotation is a text label drawn at a point of a plot.
+//
+// Fields:
+//   - X: The x value of the point.
+//   - Y: The y value of the point (on the primary y-axis).
+//   - Text: The text of the label.
+type PlotAnnotation struct {
+	X    float64
+	Y    float64
+	Text string
+}
+
+// LinePlot generates a line plot for the specified columns and saves it to a file.
+// With PlotOption.ExportData the x, y and secondary columns are saved next to it.
+func (df *DataFrame) LinePlot(xCol, yCol, outputFile string, options ...PlotOption) error {
+	return savePlot(outputFile, options, func(w io.Writer) error {
+		return df.LinePlotWriter(xCol, yCol, w, options...)
+	}, func() (*DataFrame, error) {
+		columns := []string{xCol, yCol}
+		if len(options) > 0 && options[0].SecondaryColumn != "" && !slices.Contains(columns, options[0].SecondaryColumn) {
+			columns = append(columns, options[0].SecondaryColumn)
+		}
+		return df.MultiSelect(columns...)
+	})
+}
+
+// savePlot renders a plot to a file and, with PlotOption.ExportData, saves the plotted data next to it.
+// Everything is rendered first so that no empty file is left behind on error.
+func savePlot(outputFile string, options []PlotOption, render func(io.Writer) error, data func() (*DataFrame, error)) error {
+	format := ""
+	if len(options) > 0 {
+		format = options[0].ExportData
+	}
+	if format != "" && format != "csv" && format != "json" {
+		return fmt.Errorf("unsupported data export format '%s', expected 'csv' or 'json'", format)
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		return err
+	}
+	var dataBuf bytes.Buffer
+	if format != "" {
+		plotted, err := data()
+		if err != nil {
+			return err
+		}
+		if format == "csv" {
+			err = plotted.ToCSVWriter(&dataBuf)
+		} else {
+			err = plotted.ToJSONWriter(&dataBuf)
+		}
+		if err != nil {
+			return fmt.Errorf("error exporting plot data: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(outputFile, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("error creating output file: %v", err)
+	}
+	if format != "" {
+		dataFile := strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + "." + format
+		if err := os.WriteFile(dataFile, dataBuf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("error creating data file: %v", err)
+		}
+	}
+	return nil
+}
+
+// LinePlotWriter generates a line plot for the specified columns and writes it as PNG to a writer.
+// With PlotOption.SecondaryColumn a second line is drawn against its own y-axis, e.g. revenue vs. conversion rate.
+func (df *DataFrame) LinePlotWriter(xCol, yCol string, writer io.Writer, options ...PlotOption) error {
+	var opts PlotOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
 	xData, xExists := df.Columns[xCol]
 	yData, yExists := df.Columns[yCol]
 	if !xExists || !yExists {
 		return fmt.Errorf("specified columns '%s' or '%s' do not exist", xCol, yCol)
 	}
 
-	xValues := make([]float64, len(xData.Data))
-	yValues := make([]float64, len(yData.Data))
+	xRows, yRows := xData.Values(), yData.Values()
+	xValues := make([]float64, len(xRows))
+	yValues := make([]float64, len(yRows))
 
-	for i := 0; i < len(xData.Data); i++ {
-		xVal, xOk := xData.Data[i].(float64)
-		yVal, yOk := yData.Data[i].(float64)
+	for i := 0; i < len(xRows); i++ {
+		xVal, xOk := xRows[i].(float64)
+		yVal, yOk := yRows[i].(float64)
 		if !xOk || !yOk {
 			return fmt.Errorf("non-numeric data found in columns '%s' or '%s'", xCol, yCol)
 		}
@@ -31,35 +177,181 @@ func (df *DataFrame) LinePlot(xCol, yCol, outputFile string) error {
 	}
 
 	graph := chart.Chart{
+		XAxis: chart.XAxis{Name: opts.XLabel},
+		YAxis: chart.YAxis{Name: opts.YLabel},
 		Series: []chart.Series{
 			chart.ContinuousSeries{
+				Name:    yCol,
 				XValues: xValues,
 				YValues: yValues,
 			},
 		},
 	}
 
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("error creating output file: %v", err)
+	if opts.SecondaryColumn != "" {
+		secondary, exists := df.Columns[opts.SecondaryColumn]
+		if !exists {
+			return fmt.Errorf("specified column '%s' does not exist", opts.SecondaryColumn)
+		}
+		rows := secondary.Values()
+		values := make([]float64, len(rows))
+		for i, v := range rows {
+			val, ok := v.(float64)
+			if !ok {
+				return fmt.Errorf("non-numeric data found in column '%s'", opts.SecondaryColumn)
+			}
+			values[i] = val
+		}
+
+		graph.YAxisSecondary = chart.YAxis{Name: opts.SecondaryYLabel, ValueFormatter: compactValueFormatter}
+		// room for the secondary axis name on the left
+		graph.Background = chart.Style{Padding: chart.Box{Top: 20, Left: 40, Right: 20, Bottom: 20}}
+		graph.Series = append(graph.Series, chart.ContinuousSeries{
+			Name:    opts.SecondaryColumn,
+			YAxis:   chart.YAxisSecondary,
+			XValues: xValues,
+			YValues: values,
+		})
+		// tell the lines apart
+		graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+	}
+
+	applyTheme(&graph, plotTheme(opts))
+	addPlotDecorations(&graph, opts, xValues, yValues)
+	return graph.Render(chart.PNG, writer)
+}
+
+// addPlotDecorations adds the reference lines, shaded regions and annotations of the options to a chart.
+// Regions are drawn first so that the data stays visible, lines span the range of the data.
+func addPlotDecorations(graph *chart.Chart, opts PlotOption, xValues, yValues []float64) {
+	if len(xValues) == 0 {
+		return
+	}
+	xMin, xMax := slices.Min(xValues), slices.Max(xValues)
+	yMin, yMax := slices.Min(yValues), slices.Max(yValues)
+	for _, line := range opts.HLines {
+		yMin, yMax = math.Min(yMin, line.Value), math.Max(yMax, line.Value)
+	}
+
+	var regions []chart.Series
+	if len(opts.XRegions) > 0 {
+		regions = append(regions, regionSeries{regions: opts.XRegions, vertical: true})
+	}
+	if len(opts.YRegions) > 0 {
+		regions = append(regions, regionSeries{regions: opts.YRegions})
 	}
-	defer file.Close()
+	graph.Series = append(regions, graph.Series...)
 
-	return graph.Render(chart.PNG, file)
+	labels := []chart.Value2{}
+	for _, line := range opts.HLines {
+		graph.Series = append(graph.Series, referenceLineSeries(line, []float64{xMin, xMax}, []float64{line.Value, line.Value}))
+		if line.Label != "" {
+			labels = append(labels, chart.Value2{XValue: xMax, YValue: line.Value, Label: line.Label})
+		}
+	}
+	for _, line := range opts.VLines {
+		graph.Series = append(graph.Series, referenceLineSeries(line, []float64{line.Value, line.Value}, []float64{yMin, yMax}))
+		if line.Label != "" {
+			labels = append(labels, chart.Value2{XValue: line.Value, YValue: yMax, Label: line.Label})
+		}
+	}
+	for _, annotation := range opts.Annotations {
+		labels = append(labels, chart.Value2{XValue: annotation.X, YValue: annotation.Y, Label: annotation.Text})
+	}
+	if len(labels) > 0 {
+		graph.Series = append(graph.Series, chart.AnnotationSeries{Annotations: labels})
+	}
 }
 
-// BarPlot generates a bar plot for the specified column and saves it to a file
-func (df *DataFrame) BarPlot(columnName, outputFile string) error {
+// referenceLineSeries builds the dashed series of a reference line
+func referenceLineSeries(line ReferenceLine, xValues, yValues []float64) chart.ContinuousSeries {
+	color := chart.ColorRed
+	if line.Color != "" {
+		color = drawing.ParseColor(line.Color)
+	}
+	return chart.ContinuousSeries{
+		XValues: xValues,
+		YValues: yValues,
+		Style:   chart.Style{StrokeColor: color, StrokeWidth: 1.5, StrokeDashArray: []float64{6, 4}},
+	}
+}
+
+// regionSeries draws shaded bands across the whole plot area, between two x values (vertical bands)
+// or two y values of the primary axis
+type regionSeries struct {
+	regions  []PlotRegion
+	vertical bool
+}
+
+func (rs regionSeries) GetName() string           { return "" }
+func (rs regionSeries) GetYAxis() chart.YAxisType { return chart.YAxisPrimary }
+func (rs regionSeries) GetStyle() chart.Style     { return chart.Style{} }
+func (rs regionSeries) Validate() error           { return nil }
+
+// Render draws the bands, clipped to the plot area
+func (rs regionSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	for _, region := range rs.regions {
+		color := drawing.Color{R: 128, G: 128, B: 128, A: 48}
+		if region.Color != "" {
+			color = drawing.ParseColor(region.Color)
+			if color.A == 255 {
+				color = color.WithAlpha(64)
+			}
+		}
+
+		box := canvasBox.Clone()
+		if rs.vertical {
+			from, to := canvasBox.Left+xrange.Translate(region.From), canvasBox.Left+xrange.Translate(region.To)
+			box.Left, box.Right = max(min(from, to), canvasBox.Left), min(max(from, to), canvasBox.Right)
+		} else {
+			from, to := canvasBox.Bottom-yrange.Translate(region.From), canvasBox.Bottom-yrange.Translate(region.To)
+			box.Top, box.Bottom = max(min(from, to), canvasBox.Top), min(max(from, to), canvasBox.Bottom)
+		}
+		if box.Left >= box.Right || box.Top >= box.Bottom {
+			continue
+		}
+		chart.Draw.Box(r, box, chart.Style{FillColor: color, StrokeColor: color, StrokeWidth: 1})
+	}
+}
+
+// BarPlot generates a bar plot for the specified column and saves it to a file.
+// With PlotOption.ExportData the bar labels ("label") and values are saved next to it.
+func (df *DataFrame) BarPlot(columnName, outputFile string, options ...PlotOption) error {
+	return savePlot(outputFile, options, func(w io.Writer) error {
+		return df.BarPlotWriter(columnName, w, options...)
+	}, func() (*DataFrame, error) {
+		values := df.Columns[columnName].Values()
+		labels := make([]any, len(values))
+		for i := range values {
+			labels[i] = fmt.Sprintf("%v", i)
+		}
+		return plotData(map[string][]any{"label": labels, columnName: values}, "label", columnName), nil
+	})
+}
+
+// plotData builds the DataFrame of plotted data with the given columns, in order
+func plotData(data map[string][]any, order ...string) *DataFrame {
+	result := NewDataFrame()
+	for _, name := range order {
+		result.Columns[name] = &Column[any]{Name: name, Data: data[name]}
+	}
+	result.order = order
+	return result
+}
+
+// BarPlotWriter generates a bar plot for the specified column and writes it as PNG to a writer
+func (df *DataFrame) BarPlotWriter(columnName string, writer io.Writer, options ...PlotOption) error {
 	col, exists := df.Columns[columnName]
 	if !exists {
 		return fmt.Errorf("specified column '%s' does not exist", columnName)
 	}
 
-	values := make([]float64, len(col.Data))
-	labels := make([]string, len(col.Data))
+	rows := col.Values()
+	values := make([]float64, len(rows))
+	labels := make([]string, len(rows))
 
-	for i := 0; i < len(col.Data); i++ {
-		val, ok := col.Data[i].(float64)
+	for i := 0; i < len(rows); i++ {
+		val, ok := rows[i].(float64)
 		if !ok {
 			return fmt.Errorf("non-numeric data found in column '%s'", columnName)
 		}
@@ -67,9 +359,19 @@ func (df *DataFrame) BarPlot(columnName, outputFile string) error {
 		labels[i] = fmt.Sprintf("%v", i)
 	}
 
+	var opts PlotOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	theme := plotTheme(opts)
+	if opts.Horizontal {
+		return horizontalBarChart(labels, values, theme).Render(chart.PNG, writer)
+	}
+
 	graph := chart.BarChart{
 		Bars: []chart.Value{},
 	}
+	applyBarTheme(&graph, theme)
 
 	for i, val := range values {
 		graph.Bars = append(graph.Bars, chart.Value{
@@ -78,11 +380,213 @@ func (df *DataFrame) BarPlot(columnName, outputFile string) error {
 		})
 	}
 
-	file, err := os.Create(outputFile)
+	return graph.Render(chart.PNG, writer)
+}
+
+// ParetoPlot generates a Pareto chart and saves it to a file (see ParetoPlotWriter). With PlotOption.ExportData
+// the sorted labels and values and their cumulative share ("cumulative", 0 to 1) are saved next to it.
+func (df *DataFrame) ParetoPlot(labelCol, valueCol, outputFile string, options ...PlotOption) error {
+	return savePlot(outputFile, options, func(w io.Writer) error {
+		return df.ParetoPlotWriter(labelCol, valueCol, w, options...)
+	}, func() (*DataFrame, error) {
+		labels, values, cumulative, err := df.paretoData(labelCol, valueCol)
+		if err != nil {
+			return nil, err
+		}
+		data := map[string][]any{labelCol: make([]any, len(labels)), valueCol: make([]any, len(labels)), "cumulative": make([]any, len(labels))}
+		for i := range labels {
+			data[labelCol][i], data[valueCol][i], data["cumulative"][i] = labels[i], values[i], cumulative[i]
+		}
+		return plotData(data, labelCol, valueCol, "cumulative"), nil
+	})
+}
+
+// ParetoPlotWriter generates a Pareto chart and writes it as PNG to a writer: the values are drawn as bars
+// sorted in descending order, with a line of their cumulative percentage of the total on a secondary axis.
+//
+// Parameters:
+//   - labelCol: The column holding the category labels.
+//   - valueCol: The column holding the non-negative values of the categories.
+//   - writer: An io.Writer for the PNG data.
+//   - options (optional): The PlotOption struct, only Theme applies.
+//
+// Returns:
+//   - error: An error if a column does not exist, a value is not a non-negative number or the chart cannot be rendered.
+func (df *DataFrame) ParetoPlotWriter(labelCol, valueCol string, writer io.Writer, options ...PlotOption) error {
+	labels, values, cumulative, err := df.paretoData(labelCol, valueCol)
 	if err != nil {
-		return fmt.Errorf("error creating output file: %v", err)
+		return err
+	}
+	positions := make([]float64, len(values))
+	for i := range positions {
+		positions[i] = float64(i)
+	}
+
+	var opts PlotOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	theme := plotTheme(opts)
+	palette := theme.palette(chart.DefaultColorPalette)
+	graph := chart.Chart{
+		XAxis: chart.XAxis{Ticks: categoryTicks(labels, false)},
+		YAxis: chart.YAxis{Range: valueRange(values)},
+		YAxisSecondary: chart.YAxis{
+			Range:          &chart.ContinuousRange{Min: 0, Max: 1},
+			ValueFormatter: chart.PercentValueFormatter,
+		},
+		Series: []chart.Series{
+			barSeries{values: values, style: chart.Style{FillColor: palette.GetSeriesColor(0), StrokeColor: palette.GetSeriesColor(0)}},
+			chart.ContinuousSeries{
+				YAxis:   chart.YAxisSecondary,
+				XValues: positions,
+				YValues: cumulative,
+				Style:   chart.Style{StrokeColor: palette.GetSeriesColor(1), StrokeWidth: 2, DotWidth: 3, DotColor: palette.GetSeriesColor(1)},
+			},
+		},
+	}
+	applyTheme(&graph, theme)
+	return graph.Render(chart.PNG, writer)
+}
+
+// paretoData returns the labels and values of a Pareto chart sorted by descending value,
+// with the cumulative share of the total of each category
+func (df *DataFrame) paretoData(labelCol, valueCol string) ([]string, []float64, []float64, error) {
+	labelData, labelExists := df.Columns[labelCol]
+	valueData, valueExists := df.Columns[valueCol]
+	if !labelExists || !valueExists {
+		return nil, nil, nil, fmt.Errorf("specified columns '%s' or '%s' do not exist", labelCol, valueCol)
+	}
+
+	type category struct {
+		label string
+		value float64
+	}
+	labelRows, valueRows := labelData.Values(), valueData.Values()
+	categories := make([]category, len(valueRows))
+	total := 0.0
+	for i, v := range valueRows {
+		val, ok := toFloat(v)
+		if !ok || math.IsNaN(val) || math.IsInf(val, 0) {
+			return nil, nil, nil, fmt.Errorf("non-numeric data found in column '%s'", valueCol)
+		}
+		if val < 0 {
+			return nil, nil, nil, fmt.Errorf("negative value %v found in column '%s'", val, valueCol)
+		}
+		categories[i] = category{label: fmt.Sprintf("%v", labelRows[i]), value: val}
+		total += val
+	}
+	if len(categories) == 0 || total == 0 {
+		return nil, nil, nil, fmt.Errorf("column '%s' has no positive values", valueCol)
+	}
+
+	// largest categories first, ties keep the row order
+	sort.SliceStable(categories, func(i, j int) bool { return categories[i].value > categories[j].value })
+
+	labels := make([]string, len(categories))
+	values := make([]float64, len(categories))
+	cumulative := make([]float64, len(categories))
+	running := 0.0
+	for i, c := range categories {
+		labels[i], values[i] = c.label, c.value
+		running += c.value
+		cumulative[i] = running / total
+	}
+	return labels, values, cumulative, nil
+}
+
+// compactValueFormatter formats axis values with up to 6 significant digits and no trailing zeros,
+// so small scales such as rates (0.0275) keep their precision
+func compactValueFormatter(v any) string {
+	f, ok := v.(float64)
+	if !ok {
+		return fmt.Sprintf("%v", v)
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(f, 'g', 6, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// horizontalBarChart builds a chart with one horizontal bar per value, the first value at the top
+func horizontalBarChart(labels []string, values []float64, theme *Theme) chart.Chart {
+	color := theme.palette(chart.DefaultColorPalette).GetSeriesColor(0)
+	graph := chart.Chart{
+		XAxis: chart.XAxis{Range: valueRange(values)},
+		YAxis: chart.YAxis{Ticks: categoryTicks(labels, true)},
+		Series: []chart.Series{
+			barSeries{values: values, horizontal: true, style: chart.Style{FillColor: color, StrokeColor: color}},
+		},
 	}
-	defer file.Close()
+	applyTheme(&graph, theme)
+	return graph
+}
 
-	return graph.Render(chart.PNG, file)
+// categoryTicks labels the positions of the categories of a bar chart, with half a bar of margin on both sides
+func categoryTicks(labels []string, reversed bool) []chart.Tick {
+	n := len(labels)
+	ticks := []chart.Tick{{Value: -0.5}}
+	for i, label := range labels {
+		ticks = append(ticks, chart.Tick{Value: barPosition(i, n, reversed), Label: label})
+	}
+	return append(ticks, chart.Tick{Value: float64(n) - 0.5})
+}
+
+// valueRange returns the rounded range of the value axis of a bar chart, which always includes 0
+func valueRange(values []float64) *chart.ContinuousRange {
+	lo, hi := 0.0, 0.0
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	roundTo := chart.GetRoundToForDelta(hi - lo)
+	return &chart.ContinuousRange{Min: chart.RoundDown(lo, roundTo), Max: chart.RoundUp(hi, roundTo)}
+}
+
+// barPosition returns the position of a category on the category axis
+func barPosition(i, n int, reversed bool) float64 {
+	if reversed {
+		return float64(n - 1 - i)
+	}
+	return float64(i)
+}
+
+// barSeries draws one bar per value at the positions 0..n-1 of the category axis. Vertical bars use the
+// x axis for the categories, horizontal bars the y axis with the first value at the top.
+type barSeries struct {
+	values     []float64
+	horizontal bool
+	style      chart.Style
+}
+
+func (bs barSeries) GetName() string           { return "" }
+func (bs barSeries) GetYAxis() chart.YAxisType { return chart.YAxisPrimary }
+func (bs barSeries) GetStyle() chart.Style     { return bs.style }
+func (bs barSeries) Validate() error           { return nil }
+
+// Render draws the bars, each one filling 80% of its category slot
+func (bs barSeries) Render(r chart.Renderer, canvasBox chart.Box, xrange, yrange chart.Range, defaults chart.Style) {
+	style := bs.style.InheritFrom(defaults)
+	for i, v := range bs.values {
+		pos := barPosition(i, len(bs.values), bs.horizontal)
+		var box chart.Box
+		if bs.horizontal {
+			x0, x1 := canvasBox.Left+xrange.Translate(0), canvasBox.Left+xrange.Translate(v)
+			box = chart.Box{
+				Top:    canvasBox.Bottom - yrange.Translate(pos+0.4),
+				Bottom: canvasBox.Bottom - yrange.Translate(pos-0.4),
+				Left:   min(x0, x1),
+				Right:  max(x0, x1),
+			}
+		} else {
+			y0, y1 := canvasBox.Bottom-yrange.Translate(0), canvasBox.Bottom-yrange.Translate(v)
+			box = chart.Box{
+				Left:   canvasBox.Left + xrange.Translate(pos-0.4),
+				Right:  canvasBox.Left + xrange.Translate(pos+0.4),
+				Top:    min(y0, y1),
+				Bottom: max(y0, y1),
+			}
+		}
+		chart.Draw.Box(r, box, style)
+	}
 }
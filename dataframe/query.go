@@ -0,0 +1,774 @@
+package dataframe
+
+/*
+
+	This is where string expressions are defined, so that filters can be built at runtime
+	(from a config file or HTTP parameters) instead of as closures:
+
+		df.Query("age > 30 && dept == 'IT'")
+
+	Grammar, from the lowest to the highest precedence:
+
+		or         := and (("||" | "or") and)*
+		and        := not (("&&" | "and") not)*
+		not        := ("!" | "not") not | comparison
+		comparison := additive (("==" | "!=" | "<" | "<=" | ">" | ">=") additive | ["not"] "in" "(" list ")")?
+		additive   := term (("+" | "-") term)*
+		term       := unary (("*" | "/" | "%") unary)*
+		unary      := "-" unary | primary
+		primary    := number | 'string' | "string" | true | false | null | column | `column` | call | "(" or ")"
+		call       := function "(" [or ("," or)*] ")"
+
+	Eval parses an assignment, column "=" or.
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query returns the rows for which a boolean expression holds. Expressions reference columns by
+// name (or between backquotes for names that are not identifiers), compare them with ==, !=, <, <=,
+// >, >= and in (...), combine numbers with +, -, *, / and % and conditions with &&, || and !.
+// Numbers are compared numerically regardless of their type, strings lexically and time.Time values
+// chronologically. A null operand makes arithmetic null and comparisons false, except for == null
+// and != null, which test for missing values.
+//
+// The expression is evaluated column by column with loops specialized for float, string and bool
+// values, other values are handled one at a time.
+//
+// Parameters:
+//   - expr: The expression, e.g. "age > 30 && dept == 'IT'" or "price * qty >= 100 || status in ('open', 'late')".
+//
+// Returns:
+//   - *DataFrame: A new DataFrame containing the matching rows.
+//   - error: An error if the expression cannot be parsed, references a missing column, or does not
+//     evaluate to a boolean on a row.
+func (df *DataFrame) Query(expr string) (*DataFrame, error) {
+	node, err := parseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := node.check(df); err != nil {
+		return nil, err
+	}
+	return df.queryRows(node, expr)
+}
+
+// queryRows returns the rows for which a checked expression holds, see Query
+func (df *DataFrame) queryRows(node queryNode, expr string) (*DataFrame, error) {
+	// column by column first, the row interpreter reports errors and handles non-boolean results
+	if vector, err := vectorizeQuery(node, df); err == nil && vector.kind == "bool" {
+		rowsToKeep := []int{}
+		for i, keep := range vector.bools {
+			if keep && !vector.nulls.isNull(i) {
+				rowsToKeep = append(rowsToKeep, i)
+			}
+		}
+		return df.takeRows(rowsToKeep), nil
+	}
+
+	var evalErr error
+	result := df.FilterRows(func(get func(col string) any) bool {
+		if evalErr != nil {
+			return false
+		}
+		value, err := node.eval(get)
+		if err != nil {
+			evalErr = err
+			return false
+		}
+		keep, ok := value.(bool)
+		if !ok && value != nil {
+			evalErr = fmt.Errorf("query must evaluate to a boolean, got %v (%T)", value, value)
+		}
+		return keep
+	})
+	if evalErr != nil {
+		return nil, fmt.Errorf("error evaluating query %q: %w", expr, evalErr)
+	}
+	return result, nil
+}
+
+// Eval returns a copy of the DataFrame with a column computed from an assignment expression. The
+// expression uses the syntax of Query and may call the functions abs, ceil, exp, floor, log (natural),
+// log10, pow, round (to the nearest integer, or to a number of decimals with a second argument) and
+// sqrt. Numeric results are float64 values, null where an operand is null. An existing column of the
+// same name is replaced in place.
+//
+// Parameters:
+//   - expr: The assignment, e.g. "profit = revenue - cost" or "`unit price` = round(total / qty, 2)".
+//
+// Returns:
+//   - *DataFrame: A new DataFrame holding the computed column.
+//   - error: An error if the expression cannot be parsed, references a missing column, or fails on a row.
+func (df *DataFrame) Eval(expr string) (*DataFrame, error) {
+	name, node, err := parseAssignment(expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := node.check(df); err != nil {
+		return nil, err
+	}
+
+	if vector, err := vectorizeQuery(node, df); err == nil {
+		return df.WithColumn(name, NewSeries(name, vector.boxed()))
+	}
+
+	// the row interpreter reports the row of the error
+	values := make([]any, 0, df.Nrows())
+	var evalErr error
+	df.FilterRows(func(get func(col string) any) bool {
+		if evalErr == nil {
+			value, err := node.eval(get)
+			if err != nil {
+				evalErr = fmt.Errorf("error evaluating %q at row %d: %w", expr, len(values), err)
+			}
+			values = append(values, value)
+		}
+		return false
+	})
+	if evalErr != nil {
+		return nil, evalErr
+	}
+	return df.WithColumn(name, NewSeries(name, values))
+}
+
+// queryNode is a node of a parsed expression
+type queryNode interface {
+	eval(get func(col string) any) (any, error)
+	check(df *DataFrame) error
+}
+
+// queryLiteral is a constant
+type queryLiteral struct {
+	value any
+}
+
+func (n queryLiteral) eval(func(string) any) (any, error) { return n.value, nil }
+func (n queryLiteral) check(*DataFrame) error             { return nil }
+
+// queryColumn is a column reference
+type queryColumn struct {
+	name string
+}
+
+func (n queryColumn) eval(get func(string) any) (any, error) { return get(n.name), nil }
+
+func (n queryColumn) check(df *DataFrame) error {
+	if _, exists := df.Columns[n.name]; !exists {
+		return fmt.Errorf("column '%s' does not exist", n.name)
+	}
+	return nil
+}
+
+// queryUnary is a negation, "-" for numbers and "!" for booleans
+type queryUnary struct {
+	op      string
+	operand queryNode
+}
+
+func (n queryUnary) eval(get func(string) any) (any, error) {
+	value, err := n.operand.eval(get)
+	if err != nil {
+		return nil, err
+	}
+	return queryUnaryValue(n.op, value)
+}
+
+func (n queryUnary) check(df *DataFrame) error { return n.operand.check(df) }
+
+// queryUnaryValue applies a negation to a value, null gives null
+func queryUnaryValue(op string, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if op == "!" {
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator ! expects a boolean, got %v (%T)", value, value)
+		}
+		return !b, nil
+	}
+	f, ok := numericValue(value)
+	if !ok {
+		return nil, fmt.Errorf("operator - expects a number, got %v (%T)", value, value)
+	}
+	return -f, nil
+}
+
+// queryBinary is an arithmetic, comparison or logical operation
+type queryBinary struct {
+	op          string
+	left, right queryNode
+}
+
+func (n queryBinary) eval(get func(string) any) (any, error) {
+	left, err := n.left.eval(get)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit, a null operand counts as false
+	if n.op == "&&" || n.op == "||" {
+		l, err := queryBool(n.op, left)
+		if err != nil {
+			return nil, err
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := n.right.eval(get)
+		if err != nil {
+			return nil, err
+		}
+		return queryBool(n.op, right)
+	}
+
+	right, err := n.right.eval(get)
+	if err != nil {
+		return nil, err
+	}
+	return queryOperation(n.op, left, right)
+}
+
+func (n queryBinary) check(df *DataFrame) error {
+	if err := n.left.check(df); err != nil {
+		return err
+	}
+	return n.right.check(df)
+}
+
+// queryFunction is a numeric function callable in expressions
+type queryFunction struct {
+	minArgs, maxArgs int
+	apply            func(args []float64) float64
+}
+
+// arity describes the number of arguments of the function
+func (f queryFunction) arity() string {
+	if f.minArgs == f.maxArgs {
+		return fmt.Sprintf("%d argument(s)", f.minArgs)
+	}
+	return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+}
+
+// queryFunctions are the functions available in expressions, by lowercase name
+var queryFunctions = map[string]queryFunction{
+	"abs":   {1, 1, func(x []float64) float64 { return math.Abs(x[0]) }},
+	"ceil":  {1, 1, func(x []float64) float64 { return math.Ceil(x[0]) }},
+	"exp":   {1, 1, func(x []float64) float64 { return math.Exp(x[0]) }},
+	"floor": {1, 1, func(x []float64) float64 { return math.Floor(x[0]) }},
+	"log":   {1, 1, func(x []float64) float64 { return math.Log(x[0]) }},
+	"log10": {1, 1, func(x []float64) float64 { return math.Log10(x[0]) }},
+	"pow":   {2, 2, func(x []float64) float64 { return math.Pow(x[0], x[1]) }},
+	"round": {1, 2, func(x []float64) float64 {
+		if len(x) == 1 {
+			return math.Round(x[0])
+		}
+		scale := math.Pow(10, math.Trunc(x[1]))
+		return math.Round(x[0]*scale) / scale
+	}},
+	"sqrt": {1, 1, func(x []float64) float64 { return math.Sqrt(x[0]) }},
+}
+
+// queryCall is a function call
+type queryCall struct {
+	name string
+	fn   queryFunction
+	args []queryNode
+}
+
+func (n queryCall) eval(get func(string) any) (any, error) {
+	values := make([]any, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(get)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return n.call(values)
+}
+
+// call applies the function to argument values, a null argument gives null
+func (n queryCall) call(values []any) (any, error) {
+	args := make([]float64, len(values))
+	for i, value := range values {
+		if value == nil {
+			return nil, nil
+		}
+		f, ok := numericValue(value)
+		if !ok {
+			return nil, fmt.Errorf("function %s expects numbers, got %v (%T)", n.name, value, value)
+		}
+		args[i] = f
+	}
+	return n.fn.apply(args), nil
+}
+
+func (n queryCall) check(df *DataFrame) error {
+	for _, arg := range n.args {
+		if err := arg.check(df); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryIsNull tests for a missing value, written "x == null" or "x != null"
+type queryIsNull struct {
+	operand queryNode
+	negate  bool
+}
+
+func (n queryIsNull) eval(get func(string) any) (any, error) {
+	value, err := n.operand.eval(get)
+	return (value == nil) != n.negate, err
+}
+
+func (n queryIsNull) check(df *DataFrame) error { return n.operand.check(df) }
+
+// queryIn tests membership in a list of values
+type queryIn struct {
+	operand queryNode
+	values  []queryNode
+	negate  bool
+}
+
+func (n queryIn) eval(get func(string) any) (any, error) {
+	value, err := n.operand.eval(get)
+	if err != nil || value == nil {
+		return false, err
+	}
+	for _, candidate := range n.values {
+		v, err := candidate.eval(get)
+		if err != nil {
+			return nil, err
+		}
+		if valuesEqual(value, v) {
+			return !n.negate, nil
+		}
+	}
+	return n.negate, nil
+}
+
+func (n queryIn) check(df *DataFrame) error {
+	for _, node := range append([]queryNode{n.operand}, n.values...) {
+		if err := node.check(df); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryBool reads an operand of a logical operator, null counts as false
+func queryBool(op string, value any) (bool, error) {
+	if value == nil {
+		return false, nil
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("operator %s expects booleans, got %v (%T)", op, value, value)
+	}
+	return b, nil
+}
+
+// queryOperation applies an arithmetic or comparison operator to two values
+func queryOperation(op string, left, right any) (any, error) {
+	switch op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return left != nil && right != nil && !valuesEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		c, ok := compareValues(left, right)
+		if !ok {
+			return false, nil
+		}
+		switch op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	}
+
+	// arithmetic
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	if op == "+" {
+		if l, ok := left.(string); ok {
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		}
+	}
+	l, okL := numericValue(left)
+	r, okR := numericValue(right)
+	if !okL || !okR {
+		return nil, fmt.Errorf("operator %s expects numbers, got %v (%T) and %v (%T)", op, left, left, right, right)
+	}
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		return l / r, nil
+	}
+	return math.Mod(l, r), nil
+}
+
+// queryToken is a lexical token of an expression
+type queryToken struct {
+	kind  string // "number", "string", "ident", "column" (backquoted), "op" or "eof"
+	text  string
+	value any
+	pos   int
+}
+
+// queryOperators are the operator and punctuation tokens
+var queryOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", ",", "="}
+
+// tokenizeQuery splits an expression into tokens
+func tokenizeQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == '_' ||
+				runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '+' || runes[i] == '-') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			text := string(runes[start:i])
+			f, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number '%s' at position %d", text, start)
+			}
+			tokens = append(tokens, queryToken{kind: "number", text: text, value: f, pos: start})
+		case r == '\'' || r == '"' || r == '`':
+			start := i
+			var sb strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated %c at position %d", r, start)
+			}
+			i++
+			kind := "string"
+			if r == '`' {
+				kind = "column"
+			}
+			tokens = append(tokens, queryToken{kind: kind, text: sb.String(), value: sb.String(), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: "ident", text: string(runes[start:i]), pos: start})
+		default:
+			start := i
+			op := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					op = two
+				}
+			}
+			if !slices.Contains(queryOperators, op) {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", r, start)
+			}
+			i += len([]rune(op))
+			tokens = append(tokens, queryToken{kind: "op", text: op, pos: start})
+		}
+	}
+	return append(tokens, queryToken{kind: "eof", pos: len(runes)}), nil
+}
+
+// queryParser is a recursive descent parser over the tokens of an expression
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+// parseQuery parses an expression into a tree
+func parseQuery(expr string) (queryNode, error) {
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	return node, nil
+}
+
+// parseAssignment parses an expression of the form "column = expression"
+func parseAssignment(expr string) (string, queryNode, error) {
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid expression %q: %w", expr, err)
+	}
+	if len(tokens) < 3 || (tokens[0].kind != "ident" && tokens[0].kind != "column") || tokens[1].text != "=" {
+		return "", nil, fmt.Errorf("invalid expression %q: expected an assignment such as \"total = price * qty\"", expr)
+	}
+	p := &queryParser{tokens: tokens, pos: 2}
+	node, err := p.parseExpression()
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid expression %q: %w", expr, err)
+	}
+	return tokens[0].text, node, nil
+}
+
+// parseExpression parses the remaining tokens as a single expression
+func (p *queryParser) parseExpression() (queryNode, error) {
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != "eof" {
+		if tok.text == "=" {
+			return nil, fmt.Errorf("unexpected '=' at position %d, use == to compare", tok.pos)
+		}
+		return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+	}
+	return node, nil
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+// accept consumes the next token if it is one of the operators or keywords
+func (p *queryParser) accept(texts ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != "op" && tok.kind != "ident" {
+		return "", false
+	}
+	for _, text := range texts {
+		if tok.text == text || (tok.kind == "ident" && strings.EqualFold(tok.text, text)) {
+			p.pos++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+func (p *queryParser) expect(text string) error {
+	if _, ok := p.accept(text); !ok {
+		return fmt.Errorf("expected '%s' at position %d, got '%s'", text, p.peek().pos, p.peek().text)
+	}
+	return nil
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	for err == nil {
+		if _, ok := p.accept("||", "or"); !ok {
+			break
+		}
+		var right queryNode
+		if right, err = p.parseAnd(); err == nil {
+			left = queryBinary{op: "||", left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNot()
+	for err == nil {
+		if _, ok := p.accept("&&", "and"); !ok {
+			break
+		}
+		var right queryNode
+		if right, err = p.parseNot(); err == nil {
+			left = queryBinary{op: "&&", left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		operand, err := p.parseNot()
+		return queryUnary{op: "!", operand: operand}, err
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := p.accept("==", "!=", "<=", ">=", "<", ">"); ok {
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		// comparing with the null literal tests for missing values
+		for _, pair := range [][2]queryNode{{left, right}, {right, left}} {
+			if literal, ok := pair[1].(queryLiteral); ok && literal.value == nil && (op == "==" || op == "!=") {
+				return queryIsNull{operand: pair[0], negate: op == "!="}, nil
+			}
+		}
+		return queryBinary{op: op, left: left, right: right}, nil
+	}
+
+	negate := false
+	if _, ok := p.accept("not"); ok {
+		negate = true
+		if p.peek().kind != "ident" || !strings.EqualFold(p.peek().text, "in") {
+			return nil, fmt.Errorf("expected 'in' after 'not' at position %d", p.peek().pos)
+		}
+	}
+	if _, ok := p.accept("in"); !ok {
+		return left, nil
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	in := queryIn{operand: left, negate: negate}
+	for {
+		value, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		in.values = append(in.values, value)
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	return in, p.expect(")")
+}
+
+func (p *queryParser) parseAdditive() (queryNode, error) {
+	left, err := p.parseTerm()
+	for err == nil {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			break
+		}
+		var right queryNode
+		if right, err = p.parseTerm(); err == nil {
+			left = queryBinary{op: op, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *queryParser) parseTerm() (queryNode, error) {
+	left, err := p.parseUnary()
+	for err == nil {
+		op, ok := p.accept("*", "/", "%")
+		if !ok {
+			break
+		}
+		var right queryNode
+		if right, err = p.parseUnary(); err == nil {
+			left = queryBinary{op: op, left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.parseUnary()
+		return queryUnary{op: "-", operand: operand}, err
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case "number", "string":
+		p.pos++
+		return queryLiteral{value: tok.value}, nil
+	case "column":
+		p.pos++
+		return queryColumn{name: tok.text}, nil
+	case "ident":
+		p.pos++
+		switch strings.ToLower(tok.text) {
+		case "true":
+			return queryLiteral{value: true}, nil
+		case "false":
+			return queryLiteral{value: false}, nil
+		case "null", "nil":
+			return queryLiteral{value: nil}, nil
+		case "and", "or", "not", "in":
+			return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(tok)
+		}
+		return queryColumn{name: tok.text}, nil
+	case "op":
+		if tok.text == "(" {
+			p.pos++
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+	case "eof":
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected '%s' at position %d", tok.text, tok.pos)
+}
+
+// parseCall parses the arguments of a function call, the opening parenthesis is already consumed
+func (p *queryParser) parseCall(name queryToken) (queryNode, error) {
+	fn, known := queryFunctions[strings.ToLower(name.text)]
+	if !known {
+		return nil, fmt.Errorf("unknown function '%s' at position %d", name.text, name.pos)
+	}
+	call := queryCall{name: strings.ToLower(name.text), fn: fn}
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if len(call.args) < fn.minArgs || len(call.args) > fn.maxArgs {
+		return nil, fmt.Errorf("function %s takes %s, got %d at position %d", call.name, fn.arity(), len(call.args), name.pos)
+	}
+	return call, nil
+}
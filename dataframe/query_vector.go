@@ -0,0 +1,453 @@
+package dataframe
+
+/*
+
+	This is where parsed expressions are evaluated column by column. Each node produces a
+	queryVector holding a whole column of results in a native slice, so that "price * qty > 100"
+	runs as two tight float64 loops instead of interpreting the tree once per row.
+
+	Operands without a kernel (time values, mixed types, booleans compared with <) are evaluated
+	value by value with the same rules as the row interpreter, and any error sends the whole
+	expression back to the row interpreter, which short-circuits && and || and reports the row.
+
+*/
+
+import (
+	"math"
+	"strings"
+)
+
+// queryVector is a column of intermediate results. Exactly one slice is set, depending on kind:
+// floats for "float", bools for "bool", strings for "string" and values for "any".
+// Null rows of the native kinds hold the zero value and are marked in nulls.
+type queryVector struct {
+	kind    string
+	floats  []float64
+	bools   []bool
+	strings []string
+	values  []any
+	nulls   nullBitmap
+}
+
+// null reports whether row i is null
+func (v *queryVector) null(i int) bool {
+	if v.kind == "any" {
+		return v.values[i] == nil
+	}
+	return v.nulls.isNull(i)
+}
+
+// boxed returns the values of the vector as []any, nil for null rows
+func (v *queryVector) boxed() []any {
+	switch v.kind {
+	case "float":
+		return (&nativeColumn[float64]{data: v.floats, nulls: v.nulls}).boxed()
+	case "bool":
+		return (&nativeColumn[bool]{data: v.bools, nulls: v.nulls}).boxed()
+	case "string":
+		return (&nativeColumn[string]{data: v.strings, nulls: v.nulls}).boxed()
+	}
+	return v.values
+}
+
+// newQueryVector builds a vector from boxed values, using a native kind when they all share one
+func newQueryVector(values []any) *queryVector {
+	if native := toNative(values); native != nil {
+		return nativeQueryVector(native)
+	}
+	return &queryVector{kind: "any", values: values}
+}
+
+// nativeQueryVector builds a vector over native storage. The slices are shared, never modify them.
+func nativeQueryVector(native nativeStorage) *queryVector {
+	switch n := native.(type) {
+	case *nativeColumn[float64]:
+		return &queryVector{kind: "float", floats: n.data, nulls: n.nulls}
+	case *nativeColumn[int64]:
+		floats := make([]float64, len(n.data))
+		for i, x := range n.data {
+			floats[i] = float64(x)
+		}
+		return &queryVector{kind: "float", floats: floats, nulls: n.nulls}
+	case *nativeColumn[bool]:
+		return &queryVector{kind: "bool", bools: n.data, nulls: n.nulls}
+	case *nativeColumn[string]:
+		return &queryVector{kind: "string", strings: n.data, nulls: n.nulls}
+	}
+	return &queryVector{kind: "any", values: native.boxed()}
+}
+
+// constantVector repeats a literal over n rows
+func constantVector(value any, n int) *queryVector {
+	switch v := value.(type) {
+	case float64:
+		return &queryVector{kind: "float", floats: repeat(v, n)}
+	case string:
+		return &queryVector{kind: "string", strings: repeat(v, n)}
+	case bool:
+		return &queryVector{kind: "bool", bools: repeat(v, n)}
+	}
+	return &queryVector{kind: "any", values: repeat(value, n)}
+}
+
+// repeat returns a slice holding n copies of v
+func repeat[V any](v V, n int) []V {
+	values := make([]V, n)
+	for i := range values {
+		values[i] = v
+	}
+	return values
+}
+
+// unionNulls returns the rows that are null in either bitmap
+func unionNulls(a, b nullBitmap) nullBitmap {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(b) == 0 {
+		return a
+	}
+	result := append(nullBitmap{}, a...)
+	for i, word := range b {
+		result[i] |= word
+	}
+	return result
+}
+
+// queryVectorizer evaluates parsed expressions over every row of a DataFrame
+type queryVectorizer struct {
+	df      *DataFrame
+	columns map[string]*queryVector // columns already loaded, read only
+}
+
+// vectorizeQuery evaluates a parsed expression over every row of the DataFrame
+func vectorizeQuery(node queryNode, df *DataFrame) (*queryVector, error) {
+	v := &queryVectorizer{df: df, columns: make(map[string]*queryVector)}
+	return v.vector(node)
+}
+
+// vector evaluates a node, see vectorizeQuery
+func (v *queryVectorizer) vector(node queryNode) (*queryVector, error) {
+	n := v.df.Nrows()
+	switch x := node.(type) {
+	case queryLiteral:
+		return constantVector(x.value, n), nil
+
+	case queryColumn:
+		if vector, loaded := v.columns[x.name]; loaded {
+			return vector, nil
+		}
+		col := v.df.Columns[x.name]
+		vector := &queryVector{}
+		if col.native != nil {
+			vector = nativeQueryVector(col.native)
+		} else {
+			vector = newQueryVector(col.Values())
+		}
+		v.columns[x.name] = vector
+		return vector, nil
+
+	case queryUnary:
+		operand, err := v.vector(x.operand)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case x.op == "-" && operand.kind == "float":
+			result := make([]float64, n)
+			for i, f := range operand.floats {
+				result[i] = -f
+			}
+			return &queryVector{kind: "float", floats: result, nulls: operand.nulls}, nil
+		case x.op == "!" && operand.kind == "bool":
+			result := make([]bool, n)
+			for i, b := range operand.bools {
+				result[i] = !b
+			}
+			return &queryVector{kind: "bool", bools: result, nulls: operand.nulls}, nil
+		}
+		values := operand.boxed()
+		return rowWise(n, func(i int) (any, error) {
+			return queryUnaryValue(x.op, values[i])
+		})
+
+	case queryIsNull:
+		operand, err := v.vector(x.operand)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]bool, n)
+		for i := range result {
+			result[i] = operand.null(i) != x.negate
+		}
+		return &queryVector{kind: "bool", bools: result}, nil
+
+	case queryIn:
+		return v.in(x)
+
+	case queryCall:
+		args := make([]*queryVector, len(x.args))
+		floats := true
+		for i, arg := range x.args {
+			vector, err := v.vector(arg)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = vector
+			floats = floats && vector.kind == "float"
+		}
+		if floats {
+			result := make([]float64, n)
+			var nulls nullBitmap
+			values := make([]float64, len(args))
+			for _, arg := range args {
+				nulls = unionNulls(nulls, arg.nulls)
+			}
+			for i := range result {
+				for j, arg := range args {
+					values[j] = arg.floats[i]
+				}
+				result[i] = x.fn.apply(values)
+			}
+			return &queryVector{kind: "float", floats: result, nulls: nulls}, nil
+		}
+		boxed := make([][]any, len(args))
+		for j, arg := range args {
+			boxed[j] = arg.boxed()
+		}
+		values := make([]any, len(args))
+		return rowWise(n, func(i int) (any, error) {
+			for j := range boxed {
+				values[j] = boxed[j][i]
+			}
+			return x.call(values)
+		})
+
+	case queryBinary:
+		left, err := v.vector(x.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := v.vector(x.right)
+		if err != nil {
+			return nil, err
+		}
+		if result := queryKernel(x.op, left, right); result != nil {
+			return result, nil
+		}
+		leftValues, rightValues := left.boxed(), right.boxed()
+		return rowWise(n, func(i int) (any, error) {
+			if x.op == "&&" || x.op == "||" {
+				l, err := queryBool(x.op, leftValues[i])
+				if err != nil {
+					return nil, err
+				}
+				r, err := queryBool(x.op, rightValues[i])
+				if x.op == "&&" {
+					return l && r, err
+				}
+				return l || r, err
+			}
+			return queryOperation(x.op, leftValues[i], rightValues[i])
+		})
+	}
+	return rowWise(n, func(int) (any, error) { return nil, nil })
+}
+
+// rowWise builds a vector by evaluating every row with the rules of the row interpreter
+func rowWise(n int, value func(i int) (any, error)) (*queryVector, error) {
+	values := make([]any, n)
+	for i := range values {
+		v, err := value(i)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return newQueryVector(values), nil
+}
+
+// queryKernel applies a binary operator with a type-specialized loop, or returns nil when the
+// kinds of the operands have no kernel
+func queryKernel(op string, left, right *queryVector) *queryVector {
+	switch {
+	case op == "&&" || op == "||":
+		if left.kind != "bool" || right.kind != "bool" {
+			return nil
+		}
+		// a null operand counts as false
+		result := make([]bool, len(left.bools))
+		for i := range result {
+			l := left.bools[i] && !left.nulls.isNull(i)
+			r := right.bools[i] && !right.nulls.isNull(i)
+			if op == "&&" {
+				result[i] = l && r
+			} else {
+				result[i] = l || r
+			}
+		}
+		return &queryVector{kind: "bool", bools: result}
+
+	case left.kind == "float" && right.kind == "float":
+		nulls := unionNulls(left.nulls, right.nulls)
+		a, b := left.floats, right.floats
+		switch op {
+		case "+", "-", "*", "/", "%":
+			result := make([]float64, len(a))
+			switch op {
+			case "+":
+				for i := range result {
+					result[i] = a[i] + b[i]
+				}
+			case "-":
+				for i := range result {
+					result[i] = a[i] - b[i]
+				}
+			case "*":
+				for i := range result {
+					result[i] = a[i] * b[i]
+				}
+			case "/":
+				for i := range result {
+					result[i] = a[i] / b[i]
+				}
+			case "%":
+				for i := range result {
+					result[i] = floatMod(a[i], b[i])
+				}
+			}
+			return &queryVector{kind: "float", floats: result, nulls: nulls}
+		}
+		return compareKernel(op, len(a), nulls, func(i int) int {
+			switch {
+			case math.IsNaN(a[i]) || math.IsNaN(b[i]):
+				return 2 // unordered, only != holds
+			case a[i] < b[i]:
+				return -1
+			case a[i] > b[i]:
+				return 1
+			}
+			return 0
+		})
+
+	case left.kind == "string" && right.kind == "string":
+		nulls := unionNulls(left.nulls, right.nulls)
+		a, b := left.strings, right.strings
+		if op == "+" {
+			result := make([]string, len(a))
+			for i := range result {
+				result[i] = a[i] + b[i]
+			}
+			return &queryVector{kind: "string", strings: result, nulls: nulls}
+		}
+		return compareKernel(op, len(a), nulls, func(i int) int { return strings.Compare(a[i], b[i]) })
+
+	case left.kind == "bool" && right.kind == "bool" && (op == "==" || op == "!="):
+		nulls := unionNulls(left.nulls, right.nulls)
+		a, b := left.bools, right.bools
+		return compareKernel(op, len(a), nulls, func(i int) int {
+			if a[i] == b[i] {
+				return 0
+			}
+			return 2
+		})
+	}
+	return nil
+}
+
+// floatMod is math.Mod with a fast path for integral values, which are the common case
+func floatMod(a, b float64) float64 {
+	const exact = 1 << 53
+	if a == math.Trunc(a) && b == math.Trunc(b) && b != 0 && math.Abs(a) < exact && math.Abs(b) < exact {
+		if r := float64(int64(a) % int64(b)); r != 0 || a >= 0 {
+			return r
+		}
+	}
+	return math.Mod(a, b)
+}
+
+// compareKernel applies a comparison operator to the results of cmp: -1, 0 or 1 for ordered
+// values and 2 for values that are not equal but cannot be ordered. Null rows give false.
+func compareKernel(op string, n int, nulls nullBitmap, cmp func(i int) int) *queryVector {
+	var test func(c int) bool
+	switch op {
+	case "==":
+		test = func(c int) bool { return c == 0 }
+	case "!=":
+		test = func(c int) bool { return c != 0 }
+	case "<":
+		test = func(c int) bool { return c == -1 }
+	case "<=":
+		test = func(c int) bool { return c == -1 || c == 0 }
+	case ">":
+		test = func(c int) bool { return c == 1 }
+	case ">=":
+		test = func(c int) bool { return c == 0 || c == 1 }
+	default:
+		return nil
+	}
+	result := make([]bool, n)
+	for i := range result {
+		result[i] = !nulls.isNull(i) && test(cmp(i))
+	}
+	return &queryVector{kind: "bool", bools: result}
+}
+
+// in tests membership with a hash set when the list holds literals of the operand's kind
+func (v *queryVectorizer) in(x queryIn) (*queryVector, error) {
+	operand, err := v.vector(x.operand)
+	if err != nil {
+		return nil, err
+	}
+	floats := make(map[float64]bool)
+	strs := make(map[string]bool)
+	literals := true
+	for _, node := range x.values {
+		literal, ok := node.(queryLiteral)
+		if !ok {
+			literals = false
+			break
+		}
+		if f, ok := numericValue(literal.value); ok {
+			floats[f] = true
+		} else if s, ok := literal.value.(string); ok {
+			strs[s] = true
+		}
+	}
+
+	result := make([]bool, v.df.Nrows())
+	switch {
+	case literals && operand.kind == "float":
+		for i, f := range operand.floats {
+			result[i] = !operand.nulls.isNull(i) && floats[f] != x.negate
+		}
+	case literals && operand.kind == "string":
+		for i, s := range operand.strings {
+			result[i] = !operand.nulls.isNull(i) && strs[s] != x.negate
+		}
+	default:
+		values := operand.boxed()
+		candidates := make([][]any, len(x.values))
+		for j, node := range x.values {
+			vector, err := v.vector(node)
+			if err != nil {
+				return nil, err
+			}
+			candidates[j] = vector.boxed()
+		}
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			found := false
+			for _, candidate := range candidates {
+				if valuesEqual(value, candidate[i]) {
+					found = true
+					break
+				}
+			}
+			result[i] = found != x.negate
+		}
+	}
+	return &queryVector{kind: "bool", bools: result}, nil
+}
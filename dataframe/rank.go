@@ -0,0 +1,172 @@
+package dataframe
+
+/*
+
+	This is where ranking and clipping are defined, on a Series or on the numeric columns of a
+	DataFrame: Rank replaces the values by their position in sorted order and Clip bounds them,
+	e.g. to prepare features for robust statistics or machine learning.
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// RankOption is the parameters we can set to the Rank method.
+//
+// Fields:
+//   - Method: How equal values are ranked. "average" (the default) gives them the mean of their ranks,
+//     "min" and "max" the lowest and highest of their ranks, "first" their order of appearance and "dense"
+//     the lowest rank, with no gap after them.
+//   - Descending: Ranks the largest value first.
+type RankOption struct {
+	Method     string
+	Descending bool
+}
+
+// Rank returns the rank of each value in sorted order, from 1. Numbers are compared numerically,
+// time.Time values chronologically and strings lexically.
+//
+// Parameters:
+//   - options (optional): The RankOption struct to set how equal values are ranked and the order.
+//
+// Returns:
+//   - *Series: The float64 ranks, nil where the value is missing (nil or NaN). Err returns an error if the
+//     method is unknown.
+func (s *Series) Rank(options ...RankOption) *Series {
+	if err := s.Err(); err != nil {
+		return failedSeries(s.Name, err)
+	}
+	ranks, err := rankValues(s.Data, options)
+	if err != nil {
+		return failedSeries(s.Name, err)
+	}
+	return NewSeries(s.Name, ranks)
+}
+
+// rankValues returns the ranks of the values, missing values give nil
+func rankValues(values []any, options []RankOption) ([]any, error) {
+	var opts RankOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Method == "" {
+		opts.Method = "average"
+	}
+	if !slices.Contains([]string{"average", "min", "max", "first", "dense"}, opts.Method) {
+		return nil, fmt.Errorf("unknown rank method: %s (must be 'average', 'min', 'max', 'first' or 'dense')", opts.Method)
+	}
+
+	var order []int
+	for i, v := range values {
+		if !isMissing(v) {
+			order = append(order, i)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return compareSortValues(values[a], values[b], !opts.Descending, false)
+	})
+
+	ranks := make([]any, len(values))
+	dense := 0
+	for start := 0; start < len(order); {
+		// the values from start to end are equal
+		end := start + 1
+		for end < len(order) && compareSortValues(values[order[start]], values[order[end]], true, false) == 0 {
+			end++
+		}
+		dense++
+		for i := start; i < end; i++ {
+			var rank float64
+			switch opts.Method {
+			case "average":
+				rank = float64(start+end+1) / 2
+			case "min":
+				rank = float64(start + 1)
+			case "max":
+				rank = float64(end)
+			case "first":
+				rank = float64(i + 1)
+			case "dense":
+				rank = float64(dense)
+			}
+			ranks[order[i]] = rank
+		}
+		start = end
+	}
+	return ranks, nil
+}
+
+// Clip bounds the values, a value below lower becomes lower and a value above upper becomes upper.
+//
+// Parameters:
+//   - lower: The lower bound, math.Inf(-1) for none.
+//   - upper: The upper bound, math.Inf(1) for none.
+//
+// Returns:
+//   - *Series: The float64 values, nil where the value is missing. Err returns an error if a value is not
+//     numeric or lower is above upper.
+func (s *Series) Clip(lower, upper float64) *Series {
+	if err := s.Err(); err != nil {
+		return failedSeries(s.Name, err)
+	}
+	clipped, err := clipValues(s.Name, s.Data, lower, upper)
+	if err != nil {
+		return failedSeries(s.Name, err)
+	}
+	return NewSeries(s.Name, clipped)
+}
+
+// clipValues bounds the numeric values, missing values give nil
+func clipValues(name string, values []any, lower, upper float64) ([]any, error) {
+	if lower > upper {
+		return nil, fmt.Errorf("lower bound %v is above upper bound %v", lower, upper)
+	}
+	nums, ok := nanFloats(values)
+	if !ok {
+		return nil, fmt.Errorf("column '%s' is not numeric", name)
+	}
+	clipped := make([]any, len(nums))
+	for i, x := range nums {
+		if !math.IsNaN(x) {
+			clipped[i] = math.Min(math.Max(x, lower), upper)
+		}
+	}
+	return clipped, nil
+}
+
+// Rank returns the ranks of the values of the columns, see Series.Rank.
+//
+// Parameters:
+//   - columns: The columns to rank. Defaults to every numeric column if empty.
+//   - options (optional): The RankOption struct to set how equal values are ranked and the order.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the same columns, the other columns are copied as is.
+//   - error: An error if a column does not exist or the method is unknown.
+func (df *DataFrame) Rank(columns []string, options ...RankOption) (*DataFrame, error) {
+	return df.mapNumericColumns(columns, func(_ string, values []any) ([]any, error) {
+		return rankValues(values, options)
+	})
+}
+
+// Clip bounds the values of the columns, see Series.Clip.
+//
+// Parameters:
+//   - lower: The lower bound, math.Inf(-1) for none.
+//   - upper: The upper bound, math.Inf(1) for none.
+//   - columns (optional): The columns to clip. Defaults to every numeric column.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the same columns, the other columns are copied as is.
+//   - error: An error if a column does not exist or holds non-numeric values, or lower is above upper.
+func (df *DataFrame) Clip(lower, upper float64, columns ...string) (*DataFrame, error) {
+	if lower > upper {
+		return nil, fmt.Errorf("lower bound %v is above upper bound %v", lower, upper)
+	}
+	return df.mapNumericColumns(columns, func(name string, values []any) ([]any, error) {
+		return clipValues(name, values, lower, upper)
+	})
+}
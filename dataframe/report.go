@@ -0,0 +1,257 @@
+package dataframe
+
+/*
+
+	This is where the multi-frame report writer (xlsx workbooks and HTML reports) is defined
+
+*/
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReportWriter collects named DataFrames and plots and writes them as a single deliverable:
+// a multi-sheet xlsx workbook or an HTML report with one section per entry.
+//
+// Entries keep the order in which they are added.
+type ReportWriter struct {
+	Title    string
+	sections []reportSection
+}
+
+// reportSection is a single DataFrame or plot of a report
+type reportSection struct {
+	name  string
+	frame *DataFrame
+	excel ExcelOption
+	plot  []byte
+}
+
+// NewReportWriter creates an empty report.
+//
+// Parameters:
+//   - title: The title of the report, used as the HTML page heading.
+//
+// Returns:
+//   - *ReportWriter: The new report.
+func NewReportWriter(title string) *ReportWriter {
+	return &ReportWriter{Title: title}
+}
+
+// AddFrame adds a DataFrame to the report. It becomes its own sheet in xlsx output
+// and a table section in HTML output.
+//
+// Parameters:
+//   - name: The section name, used as the sheet name in xlsx output.
+//   - df: The DataFrame to add.
+//   - options (optional): The ExcelOption struct to style the sheet in xlsx output. SheetName is ignored.
+//
+// Returns:
+//   - error: An error if the name is empty or already used, or the DataFrame is nil.
+func (r *ReportWriter) AddFrame(name string, df *DataFrame, options ...ExcelOption) error {
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+	if df == nil {
+		return fmt.Errorf("frame '%s' is nil", name)
+	}
+	section := reportSection{name: name, frame: df}
+	if len(options) > 0 {
+		section.excel = options[0]
+	}
+	section.excel.SheetName = name
+	r.sections = append(r.sections, section)
+	return nil
+}
+
+// AddPlot adds a PNG image (e.g. rendered with LinePlotWriter or BarPlotWriter) to the report.
+// It becomes its own sheet in xlsx output and an image section in HTML output.
+//
+// Parameters:
+//   - name: The section name, used as the sheet name in xlsx output.
+//   - png: The PNG encoded image.
+//
+// Returns:
+//   - error: An error if the name is empty or already used, or the image is empty.
+func (r *ReportWriter) AddPlot(name string, png []byte) error {
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+	if len(png) == 0 {
+		return fmt.Errorf("plot '%s' is empty", name)
+	}
+	r.sections = append(r.sections, reportSection{name: name, plot: png})
+	return nil
+}
+
+// checkName validates the name of a new section
+func (r *ReportWriter) checkName(name string) error {
+	if name == "" {
+		return fmt.Errorf("section name cannot be empty")
+	}
+	for _, section := range r.sections {
+		if section.name == name {
+			return fmt.Errorf("section '%s' already exists", name)
+		}
+	}
+	return nil
+}
+
+// ToExcel writes the report as an xlsx workbook with one sheet per section.
+//
+// Parameters:
+//   - filename: The path to the output xlsx file.
+//
+// Returns:
+//   - error: An error if the report is empty or the workbook cannot be built or written.
+func (r *ReportWriter) ToExcel(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	return r.ToExcelWriter(file)
+}
+
+// ToExcelWriter writes the report as an xlsx workbook with one sheet per section to a writer.
+//
+// Parameters:
+//   - writer: An io.Writer for the xlsx data.
+//
+// Returns:
+//   - error: An error if the report is empty or the workbook cannot be built or written.
+func (r *ReportWriter) ToExcelWriter(writer io.Writer) error {
+	if len(r.sections) == 0 {
+		return fmt.Errorf("report has no sections")
+	}
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	for i, section := range r.sections {
+		if i == 0 {
+			if err := file.SetSheetName("Sheet1", section.name); err != nil {
+				return fmt.Errorf("error creating sheet '%s': %w", section.name, err)
+			}
+		} else if _, err := file.NewSheet(section.name); err != nil {
+			return fmt.Errorf("error creating sheet '%s': %w", section.name, err)
+		}
+
+		if section.frame != nil {
+			if err := section.frame.writeExcelSheet(file, section.excel); err != nil {
+				return fmt.Errorf("error writing sheet '%s': %w", section.name, err)
+			}
+			continue
+		}
+		picture := &excelize.Picture{Extension: ".png", File: section.plot, Format: &excelize.GraphicOptions{}}
+		if err := file.AddPictureFromBytes(section.name, "A1", picture); err != nil {
+			return fmt.Errorf("error adding plot '%s': %w", section.name, err)
+		}
+	}
+
+	if _, err := file.WriteTo(writer); err != nil {
+		return fmt.Errorf("error writing workbook: %w", err)
+	}
+	return nil
+}
+
+// reportTemplate renders the HTML report, html/template escapes every cell value
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+th { background: #f0f0f0; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Sections}}<section>
+<h2>{{.Name}}</h2>
+{{if .Image}}<img src="{{.Image}}" alt="{{.Name}}">
+{{else}}<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+// ToHTML writes the report as a standalone HTML page with one section per entry. Plots are embedded as images.
+//
+// Parameters:
+//   - filename: The path to the output HTML file.
+//
+// Returns:
+//   - error: An error if the report cannot be written.
+func (r *ReportWriter) ToHTML(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	return r.ToHTMLWriter(file)
+}
+
+// ToHTMLWriter writes the report as a standalone HTML page with one section per entry to a writer.
+//
+// Parameters:
+//   - writer: An io.Writer for the HTML data.
+//
+// Returns:
+//   - error: An error if the report cannot be written.
+func (r *ReportWriter) ToHTMLWriter(writer io.Writer) error {
+	type htmlSection struct {
+		Name   string
+		Image  template.URL
+		Header []string
+		Rows   [][]string
+	}
+
+	sections := make([]htmlSection, 0, len(r.sections))
+	for _, section := range r.sections {
+		if section.frame == nil {
+			image := "data:image/png;base64," + base64.StdEncoding.EncodeToString(section.plot)
+			sections = append(sections, htmlSection{Name: section.name, Image: template.URL(image)})
+			continue
+		}
+
+		header := section.frame.ColumnNames()
+		rows := make([][]string, section.frame.Nrows())
+		for i := range rows {
+			rows[i] = make([]string, len(header))
+		}
+		for c, name := range header {
+			for i, value := range section.frame.Columns[name].Values() {
+				if value != nil {
+					rows[i][c] = fmt.Sprintf("%v", value)
+				}
+			}
+		}
+		sections = append(sections, htmlSection{Name: section.name, Header: header, Rows: rows})
+	}
+
+	err := reportTemplate.Execute(writer, struct {
+		Title    string
+		Sections []htmlSection
+	}{r.Title, sections})
+	if err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return nil
+}
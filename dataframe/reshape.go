@@ -0,0 +1,405 @@
+package dataframe
+
+/*
+
+	This is where reshaping methods (pivoting and multi-level column headers) are defined
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// levelSeparator joins the labels of a multi-level column into its internal column name
+const levelSeparator = "|"
+
+// Pivot reshapes the DataFrame from long to wide format. Every distinct value of the columns
+// column becomes a new column for each of the values columns, producing two-level column headers
+// (value column × pivot value, e.g. "salary" × "2024-01").
+//
+// Parameters:
+//   - index: The column whose distinct values become the rows of the result.
+//   - columns: The column whose distinct values become the columns of the result.
+//   - values: The columns holding the values to spread.
+//   - aggFunc (optional): How duplicate index/columns pairs are combined: "first" (default), "last",
+//     "sum", "mean", "min", "max" or "count".
+//
+// Returns:
+//   - *DataFrame: The pivoted DataFrame with multi-level column headers.
+//   - error: An error if a column does not exist or the aggregation fails.
+//
+// Note:
+//   - Rows and columns keep the order in which their values first appear.
+//   - Use SelectLevel to pick a group of columns and FlattenColumns to join the header levels into plain names.
+func (df *DataFrame) Pivot(index, columns string, values []string, aggFunc ...string) (*DataFrame, error) {
+	agg := "first"
+	if len(aggFunc) > 0 && aggFunc[0] != "" {
+		agg = aggFunc[0]
+	}
+
+	for _, name := range append([]string{index, columns}, values...) {
+		if _, exists := df.Columns[name]; !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("please enter 1 or more value column name(s)")
+	}
+
+	indexCol := df.Columns[index].Values()
+	pivotCol := df.Columns[columns].Values()
+
+	rowKeys := []any{}
+	rowPos := make(map[any]int)
+	colKeys := []any{}
+	colPos := make(map[any]int)
+	for i := range indexCol {
+		if _, seen := rowPos[indexCol[i]]; !seen {
+			rowPos[indexCol[i]] = len(rowKeys)
+			rowKeys = append(rowKeys, indexCol[i])
+		}
+		if _, seen := colPos[pivotCol[i]]; !seen {
+			colPos[pivotCol[i]] = len(colKeys)
+			colKeys = append(colKeys, pivotCol[i])
+		}
+	}
+
+	result := NewDataFrame()
+	if err := result.AddColumn(NewColumn(index, rowKeys)); err != nil {
+		return nil, err
+	}
+
+	for _, valueName := range values {
+		valueCol := df.Columns[valueName].Values()
+
+		// cells[row][col] collects every value that falls into that cell
+		cells := make([][][]any, len(rowKeys))
+		for r := range cells {
+			cells[r] = make([][]any, len(colKeys))
+		}
+		for i := range valueCol {
+			r, c := rowPos[indexCol[i]], colPos[pivotCol[i]]
+			cells[r][c] = append(cells[r][c], valueCol[i])
+		}
+
+		for c, colKey := range colKeys {
+			label := fmt.Sprintf("%v", colKey)
+			data := make([]any, len(rowKeys))
+			for r := range rowKeys {
+				if cells[r][c] == nil {
+					continue // missing combination stays nil
+				}
+				aggregated, err := aggregateValues(cells[r][c], agg)
+				if err != nil {
+					return nil, fmt.Errorf("error aggregating column '%s': %w", valueName, err)
+				}
+				data[r] = aggregated
+			}
+
+			name := strings.Join([]string{valueName, label}, levelSeparator)
+			if err := result.AddColumn(NewColumn(name, data)); err != nil {
+				return nil, err
+			}
+			result.setColumnLevels(name, []string{valueName, label})
+		}
+	}
+
+	return result, nil
+}
+
+// Unstack pivots a level of the multi-level index (see SetMultiIndex) into columns: every distinct
+// label of the level becomes a column for each of the other columns, with two-level column headers
+// (column × label) like Pivot. The remaining levels become the index of the result.
+//
+// Parameters:
+//   - level: The position of the index level to pivot, negative values count from the innermost level (-1).
+//
+// Returns:
+//   - *DataFrame: The unstacked DataFrame, missing combinations are nil.
+//   - error: An error if the DataFrame has no multi-level index, the level is out of range or the index has duplicate entries.
+//
+// Experimental: the multi-level index API may change in a minor release.
+func (df *DataFrame) Unstack(level int) (*DataFrame, error) {
+	names := df.IndexNames()
+	if len(names) < 2 {
+		return nil, fmt.Errorf("unstack needs a multi-level index, use SetMultiIndex first")
+	}
+	if level < 0 {
+		level += len(names)
+	}
+	if level < 0 || level >= len(names) {
+		return nil, fmt.Errorf("level %d is out of range for an index with %d levels", level, len(names))
+	}
+	rest := append(append([]string{}, names[:level]...), names[level+1:]...)
+
+	rowKeys := [][]any{}
+	rowPos := make(map[string]int)
+	colKeys := []any{}
+	colPos := make(map[string]int)
+	cellRows := make(map[[2]int]int) // (row, column) -> source row
+	for i := 0; i < df.Nrows(); i++ {
+		tuple := df.indexTuple(rest, i)
+		rowKey := fmt.Sprintf("%#v", normalizedLabels(tuple))
+		if _, seen := rowPos[rowKey]; !seen {
+			rowPos[rowKey] = len(rowKeys)
+			rowKeys = append(rowKeys, tuple)
+		}
+		label, _ := df.Columns[names[level]].At(i)
+		colKey := fmt.Sprintf("%#v", labelKey(label))
+		if _, seen := colPos[colKey]; !seen {
+			colPos[colKey] = len(colKeys)
+			colKeys = append(colKeys, label)
+		}
+		cell := [2]int{rowPos[rowKey], colPos[colKey]}
+		if _, duplicate := cellRows[cell]; duplicate {
+			return nil, fmt.Errorf("index has duplicate entries for %v, cannot unstack", append(tuple, label))
+		}
+		cellRows[cell] = i
+	}
+
+	result := NewDataFrame()
+	for l, name := range rest {
+		data := make([]any, len(rowKeys))
+		for r, tuple := range rowKeys {
+			data[r] = tuple[l]
+		}
+		result.Columns[name] = &Column[any]{Name: name, Data: data}
+		result.order = append(result.order, name)
+	}
+	for _, valueName := range df.ColumnNames() {
+		if slices.Contains(names, valueName) {
+			continue
+		}
+		values := df.Columns[valueName].Values()
+		for c, colKey := range colKeys {
+			label := fmt.Sprintf("%v", colKey)
+			data := make([]any, len(rowKeys))
+			for r := range rowKeys {
+				if i, ok := cellRows[[2]int{r, c}]; ok {
+					data[r] = values[i]
+				}
+			}
+			name := strings.Join([]string{valueName, label}, levelSeparator)
+			if err := result.AddColumn(NewColumn(name, data)); err != nil {
+				return nil, err
+			}
+			result.setColumnLevels(name, []string{valueName, label})
+		}
+	}
+	result.indexNames = rest
+	return result, nil
+}
+
+// normalizedLabels returns the labels with numbers normalized, see labelKey
+func normalizedLabels(labels []any) []any {
+	normalized := make([]any, len(labels))
+	for i, label := range labels {
+		normalized[i] = labelKey(label)
+	}
+	return normalized
+}
+
+// aggregateValues reduces a group of values to a single value using a named aggregation.
+// Numeric aggregations skip values that are not numbers.
+func aggregateValues(values []any, agg string) (any, error) {
+	switch agg {
+	case "first":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return values[0], nil
+	case "last":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return values[len(values)-1], nil
+	case "count":
+		count := 0
+		for _, v := range values {
+			if v != nil {
+				count++
+			}
+		}
+		return count, nil
+	case "sum", "mean", "min", "max":
+		nums := []float64{}
+		for _, v := range values {
+			if v == nil {
+				continue
+			}
+			if _, isString := v.(string); isString {
+				continue
+			}
+			if f, ok := toFloat(v); ok && !math.IsNaN(f) {
+				nums = append(nums, f)
+			}
+		}
+		if len(nums) == 0 {
+			if agg == "sum" {
+				return 0.0, nil
+			}
+			return nil, nil
+		}
+
+		sum := 0.0
+		min, max := nums[0], nums[0]
+		for _, f := range nums {
+			sum += f
+			min = math.Min(min, f)
+			max = math.Max(max, f)
+		}
+
+		switch agg {
+		case "sum":
+			return sum, nil
+		case "mean":
+			return sum / float64(len(nums)), nil
+		case "min":
+			return min, nil
+		}
+		return max, nil
+	default:
+		return nil, fmt.Errorf("unsupported aggregation '%s'", agg)
+	}
+}
+
+// setColumnLevels records the header labels of a multi-level column
+func (df *DataFrame) setColumnLevels(name string, levels []string) {
+	if df.columnLevels == nil {
+		df.columnLevels = make(map[string][]string)
+	}
+	df.columnLevels[name] = append([]string{}, levels...)
+}
+
+// SetColumnLevels assigns multi-level header labels to an existing column.
+//
+// Parameters:
+//   - name: The name of the column.
+//   - levels: The header labels from the outermost to the innermost level.
+//
+// Returns:
+//   - error: An error if the column does not exist or fewer than 2 labels are given.
+func (df *DataFrame) SetColumnLevels(name string, levels ...string) error {
+	if _, exists := df.Columns[name]; !exists {
+		return fmt.Errorf("column '%s' does not exist", name)
+	}
+	if len(levels) < 2 {
+		return fmt.Errorf("a multi-level header needs at least 2 labels, got %d", len(levels))
+	}
+	df.setColumnLevels(name, levels)
+	return nil
+}
+
+// ColumnLevels returns the header labels of a column. Columns without a multi-level header
+// return a single label, their name.
+//
+// Parameters:
+//   - name: The name of the column.
+//
+// Returns:
+//   - []string: The header labels from the outermost to the innermost level, nil if the column does not exist.
+func (df *DataFrame) ColumnLevels(name string) []string {
+	if _, exists := df.Columns[name]; !exists {
+		return nil
+	}
+	if levels, ok := df.columnLevels[name]; ok {
+		return append([]string{}, levels...)
+	}
+	return []string{name}
+}
+
+// NLevels returns the number of column header levels of the DataFrame.
+func (df *DataFrame) NLevels() int {
+	n := 1
+	for name, levels := range df.columnLevels {
+		if _, exists := df.Columns[name]; exists && len(levels) > n {
+			n = len(levels)
+		}
+	}
+	return n
+}
+
+// SelectLevel returns the columns whose header label at the given level equals label.
+// The selected columns are named by their remaining header labels, so selecting "salary" at level 0
+// of a "salary" × month pivot returns one column per month. Columns without a multi-level header
+// (e.g. the pivot index) are kept as they are.
+//
+// Parameters:
+//   - label: The header label to select.
+//   - level (optional): The header level to match, 0 (outermost) by default.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the selected columns.
+//   - error: An error if no multi-level column matches.
+func (df *DataFrame) SelectLevel(label string, level ...int) (*DataFrame, error) {
+	lvl := 0
+	if len(level) > 0 {
+		lvl = level[0]
+	}
+
+	result := NewDataFrame()
+	matched := 0
+	for _, name := range df.ColumnNames() {
+		col := df.Columns[name]
+		levels, multi := df.columnLevels[name]
+		if !multi {
+			result.Columns[name] = &Column[any]{Name: name, Data: col.Values()}
+			result.order = append(result.order, name)
+			continue
+		}
+		if lvl < 0 || lvl >= len(levels) || levels[lvl] != label {
+			continue
+		}
+
+		remaining := append(append([]string{}, levels[:lvl]...), levels[lvl+1:]...)
+		newName := strings.Join(remaining, levelSeparator)
+		if _, exists := result.Columns[newName]; exists {
+			return nil, fmt.Errorf("column '%s' already exists", newName)
+		}
+		result.Columns[newName] = &Column[any]{Name: newName, Data: col.Values()}
+		result.order = append(result.order, newName)
+		if len(remaining) > 1 {
+			result.setColumnLevels(newName, remaining)
+		}
+		matched++
+	}
+
+	if matched == 0 {
+		return nil, fmt.Errorf("no column has label '%s' at level %d", label, lvl)
+	}
+	return result, nil
+}
+
+// FlattenColumns joins the header labels of every multi-level column into a plain column name,
+// e.g. "salary" × "2024-01" becomes "salary_2024-01" with the default separator.
+//
+// Parameters:
+//   - sep (optional): The separator placed between labels, "_" by default.
+//
+// Returns:
+//   - error: An error if a flattened name collides with an existing column.
+func (df *DataFrame) FlattenColumns(sep ...string) error {
+	separator := "_"
+	if len(sep) > 0 {
+		separator = sep[0]
+	}
+
+	for _, name := range df.ColumnNames() {
+		levels, multi := df.columnLevels[name]
+		if !multi {
+			continue
+		}
+		newName := strings.Join(levels, separator)
+		if newName == name {
+			delete(df.columnLevels, name)
+			continue
+		}
+		if err := df.RenameColumn(name, newName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
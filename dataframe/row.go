@@ -0,0 +1,113 @@
+package dataframe
+
+/*
+
+	This is where safe typed accessors for rows (map[string]any) are defined
+
+*/
+
+import (
+	"fmt"
+	"time"
+)
+
+// Row is a row of a DataFrame, the value of each column by name, as returned by the Row method and
+// passed to Filter conditions and ApplyRows functions.
+type Row = map[string]any
+
+// RowGetTime returns the time.Time stored in a row cell.
+//
+// Parameters:
+//   - row: The row, as returned by Row or passed to Filter conditions.
+//   - column: The name of the column.
+//
+// Returns:
+//   - time.Time: The value, the zero time if ok is false.
+//   - bool: False if the column is missing, nil or not a time.Time.
+func RowGetTime(row map[string]any, column string) (time.Time, bool) {
+	t, ok := row[column].(time.Time)
+	return t, ok
+}
+
+// RowGetFloat returns a numeric row cell as float64, converting any integer or float type.
+//
+// Parameters:
+//   - row: The row, as returned by Row or passed to Filter conditions.
+//   - column: The name of the column.
+//
+// Returns:
+//   - float64: The value, 0 if ok is false.
+//   - bool: False if the column is missing, nil or not numeric. Numeric strings are not converted.
+func RowGetFloat(row map[string]any, column string) (float64, bool) {
+	switch v := row[column].(type) {
+	case nil, string:
+		return 0, false
+	default:
+		return toFloat(v)
+	}
+}
+
+// RowGetInt returns an integer row cell as int. Floats are accepted if they hold a whole number.
+//
+// Parameters:
+//   - row: The row, as returned by Row or passed to Filter conditions.
+//   - column: The name of the column.
+//
+// Returns:
+//   - int: The value, 0 if ok is false.
+//   - bool: False if the column is missing, nil, not numeric or a fractional number.
+func RowGetInt(row map[string]any, column string) (int, bool) {
+	f, ok := RowGetFloat(row, column)
+	if !ok || f != float64(int(f)) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// RowGetString returns the string stored in a row cell.
+//
+// Parameters:
+//   - row: The row, as returned by Row or passed to Filter conditions.
+//   - column: The name of the column.
+//
+// Returns:
+//   - string: The value, an empty string if ok is false.
+//   - bool: False if the column is missing, nil or not a string.
+func RowGetString(row map[string]any, column string) (string, bool) {
+	s, ok := row[column].(string)
+	return s, ok
+}
+
+// RowGetBool returns the bool stored in a row cell.
+//
+// Parameters:
+//   - row: The row, as returned by Row or passed to Filter conditions.
+//   - column: The name of the column.
+//
+// Returns:
+//   - bool: The value, false if ok is false.
+//   - bool: False if the column is missing, nil or not a bool.
+func RowGetBool(row map[string]any, column string) (bool, bool) {
+	b, ok := row[column].(bool)
+	return b, ok
+}
+
+// recoverRow runs fn and converts a panic into an error mentioning the row being processed,
+// so a single bad cell in a user callback does not crash the whole program.
+func recoverRow(index int, fn func()) error {
+	if err := recoverPanic(fn); err != nil {
+		return fmt.Errorf("error processing row %d: %w", index, err)
+	}
+	return nil
+}
+
+// recoverPanic runs fn and converts a panic into an error
+func recoverPanic(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic: %v", r)
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,144 @@
+package dataframe
+
+/*
+
+	This is where random sampling is defined: Sample draws rows with or without replacement and
+	TrainTestSplit splits the rows into a training and a test set, optionally keeping the share
+	of each class of a column. The same seed always gives the same rows.
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// SampleOption is the parameters of the Sample method. Exactly one of N and Frac must be set.
+//
+// Fields:
+//   - N: The number of rows to draw.
+//   - Frac: The fraction of the rows to draw, e.g. 0.1 for 10%, rounded to the nearest number of rows.
+//   - Seed: The seed of the random generator, the same seed gives the same rows.
+//   - Replace: Draws with replacement, so a row can be drawn several times and N or Frac can exceed
+//     the number of rows.
+type SampleOption struct {
+	N       int
+	Frac    float64
+	Seed    int64
+	Replace bool
+}
+
+// newRandom returns the random generator of a seed
+func newRandom(seed int64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), 0))
+}
+
+// Sample draws random rows of the DataFrame.
+//
+// Parameters:
+//   - options: The SampleOption struct, with either N or Frac set.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the drawn rows, in the order they were drawn.
+//   - error: An error if both or neither of N and Frac are set, one is negative, or more rows than
+//     the DataFrame has are drawn without replacement.
+func (df *DataFrame) Sample(options SampleOption) (*DataFrame, error) {
+	if (options.N != 0) == (options.Frac != 0) {
+		return nil, fmt.Errorf("exactly one of N and Frac must be set")
+	}
+	if options.N < 0 || options.Frac < 0 {
+		return nil, fmt.Errorf("the number of rows to draw must not be negative")
+	}
+	nRows := df.Nrows()
+	n := options.N
+	if options.Frac != 0 {
+		n = int(math.Round(options.Frac * float64(nRows)))
+	}
+
+	r := newRandom(options.Seed)
+	if options.Replace {
+		if nRows == 0 && n > 0 {
+			return nil, fmt.Errorf("cannot draw %d rows from an empty DataFrame", n)
+		}
+		positions := make([]int, n)
+		for i := range positions {
+			positions[i] = r.IntN(nRows)
+		}
+		return df.takeRows(positions), nil
+	}
+	if n > nRows {
+		return nil, fmt.Errorf("cannot draw %d rows from %d without replacement", n, nRows)
+	}
+	return df.takeRows(r.Perm(nRows)[:n]), nil
+}
+
+// SplitOption is the parameters we can set to the TrainTestSplit method.
+//
+// Fields:
+//   - Seed: The seed of the random generator, the same seed gives the same split.
+//   - StratifyBy: The column whose classes keep their share in both sets, e.g. the label of a
+//     classification. Each class puts its fraction of the rows in the test set.
+type SplitOption struct {
+	Seed       int64
+	StratifyBy string
+}
+
+// TrainTestSplit splits the rows of the DataFrame at random into a training and a test set.
+//
+// Parameters:
+//   - testFrac: The fraction of the rows in the test set, between 0 and 1, rounded to the nearest
+//     number of rows (of each class when stratifying).
+//   - options (optional): The SplitOption struct with the seed and the column to stratify by.
+//
+// Returns:
+//   - *DataFrame: The training set, its rows in their original order.
+//   - *DataFrame: The test set, its rows in their original order.
+//   - error: An error if testFrac is not between 0 and 1 or the column to stratify by does not exist.
+func (df *DataFrame) TrainTestSplit(testFrac float64, options ...SplitOption) (*DataFrame, *DataFrame, error) {
+	var opts SplitOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if testFrac <= 0 || testFrac >= 1 {
+		return nil, nil, fmt.Errorf("test fraction must be between 0 and 1, got %v", testFrac)
+	}
+
+	// the rows of each class, in order of first appearance, all rows are one class without stratification
+	var classes [][]int
+	if opts.StratifyBy == "" {
+		classes = [][]int{make([]int, df.Nrows())}
+		for i := range classes[0] {
+			classes[0][i] = i
+		}
+	} else {
+		col, exists := df.Columns[opts.StratifyBy]
+		if !exists {
+			return nil, nil, fmt.Errorf("column '%s' does not exist", opts.StratifyBy)
+		}
+		index := make(map[any]int)
+		for i, v := range col.Values() {
+			key := labelKey(v)
+			c, seen := index[key]
+			if !seen {
+				c = len(classes)
+				index[key] = c
+				classes = append(classes, nil)
+			}
+			classes[c] = append(classes[c], i)
+		}
+	}
+
+	r := newRandom(opts.Seed)
+	var train, test []int
+	for _, rows := range classes {
+		r.Shuffle(len(rows), func(i, j int) { rows[i], rows[j] = rows[j], rows[i] })
+		n := int(math.Round(testFrac * float64(len(rows))))
+		test = append(test, rows[:n]...)
+		train = append(train, rows[n:]...)
+	}
+	slices.Sort(train)
+	slices.Sort(test)
+	return df.takeRows(train), df.takeRows(test), nil
+}
@@ -8,16 +8,32 @@ package dataframe
 import (
 	"fmt"
 	"math"
+	"reflect"
+	"runtime"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"weak"
 )
 
 // Series represents a single column of data with a name and type.
 // It provides methods for accessing and manipulating the data.
+//
+// Fields:
+//   - Name: The name of the series.
+//   - Data: The values of the series.
 type Series struct {
 	Name string
 	Data []any
 }
 
+// seriesErrors maps the series returned by failed chained operations to their error. The keys are
+// weak pointers removed when their series is collected, so that Series keeps its two fields.
+var seriesErrors sync.Map // weak.Pointer[Series] -> error
+
 // NewSeries creates a new Series with the given name and data.
 //
 // Parameters:
@@ -33,6 +49,26 @@ func NewSeries(name string, data []any) *Series {
 	}
 }
 
+// Err returns the error of the chained operation that produced the series (see Add or Gt).
+//
+// Returns:
+//   - error: The first error of the chain, nil if every operation succeeded.
+func (s *Series) Err() error {
+	if err, ok := seriesErrors.Load(weak.Make(s)); ok {
+		return err.(error)
+	}
+	return nil
+}
+
+// failedSeries returns an empty series carrying the error of a chained operation, see Err
+func failedSeries(name string, err error) *Series {
+	s := &Series{Name: name}
+	key := weak.Make(s)
+	seriesErrors.Store(key, err)
+	runtime.AddCleanup(s, func(key weak.Pointer[Series]) { seriesErrors.Delete(key) }, key)
+	return s
+}
+
 // Len returns the length of the series.
 //
 // Returns:
@@ -86,12 +122,192 @@ func (s *Series) AsFloat64() ([]float64, error) {
 	return result, nil
 }
 
+// BoolOption configures which strings are recognized as booleans. Matching ignores case and surrounding spaces.
+//
+// Fields:
+//   - TrueValues: The strings read as true. Defaults to "true", "yes" and "1".
+//   - FalseValues: The strings read as false. Defaults to "false", "no" and "0".
+type BoolOption struct {
+	TrueValues  []string
+	FalseValues []string
+}
+
+// parser returns a function reporting the boolean value of a string and whether it is recognized
+func (o BoolOption) parser() func(string) (bool, bool) {
+	trueValues, falseValues := o.TrueValues, o.FalseValues
+	if len(trueValues) == 0 {
+		trueValues = []string{"true", "yes", "1"}
+	}
+	if len(falseValues) == 0 {
+		falseValues = []string{"false", "no", "0"}
+	}
+
+	lookup := make(map[string]bool, len(trueValues)+len(falseValues))
+	for _, v := range falseValues {
+		lookup[strings.ToLower(strings.TrimSpace(v))] = false
+	}
+	for _, v := range trueValues {
+		lookup[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	return func(s string) (bool, bool) {
+		b, ok := lookup[strings.ToLower(strings.TrimSpace(s))]
+		return b, ok
+	}
+}
+
+// AsBool converts the series to boolean values. Strings are matched against the recognized boolean
+// values (see BoolOption), numbers 1 and 0 become true and false, nil values and empty strings become nil.
+//
+// Parameters:
+//   - options (optional): The BoolOption struct to configure the recognized strings.
+//
+// Returns:
+//   - *Series: A new series holding bool values and nils.
+//   - error: An error if any value cannot be converted.
+func (s *Series) AsBool(options ...BoolOption) (*Series, error) {
+	var opts BoolOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	parseBool := opts.parser()
+
+	result := make([]any, len(s.Data))
+	for i, v := range s.Data {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case bool:
+			result[i] = val
+		case string:
+			if strings.TrimSpace(val) == "" {
+				continue
+			}
+			b, ok := parseBool(val)
+			if !ok {
+				return nil, fmt.Errorf("cannot convert %q at index %d to bool", val, i)
+			}
+			result[i] = b
+		default:
+			f, ok := toFloat(val)
+			if !ok || (f != 0 && f != 1) {
+				return nil, fmt.Errorf("cannot convert %v of type %T at index %d to bool", val, val, i)
+			}
+			result[i] = f == 1
+		}
+	}
+	return NewSeries(s.Name, result), nil
+}
+
+// NumberOption configures how numbers written as strings are parsed, e.g. "1.234,56", "$1,234" or "45%".
+// The zero value parses plain numbers, including scientific notation ("1.5e3").
+//
+// Fields:
+//   - DecimalSeparator: The decimal separator. Defaults to '.'.
+//   - ThousandsSeparator: The digit grouping separator removed before parsing, e.g. ',' or '.'. Defaults to none.
+//   - CurrencySymbols: The currency symbols removed before parsing, e.g. "$" or "€".
+//   - Percent: Parses values with a trailing "%" as fractions, "45%" becomes 0.45.
+type NumberOption struct {
+	DecimalSeparator   rune
+	ThousandsSeparator rune
+	CurrencySymbols    []string
+	Percent            bool
+}
+
+// validate checks that the separators can be told apart
+func (o NumberOption) validate() error {
+	decimal := o.DecimalSeparator
+	if decimal == 0 {
+		decimal = '.'
+	}
+	if decimal == o.ThousandsSeparator {
+		return fmt.Errorf("decimal and thousands separators must differ, both are %q", decimal)
+	}
+	return nil
+}
+
+// parse converts a string to float64, it reports false if the string is not a number in the configured locale
+func (o NumberOption) parse(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	if o.Percent && strings.HasSuffix(s, "%") {
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+		scale = 0.01
+	}
+	for _, symbol := range o.CurrencySymbols {
+		if symbol != "" && strings.Contains(s, symbol) {
+			s = strings.TrimSpace(strings.Replace(s, symbol, "", 1))
+			break
+		}
+	}
+	if o.ThousandsSeparator != 0 {
+		s = strings.ReplaceAll(s, string(o.ThousandsSeparator), "")
+	}
+	if o.DecimalSeparator != 0 && o.DecimalSeparator != '.' {
+		if strings.Contains(s, ".") {
+			return 0, false
+		}
+		s = strings.Replace(s, string(o.DecimalSeparator), ".", 1)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f * scale, true
+}
+
+// AsNumeric converts the series to float64 values. Strings are parsed in the configured locale
+// (see NumberOption), numbers are converted, nil values and empty strings become nil.
+//
+// Parameters:
+//   - options (optional): The NumberOption struct to configure separators, currency symbols and percentages.
+//
+// Returns:
+//   - *Series: A new series holding float64 values and nils.
+//   - error: An error if the options are invalid or any value cannot be converted.
+func (s *Series) AsNumeric(options ...NumberOption) (*Series, error) {
+	var opts NumberOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	result := make([]any, len(s.Data))
+	for i, v := range s.Data {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string:
+			if strings.TrimSpace(val) == "" {
+				continue
+			}
+			f, ok := opts.parse(val)
+			if !ok {
+				return nil, fmt.Errorf("cannot convert %q at index %d to float64", val, i)
+			}
+			result[i] = f
+		default:
+			f, ok := toFloat(val)
+			if !ok {
+				return nil, fmt.Errorf("cannot convert %v of type %T at index %d to float64", val, val, i)
+			}
+			result[i] = f
+		}
+	}
+	return NewSeries(s.Name, result), nil
+}
+
 // Mean calculates the mean of numeric values in the series.
 //
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
 // Returns:
-//   - float64: The mean of the numeric values.
+//   - float64: The mean of the numeric values, NaN if every value is NaN.
 //   - error: An error if the series is empty or contains non-numeric values.
-func (s *Series) Mean() (float64, error) {
+func (s *Series) Mean(options ...AggOption) (float64, error) {
 	nums, err := s.AsFloat64()
 	if err != nil {
 		return 0, err
@@ -99,38 +315,34 @@ func (s *Series) Mean() (float64, error) {
 	if len(nums) == 0 {
 		return 0, fmt.Errorf("empty series")
 	}
-
-	sum := 0.0
-	for _, v := range nums {
-		sum += v
-	}
-	return sum / float64(len(nums)), nil
+	return floatMean(nums, keepNaN(options)), nil
 }
 
 // Sum calculates the sum of numeric values in the series.
 //
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
 // Returns:
 //   - float64: The sum of the numeric values.
 //   - error: An error if the series contains non-numeric values.
-func (s *Series) Sum() (float64, error) {
+func (s *Series) Sum(options ...AggOption) (float64, error) {
 	nums, err := s.AsFloat64()
 	if err != nil {
 		return 0, err
 	}
-
-	sum := 0.0
-	for _, v := range nums {
-		sum += v
-	}
-	return sum, nil
+	return floatSum(nums, keepNaN(options)), nil
 }
 
 // Min finds the minimum value in the series.
 //
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
 // Returns:
-//   - float64: The minimum value.
+//   - float64: The minimum value, NaN if every value is NaN.
 //   - error: An error if the series is empty or contains non-numeric values.
-func (s *Series) Min() (float64, error) {
+func (s *Series) Min(options ...AggOption) (float64, error) {
 	nums, err := s.AsFloat64()
 	if err != nil {
 		return 0, err
@@ -138,22 +350,18 @@ func (s *Series) Min() (float64, error) {
 	if len(nums) == 0 {
 		return 0, fmt.Errorf("empty series")
 	}
-
-	min := nums[0]
-	for _, v := range nums[1:] {
-		if v < min {
-			min = v
-		}
-	}
-	return min, nil
+	return floatMin(nums, keepNaN(options)), nil
 }
 
 // Max finds the maximum value in the series.
 //
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
 // Returns:
-//   - float64: The maximum value.
+//   - float64: The maximum value, NaN if every value is NaN.
 //   - error: An error if the series is empty or contains non-numeric values.
-func (s *Series) Max() (float64, error) {
+func (s *Series) Max(options ...AggOption) (float64, error) {
 	nums, err := s.AsFloat64()
 	if err != nil {
 		return 0, err
@@ -161,12 +369,437 @@ func (s *Series) Max() (float64, error) {
 	if len(nums) == 0 {
 		return 0, fmt.Errorf("empty series")
 	}
+	return floatMax(nums, keepNaN(options)), nil
+}
+
+// numbers returns the numeric values of the series without its nil values
+func (s *Series) numbers() ([]float64, error) {
+	present := make([]any, 0, len(s.Data))
+	for _, v := range s.Data {
+		if v != nil {
+			present = append(present, v)
+		}
+	}
+	return (&Series{Data: present}).AsFloat64()
+}
+
+// floatStat applies a statistic to the numeric values of the series, skipping nil values
+func (s *Series) floatStat(stat func([]float64) float64) (float64, error) {
+	if len(s.Data) == 0 {
+		return 0, fmt.Errorf("empty series")
+	}
+	nums, err := s.numbers()
+	if err != nil {
+		return 0, err
+	}
+	return stat(nums), nil
+}
+
+// Median finds the median of the numeric values in the series. Nil values are skipped.
+//
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
+// Returns:
+//   - float64: The median, the mean of the two middle values for an even count. NaN if there is no value.
+//   - error: An error if the series is empty or contains non-numeric values.
+func (s *Series) Median(options ...AggOption) (float64, error) {
+	return s.floatStat(func(nums []float64) float64 { return floatMedian(nums, keepNaN(options)) })
+}
+
+// Var calculates the variance of the numeric values in the series. Nil values are skipped.
+//
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set. The
+//     sample variance (n-1 denominator) is returned unless Population is set.
+//
+// Returns:
+//   - float64: The variance, NaN with fewer than two values (one for the population variance).
+//   - error: An error if the series is empty or contains non-numeric values.
+func (s *Series) Var(options ...AggOption) (float64, error) {
+	return s.floatStat(func(nums []float64) float64 { return floatVariance(nums, keepNaN(options), ddof(options)) })
+}
+
+// Std calculates the standard deviation of the numeric values in the series. Nil values are skipped.
+//
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set. The
+//     sample standard deviation is returned unless Population is set.
+//
+// Returns:
+//   - float64: The standard deviation, NaN with fewer than two values (one for the population standard deviation).
+//   - error: An error if the series is empty or contains non-numeric values.
+func (s *Series) Std(options ...AggOption) (float64, error) {
+	return s.floatStat(func(nums []float64) float64 {
+		return math.Sqrt(floatVariance(nums, keepNaN(options), ddof(options)))
+	})
+}
+
+// Quantile finds the q quantile of the numeric values in the series, interpolating linearly between
+// the closest values like pandas. Nil values are skipped.
+//
+// Parameters:
+//   - q: The quantile, between 0 and 1, e.g. 0.9 for the 90th percentile.
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
+// Returns:
+//   - float64: The quantile, NaN if there is no value.
+//   - error: An error if q is outside [0, 1], the series is empty or contains non-numeric values.
+func (s *Series) Quantile(q float64, options ...AggOption) (float64, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, fmt.Errorf("quantile %v is outside [0, 1]", q)
+	}
+	return s.floatStat(func(nums []float64) float64 { return floatQuantileOf(nums, keepNaN(options), q) })
+}
+
+// Skew calculates the unbiased skewness of the numeric values in the series, like pandas. Nil values are skipped.
+//
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
+// Returns:
+//   - float64: The skewness, NaN with fewer than three values and 0 if every value is equal.
+//   - error: An error if the series is empty or contains non-numeric values.
+func (s *Series) Skew(options ...AggOption) (float64, error) {
+	return s.floatStat(func(nums []float64) float64 { return floatSkew(nums, keepNaN(options)) })
+}
+
+// Kurtosis calculates the unbiased excess kurtosis of the numeric values in the series (0 for a
+// normal distribution), like pandas. Nil values are skipped.
+//
+// Parameters:
+//   - options (optional): The AggOption struct, NaN values are skipped unless KeepNaN is set.
+//
+// Returns:
+//   - float64: The kurtosis, NaN with fewer than four values and 0 if every value is equal.
+//   - error: An error if the series is empty or contains non-numeric values.
+func (s *Series) Kurtosis(options ...AggOption) (float64, error) {
+	return s.floatStat(func(nums []float64) float64 { return floatKurtosis(nums, keepNaN(options)) })
+}
+
+// Mode returns the most frequent values of the series. Nil and NaN values are skipped and
+// numeric values are counted regardless of their type, so 1 and 1.0 are the same value.
+//
+// Returns:
+//   - []any: The values with the highest count in ascending order, several when they are tied.
+//     Empty if there is no value.
+func (s *Series) Mode() []any {
+	unique, counts := countValues(s.Data, false)
+	best := slices.Max(append(counts, 0))
+	modes := []any{}
+	for i, count := range counts {
+		if count == best {
+			modes = append(modes, unique[i])
+		}
+	}
+	sort.SliceStable(modes, func(i, j int) bool {
+		if c, ok := compareValues(modes[i], modes[j]); ok {
+			return c < 0
+		}
+		return fmt.Sprintf("%T%v", modes[i], modes[i]) < fmt.Sprintf("%T%v", modes[j], modes[j])
+	})
+	return modes
+}
+
+// UniqueOption configures Unique, NUnique and ValueCounts.
+//
+// Fields:
+//   - KeepNil: Counts the nil values, and the NaN values, as values instead of skipping them.
+type UniqueOption struct {
+	KeepNil bool
+}
+
+// keepNil reports whether the unique options ask to count nil values
+func keepNil(options []UniqueOption) bool {
+	return len(options) > 0 && options[0].KeepNil
+}
+
+// Unique returns the distinct values of the series in order of first appearance. Numeric values
+// are compared regardless of their type, so 1 and 1.0 are the same value.
+//
+// Parameters:
+//   - options (optional): The UniqueOption struct, nil and NaN values are skipped unless KeepNil is set.
+//
+// Returns:
+//   - []any: The distinct values, the first occurrence of each.
+func (s *Series) Unique(options ...UniqueOption) []any {
+	unique, _ := countValues(s.Data, keepNil(options))
+	return unique
+}
+
+// NUnique returns the number of distinct values of the series, see Unique.
+func (s *Series) NUnique(options ...UniqueOption) int {
+	unique, _ := countValues(s.Data, keepNil(options))
+	return len(unique)
+}
+
+// ValueCounts counts the occurrences of each distinct value of the series, see Unique.
+//
+// Parameters:
+//   - options (optional): The UniqueOption struct, nil and NaN values are skipped unless KeepNil is set.
+//
+// Returns:
+//   - *DataFrame: A DataFrame with the values in a column named after the series ("value" if it has
+//     no name) and their counts in a "count" column, by descending count. Tied values keep their order
+//     of first appearance.
+func (s *Series) ValueCounts(options ...UniqueOption) *DataFrame {
+	unique, counts := countValues(s.Data, keepNil(options))
+	order := make([]int, len(unique))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return counts[order[i]] > counts[order[j]] })
+
+	name := s.Name
+	if name == "" || name == "count" {
+		name = "value"
+	}
+	values := make([]any, len(order))
+	frequencies := make([]any, len(order))
+	for i, position := range order {
+		values[i] = unique[position]
+		frequencies[i] = counts[position]
+	}
+	return fromColumnData([]string{name, "count"}, [][]any{values, frequencies})
+}
+
+// Unique returns the distinct values of the column in order of first appearance, see Series.Unique.
+func (c *Column[T]) Unique(options ...UniqueOption) []T {
+	unique, _ := countValues(c.Values(), keepNil(options))
+	return unique
+}
+
+// NUnique returns the number of distinct values of the column, see Series.Unique.
+func (c *Column[T]) NUnique(options ...UniqueOption) int {
+	unique, _ := countValues(c.Values(), keepNil(options))
+	return len(unique)
+}
+
+// countValues returns the distinct values in order of first appearance and their counts.
+// Nil and NaN values are skipped unless keepNil is set.
+func countValues[T any](values []T, keepNil bool) ([]T, []int) {
+	positions := make(map[any]int)
+	var unique []T
+	var counts []int
+	for _, v := range values {
+		boxed := any(v)
+		if !keepNil && (boxed == nil || isNaNValue(boxed)) {
+			continue
+		}
+		key := valueKey(boxed)
+		if i, seen := positions[key]; seen {
+			counts[i]++
+			continue
+		}
+		positions[key] = len(unique)
+		unique = append(unique, v)
+		counts = append(counts, 1)
+	}
+	return unique, counts
+}
+
+// nanKey is the map key of NaN values, which are not equal to themselves
+type nanKey struct{}
+
+// valueKey returns the map key counting a value: numbers regardless of their type, NaN values
+// together and values that cannot be map keys by their printed form
+func valueKey(v any) any {
+	if isNaNValue(v) {
+		return nanKey{}
+	}
+	key := labelKey(v)
+	if !isComparable(key) {
+		return fmt.Sprintf("%T:%#v", v, v)
+	}
+	return key
+}
+
+// Between returns a boolean mask that is true where the value lies between lo and hi (both inclusive).
+// Numbers are compared numerically, time.Time values chronologically and strings lexically.
+//
+// Parameters:
+//   - lo: The lower bound.
+//   - hi: The upper bound.
+//
+// Returns:
+//   - *Series: A boolean Series with the same length, nil and incomparable values give false.
+func (s *Series) Between(lo, hi any) *Series {
+	mask := make([]any, len(s.Data))
+	for i, v := range s.Data {
+		cmpLo, okLo := compareValues(v, lo)
+		cmpHi, okHi := compareValues(v, hi)
+		mask[i] = okLo && okHi && cmpLo >= 0 && cmpHi <= 0
+	}
+	return NewSeries(s.Name, mask)
+}
 
-	max := nums[0]
-	for _, v := range nums[1:] {
-		if v > max || math.IsNaN(max) {
-			max = v
+// IsIn returns a boolean mask that is true where the value is one of the given values.
+// Numeric values match regardless of their type, so 1 matches 1.0.
+//
+// Parameters:
+//   - values: The values to look for.
+//
+// Returns:
+//   - *Series: A boolean Series with the same length.
+func (s *Series) IsIn(values ...any) *Series {
+	lookup := make(map[any]bool, len(values))
+	for _, v := range values {
+		if key := labelKey(v); isComparable(key) {
+			lookup[key] = true
 		}
 	}
-	return max, nil
+
+	mask := make([]any, len(s.Data))
+	for i, v := range s.Data {
+		key := labelKey(v)
+		mask[i] = isComparable(key) && lookup[key]
+	}
+	return NewSeries(s.Name, mask)
+}
+
+// Shift moves the values of the series by a number of rows, e.g. yesterday's value next to today's
+// with Shift(1). Unlike DataFrame.Shift it returns a new column, to add with DataFrame.WithColumn.
+//
+// Parameters:
+//   - periods: The number of rows, negative to move the values up.
+//   - fill (optional): The value of the rows left without a value. Defaults to nil.
+//
+// Returns:
+//   - *Series: The shifted values, with the same length.
+func (s *Series) Shift(periods int, fill ...any) *Series {
+	if err := s.Err(); err != nil {
+		return failedSeries(s.Name, err)
+	}
+	var fillValue any
+	if len(fill) > 0 {
+		fillValue = fill[0]
+	}
+	shifted := make([]any, len(s.Data))
+	for i := range shifted {
+		if from := i - periods; from >= 0 && from < len(s.Data) {
+			shifted[i] = s.Data[from]
+		} else {
+			shifted[i] = fillValue
+		}
+	}
+	return &Series{Name: s.Name, Data: shifted}
+}
+
+// Lag returns the value of the row a number of rows before each row, see Shift.
+func (s *Series) Lag(periods int, fill ...any) *Series {
+	return s.Shift(periods, fill...)
+}
+
+// Lead returns the value of the row a number of rows after each row, see Shift.
+func (s *Series) Lead(periods int, fill ...any) *Series {
+	return s.Shift(-periods, fill...)
+}
+
+// Diff returns the difference between each value and the value a number of rows before, e.g. the
+// day-over-day change with Diff(1). Numbers give float64 differences and time.Time values give
+// time.Duration differences. Rows without an earlier row or with a missing value (nil or NaN) give nil.
+//
+// Parameters:
+//   - periods: The number of rows between the compared values, negative to compare with a later row.
+//
+// Returns:
+//   - *Series: The differences, Err returns an error if a value is neither a number nor a time.Time.
+func (s *Series) Diff(periods int) *Series {
+	return s.change(periods, func(current, previous float64) float64 { return current - previous }, true)
+}
+
+// PctChange returns the relative change between each value and the value a number of rows before, e.g.
+// 0.1 for a 10% increase. Rows without an earlier row or with a missing value (nil or NaN) give nil, a
+// change from 0 gives an infinite value (NaN from 0 to 0).
+//
+// Parameters:
+//   - periods: The number of rows between the compared values, negative to compare with a later row.
+//
+// Returns:
+//   - *Series: The float64 changes, Err returns an error if a value is not numeric.
+func (s *Series) PctChange(periods int) *Series {
+	return s.change(periods, func(current, previous float64) float64 { return (current - previous) / previous }, false)
+}
+
+// change applies fn to each value and the value periods rows before, times give durations when allowed
+func (s *Series) change(periods int, fn func(current, previous float64) float64, times bool) *Series {
+	if err := s.Err(); err != nil {
+		return failedSeries(s.Name, err)
+	}
+	for i, v := range s.Data {
+		if isMissing(v) {
+			continue
+		}
+		if _, ok := toFloat(v); ok {
+			continue
+		}
+		if _, ok := v.(time.Time); ok && times {
+			continue
+		}
+		return failedSeries(s.Name, fmt.Errorf("value '%v' at row %d in column '%s' is not numeric", v, i, s.Name))
+	}
+
+	result := make([]any, len(s.Data))
+	for i, v := range s.Data {
+		from := i - periods
+		if from < 0 || from >= len(s.Data) || isMissing(v) || isMissing(s.Data[from]) {
+			continue
+		}
+		if t, ok := v.(time.Time); ok {
+			if previous, ok := s.Data[from].(time.Time); ok {
+				result[i] = t.Sub(previous)
+			}
+			continue
+		}
+		current, _ := toFloat(v)
+		previous, ok := toFloat(s.Data[from])
+		if ok {
+			result[i] = fn(current, previous)
+		}
+	}
+	return NewSeries(s.Name, result)
+}
+
+// isComparable reports whether a value can be used as a map key
+func isComparable(v any) bool {
+	return v == nil || reflect.TypeOf(v).Comparable()
+}
+
+// compareValues compares two values, returning -1, 0 or 1.
+// Numbers are compared numerically, time.Time values chronologically and strings lexically.
+// The boolean is false if the values cannot be compared (nil or mismatched types).
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return ta.Compare(tb), true
+	}
+
+	sa, aIsString := a.(string)
+	sb, bIsString := b.(string)
+	if aIsString && bIsString {
+		return strings.Compare(sa, sb), true
+	}
+	if aIsString || bIsString {
+		return 0, false
+	}
+
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if !okA || !okB || math.IsNaN(fa) || math.IsNaN(fb) {
+		return 0, false
+	}
+	switch {
+	case fa < fb:
+		return -1, true
+	case fa > fb:
+		return 1, true
+	}
+	return 0, true
 }
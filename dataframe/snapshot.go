@@ -0,0 +1,319 @@
+package dataframe
+
+/*
+
+	This is where binary snapshot (Save/Load) methods for the DataFrame struct are defined. A snapshot
+	keeps the values, the column order, the index, the multi-level column headers and whether the
+	storage is typed. Compressed columns are saved decoded and loaded uncompressed.
+
+*/
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// snapshotMagic identifies a goframe snapshot file
+const snapshotMagic = "GFSNAP"
+
+// snapshotVersion is the current version of the snapshot format
+const snapshotVersion byte = 1
+
+const (
+	snapshotPlain     byte = 0
+	snapshotEncrypted byte = 1
+)
+
+// defaultKDFIterations is the default number of PBKDF2 iterations used to derive a key from a passphrase
+const defaultKDFIterations = 600000
+
+// maxKDFIterations bounds the iteration count read from a snapshot, so that a crafted file cannot
+// stall LoadReader in the key derivation before the authentication fails
+const maxKDFIterations = 10000000
+
+// SnapshotOption configures how a snapshot is written and read.
+//
+// Fields:
+//   - Passphrase: Encrypts the snapshot with AES-256-GCM using a key derived from the passphrase (PBKDF2-SHA256).
+//   - Key: A raw 32 byte AES-256 key. Takes precedence over Passphrase.
+//   - KDFIterations: The number of PBKDF2 iterations when deriving the key from Passphrase. Defaults to 600000,
+//     at most 10000000. The iteration count is stored in the snapshot header, so it is only needed when saving.
+type SnapshotOption struct {
+	Passphrase    string
+	Key           []byte
+	KDFIterations int
+}
+
+// snapshotColumn is the serialized representation of a column
+type snapshotColumn struct {
+	Name string
+	Data []any
+}
+
+// snapshotPayload is the serialized representation of a DataFrame. Fields added later are zero
+// when decoding an older snapshot.
+type snapshotPayload struct {
+	Columns      []snapshotColumn
+	Index        []string            // see SetIndex and SetMultiIndex
+	ColumnLevels map[string][]string // see SetColumnLevels
+	Typed        bool                // see NewTypedDataFrame
+}
+
+func init() {
+	// gob needs to know every concrete type that can be stored inside a Column[any]
+	gob.Register(int(0))
+	gob.Register(int8(0))
+	gob.Register(int16(0))
+	gob.Register(int32(0))
+	gob.Register(int64(0))
+	gob.Register(uint(0))
+	gob.Register(uint8(0))
+	gob.Register(uint16(0))
+	gob.Register(uint32(0))
+	gob.Register(uint64(0))
+	gob.Register(float32(0))
+	gob.Register(float64(0))
+	gob.Register("")
+	gob.Register(false)
+	gob.Register(time.Time{})
+}
+
+// Save writes the DataFrame to a binary snapshot file. The file is written atomically, through a
+// temporary file renamed over filename, so a failed Save leaves an existing snapshot intact.
+//
+// Parameters:
+//   - filename: The path to the output snapshot file.
+//   - options (optional): The SnapshotOption struct to enable encryption.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func (df *DataFrame) Save(filename string, options ...SnapshotOption) error {
+	return writeFileAtomic(filename, func(writer io.Writer) error {
+		return df.SaveWriter(writer, options...)
+	})
+}
+
+// SaveWriter writes the DataFrame as a binary snapshot to a writer.
+//
+// Parameters:
+//   - writer: An io.Writer for the snapshot data.
+//   - options (optional): The SnapshotOption struct to enable encryption.
+//
+// Returns:
+//   - error: An error if the data cannot be written or KDFIterations is above 10000000.
+func (df *DataFrame) SaveWriter(writer io.Writer, options ...SnapshotOption) error {
+	opts := SnapshotOption{KDFIterations: defaultKDFIterations}
+	if len(options) > 0 {
+		userOpt := options[0]
+		opts.Passphrase = userOpt.Passphrase
+		opts.Key = userOpt.Key
+		if userOpt.KDFIterations > 0 {
+			opts.KDFIterations = userOpt.KDFIterations
+		}
+	}
+	if opts.KDFIterations > maxKDFIterations {
+		return fmt.Errorf("key derivation iterations must be at most %d, got %d", maxKDFIterations, opts.KDFIterations)
+	}
+
+	payload := snapshotPayload{Index: df.indexNames, ColumnLevels: df.columnLevels, Typed: df.typed}
+	for _, name := range df.ColumnNames() {
+		payload.Columns = append(payload.Columns, snapshotColumn{Name: name, Data: df.Columns[name].Values()})
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(payload); err != nil {
+		return fmt.Errorf("error encoding snapshot: %w", err)
+	}
+
+	header := []byte(snapshotMagic)
+	header = append(header, snapshotVersion)
+
+	if opts.Key == nil && opts.Passphrase == "" {
+		header = append(header, snapshotPlain)
+		if _, err := writer.Write(header); err != nil {
+			return fmt.Errorf("error writing snapshot header: %w", err)
+		}
+		if _, err := writer.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("error writing snapshot: %w", err)
+		}
+		return nil
+	}
+
+	// Encrypted layout: header | iterations (uint32) | salt (16) | nonce (12) | ciphertext
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("error generating salt: %w", err)
+	}
+	header = append(header, snapshotEncrypted)
+	header = binary.BigEndian.AppendUint32(header, uint32(opts.KDFIterations))
+	header = append(header, salt...)
+
+	gcm, err := snapshotCipher(opts, salt, opts.KDFIterations)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	// the header is authenticated so it cannot be tampered with
+	ciphertext := gcm.Seal(nil, nonce, buf.Bytes(), header)
+
+	if _, err := writer.Write(header); err != nil {
+		return fmt.Errorf("error writing snapshot header: %w", err)
+	}
+	if _, err := writer.Write(nonce); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	if _, err := writer.Write(ciphertext); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads a DataFrame from a binary snapshot file.
+//
+// Parameters:
+//   - filename: The path to the snapshot file.
+//   - options (optional): The SnapshotOption struct holding the passphrase or key of an encrypted snapshot.
+//
+// Returns:
+//   - *DataFrame: The loaded DataFrame.
+//   - error: An error if the file cannot be read or decrypted.
+func Load(filename string, options ...SnapshotOption) (*DataFrame, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	return LoadReader(file, options...)
+}
+
+// LoadReader reads a DataFrame from a binary snapshot reader.
+//
+// Parameters:
+//   - reader: An io.Reader for the snapshot data.
+//   - options (optional): The SnapshotOption struct holding the passphrase or key of an encrypted snapshot.
+//
+// Returns:
+//   - *DataFrame: The loaded DataFrame.
+//   - error: An error if the data cannot be read or decrypted.
+func LoadReader(reader io.Reader, options ...SnapshotOption) (*DataFrame, error) {
+	opts := SnapshotOption{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+
+	headerLen := len(snapshotMagic) + 2
+	if len(data) < headerLen || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("error reading snapshot: not a goframe snapshot")
+	}
+	if version := data[len(snapshotMagic)]; version != snapshotVersion {
+		return nil, fmt.Errorf("error reading snapshot: unsupported version %d", version)
+	}
+
+	body := data[headerLen:]
+	switch data[headerLen-1] {
+	case snapshotPlain:
+		// nothing to decrypt
+	case snapshotEncrypted:
+		if opts.Key == nil && opts.Passphrase == "" {
+			return nil, fmt.Errorf("error reading snapshot: snapshot is encrypted but no passphrase or key was provided")
+		}
+		if len(body) < 4+16 {
+			return nil, fmt.Errorf("error reading snapshot: truncated encryption header")
+		}
+		iterations := int(binary.BigEndian.Uint32(body[:4]))
+		if iterations < 1 || iterations > maxKDFIterations {
+			return nil, fmt.Errorf("error reading snapshot: invalid key derivation iterations %d (must be between 1 and %d)", iterations, maxKDFIterations)
+		}
+		salt := body[4:20]
+		header := data[:headerLen+20]
+
+		gcm, err := snapshotCipher(opts, salt, iterations)
+		if err != nil {
+			return nil, err
+		}
+		rest := body[20:]
+		if len(rest) < gcm.NonceSize() {
+			return nil, fmt.Errorf("error reading snapshot: truncated nonce")
+		}
+		nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+		body, err = gcm.Open(nil, nonce, ciphertext, header)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting snapshot (wrong passphrase or key?): %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("error reading snapshot: unknown flag %d", data[headerLen-1])
+	}
+
+	var payload snapshotPayload
+	if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %w", err)
+	}
+
+	df := NewDataFrame()
+	df.typed = payload.Typed
+	for _, col := range payload.Columns {
+		data := col.Data
+		if data == nil {
+			data = []any{}
+		}
+		if err := df.AddColumn(NewColumn(col.Name, data)); err != nil {
+			return nil, err
+		}
+	}
+	for name, levels := range payload.ColumnLevels {
+		if _, exists := df.Columns[name]; exists {
+			df.setColumnLevels(name, levels)
+		}
+	}
+	if len(payload.Index) > 0 {
+		if err := df.SetMultiIndex(payload.Index...); err != nil {
+			return nil, fmt.Errorf("error reading snapshot: %w", err)
+		}
+	}
+	return df, nil
+}
+
+// snapshotCipher builds the AES-256-GCM cipher from a raw key or a passphrase
+func snapshotCipher(opts SnapshotOption, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := opts.Key
+	if key == nil {
+		var err error
+		key, err = pbkdf2.Key(sha256.New, opts.Passphrase, salt, iterations, 32)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving key: %w", err)
+		}
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return gcm, nil
+}
@@ -1,16 +1,32 @@
 package dataframe
 
 import (
+	"container/heap"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
+	"strings"
 )
 
 // DataFrameSorter is a helper structure to implement the sort.Interface.
 // It allows us to use Go's standard library sort function on the DataFrame.
 type DataFrameSorter struct {
-	df        *DataFrame
-	colName   []string
-	ascending bool
+	df         *DataFrame
+	colName    []string
+	ascending  []bool // one direction per column of colName
+	nullsFirst bool
+}
+
+// SortOption is the parameters we can set to the SortValuesWithOption method.
+//
+// Fields:
+//   - Ascending: The direction of each sort column, true for ascending. Empty sorts every column ascending,
+//     a single value applies to every column, otherwise there must be one value per column.
+//   - NullsFirst: Places nil and NaN values first instead of last, whatever the direction.
+type SortOption struct {
+	Ascending  []bool
+	NullsFirst bool
 }
 
 // Len is part of sort.Interface.
@@ -31,75 +47,120 @@ func (s DataFrameSorter) Swap(i, j int) {
 // returning false means that the order is incorrect and should be swapped.
 // Example: if i is less than j, it return true for ascending
 func (s DataFrameSorter) Less(i, j int) bool {
-
-	for _, colName := range s.colName {
-
+	for k, colName := range s.colName {
 		col := s.df.Columns[colName]
-		value1 := col.Data[i]
-		value2 := col.Data[j]
-
-		// check if they are nil value
-		if value1 == nil && value2 == nil {
-			continue // They are equal, move to the next column tie-breaker
+		if c := compareSortValues(col.Data[i], col.Data[j], s.ascending[k], s.nullsFirst); c != 0 {
+			return c < 0
 		}
-		if value1 == nil {
-			// returning false means they are in the wrong order and should be swapped.
-			return false // value1 is "greater" a row lower than value2
-		}
-		if value2 == nil {
-			// returning true means they are in the right order and should stay that way.
-			return true // value1 is "less" (comes first) than value2
-		}
-
-		// try numeric comparison first (using the existing helper function)
-		float1, ok1 := toFloat(value1)
-		float2, ok2 := toFloat(value2)
+	}
+	// if everything is identical, return false
+	return false
+}
 
-		if ok1 && ok2 {
-			// check if they are equal, and if they are, continue to next col
-			if float1 == float2 {
-				continue
-			}
-			if s.ascending {
-				return float1 < float2
-			}
-			return float1 > float2
-		}
+// compareSortValues returns -1 when value1 sorts before value2, 1 when it sorts after and 0 when
+// they are tied on this column
+func compareSortValues(value1, value2 any, ascending, nullsFirst bool) int {
+	// NaN values sort last, like nil values
+	if isNaNValue(value1) {
+		value1 = nil
+	}
+	if isNaNValue(value2) {
+		value2 = nil
+	}
 
-		// fallback to string comparison for non-numeric types
-		string1 := fmt.Sprintf("%v", value1)
-		string2 := fmt.Sprintf("%v", value2)
-		if string1 == string2 {
-			continue
+	// check if they are nil value
+	if value1 == nil && value2 == nil {
+		return 0 // They are equal, move to the next column tie-breaker
+	}
+	if value1 == nil {
+		// value1 is "greater" a row lower than value2, unless nulls come first
+		if nullsFirst {
+			return -1
 		}
-		if s.ascending {
-			return string1 < string2
+		return 1
+	}
+	if value2 == nil {
+		// value1 is "less" (comes first) than value2, unless nulls come first
+		if nullsFirst {
+			return 1
 		}
-		return string1 > string2
+		return -1
 	}
-	// if everything is identical, return false
-	return false
 
+	c := 0
+	// try numeric comparison first (using the existing helper function)
+	float1, ok1 := toFloat(value1)
+	float2, ok2 := toFloat(value2)
+	if ok1 && ok2 {
+		switch {
+		case float1 < float2:
+			c = -1
+		case float1 > float2:
+			c = 1
+		}
+	} else {
+		// fallback to string comparison for non-numeric types
+		c = strings.Compare(fmt.Sprintf("%v", value1), fmt.Sprintf("%v", value2))
+	}
+	if !ascending {
+		return -c
+	}
+	return c
 }
 
 // sort_values is a DataFrame method that sorts the columns and returns the new sorted DataFrame.
+// The sort is stable: rows that compare equal on every column keep their order. Nil and NaN values come last.
 //
 // Parameters:
-//   - by : The column name to sort by.
+//   - by : The column names to sort by, later columns break the ties of earlier ones.
 //   - ascending (optional) : The order of the values to sort by.
 //     True = Ascending,
 //     False = Descending
-//     If it is not declared by user, it will be ascending by default.
+//     If it is not declared by user, it will be ascending by default. A single value applies to every
+//     column, otherwise give one value per column, e.g. SortValues([]string{"dept", "salary"}, true, false).
 //
 // Returns:
 //   - *DataFrame: The sorted DataFrame, returns an empty dataframe if there is an error.
-//   - error: An error if the operation fails.
+//   - error: An error if a column does not exist or the number of directions does not match the columns.
 func (df *DataFrame) SortValues(by []string, ascending ...bool) (*DataFrame, error) {
+	return df.SortValuesWithOption(by, SortOption{Ascending: ascending})
+}
+
+// SortValuesWithOption sorts the DataFrame like SortValues, with per-column directions and the position of nil values.
+//
+// Parameters:
+//   - by: The column names to sort by, later columns break the ties of earlier ones.
+//   - options: The SortOption struct with the directions and NullsFirst.
+//
+// Returns:
+//   - *DataFrame: The sorted DataFrame.
+//   - error: An error if a column does not exist or the number of directions does not match the columns.
+func (df *DataFrame) SortValuesWithOption(by []string, options SortOption) (*DataFrame, error) {
+	for _, name := range by {
+		if _, exists := df.Columns[name]; !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+	}
 
 	// default value is ascending
-	isAscending := true
-	if len(ascending) > 0 {
-		isAscending = ascending[0]
+	directions := make([]bool, len(by))
+	switch len(options.Ascending) {
+	case 0:
+		for i := range directions {
+			directions[i] = true
+		}
+	case 1:
+		for i := range directions {
+			directions[i] = options.Ascending[0]
+		}
+	case len(by):
+		copy(directions, options.Ascending)
+	default:
+		return nil, fmt.Errorf("got %d sort directions for %d columns", len(options.Ascending), len(by))
+	}
+
+	if opts, partitions := spillPartitions(estimateMemory(df, df.ColumnNames())); partitions > 1 {
+		return df.spillSort(by, directions, options.NullsFirst, opts, partitions)
 	}
 
 	// we create a new DataFrame to copy the data into for mutilation
@@ -110,20 +171,174 @@ func (df *DataFrame) SortValues(by []string, ascending ...bool) (*DataFrame, err
 		newCol := &Column[any]{
 			Name: col.Name,
 			// create a brand new slice to copy the data
-			Data: append([]any{}, col.Data...),
+			Data: append([]any{}, col.Values()...),
 		}
 		// directly assign the column to sortedDf
 		sortedDf.Columns[name] = newCol
 	}
+	sortedDf.order = df.ColumnNames()
+	sortedDf.indexNames = df.indexNames
 	dfSorter := DataFrameSorter{
-		df:        sortedDf,
-		colName:   by,
-		ascending: isAscending,
+		df:         sortedDf,
+		colName:    by,
+		ascending:  directions,
+		nullsFirst: options.NullsFirst,
+	}
+
+	sort.Stable(dfSorter)
+
+	return sortedDf, nil
+}
+
+// SortIndex sorts the rows by their index labels (see SetIndex), like SortValues on the index column(s).
+// Without an index the rows are in range index order already, descending order reverses them.
+//
+// Parameters:
+//   - ascending (optional): The order of the labels, ascending by default.
+//
+// Returns:
+//   - *DataFrame: The sorted DataFrame, which keeps the index.
+//   - error: An error if more than one direction is given.
+func (df *DataFrame) SortIndex(ascending ...bool) (*DataFrame, error) {
+	if len(ascending) > 1 {
+		return nil, fmt.Errorf("got %d sort directions for the index", len(ascending))
+	}
+	if names := df.IndexNames(); len(names) > 0 {
+		return df.SortValues(names, ascending...)
+	}
+
+	positions := make([]int, df.Nrows())
+	for i := range positions {
+		positions[i] = i
+		if len(ascending) > 0 && !ascending[0] {
+			positions[i] = len(positions) - 1 - i
+		}
+	}
+	return df.takeRows(positions), nil
+}
+
+// spillSort is the external merge sort of SortValuesWithOption: runs of rows are sorted in memory
+// and spilled, then merged. Ties are resolved in favour of the earlier run, so the sort stays stable.
+func (df *DataFrame) spillSort(by []string, directions []bool, nullsFirst bool, opts SpillOption, partitions int) (*DataFrame, error) {
+	names := df.ColumnNames()
+	n := df.Nrows()
+	runRows := (n + partitions - 1) / partitions
+	runs, err := newSpillFiles(opts, (n+runRows-1)/runRows)
+	if err != nil {
+		return nil, err
+	}
+	defer removeSpillFiles(runs)
+
+	for r, run := range runs {
+		positions := make([]int, 0, runRows)
+		for i := r * runRows; i < min(n, (r+1)*runRows); i++ {
+			positions = append(positions, i)
+		}
+		chunk := df.takeRows(positions)
+		sort.Stable(DataFrameSorter{df: chunk, colName: by, ascending: directions, nullsFirst: nullsFirst})
+		for i := range positions {
+			record := make([]any, len(names))
+			for c, name := range names {
+				record[c] = chunk.Columns[name].Data[i]
+			}
+			if err := run.write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// k-way merge of the runs
+	merge := &sortMerge{directions: directions, nullsFirst: nullsFirst}
+	for _, name := range by {
+		merge.keys = append(merge.keys, slices.Index(names, name))
+	}
+	for r, run := range runs {
+		dec, err := run.reader()
+		if err != nil {
+			return nil, err
+		}
+		head := &sortRun{run: r, dec: dec}
+		if err := head.next(); err != nil {
+			return nil, err
+		}
+		if head.record != nil {
+			merge.runs = append(merge.runs, head)
+		}
 	}
+	heap.Init(merge)
 
-	sort.Sort(dfSorter)
+	data := make([][]any, len(names))
+	for i := range data {
+		data[i] = make([]any, 0, n)
+	}
+	for merge.Len() > 0 {
+		head := merge.runs[0]
+		for c, value := range head.record {
+			data[c] = append(data[c], value)
+		}
+		if err := head.next(); err != nil {
+			return nil, err
+		}
+		if head.record == nil {
+			heap.Pop(merge)
+		} else {
+			heap.Fix(merge, 0)
+		}
+	}
 
+	sortedDf := NewDataFrame()
+	for c, name := range names {
+		sortedDf.Columns[name] = &Column[any]{Name: name, Data: data[c]}
+	}
+	sortedDf.order = names
+	sortedDf.indexNames = df.indexNames
 	return sortedDf, nil
 }
 
-// TODO: sort_index method
+// sortRun is the next row of a spilled run of spillSort
+type sortRun struct {
+	run    int
+	dec    interface{ Decode(e any) error }
+	record []any // nil once the run is exhausted
+}
+
+// next reads the next row of the run
+func (r *sortRun) next() error {
+	var record []any
+	if err := r.dec.Decode(&record); err == io.EOF {
+		r.record = nil
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading spill file: %w", err)
+	}
+	r.record = record
+	return nil
+}
+
+// sortMerge is the heap of the runs of spillSort, ordered by their next row
+type sortMerge struct {
+	runs       []*sortRun
+	keys       []int // positions of the sort columns in a row
+	directions []bool
+	nullsFirst bool
+}
+
+func (m *sortMerge) Len() int      { return len(m.runs) }
+func (m *sortMerge) Swap(i, j int) { m.runs[i], m.runs[j] = m.runs[j], m.runs[i] }
+func (m *sortMerge) Push(x any)    { m.runs = append(m.runs, x.(*sortRun)) }
+
+func (m *sortMerge) Pop() any {
+	last := m.runs[len(m.runs)-1]
+	m.runs = m.runs[:len(m.runs)-1]
+	return last
+}
+
+func (m *sortMerge) Less(i, j int) bool {
+	a, b := m.runs[i], m.runs[j]
+	for k, key := range m.keys {
+		if c := compareSortValues(a.record[key], b.record[key], m.directions[k], m.nullsFirst); c != 0 {
+			return c < 0
+		}
+	}
+	return a.run < b.run
+}
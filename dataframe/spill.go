@@ -0,0 +1,199 @@
+package dataframe
+
+/*
+
+	This is where spilling to disk is defined. When the estimated memory of a sort or groupby
+	exceeds the budget set with SetSpillOption, its intermediate data is written to temporary files
+	in chunks and read back one chunk at a time:
+
+		- SortValues sorts runs of rows that fit the budget, spills them and merges the runs,
+		- Groupby writes the rows to partitions by group and aggregates one partition at a time.
+
+	The input and the result stay in memory, spilling bounds the intermediate copies and per-group
+	rows. Joins do not spill: the pairs of joined rows are as large as their result. An operation
+	opens at most maxSpillFiles files, so above maxSpillFiles times the budget its chunks grow
+	beyond the budget instead of exhausting the file descriptors. Values are written with
+	encoding/gob: the built-in types and time.Time are supported, register other types stored in
+	columns with gob.Register.
+
+*/
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// SpillOption configures spilling to disk, see SetSpillOption.
+//
+// Fields:
+//   - MemoryBudget: The estimated number of bytes a sort or groupby may hold in memory before
+//     spilling to disk. Zero (the default) disables spilling.
+//   - Dir: The directory of the temporary files. Defaults to os.TempDir().
+type SpillOption struct {
+	MemoryBudget int64
+	Dir          string
+}
+
+var (
+	spillMu     sync.RWMutex
+	spillOption SpillOption
+)
+
+// SetSpillOption sets the memory budget above which sorts and groupbys spill their
+// intermediate data to temporary files. It is safe to call concurrently with these operations.
+// A spilled Groupby has a nil Groups field, its rows are read back by the aggregation methods.
+//
+// Parameters:
+//   - opts: The SpillOption struct with the budget and the directory of the temporary files.
+func SetSpillOption(opts SpillOption) {
+	spillMu.Lock()
+	defer spillMu.Unlock()
+	spillOption = opts
+}
+
+// CurrentSpillOption returns the options registered with SetSpillOption.
+func CurrentSpillOption() SpillOption {
+	spillMu.RLock()
+	defer spillMu.RUnlock()
+	return spillOption
+}
+
+// spillPartitions returns the number of chunks an intermediate result of the estimated size is
+// split into to fit the budget, or 0 when it fits in memory or spilling is disabled
+func spillPartitions(estimate int64) (SpillOption, int) {
+	opts := CurrentSpillOption()
+	return opts, spillChunks(opts, estimate)
+}
+
+// maxSpillFiles is the largest number of temporary files an operation keeps open, far below the
+// usual limit of 1024 file descriptors per process
+const maxSpillFiles = 256
+
+// spillChunks returns the number of chunks an intermediate result of the estimated size is split
+// into to fit the budget of opts, at most maxSpillFiles, or 0 when it fits in memory or opts
+// disables spilling
+func spillChunks(opts SpillOption, estimate int64) int {
+	if opts.MemoryBudget <= 0 || estimate <= opts.MemoryBudget {
+		return 0
+	}
+	return int(min((estimate+opts.MemoryBudget-1)/opts.MemoryBudget, maxSpillFiles))
+}
+
+// estimateMemory estimates the bytes held by the values of the given columns, from a sample of
+// each column: 16 bytes per boxed value plus the content of strings and time values
+func estimateMemory(df *DataFrame, columns []string) int64 {
+	const sampleSize = 100
+	n := df.Nrows()
+	if n == 0 {
+		return 0
+	}
+	var total int64
+	for _, name := range columns {
+		col := df.Columns[name]
+		var sampled, bytes int64
+		for i := 0; i < n; i += max(1, n/sampleSize) {
+			value, _ := col.At(i)
+			bytes += 16
+			switch v := value.(type) {
+			case string:
+				bytes += int64(len(v))
+			case time.Time:
+				bytes += 24
+			}
+			sampled++
+		}
+		total += bytes * int64(n) / sampled
+	}
+	return total
+}
+
+// spillFile is a temporary file of gob-encoded records, written then read back sequentially
+type spillFile struct {
+	file   *os.File
+	writer *bufio.Writer
+	enc    *gob.Encoder
+}
+
+// newSpillFile creates a temporary file in the directory of the options
+func newSpillFile(opts SpillOption) (*spillFile, error) {
+	file, err := os.CreateTemp(opts.Dir, "goframe-spill-*")
+	if err != nil {
+		return nil, fmt.Errorf("error creating spill file: %w", err)
+	}
+	writer := bufio.NewWriter(file)
+	return &spillFile{file: file, writer: writer, enc: gob.NewEncoder(writer)}, nil
+}
+
+// write appends a record
+func (s *spillFile) write(record any) error {
+	if err := s.enc.Encode(record); err != nil {
+		return fmt.Errorf("error writing spill file: %w", err)
+	}
+	return nil
+}
+
+// reader flushes the written records and returns a decoder reading them from the start
+func (s *spillFile) reader() (*gob.Decoder, error) {
+	if err := s.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("error writing spill file: %w", err)
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error reading spill file: %w", err)
+	}
+	return gob.NewDecoder(bufio.NewReader(s.file)), nil
+}
+
+// remove closes and deletes the file
+func (s *spillFile) remove() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
+
+// newSpillFiles creates n temporary files, removing them all on error
+func newSpillFiles(opts SpillOption, n int) ([]*spillFile, error) {
+	files := make([]*spillFile, 0, n)
+	for range n {
+		file, err := newSpillFile(opts)
+		if err != nil {
+			removeSpillFiles(files)
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// removeSpillFiles deletes temporary files
+func removeSpillFiles(files []*spillFile) {
+	for _, file := range files {
+		file.remove()
+	}
+}
+
+// readSpillRecords decodes the records of a file until its end
+func readSpillRecords[T any](file *spillFile, fn func(record T) error) error {
+	dec, err := file.reader()
+	if err != nil {
+		return err
+	}
+	for {
+		var record T
+		if err := dec.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading spill file: %w", err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -3,6 +3,7 @@ package dataframe
 import (
 	"database/sql"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strings"
@@ -64,9 +65,9 @@ func (d *SQLiteDialect) Placeholder(index int) string {
 	return "?"
 }
 
-// QuoteIdentifier quotes identifiers with double quotes
+// QuoteIdentifier quotes identifiers with double quotes, embedded quotes are doubled
 func (d *SQLiteDialect) QuoteIdentifier(name string) string {
-	return fmt.Sprintf(`"%s"`, name)
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
 }
 
 // CreateTableSQL generates a CREATE TABLE statement for SQLite
@@ -130,9 +131,9 @@ func (d *PostgresDialect) Placeholder(index int) string {
 	return fmt.Sprintf("$%d", index)
 }
 
-// QuoteIdentifier quotes identifiers with double quotes
+// QuoteIdentifier quotes identifiers with double quotes, embedded quotes are doubled
 func (d *PostgresDialect) QuoteIdentifier(name string) string {
-	return fmt.Sprintf(`"%s"`, name)
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
 }
 
 // CreateTableSQL generates a CREATE TABLE statement for PostgreSQL
@@ -195,9 +196,9 @@ func (d *MySQLDialect) Placeholder(index int) string {
 	return "?"
 }
 
-// QuoteIdentifier quotes identifiers with backticks
+// QuoteIdentifier quotes identifiers with backticks, embedded backticks are doubled
 func (d *MySQLDialect) QuoteIdentifier(name string) string {
-	return fmt.Sprintf("`%s`", name)
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
 }
 
 // CreateTableSQL generates a CREATE TABLE statement for MySQL
@@ -263,6 +264,39 @@ func getDialect(dialectName string, db *sql.DB) (SQLDialect, error) {
 	return detectDialect(db)
 }
 
+// validateTimeTypes checks the temporal types listed in SQLWriteOption.TimeTypes or SQLReadOption.TimeTypes
+func validateTimeTypes(timeTypes map[string]string) error {
+	for colName, kind := range timeTypes {
+		switch kind {
+		case "date", "timestamp", "timestamptz":
+		default:
+			return fmt.Errorf("invalid TimeTypes value '%s' for column '%s' (must be 'date', 'timestamp' or 'timestamptz')", kind, colName)
+		}
+	}
+	return nil
+}
+
+// temporalSQLType returns the column type used by a dialect for a temporal type of SQLWriteOption.TimeTypes
+func temporalSQLType(dialect SQLDialect, kind string) string {
+	switch kind {
+	case "date":
+		return "DATE"
+	case "timestamptz":
+		switch dialect.(type) {
+		case *PostgresDialect:
+			return "TIMESTAMPTZ"
+		case *MySQLDialect:
+			// MySQL TIMESTAMP values are stored in UTC and converted to the session time zone
+			return "TIMESTAMP"
+		}
+		return "TIMESTAMP"
+	}
+	if _, ok := dialect.(*MySQLDialect); ok {
+		return "DATETIME"
+	}
+	return "TIMESTAMP"
+}
+
 // inferGoTypeFromValue infers the Go type from a value, handling nil appropriately
 func inferGoTypeFromValue(value any) reflect.Type {
 	if value == nil {
@@ -275,7 +309,7 @@ func inferGoTypeFromValue(value any) reflect.Type {
 // inferGoTypeFromColumn infers the Go type from a column by examining its values
 func inferGoTypeFromColumn(col *Column[any]) reflect.Type {
 	// Try to find a non-nil value to infer the type
-	for _, value := range col.Data {
+	for _, value := range col.Values() {
 		if value != nil {
 			return reflect.TypeOf(value)
 		}
@@ -305,9 +339,9 @@ func convertGoTypeToSQLNullable(value any) any {
 		val := int64(reflect.ValueOf(v).Convert(reflect.TypeOf(uint64(0))).Uint())
 		return sql.NullInt64{Int64: val, Valid: true}
 	case float32, float64:
-		// Convert all float types to float64
+		// Convert all float types to float64, NaN is stored as NULL
 		val := reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0))).Float()
-		return sql.NullFloat64{Float64: val, Valid: true}
+		return sql.NullFloat64{Float64: val, Valid: !math.IsNaN(val)}
 	case bool:
 		return sql.NullBool{Bool: v, Valid: true}
 	case time.Time:
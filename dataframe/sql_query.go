@@ -0,0 +1,282 @@
+package dataframe
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// QueryBuilder builds a SELECT statement that is rendered for a specific SQL dialect,
+// so identifiers are quoted and placeholders numbered correctly for every database.
+//
+// Example:
+//
+//	query := Table("users").Select("id", "name").Where("age", ">=", 18).OrderBy("name").Limit(10)
+//	sqlText, args, err := query.Build("postgres")
+type QueryBuilder struct {
+	table      string
+	columns    []string
+	conditions []queryCondition
+	orders     []queryOrder
+	limit      int
+	offset     int
+	err        error
+}
+
+// queryCondition is a single WHERE condition, conditions are combined with AND
+type queryCondition struct {
+	column string
+	op     string
+	value  any
+}
+
+// queryOrder is a single ORDER BY term
+type queryOrder struct {
+	column     string
+	descending bool
+}
+
+// supportedOperators lists the comparison operators accepted by QueryBuilder.Where
+var supportedOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "IN": true, "NOT IN": true, "IS NULL": true, "IS NOT NULL": true,
+}
+
+// Table starts a SELECT query on the given table.
+//
+// Parameters:
+//   - name: The name of the table, optionally qualified by a schema ("sales.orders").
+//
+// Returns:
+//   - *QueryBuilder: The query builder, selecting every column until Select is called.
+func Table(name string) *QueryBuilder {
+	q := &QueryBuilder{table: name}
+	if name == "" {
+		q.err = fmt.Errorf("table name cannot be empty")
+	}
+	return q
+}
+
+// Select sets the columns returned by the query.
+func (q *QueryBuilder) Select(columns ...string) *QueryBuilder {
+	q.columns = append(q.columns, columns...)
+	return q
+}
+
+// Where adds a condition to the query. Multiple conditions are combined with AND.
+//
+// Parameters:
+//   - column: The column to compare.
+//   - op: The operator: "=", "!=", "<>", "<", "<=", ">", ">=", "LIKE", "NOT LIKE", "IN", "NOT IN",
+//     "IS NULL" or "IS NOT NULL".
+//   - value (optional): The value to compare with, passed as a query argument. IN and NOT IN take a slice,
+//     IS NULL and IS NOT NULL take no value.
+//
+// Returns:
+//   - *QueryBuilder: The query builder. An invalid condition is reported by Build.
+func (q *QueryBuilder) Where(column, op string, value ...any) *QueryBuilder {
+	op = strings.ToUpper(strings.TrimSpace(op))
+	if !supportedOperators[op] {
+		q.setErr(fmt.Errorf("unsupported operator '%s' for column '%s'", op, column))
+		return q
+	}
+
+	cond := queryCondition{column: column, op: op}
+	switch op {
+	case "IS NULL", "IS NOT NULL":
+		if len(value) > 0 {
+			q.setErr(fmt.Errorf("operator '%s' takes no value", op))
+			return q
+		}
+	default:
+		if len(value) != 1 {
+			q.setErr(fmt.Errorf("operator '%s' takes exactly one value, got %d", op, len(value)))
+			return q
+		}
+		cond.value = value[0]
+	}
+
+	q.conditions = append(q.conditions, cond)
+	return q
+}
+
+// OrderBy sorts the result by a column.
+//
+// Parameters:
+//   - column: The column to sort by.
+//   - descending (optional): Sorts in descending order if true.
+//
+// Returns:
+//   - *QueryBuilder: The query builder.
+func (q *QueryBuilder) OrderBy(column string, descending ...bool) *QueryBuilder {
+	q.orders = append(q.orders, queryOrder{column: column, descending: len(descending) > 0 && descending[0]})
+	return q
+}
+
+// Limit sets the maximum number of rows returned.
+func (q *QueryBuilder) Limit(n int) *QueryBuilder {
+	if n < 0 {
+		q.setErr(fmt.Errorf("limit cannot be negative"))
+	}
+	q.limit = n
+	return q
+}
+
+// Offset sets the number of rows skipped before rows are returned.
+func (q *QueryBuilder) Offset(n int) *QueryBuilder {
+	if n < 0 {
+		q.setErr(fmt.Errorf("offset cannot be negative"))
+	}
+	q.offset = n
+	return q
+}
+
+// setErr records the first error raised while building the query
+func (q *QueryBuilder) setErr(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// Build renders the query for a SQL dialect.
+//
+// Parameters:
+//   - dialect: The SQL dialect: "sqlite", "postgres" or "mysql".
+//
+// Returns:
+//   - string: The SQL statement with dialect specific quoting and placeholders.
+//   - []any: The query arguments, in placeholder order.
+//   - error: An error if the dialect is unknown or the query is invalid.
+func (q *QueryBuilder) Build(dialect string) (string, []any, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	if dialect == "" {
+		return "", nil, fmt.Errorf("no sql dialect provided (supported: sqlite, postgres, mysql)")
+	}
+	d, err := getDialect(dialect, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return q.render(d)
+}
+
+// render writes the SELECT statement using the given dialect
+func (q *QueryBuilder) render(d SQLDialect) (string, []any, error) {
+	var sb strings.Builder
+	var args []any
+
+	sb.WriteString("SELECT ")
+	if len(q.columns) == 0 {
+		sb.WriteString("*")
+	} else {
+		quoted := make([]string, len(q.columns))
+		for i, col := range q.columns {
+			quoted[i] = quoteQualified(d, col)
+		}
+		sb.WriteString(strings.Join(quoted, ", "))
+	}
+	sb.WriteString(" FROM ")
+	sb.WriteString(quoteQualified(d, q.table))
+
+	for i, cond := range q.conditions {
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString(quoteQualified(d, cond.column))
+		sb.WriteString(" ")
+		sb.WriteString(cond.op)
+
+		switch cond.op {
+		case "IS NULL", "IS NOT NULL":
+			// no argument
+		case "IN", "NOT IN":
+			values := reflect.ValueOf(cond.value)
+			if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+				return "", nil, fmt.Errorf("operator '%s' for column '%s' requires a slice, got %T", cond.op, cond.column, cond.value)
+			}
+			if values.Len() == 0 {
+				return "", nil, fmt.Errorf("operator '%s' for column '%s' requires at least one value", cond.op, cond.column)
+			}
+			placeholders := make([]string, values.Len())
+			for j := range placeholders {
+				args = append(args, values.Index(j).Interface())
+				placeholders[j] = d.Placeholder(len(args))
+			}
+			sb.WriteString(" (" + strings.Join(placeholders, ", ") + ")")
+		default:
+			args = append(args, cond.value)
+			sb.WriteString(" " + d.Placeholder(len(args)))
+		}
+	}
+
+	for i, order := range q.orders {
+		if i == 0 {
+			sb.WriteString(" ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(quoteQualified(d, order.column))
+		if order.descending {
+			sb.WriteString(" DESC")
+		}
+	}
+
+	if q.limit > 0 {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d", q.limit))
+	}
+	if q.offset > 0 {
+		if q.limit == 0 {
+			// SQLite and MySQL do not support OFFSET without LIMIT
+			switch d.(type) {
+			case *SQLiteDialect:
+				sb.WriteString(" LIMIT -1")
+			case *MySQLDialect:
+				sb.WriteString(" LIMIT 18446744073709551615")
+			}
+		}
+		sb.WriteString(fmt.Sprintf(" OFFSET %d", q.offset))
+	}
+
+	return sb.String(), args, nil
+}
+
+// quoteQualified quotes every part of a possibly qualified identifier ("schema.table")
+func quoteQualified(d SQLDialect, name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = d.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// FromSQLQuery runs a query built with Table and reads the result into a DataFrame. The Select and
+// Where clauses of the builder are evaluated by the database, so only the requested columns and the
+// matching rows are transferred, prefer them to filtering a whole table after reading it.
+// LazyFromSQLTable builds the query from the filters of a lazy pipeline.
+//
+// Parameters:
+//   - db: The database connection.
+//   - query: The query builder.
+//   - dialect: The SQL dialect: "sqlite", "postgres" or "mysql".
+//   - options (optional): The SQLReadOption struct to configure NULL handling and date parsing.
+//
+// Returns:
+//   - *DataFrame: The query result.
+//   - error: An error if the query cannot be built or executed.
+func FromSQLQuery(db *sql.DB, query *QueryBuilder, dialect string, options ...SQLReadOption) (*DataFrame, error) {
+	return FromSQLQueryContext(context.Background(), db, query, dialect, options...)
+}
+
+// FromSQLQueryContext runs a query built with Table and reads the result into a DataFrame with context support.
+func FromSQLQueryContext(ctx context.Context, db *sql.DB, query *QueryBuilder, dialect string, options ...SQLReadOption) (*DataFrame, error) {
+	sqlText, args, err := query.Build(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+	return FromSQLContext(ctx, db, sqlText, args, options...)
+}
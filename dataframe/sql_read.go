@@ -26,6 +26,27 @@ type SQLReadOption struct {
 	// are automatically handled by SQL type mapping and don't need to be listed here.
 	// Supported string formats: RFC3339, "2006-01-02 15:04:05", "2006-01-02", and others.
 	ParseDates []string
+
+	// DateLayouts sets the layout (see time.Parse) used to parse the string values of a column,
+	// e.g. {"created": "02/01/2006 15:04"}. Listed columns are parsed as dates without being added
+	// to ParseDates, and only their layout is tried.
+	DateLayouts map[string]string
+
+	// CustomDateLayouts lists additional layouts tried before the built-in ones for ParseDates columns.
+	CustomDateLayouts []string
+
+	// DateLocation is the location used for parsed date strings without an offset and for Unix timestamps.
+	// Default: UTC for date strings, the local time zone for Unix timestamps.
+	DateLocation *time.Location
+
+	// TimeTypes declares the temporal type columns were written with (see SQLWriteOption.TimeTypes):
+	// "date", "timestamp" or "timestamptz". Listed columns are parsed like ParseDates and "date" values
+	// are truncated to the day.
+	TimeTypes map[string]string
+
+	// TimeUTC returns time.Time values in UTC. Values of "date" and "timestamp" columns, which carry no
+	// offset in the database, keep their wall clock time and are interpreted as UTC, mirroring SQLWriteOption.TimeUTC.
+	TimeUTC bool
 }
 
 // FromSQL reads a SQL query into a DataFrame with auto-commit
@@ -42,6 +63,11 @@ func FromSQLContext(ctx context.Context, db *sql.DB, query string, args []any, o
 	if query == "" {
 		return nil, fmt.Errorf("query cannot be empty")
 	}
+	if len(options) > 0 {
+		if err := validateTimeTypes(options[0].TimeTypes); err != nil {
+			return nil, err
+		}
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -71,6 +97,11 @@ func FromSQLTxContext(ctx context.Context, tx *sql.Tx, query string, args []any,
 	if query == "" {
 		return nil, fmt.Errorf("query cannot be empty")
 	}
+	if len(options) > 0 {
+		if err := validateTimeTypes(options[0].TimeTypes); err != nil {
+			return nil, err
+		}
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -100,6 +131,11 @@ func fromSQLRows(rows *sql.Rows, options ...SQLReadOption) (*DataFrame, error) {
 		if userOpt.ParseDates != nil {
 			opts.ParseDates = userOpt.ParseDates
 		}
+		opts.DateLayouts = userOpt.DateLayouts
+		opts.CustomDateLayouts = userOpt.CustomDateLayouts
+		opts.DateLocation = userOpt.DateLocation
+		opts.TimeTypes = userOpt.TimeTypes
+		opts.TimeUTC = userOpt.TimeUTC
 	}
 
 	// Get column metadata
@@ -141,14 +177,26 @@ func fromSQLRows(rows *sql.Rows, options ...SQLReadOption) (*DataFrame, error) {
 				return nil, err
 			}
 
-			// Apply date parsing if column is in ParseDates slice
-			if len(opts.ParseDates) > 0 && slices.Contains(opts.ParseDates, colName) {
-				parsedDate, err := parseDateValue(value)
+			// Apply date parsing if column is in ParseDates slice, DateLayouts or TimeTypes
+			kind, temporal := opts.TimeTypes[colName]
+			layout, hasLayout := opts.DateLayouts[colName]
+			if hasLayout && value != nil {
+				parsedDate, err := parseDateValue(value, []string{layout}, opts.DateLocation)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing date for column %s: %w", colName, err)
+				}
+				value = parsedDate
+			} else if (temporal && value != nil) || (len(opts.ParseDates) > 0 && slices.Contains(opts.ParseDates, colName)) {
+				layouts := append(append([]string{}, opts.CustomDateLayouts...), defaultDateLayouts...)
+				parsedDate, err := parseDateValue(value, layouts, opts.DateLocation)
 				if err != nil {
 					return nil, fmt.Errorf("error parsing date for column %s: %w", colName, err)
 				}
 				value = parsedDate
 			}
+			if t, ok := value.(time.Time); ok && (temporal || opts.TimeUTC) {
+				value = readTime(t, kind, opts.TimeUTC)
+			}
 
 			rowValues[i] = value
 		}
@@ -302,50 +350,80 @@ func handleNull(colName string, nullHandler any, dest any) (any, error) {
 	}
 }
 
+// readTime restores a time.Time value read from a column of the given temporal type, see SQLReadOption.TimeTypes
+func readTime(t time.Time, kind string, utc bool) time.Time {
+	loc := t.Location()
+	if utc {
+		loc = time.UTC
+	}
+	switch kind {
+	case "date":
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	case "timestamp":
+		// the database stores no offset, keep the wall clock time
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	}
+	return t.In(loc)
+}
+
+// defaultDateLayouts are the layouts tried when parsing date strings of ParseDates columns
+var defaultDateLayouts = []string{
+	time.RFC3339,                 // "2006-01-02T15:04:05Z07:00"
+	time.RFC3339Nano,             // "2006-01-02T15:04:05.999999999Z07:00"
+	"2006-01-02 15:04:05",        // SQLite DATETIME format
+	"2006-01-02",                 // Date only
+	"2006-01-02 15:04:05.999999", // With microseconds
+	time.RFC1123,                 // "Mon, 02 Jan 2006 15:04:05 MST"
+	time.RFC822,                  // "02 Jan 06 15:04 MST"
+}
+
 // parseDateValue attempts to parse a value as time.Time
-// Supports: time.Time (pass-through), string (various formats), int64 (Unix timestamp), float64 (Unix timestamp)
-func parseDateValue(value any) (time.Time, error) {
+// Supports: time.Time (pass-through), string (the given layouts), int64 (Unix timestamp), float64 (Unix timestamp)
+// Strings without an offset and Unix timestamps use loc when it is not nil.
+func parseDateValue(value any, layouts []string, loc *time.Location) (time.Time, error) {
 	if value == nil {
 		return time.Time{}, nil // Return zero time for nil
 	}
 
+	var t time.Time
 	switch v := value.(type) {
 	case time.Time:
 		// Already a time.Time, return as-is
 		return v, nil
 
 	case string:
-		// Try common date/time formats
-		formats := []string{
-			time.RFC3339,                 // "2006-01-02T15:04:05Z07:00"
-			time.RFC3339Nano,             // "2006-01-02T15:04:05.999999999Z07:00"
-			"2006-01-02 15:04:05",        // SQLite DATETIME format
-			"2006-01-02",                 // Date only
-			"2006-01-02 15:04:05.999999", // With microseconds
-			time.RFC1123,                 // "Mon, 02 Jan 2006 15:04:05 MST"
-			time.RFC822,                  // "02 Jan 06 15:04 MST"
+		parseLoc := loc
+		if parseLoc == nil {
+			parseLoc = time.UTC
 		}
-
-		for _, format := range formats {
-			if t, err := time.Parse(format, v); err == nil {
-				return t, nil
+		for _, layout := range layouts {
+			if parsed, err := time.ParseInLocation(layout, v, parseLoc); err == nil {
+				return parsed, nil
 			}
 		}
+		if len(layouts) == 1 {
+			return time.Time{}, fmt.Errorf("unable to parse date string %s with layout %s", v, layouts[0])
+		}
 		return time.Time{}, fmt.Errorf("unable to parse date string: %s", v)
 
 	case int64:
-		return time.Unix(v, 0), nil
+		t = time.Unix(v, 0)
 
 	case int:
-		return time.Unix(int64(v), 0), nil
+		t = time.Unix(int64(v), 0)
 
 	case float64:
 		// Use heuristic to determine if milliseconds or seconds
-		return timeFromFloat64(v), nil
+		t = timeFromFloat64(v)
 
 	default:
 		return time.Time{}, fmt.Errorf("unsupported type for date parsing: %T", value)
 	}
+
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t, nil
 }
 
 // timeFromFloat64 converts a float64 timestamp to time.Time
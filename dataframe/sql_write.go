@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // SQLWriteOption configures how a DataFrame is written to a SQL database
@@ -29,6 +30,18 @@ type SQLWriteOption struct {
 	// CreateTable specifies whether to auto-create the table if it doesn't exist
 	// Default: true
 	CreateTable bool
+
+	// TimeTypes sets the SQL type of time.Time columns per column name:
+	// "date" (DATE, values are truncated to the day), "timestamp" (TIMESTAMP / DATETIME, no offset is stored)
+	// or "timestamptz" (TIMESTAMPTZ on PostgreSQL, TIMESTAMP on MySQL).
+	// Columns that are not listed use the dialect default. TypeMap takes precedence.
+	// Read the table back with the same SQLReadOption.TimeTypes to restore the values.
+	TimeTypes map[string]string
+
+	// TimeUTC converts time.Time values to UTC before they are written, so that types without
+	// an offset ("date", "timestamp") do not silently store local wall clock times.
+	// Read the table back with SQLReadOption.TimeUTC to restore the values.
+	TimeUTC bool
 }
 
 // ToSQL writes the DataFrame to a SQL table with auto-commit
@@ -84,6 +97,22 @@ func (df *DataFrame) ToSQLTxContext(ctx context.Context, tx *sql.Tx, tableName s
 			return fmt.Errorf("BatchSize must be greater than 0, got %d", userOpt.BatchSize)
 		}
 
+		// Validate TimeTypes if provided
+		if err := validateTimeTypes(userOpt.TimeTypes); err != nil {
+			return err
+		}
+		for colName := range userOpt.TimeTypes {
+			col, exists := df.Columns[colName]
+			if !exists {
+				return fmt.Errorf("TimeTypes column '%s' does not exist", colName)
+			}
+			for _, value := range col.Values() {
+				if _, ok := value.(time.Time); value != nil && !ok {
+					return fmt.Errorf("TimeTypes column '%s' holds %T values, expected time.Time", colName, value)
+				}
+			}
+		}
+
 		// Validate Dialect if provided
 		if userOpt.Dialect != "" {
 			switch strings.ToLower(userOpt.Dialect) {
@@ -116,6 +145,8 @@ func (df *DataFrame) ToSQLTxContext(ctx context.Context, tx *sql.Tx, tableName s
 		if userOpt.TypeMap != nil {
 			opts.TypeMap = userOpt.TypeMap
 		}
+		opts.TimeTypes = userOpt.TimeTypes
+		opts.TimeUTC = userOpt.TimeUTC
 		// Note: We don't override CreateTable to preserve the default value of true
 		// If users need to disable table creation, they should not use this function
 	}
@@ -167,7 +198,7 @@ func (df *DataFrame) ToSQLTxContext(ctx context.Context, tx *sql.Tx, tableName s
 
 	// Create table if it doesn't exist and CreateTable is true
 	if !exists && opts.CreateTable {
-		if err := createTableTx(ctx, tx, tableName, df, dialect, opts.TypeMap); err != nil {
+		if err := createTableTx(ctx, tx, tableName, df, dialect, opts.TypeMap, opts.TimeTypes); err != nil {
 			return fmt.Errorf("error creating table: %w", err)
 		}
 	}
@@ -178,7 +209,7 @@ func (df *DataFrame) ToSQLTxContext(ctx context.Context, tx *sql.Tx, tableName s
 	}
 
 	// Perform batch insert
-	if err := batchInsertTx(ctx, tx, tableName, df, dialect, opts.BatchSize); err != nil {
+	if err := batchInsertTx(ctx, tx, tableName, df, dialect, opts); err != nil {
 		return fmt.Errorf("error inserting data: %w", err)
 	}
 
@@ -199,8 +230,18 @@ func tableExistsTx(ctx context.Context, tx *sql.Tx, tableName string, dialect SQ
 	return true, nil
 }
 
+// createTableSQL generates a CREATE TABLE statement listing the columns in the given order.
+// SQLDialect.CreateTableSQL takes an unordered map and sorts the columns by name.
+func createTableSQL(dialect SQLDialect, tableName string, names []string, columns map[string]string) string {
+	columnDefs := make([]string, len(names))
+	for i, name := range names {
+		columnDefs[i] = fmt.Sprintf("%s %s", dialect.QuoteIdentifier(name), columns[name])
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", dialect.QuoteIdentifier(tableName), strings.Join(columnDefs, ", "))
+}
+
 // createTableTx creates a new table with the appropriate schema
-func createTableTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFrame, dialect SQLDialect, typeMap map[string]string) error {
+func createTableTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFrame, dialect SQLDialect, typeMap map[string]string, timeTypes map[string]string) error {
 	// Build column type map
 	columns := make(map[string]string)
 
@@ -218,14 +259,20 @@ func createTableTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFr
 			}
 		}
 
+		// Use the requested temporal type for time columns
+		if kind, ok := timeTypes[colName]; ok {
+			columns[colName] = temporalSQLType(dialect, kind)
+			continue
+		}
+
 		// Infer type from column data
 		goType := inferGoTypeFromColumn(col)
 		sqlType := dialect.GoTypeToSQLType(goType)
 		columns[colName] = sqlType
 	}
 
-	// Generate CREATE TABLE SQL
-	createSQL := dialect.CreateTableSQL(tableName, columns)
+	// Generate CREATE TABLE SQL, keeping the column order of the DataFrame
+	createSQL := createTableSQL(dialect, tableName, df.ColumnNames(), columns)
 
 	// Execute CREATE TABLE
 	if _, err := tx.ExecContext(ctx, createSQL); err != nil {
@@ -236,7 +283,8 @@ func createTableTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFr
 }
 
 // batchInsertTx performs batch insertion of rows
-func batchInsertTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFrame, dialect SQLDialect, batchSize int) error {
+func batchInsertTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFrame, dialect SQLDialect, opts SQLWriteOption) error {
+	batchSize := opts.BatchSize
 	colNames := df.ColumnNames()
 	nRows := df.Nrows()
 	nCols := len(colNames)
@@ -255,6 +303,20 @@ func batchInsertTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFr
 		columns[i] = col
 	}
 
+	// Adjust time.Time values to their temporal type before they are written
+	timeFuncs := make([]func(time.Time) time.Time, nCols)
+	for i, colName := range colNames {
+		timeFuncs[i] = writeTimeFunc(opts.TimeTypes[colName], opts.TimeUTC)
+	}
+
+	// Prepare the INSERT once per batch shape (full batches and the remainder) and reuse it
+	statements := make(map[int]*sql.Stmt)
+	defer func() {
+		for _, stmt := range statements {
+			stmt.Close()
+		}
+	}()
+
 	// Process in batches
 	for batchStart := 0; batchStart < nRows; batchStart += batchSize {
 		batchEnd := batchStart + batchSize
@@ -262,7 +324,18 @@ func batchInsertTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFr
 			batchEnd = nRows
 		}
 
-		if err := insertBatch(ctx, tx, tableName, colNames, columns, batchStart, batchEnd, dialect); err != nil {
+		stmt, ok := statements[batchEnd-batchStart]
+		if !ok {
+			insertSQL := buildInsertSQL(tableName, colNames, batchEnd-batchStart, dialect)
+			prepared, err := tx.PrepareContext(ctx, insertSQL)
+			if err != nil {
+				return fmt.Errorf("error preparing INSERT for %d rows: %w", batchEnd-batchStart, err)
+			}
+			statements[batchEnd-batchStart] = prepared
+			stmt = prepared
+		}
+
+		if err := insertBatch(ctx, stmt, columns, timeFuncs, batchStart, batchEnd); err != nil {
 			return fmt.Errorf("error inserting batch (rows %d-%d): %w", batchStart, batchEnd-1, err)
 		}
 	}
@@ -270,9 +343,8 @@ func batchInsertTx(ctx context.Context, tx *sql.Tx, tableName string, df *DataFr
 	return nil
 }
 
-// insertBatch inserts a single batch of rows
-func insertBatch(ctx context.Context, tx *sql.Tx, tableName string, colNames []string, columns []*Column[any], startIdx, endIdx int, dialect SQLDialect) error {
-	nRows := endIdx - startIdx
+// buildInsertSQL builds a multi-row INSERT statement for the given number of rows
+func buildInsertSQL(tableName string, colNames []string, nRows int, dialect SQLDialect) string {
 	nCols := len(colNames)
 
 	// Build quoted column names
@@ -294,28 +366,52 @@ func insertBatch(ctx context.Context, tx *sql.Tx, tableName string, colNames []s
 		placeholderRows = append(placeholderRows, fmt.Sprintf("(%s)", strings.Join(rowPlaceholders, ", ")))
 	}
 
-	// Build INSERT statement
-	insertSQL := fmt.Sprintf(
+	return fmt.Sprintf(
 		"INSERT INTO %s (%s) VALUES %s",
 		dialect.QuoteIdentifier(tableName),
 		strings.Join(quotedCols, ", "),
 		strings.Join(placeholderRows, ", "),
 	)
+}
+
+// insertBatch inserts a single batch of rows using a statement prepared for the batch size
+func insertBatch(ctx context.Context, stmt *sql.Stmt, columns []*Column[any], timeFuncs []func(time.Time) time.Time, startIdx, endIdx int) error {
+	nRows := endIdx - startIdx
+	nCols := len(columns)
 
 	// Build args array
 	args := make([]any, 0, nRows*nCols)
 	for rowIdx := startIdx; rowIdx < endIdx; rowIdx++ {
 		for colIdx := 0; colIdx < nCols; colIdx++ {
-			value := columns[colIdx].Data[rowIdx]
+			value, _ := columns[colIdx].At(rowIdx)
+			if t, ok := value.(time.Time); ok && timeFuncs[colIdx] != nil {
+				value = timeFuncs[colIdx](t)
+			}
 			// Wrap in sql.Null* type to handle nil values properly
 			args = append(args, convertGoTypeToSQLNullable(value))
 		}
 	}
 
 	// Execute INSERT
-	if _, err := tx.ExecContext(ctx, insertSQL, args...); err != nil {
+	if _, err := stmt.ExecContext(ctx, args...); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// writeTimeFunc returns how the time.Time values of a column are adjusted before they are written, or nil
+func writeTimeFunc(kind string, utc bool) func(time.Time) time.Time {
+	if kind != "date" && !utc {
+		return nil
+	}
+	return func(t time.Time) time.Time {
+		if utc {
+			t = t.UTC()
+		}
+		if kind == "date" {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		}
+		return t
+	}
+}
@@ -0,0 +1,199 @@
+package dataframe
+
+/*
+
+	This is where the string accessor is defined: Str groups the vectorized string methods of a
+	Series or a column, so text is cleaned without Apply boilerplate:
+
+		df.WithColumn("email", df.Col("email").Str().Strip().Str().Lower())
+
+	The methods return a new Series and can be chained. Nil values stay nil and values that are
+	not strings give nil too (false for the boolean methods), like the numbers of a mixed column.
+	An error (invalid regular expression) is returned by the Err method of the result.
+
+*/
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringAccessor holds the vectorized string methods of a Series, see Series.Str.
+type StringAccessor struct {
+	s *Series
+}
+
+// Str returns the string methods of the series.
+//
+// Returns:
+//   - *StringAccessor: The string methods, each returns a new Series.
+func (s *Series) Str() *StringAccessor {
+	return &StringAccessor{s: s}
+}
+
+// Str returns the string methods of the values of the column, as a Series named after the column.
+//
+// Returns:
+//   - *StringAccessor: The string methods, each returns a new Series.
+func (c *Column[T]) Str() *StringAccessor {
+	values := c.Values()
+	data := make([]any, len(values))
+	for i, v := range values {
+		data[i] = v
+	}
+	return NewSeries(c.Name, data).Str()
+}
+
+// mapStrings applies fn to the string values, other values give nil
+func (a *StringAccessor) mapStrings(fn func(s string) any) *Series {
+	if err := a.s.Err(); err != nil {
+		return failedSeries(a.s.Name, err)
+	}
+	result := make([]any, len(a.s.Data))
+	for i, v := range a.s.Data {
+		if s, ok := v.(string); ok {
+			result[i] = fn(s)
+		}
+	}
+	return NewSeries(a.s.Name, result)
+}
+
+// mask applies test to the string values, other values give false
+func (a *StringAccessor) mask(test func(s string) bool) *Series {
+	result := a.mapStrings(func(s string) any { return test(s) })
+	for i, v := range result.Data {
+		if v == nil {
+			result.Data[i] = false
+		}
+	}
+	return result
+}
+
+// Lower converts the values to lower case.
+//
+// Returns:
+//   - *Series: The lower case strings.
+func (a *StringAccessor) Lower() *Series {
+	return a.mapStrings(func(s string) any { return strings.ToLower(s) })
+}
+
+// Upper converts the values to upper case.
+//
+// Returns:
+//   - *Series: The upper case strings.
+func (a *StringAccessor) Upper() *Series {
+	return a.mapStrings(func(s string) any { return strings.ToUpper(s) })
+}
+
+// Strip removes the leading and trailing white space of the values.
+//
+// Returns:
+//   - *Series: The stripped strings.
+func (a *StringAccessor) Strip() *Series {
+	return a.mapStrings(func(s string) any { return strings.TrimSpace(s) })
+}
+
+// Len returns the number of characters (runes, not bytes) of the values.
+//
+// Returns:
+//   - *Series: The int lengths.
+func (a *StringAccessor) Len() *Series {
+	return a.mapStrings(func(s string) any { return utf8.RuneCountInString(s) })
+}
+
+// Contains reports whether the values contain a substring.
+//
+// Parameters:
+//   - substr: The substring to look for.
+//
+// Returns:
+//   - *Series: A boolean Series with the same length.
+func (a *StringAccessor) Contains(substr string) *Series {
+	return a.mask(func(s string) bool { return strings.Contains(s, substr) })
+}
+
+// StartsWith reports whether the values start with a prefix.
+//
+// Parameters:
+//   - prefix: The prefix to look for.
+//
+// Returns:
+//   - *Series: A boolean Series with the same length.
+func (a *StringAccessor) StartsWith(prefix string) *Series {
+	return a.mask(func(s string) bool { return strings.HasPrefix(s, prefix) })
+}
+
+// EndsWith reports whether the values end with a suffix.
+//
+// Parameters:
+//   - suffix: The suffix to look for.
+//
+// Returns:
+//   - *Series: A boolean Series with the same length.
+func (a *StringAccessor) EndsWith(suffix string) *Series {
+	return a.mask(func(s string) bool { return strings.HasSuffix(s, suffix) })
+}
+
+// Replace replaces every occurrence of a substring in the values.
+//
+// Parameters:
+//   - old: The substring to replace.
+//   - new: The replacement.
+//
+// Returns:
+//   - *Series: The strings with the replacements.
+func (a *StringAccessor) Replace(old, new string) *Series {
+	return a.mapStrings(func(s string) any { return strings.ReplaceAll(s, old, new) })
+}
+
+// Split splits the values around a separator.
+//
+// Parameters:
+//   - sep: The separator.
+//
+// Returns:
+//   - *Series: The parts of each value as a []string.
+func (a *StringAccessor) Split(sep string) *Series {
+	return a.mapStrings(func(s string) any { return strings.Split(s, sep) })
+}
+
+// Match reports whether a regular expression matches the values. The expression matches anywhere
+// in a value, anchor it with ^ and $ to match whole values.
+//
+// Parameters:
+//   - pattern: The regular expression (see regexp/syntax).
+//
+// Returns:
+//   - *Series: A boolean Series with the same length, Err returns an error if the expression is invalid.
+func (a *StringAccessor) Match(pattern string) *Series {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return failedSeries(a.s.Name, fmt.Errorf("invalid pattern '%s': %w", pattern, err))
+	}
+	return a.mask(re.MatchString)
+}
+
+// Extract returns the first match of a regular expression in the values: the first capture group
+// when the expression has one, the whole match otherwise. Values without a match give nil.
+//
+// Parameters:
+//   - pattern: The regular expression (see regexp/syntax), e.g. `(\d+)-\d+`.
+//
+// Returns:
+//   - *Series: The extracted strings, Err returns an error if the expression is invalid.
+func (a *StringAccessor) Extract(pattern string) *Series {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return failedSeries(a.s.Name, fmt.Errorf("invalid pattern '%s': %w", pattern, err))
+	}
+	group := min(1, re.NumSubexp())
+	return a.mapStrings(func(s string) any {
+		match := re.FindStringSubmatch(s)
+		if match == nil {
+			return nil
+		}
+		return match[group]
+	})
+}
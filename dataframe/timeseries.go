@@ -1,7 +1,13 @@
 package dataframe
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,8 +20,9 @@ func (df *DataFrame) AddDatetimeIndex(columnName string, format string) error {
 		return fmt.Errorf("column '%s' does not exist", columnName)
 	}
 
-	newData := make([]any, len(col.Data))
-	for i, v := range col.Data {
+	values := col.Values()
+	newData := make([]any, len(values))
+	for i, v := range values {
 		strVal, ok := v.(string)
 		if !ok {
 			return fmt.Errorf("value '%v' in column '%s' is not a string", v, columnName)
@@ -27,67 +34,431 @@ func (df *DataFrame) AddDatetimeIndex(columnName string, format string) error {
 		newData[i] = datetime
 	}
 
-	col.Data = newData
+	df.setColumnData(col, newData)
 	return nil
 }
 
-// Resample aggregates data based on a given time frequency
-func (df *DataFrame) Resample(datetimeColumn string, freq string, aggFunc func([]any) any) (*DataFrame, error) {
-	if _, exists := df.Columns[datetimeColumn]; !exists {
-		return nil, fmt.Errorf("datetime column '%s' does not exist", datetimeColumn)
+// TzLocalize sets the time zone of the times of a column, keeping their wall clock time: 09:00 UTC
+// becomes 09:00 in the location. Use it for times parsed without a zone. Nil values stay nil.
+//
+// Parameters:
+//   - columnName: The column holding the time.Time values.
+//   - loc: The time zone, e.g. from time.LoadLocation("America/New_York").
+//
+// Returns:
+//   - error: An error if the column does not exist or holds other values than time.Time.
+func (df *DataFrame) TzLocalize(columnName string, loc *time.Location) error {
+	return df.mapTimeColumn(columnName, func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	})
+}
+
+// TzConvert converts the times of a column to a time zone, keeping the instants they represent:
+// 09:00 UTC becomes 04:00 in New York. Nil values stay nil.
+//
+// Parameters:
+//   - columnName: The column holding the time.Time values.
+//   - loc: The time zone, e.g. from time.LoadLocation("America/New_York").
+//
+// Returns:
+//   - error: An error if the column does not exist or holds other values than time.Time.
+func (df *DataFrame) TzConvert(columnName string, loc *time.Location) error {
+	return df.mapTimeColumn(columnName, func(t time.Time) time.Time { return t.In(loc) })
+}
+
+// mapTimeColumn replaces the time.Time values of a column by fn of them
+func (df *DataFrame) mapTimeColumn(columnName string, fn func(t time.Time) time.Time) error {
+	col, exists := df.Columns[columnName]
+	if !exists {
+		return fmt.Errorf("column '%s' does not exist", columnName)
 	}
 
-	resampled := NewDataFrame()
-	resampled.Columns[datetimeColumn] = &Column[any]{
-		Name: datetimeColumn,
-		Data: []any{},
+	values := col.Values()
+	newData := make([]any, len(values))
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("value '%v' at row %d in column '%s' is not a time.Time", v, i, columnName)
+		}
+		newData[i] = fn(t)
 	}
 
-	for name := range df.Columns {
-		if name != datetimeColumn {
-			resampled.Columns[name] = &Column[any]{
-				Name: name,
-				Data: []any{},
+	df.setColumnData(col, newData)
+	return nil
+}
+
+// ResampleOption is the parameters we can set to the Resample method.
+//
+// Fields:
+//   - Label: The boundary that labels a bucket, "left" (its start) or "right" (its end).
+//   - Closed: The boundary that belongs to a bucket, "left" or "right". A time on a boundary goes to
+//     the bucket starting there with "left", and to the bucket ending there with "right".
+//   - Location: The time zone the buckets are computed in, the times are converted to it first. By default
+//     each time is bucketed in its own location, so times of several zones give different buckets.
+//   - Fill: How the buckets without rows between the first and the last one are emitted. "" (the
+//     default) leaves them out, "nil" emits them with nil values and "ffill" with the values of the
+//     previous bucket.
+//
+// Label and Closed default to "right" for the frequencies anchored on the end of a period ("M-end"
+// and the weekly ones) and to "left" for the others.
+type ResampleOption struct {
+	Label    string
+	Closed   string
+	Location *time.Location
+	Fill     string
+}
+
+// Resample aggregates data based on a given time frequency. The buckets are sorted by time.
+//
+// The frequencies are "S", "T" (minutes), "H", "D", "B" (business days, from Monday to Friday, the
+// weekend belongs to the Friday), "M" (months starting on the 1st), "Q" (quarters starting in
+// January, April, July and October) and "Y" (years starting on January 1st), whose buckets start at
+// the boundary, and the anchored frequencies "M-end" (months ending on their last day) and "W-MON"
+// to "W-SUN" (weeks ending on that day, "W" is "W-SUN"), whose buckets end at the boundary. The
+// seconds, minutes and hours take a multiple counted from midnight, e.g. "15T" or "4H".
+//
+// Parameters:
+//   - datetimeColumn: The column holding the time.Time of each row.
+//   - freq: The frequency of the buckets.
+//   - aggFunc: The function aggregating the values of a column in a bucket.
+//   - options (optional): The ResampleOption struct to set the labels and closed sides of the buckets
+//     and fill the empty ones.
+//
+// Returns:
+//   - *DataFrame: One row per bucket, the datetime column holds the labels of the buckets.
+//   - error: An error if the column does not exist or holds other values than time.Time, the
+//     frequency or an option is unknown, or aggFunc panics.
+func (df *DataFrame) Resample(datetimeColumn string, freq string, aggFunc func([]any) any, options ...ResampleOption) (*DataFrame, error) {
+	return df.resample(datetimeColumn, freq, df.ColumnNames(), options, func(rows []map[string]any, colName string) (any, error) {
+		values := make([]any, len(rows))
+		for i, row := range rows {
+			values[i] = row[colName]
+		}
+		var aggregated any
+		err := recoverPanic(func() { aggregated = aggFunc(values) })
+		return aggregated, err
+	})
+}
+
+// ResampleAgg aggregates data based on a given time frequency with an aggregation per column, e.g.
+// {"price": "mean", "volume": "sum"}. The buckets are sorted by time and the columns without an
+// aggregation are dropped.
+//
+// Parameters:
+//   - datetimeColumn: The column holding the time.Time of each row.
+//   - freq: The frequency of the buckets, see Resample.
+//   - aggregations: The aggregation of each column, among the ones of GroupedDataFrame.Agg.
+//   - options (optional): The ResampleOption struct to set the labels and closed sides of the buckets
+//     and fill the empty ones.
+//
+// Returns:
+//   - *DataFrame: One row per bucket, the datetime column holds the labels of the buckets.
+//   - error: An error if a column does not exist, the datetime column holds other values than
+//     time.Time, or the frequency, an aggregation or an option is unknown.
+func (df *DataFrame) ResampleAgg(datetimeColumn string, freq string, aggregations map[string]string, options ...ResampleOption) (*DataFrame, error) {
+	for colName, aggName := range aggregations {
+		if _, exists := df.Columns[colName]; !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", colName)
+		}
+		if _, known := groupAggregations[aggName]; !known {
+			return nil, fmt.Errorf("unknown aggregation '%s' for column '%s'", aggName, colName)
+		}
+	}
+	var columns []string
+	for _, name := range df.ColumnNames() {
+		if _, aggregated := aggregations[name]; aggregated || name == datetimeColumn {
+			columns = append(columns, name)
+		}
+	}
+	return df.resample(datetimeColumn, freq, columns, options, func(rows []map[string]any, colName string) (any, error) {
+		return groupAggregations[aggregations[colName]](rows, colName), nil
+	})
+}
+
+// NamedAgg is an output column of ResampleNamed: the column to aggregate and the aggregation, e.g.
+// {Column: "price", Agg: "mean"}.
+//
+// Fields:
+//   - Column: The column to aggregate, or "*" with the "count" or "size" aggregation for the number of rows.
+//   - Agg: The aggregation, among the ones of GroupedDataFrame.Agg.
+type NamedAgg struct {
+	Column string
+	Agg    string
+}
+
+// ResampleNamed aggregates data based on a given time frequency into named output columns, e.g.
+// {"avg_price": {Column: "price", Agg: "mean"}, "n": {Column: "*", Agg: "count"}}, so a column
+// can be aggregated several ways without the outputs overwriting each other. The buckets are sorted by time.
+//
+// Parameters:
+//   - datetimeColumn: The column holding the time.Time of each row.
+//   - freq: The frequency of the buckets, see Resample.
+//   - aggregations: The column and aggregation of each output column.
+//   - options (optional): The ResampleOption struct to set the labels and closed sides of the buckets
+//     and fill the empty ones.
+//
+// Returns:
+//   - *DataFrame: One row per bucket, with the datetime column holding the labels of the buckets followed
+//     by the output columns, in the order of the columns they aggregate (the row counts last), then by name.
+//   - error: An error if a column does not exist, an output column is unnamed or named like the datetime
+//     column, the datetime column holds other values than time.Time, or the frequency, an aggregation or
+//     an option is unknown.
+func (df *DataFrame) ResampleNamed(datetimeColumn string, freq string, aggregations map[string]NamedAgg, options ...ResampleOption) (*DataFrame, error) {
+	position := func(column string) int {
+		if column == "*" {
+			return df.Ncols()
+		}
+		return df.columnPosition(column)
+	}
+	outputs := slices.Sorted(maps.Keys(aggregations))
+	for _, name := range outputs {
+		spec := aggregations[name]
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("output column name must not be empty")
+		case name == datetimeColumn:
+			return nil, fmt.Errorf("duplicate column name '%s'", name)
+		case spec.Column == "*":
+			if spec.Agg != "count" && spec.Agg != "size" {
+				return nil, fmt.Errorf("column '*' only supports the 'count' and 'size' aggregations, got '%s'", spec.Agg)
 			}
+		case df.Columns[spec.Column] == nil:
+			return nil, fmt.Errorf("column '%s' does not exist", spec.Column)
+		}
+		if _, known := groupAggregations[spec.Agg]; !known {
+			return nil, fmt.Errorf("unknown aggregation '%s' for column '%s'", spec.Agg, spec.Column)
+		}
+	}
+	slices.SortStableFunc(outputs, func(a, b string) int {
+		return cmp.Compare(position(aggregations[a].Column), position(aggregations[b].Column))
+	})
+
+	columns := append([]string{datetimeColumn}, outputs...)
+	return df.resample(datetimeColumn, freq, columns, options, func(rows []map[string]any, name string) (any, error) {
+		spec := aggregations[name]
+		if spec.Column == "*" {
+			return len(rows), nil
 		}
+		return groupAggregations[spec.Agg](rows, spec.Column), nil
+	})
+}
+
+// resample puts the rows in the buckets of the frequency and aggregates the columns of each bucket
+func (df *DataFrame) resample(datetimeColumn, freq string, columns []string, options []ResampleOption,
+	agg func(rows []map[string]any, colName string) (any, error)) (*DataFrame, error) {
+	if _, exists := df.Columns[datetimeColumn]; !exists {
+		return nil, fmt.Errorf("datetime column '%s' does not exist", datetimeColumn)
+	}
+	frequency, ok := parseFrequency(freq)
+	if !ok {
+		return nil, fmt.Errorf("unknown frequency '%s'", freq)
+	}
+	var opts ResampleOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	defaultSide := "left"
+	if frequency.endAnchored {
+		defaultSide = "right"
+	}
+	if opts.Label == "" {
+		opts.Label = defaultSide
+	}
+	if opts.Closed == "" {
+		opts.Closed = defaultSide
+	}
+	if opts.Label != "left" && opts.Label != "right" {
+		return nil, fmt.Errorf("unknown label: %s (must be 'left' or 'right')", opts.Label)
+	}
+	if opts.Closed != "left" && opts.Closed != "right" {
+		return nil, fmt.Errorf("unknown closed: %s (must be 'left' or 'right')", opts.Closed)
+	}
+	if opts.Fill != "" && opts.Fill != "nil" && opts.Fill != "ffill" {
+		return nil, fmt.Errorf("unknown fill: %s (must be 'nil' or 'ffill')", opts.Fill)
 	}
 
-	// Group by frequency and apply aggregation
-	grouped := make(map[time.Time]map[string][]any)
+	// buckets are keyed by the instant they start
+	grouped := make(map[int64][]map[string]any)
+	starts := []time.Time{}
 	for i := 0; i < df.Nrows(); i++ {
-		row, _ := df.Row(i)
-		datetime := row[datetimeColumn].(time.Time)
-		bucket := truncateToFrequency(datetime, freq)
-		if _, exists := grouped[bucket]; !exists {
-			grouped[bucket] = make(map[string][]any)
-		}
-		for name, value := range row {
-			if name != datetimeColumn {
-				grouped[bucket][name] = append(grouped[bucket][name], value)
+		row, err := df.Row(i)
+		if err != nil {
+			return nil, err
+		}
+		datetime, ok := RowGetTime(row, datetimeColumn)
+		if !ok {
+			return nil, fmt.Errorf("value '%v' at row %d in column '%s' is not a time.Time", row[datetimeColumn], i, datetimeColumn)
+		}
+		if opts.Location != nil {
+			datetime = datetime.In(opts.Location)
+		}
+		start := frequency.floor(datetime)
+		if opts.Closed == "right" && start.Equal(datetime) {
+			// the time ends the previous bucket
+			start = frequency.step(start, -1)
+		}
+		if _, exists := grouped[start.UnixNano()]; !exists {
+			starts = append(starts, start)
+		}
+		grouped[start.UnixNano()] = append(grouped[start.UnixNano()], row)
+	}
+	slices.SortFunc(starts, func(a, b time.Time) int { return a.Compare(b) })
+	if opts.Fill != "" && len(starts) > 0 {
+		first, last := starts[0], starts[len(starts)-1]
+		starts = starts[:0]
+		for start := first; !start.After(last); start = frequency.step(start, 1) {
+			starts = append(starts, start)
+		}
+	}
+
+	data := make([][]any, len(columns))
+	for c := range data {
+		data[c] = []any{}
+	}
+	for _, start := range starts {
+		label := start
+		if opts.Label == "right" {
+			label = frequency.step(start, 1)
+		}
+		rows, filled := grouped[start.UnixNano()]
+		for c, name := range columns {
+			var value any
+			switch {
+			case name == datetimeColumn:
+				value = label
+			case filled:
+				aggregated, err := agg(rows, name)
+				if err != nil {
+					return nil, fmt.Errorf("error aggregating column '%s' for bucket %v: %w", name, label, err)
+				}
+				value = aggregated
+			case opts.Fill == "ffill":
+				value = data[c][len(data[c])-1]
 			}
+			data[c] = append(data[c], value)
 		}
 	}
+	return fromColumnData(columns, data), nil
+}
 
-	// Aggregate and populate the resampled DataFrame
-	for bucket, data := range grouped {
-		resampled.Columns[datetimeColumn].Data = append(resampled.Columns[datetimeColumn].Data, bucket)
-		for name, values := range data {
-			resampled.Columns[name].Data = append(resampled.Columns[name].Data, aggFunc(values))
+// TimeWeightOption is the parameters we can set to the TimeWeightedMean method.
+//
+// Fields:
+//   - Method: How a value holds between two times. "previous" (the default) keeps each value until the
+//     next time, like an account balance, "linear" moves linearly to the next value, like a sensor reading.
+//   - End: The time the last value holds until, e.g. the end of the reporting period. By default the
+//     last value only ends the previous interval.
+type TimeWeightOption struct {
+	Method string
+	End    time.Time
+}
+
+// TimeWeightedMean averages the values of a column weighted by the time they hold, so irregularly
+// sampled series are not biased toward the periods with more readings. The rows are taken in time
+// order, rows with a nil time or a nil or NaN value are skipped. When the times are all equal, the
+// result is the plain mean of the values, and NaN without values.
+//
+// Parameters:
+//   - valueCol: The numeric column to average.
+//   - timeCol: The column holding the time.Time of each row.
+//   - options (optional): The TimeWeightOption struct with the interpolation method and the end time.
+//
+// Returns:
+//   - float64: The time-weighted mean.
+//   - error: An error if a column does not exist, a value is not a number or a time, the method is
+//     unknown or End is before the last time.
+func (df *DataFrame) TimeWeightedMean(valueCol, timeCol string, options ...TimeWeightOption) (float64, error) {
+	var opts TimeWeightOption
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	if opts.Method == "" {
+		opts.Method = "previous"
+	}
+	if opts.Method != "previous" && opts.Method != "linear" {
+		return 0, fmt.Errorf("unknown method: %s (must be 'previous' or 'linear')", opts.Method)
+	}
+	for _, name := range []string{valueCol, timeCol} {
+		if _, exists := df.Columns[name]; !exists {
+			return 0, fmt.Errorf("column '%s' does not exist", name)
 		}
 	}
 
-	return resampled, nil
+	type point struct {
+		t     time.Time
+		value float64
+	}
+	times := df.Columns[timeCol].Values()
+	points := []point{}
+	for i, v := range df.Columns[valueCol].Values() {
+		if times[i] == nil || v == nil || isNaNValue(v) {
+			continue
+		}
+		t, ok := times[i].(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("value '%v' at row %d in column '%s' is not a time.Time", times[i], i, timeCol)
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return 0, fmt.Errorf("value '%v' at row %d in column '%s' is not numeric", v, i, valueCol)
+		}
+		points = append(points, point{t, f})
+	}
+	if len(points) == 0 {
+		return math.NaN(), nil
+	}
+	slices.SortStableFunc(points, func(a, b point) int { return a.t.Compare(b.t) })
+	last := points[len(points)-1]
+	if !opts.End.IsZero() && opts.End.Before(last.t) {
+		return 0, fmt.Errorf("end %v is before the last time %v", opts.End, last.t)
+	}
+
+	var weighted, total float64
+	for i := range len(points) - 1 {
+		seconds := points[i+1].t.Sub(points[i].t).Seconds()
+		value := points[i].value
+		if opts.Method == "linear" {
+			value = (points[i].value + points[i+1].value) / 2
+		}
+		weighted += value * seconds
+		total += seconds
+	}
+	if !opts.End.IsZero() {
+		seconds := opts.End.Sub(last.t).Seconds()
+		weighted += last.value * seconds
+		total += seconds
+	}
+
+	if total == 0 {
+		nums := make([]float64, len(points))
+		for i, p := range points {
+			nums[i] = p.value
+		}
+		return floatMean(nums, false), nil
+	}
+	return weighted / total, nil
 }
 
-// Shift shifts the data in the DataFrame by a given number of periods
+// Shift shifts the data in the DataFrame by a given number of periods.
+// The index columns (see SetIndex) are not shifted, so every label gets the value of an earlier or later row.
+// To move the times of a column by a frequency instead of moving the rows, see ShiftTimes.
 func (df *DataFrame) Shift(periods int) *DataFrame {
 	shifted := NewDataFrame()
+	index := df.IndexNames()
 	for name, col := range df.Columns {
-		newData := make([]any, len(col.Data))
-		for i := range col.Data {
+		values := col.Values()
+		if slices.Contains(index, name) {
+			shifted.Columns[name] = &Column[any]{Name: name, Data: append([]any{}, values...)}
+			continue
+		}
+		newData := make([]any, len(values))
+		for i := range values {
 			newIdx := i - periods
-			if newIdx >= 0 && newIdx < len(col.Data) {
-				newData[i] = col.Data[newIdx]
+			if newIdx >= 0 && newIdx < len(values) {
+				newData[i] = values[newIdx]
 			} else {
 				newData[i] = nil
 			}
@@ -97,25 +468,370 @@ func (df *DataFrame) Shift(periods int) *DataFrame {
 			Data: newData,
 		}
 	}
+	shifted.order = df.ColumnNames()
+	shifted.indexNames = df.indexNames
 	return shifted
 }
 
-// truncateToFrequency truncates a time to the specified frequency
-func truncateToFrequency(t time.Time, freq string) time.Time {
+// ShiftTimes moves the times of a column by a number of periods of a frequency, the other columns keep
+// their values: with "B" a Friday moves to the next Monday. Monthly and yearly shifts keep the day of
+// the month when it exists (see time.AddDate). Nil values stay nil.
+//
+// Parameters:
+//   - columnName: The column holding the time.Time values.
+//   - periods: The number of periods, negative to move the times back.
+//   - freq: The frequency, see Resample. The anchored frequencies move to their boundaries, e.g. "M-end"
+//     to the end of a month.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the moved times.
+//   - error: An error if the column does not exist or holds other values than time.Time, or the
+//     frequency is unknown.
+func (df *DataFrame) ShiftTimes(columnName string, periods int, freq string) (*DataFrame, error) {
+	frequency, ok := parseFrequency(freq)
+	if !ok {
+		return nil, fmt.Errorf("unknown frequency '%s'", freq)
+	}
+	shifted := df.clone()
+	err := shifted.mapTimeColumn(columnName, func(t time.Time) time.Time {
+		if frequency.endAnchored {
+			// a time between two boundaries is one period after the boundary before it
+			b := frequency.floor(t)
+			if !b.Equal(t) && periods < 0 {
+				return frequency.step(b, periods+1)
+			}
+			return frequency.step(b, periods)
+		}
+		return frequency.step(t, periods)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shifted, nil
+}
+
+// frequency is the sequence of bucket boundaries of a Resample frequency
+type frequency struct {
+	floor       func(t time.Time) time.Time        // the last boundary at or before t
+	step        func(b time.Time, n int) time.Time // the boundary n boundaries after b
+	endAnchored bool                               // boundaries end the periods, e.g. "M-end"
+}
+
+// weekdays are the anchors of the weekly frequencies
+var weekdays = map[string]time.Weekday{
+	"MON": time.Monday, "TUE": time.Tuesday, "WED": time.Wednesday, "THU": time.Thursday,
+	"FRI": time.Friday, "SAT": time.Saturday, "SUN": time.Sunday,
+}
+
+// parseFrequency returns the boundaries of a Resample frequency
+func parseFrequency(freq string) (frequency, bool) {
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	// the sub-day frequencies take a multiple, e.g. "15T", counted from midnight
+	digits := len(freq) - len(strings.TrimLeft(freq, "0123456789"))
+	multiple := 1
+	if digits > 0 {
+		var err error
+		if multiple, err = strconv.Atoi(freq[:digits]); err != nil || multiple < 1 {
+			return frequency{}, false
+		}
+		freq = freq[digits:]
+	}
+	units := map[string]time.Duration{"S": time.Second, "T": time.Minute, "H": time.Hour}
+	if unit, ok := units[freq]; ok {
+		d := time.Duration(multiple) * unit
+		return frequency{
+			// subtract the wall clock remainder instead of rebuilding the time with time.Date, which is
+			// ambiguous in the hour repeated when daylight saving time ends
+			floor: func(t time.Time) time.Time {
+				sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+					time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+				return t.Add(-(sinceMidnight % d))
+			},
+			step: func(b time.Time, n int) time.Time { return b.Add(time.Duration(n) * d) },
+		}, true
+	}
+	if multiple != 1 {
+		return frequency{}, false
+	}
+
 	switch freq {
-	case "Y":
-		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
-	case "M":
-		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
 	case "D":
-		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-	case "H":
-		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
-	case "T":
-		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
-	case "S":
-		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+		return frequency{
+			floor: day,
+			step:  func(b time.Time, n int) time.Time { return b.AddDate(0, 0, n) },
+		}, true
+	case "M":
+		return frequency{
+			floor: func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()) },
+			step:  func(b time.Time, n int) time.Time { return b.AddDate(0, n, 0) },
+		}, true
+	case "B":
+		return frequency{
+			// a weekend day belongs to the Friday before
+			floor: func(t time.Time) time.Time {
+				switch t.Weekday() {
+				case time.Saturday:
+					return day(t).AddDate(0, 0, -1)
+				case time.Sunday:
+					return day(t).AddDate(0, 0, -2)
+				}
+				return day(t)
+			},
+			step: addBusinessDays,
+		}, true
+	case "Q":
+		return frequency{
+			floor: func(t time.Time) time.Time {
+				return time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, t.Location())
+			},
+			step: func(b time.Time, n int) time.Time { return b.AddDate(0, 3*n, 0) },
+		}, true
+	case "Y":
+		return frequency{
+			floor: func(t time.Time) time.Time { return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location()) },
+			step:  func(b time.Time, n int) time.Time { return b.AddDate(n, 0, 0) },
+		}, true
+	case "M-end":
+		// the last day of the month n months after the month of t
+		monthEnd := func(t time.Time, n int) time.Time {
+			return time.Date(t.Year(), t.Month()+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location())
+		}
+		return frequency{
+			floor: func(t time.Time) time.Time {
+				if end := monthEnd(t, 0); !end.After(t) {
+					return end
+				}
+				return monthEnd(t, -1)
+			},
+			step:        monthEnd,
+			endAnchored: true,
+		}, true
+	}
+
+	anchor, weekly := strings.CutPrefix(freq, "W-")
+	if freq == "W" {
+		anchor, weekly = "SUN", true
+	}
+	weekday, known := weekdays[anchor]
+	if !weekly || !known {
+		return frequency{}, false
+	}
+	return frequency{
+		floor: func(t time.Time) time.Time {
+			return day(t).AddDate(0, 0, -((int(t.Weekday()) - int(weekday) + 7) % 7))
+		},
+		step:        func(b time.Time, n int) time.Time { return b.AddDate(0, 0, 7*n) },
+		endAnchored: true,
+	}, true
+}
+
+// addBusinessDays moves t by n days from Monday to Friday, a weekend day first moves to the next Monday
+// when n is positive and to the previous Friday when n is negative
+func addBusinessDays(t time.Time, n int) time.Time {
+	direction := 1
+	if n < 0 {
+		direction, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, direction)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return t
+}
+
+// DateRange generates the times of a frequency between two times, to build a time index:
+//
+//	days, err := DateRange(start, end, "B")
+//	df.AddColumn(ConvertToAnyColumn(NewColumn("date", days)))
+//
+// Parameters:
+//   - start: The first time, the range starts at the first boundary of the frequency at or after it.
+//   - end: The last time, included if it is on a boundary.
+//   - freq: The frequency, see Resample.
+//
+// Returns:
+//   - []time.Time: The boundaries, in the location of start. Empty if end is before start.
+//   - error: An error if the frequency is unknown.
+func DateRange(start, end time.Time, freq string) ([]time.Time, error) {
+	frequency, ok := parseFrequency(freq)
+	if !ok {
+		return nil, fmt.Errorf("unknown frequency '%s'", freq)
+	}
+	times := []time.Time{}
+	b := frequency.floor(start)
+	if b.Before(start) {
+		b = frequency.step(b, 1)
+	}
+	for ; !b.After(end); b = frequency.step(b, 1) {
+		times = append(times, b)
+	}
+	return times, nil
+}
+
+// EWMOption is the parameters of the EWM method. Exactly one of Span and Alpha must be set.
+//
+// Fields:
+//   - Span: The decay in terms of span, alpha = 2 / (span + 1). Must be at least 1.
+//   - Alpha: The smoothing factor, 0 < alpha <= 1. Higher values give more weight to recent rows.
+//   - MinPeriods: The number of non-missing values needed before a result is produced, earlier rows are nil. Defaults to 1.
+type EWMOption struct {
+	Span       float64
+	Alpha      float64
+	MinPeriods int
+}
+
+// ExponentialWindow computes exponentially weighted statistics over the rows of a DataFrame, see EWM.
+type ExponentialWindow struct {
+	df         *DataFrame
+	alpha      float64
+	minPeriods int
+}
+
+// EWM provides exponentially weighted moving statistics, used to smooth financial or sensor time series.
+// Rows are weighted by (1 - alpha)^age, so the weights are normalized over the rows seen so far (like
+// pandas' adjust=True). Missing values (nil or NaN) are skipped but still age the earlier values.
+//
+// Parameters:
+//   - options: The EWMOption struct, with either Span or Alpha set.
+//
+// Returns:
+//   - *ExponentialWindow: The window, call Mean or Std on it.
+//   - error: An error if neither or both of Span and Alpha are set, or if they are out of range.
+//
+// Example:
+//
+//	window, err := df.EWM(dataframe.EWMOption{Span: 10})
+//	if err != nil {
+//		return err
+//	}
+//	smoothed, err := window.Mean("price")
+func (df *DataFrame) EWM(options EWMOption) (*ExponentialWindow, error) {
+	alpha := options.Alpha
+	switch {
+	case options.Span != 0 && options.Alpha != 0:
+		return nil, fmt.Errorf("only one of span and alpha can be set")
+	case options.Span != 0:
+		if options.Span < 1 {
+			return nil, fmt.Errorf("span must be at least 1, got %v", options.Span)
+		}
+		alpha = 2 / (options.Span + 1)
+	case options.Alpha != 0:
+		if options.Alpha < 0 || options.Alpha > 1 {
+			return nil, fmt.Errorf("alpha must be in (0, 1], got %v", options.Alpha)
+		}
 	default:
-		return t
+		return nil, fmt.Errorf("either span or alpha must be set")
+	}
+	if options.MinPeriods < 0 {
+		return nil, fmt.Errorf("min periods must not be negative, got %d", options.MinPeriods)
+	}
+	return &ExponentialWindow{df: df, alpha: alpha, minPeriods: max(options.MinPeriods, 1)}, nil
+}
+
+// Mean returns the exponentially weighted moving average of the columns.
+//
+// Parameters:
+//   - columns (optional): The columns to smooth. Defaults to every numeric column.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the same columns, the other columns (e.g. a datetime column) are copied as is.
+//   - error: An error if a column does not exist or holds non-numeric values.
+func (w *ExponentialWindow) Mean(columns ...string) (*DataFrame, error) {
+	return w.apply(columns, false)
+}
+
+// Std returns the exponentially weighted moving standard deviation of the columns, with the bias
+// correction of the weighted sample variance. The first non-missing value has no deviation (nil).
+//
+// Parameters:
+//   - columns (optional): The columns to use. Defaults to every numeric column.
+//
+// Returns:
+//   - *DataFrame: A new DataFrame with the same columns, the other columns (e.g. a datetime column) are copied as is.
+//   - error: An error if a column does not exist or holds non-numeric values.
+func (w *ExponentialWindow) Std(columns ...string) (*DataFrame, error) {
+	return w.apply(columns, true)
+}
+
+// apply computes the weighted statistic of the selected columns and copies the others
+func (w *ExponentialWindow) apply(columns []string, std bool) (*DataFrame, error) {
+	selected := make(map[string]bool)
+	for _, name := range columns {
+		col, exists := w.df.Columns[name]
+		if !exists {
+			return nil, fmt.Errorf("column '%s' does not exist", name)
+		}
+		if _, ok := ewmFloats(col.Values()); !ok {
+			return nil, fmt.Errorf("column '%s' is not numeric", name)
+		}
+		selected[name] = true
+	}
+
+	result := NewDataFrame()
+	for _, name := range w.df.ColumnNames() {
+		values := w.df.Columns[name].Values()
+		nums, numeric := ewmFloats(values)
+		data := append([]any{}, values...)
+		if selected[name] || (len(columns) == 0 && numeric) {
+			data = w.weighted(nums, std)
+		}
+		result.Columns[name] = &Column[any]{Name: name, Data: data}
+		result.order = append(result.order, name)
+	}
+	result.indexNames = w.df.indexNames
+	return result, nil
+}
+
+// weighted returns the running weighted mean or standard deviation of the values, NaN marks missing values
+func (w *ExponentialWindow) weighted(nums []float64, std bool) []any {
+	decay := 1 - w.alpha
+	var sumW, sumW2, sumWX, sumWX2 float64
+	observed := 0
+	out := make([]any, len(nums))
+	for i, x := range nums {
+		sumW, sumW2, sumWX, sumWX2 = sumW*decay, sumW2*decay*decay, sumWX*decay, sumWX2*decay
+		if !math.IsNaN(x) {
+			sumW, sumW2, sumWX, sumWX2 = sumW+1, sumW2+1, sumWX+x, sumWX2+x*x
+			observed++
+		}
+		if observed < w.minPeriods {
+			continue
+		}
+		mean := sumWX / sumW
+		if !std {
+			out[i] = mean
+			continue
+		}
+		if observed < 2 {
+			continue
+		}
+		variance := (sumWX2/sumW - mean*mean) * sumW * sumW / (sumW*sumW - sumW2)
+		out[i] = math.Sqrt(math.Max(variance, 0))
+	}
+	return out
+}
+
+// ewmFloats converts the values of a column to floats, missing values (nil or NaN) become NaN.
+// It reports false if a value is not numeric or if the column has no numeric value.
+func ewmFloats(values []any) ([]float64, bool) {
+	nums := make([]float64, len(values))
+	found := false
+	for i, v := range values {
+		if v == nil || isNaNValue(v) {
+			nums[i] = math.NaN()
+			continue
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, false
+		}
+		nums[i] = f
+		found = true
 	}
+	return nums, found
 }
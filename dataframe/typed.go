@@ -0,0 +1,505 @@
+package dataframe
+
+/*
+
+	This is where the typed (native slice) column storage is defined
+
+*/
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// nativeType lists the Go types a column can be stored as without boxing
+type nativeType interface {
+	int64 | float64 | string | bool | time.Time
+}
+
+// nullBitmap marks the null rows of a natively stored column, one bit per row
+type nullBitmap []uint64
+
+// isNull reports whether row i is null
+func (b nullBitmap) isNull(i int) bool {
+	word := i / 64
+	return word < len(b) && b[word]&(1<<(uint(i)%64)) != 0
+}
+
+// setNull marks row i as null
+func (b *nullBitmap) setNull(i int) {
+	word := i / 64
+	for len(*b) <= word {
+		*b = append(*b, 0)
+	}
+	(*b)[word] |= 1 << (uint(i) % 64)
+}
+
+// hasNulls reports whether any row is null
+func (b nullBitmap) hasNulls() bool {
+	for _, word := range b {
+		if word != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// slice returns the bitmap of rows [start, end), shifted to start at row 0. The words are copied,
+// so marking the rows appended to a view null does not write into the bitmap of the column.
+func (b nullBitmap) slice(start, end int) nullBitmap {
+	if !b.hasNulls() || start == end {
+		return nil
+	}
+	words := make(nullBitmap, (end-start+63)/64)
+	first, shift := start/64, uint(start%64)
+	for w := range words {
+		if first+w < len(b) {
+			words[w] = b[first+w] >> shift
+		}
+		if shift != 0 && first+w+1 < len(b) {
+			words[w] |= b[first+w+1] << (64 - shift)
+		}
+	}
+	if rest := uint((end - start) % 64); rest != 0 {
+		words[len(words)-1] &= 1<<rest - 1
+	}
+	return words
+}
+
+// nativeStorage is the type independent view of a nativeColumn
+type nativeStorage interface {
+	dtype() string
+	length() int
+	at(i int) any
+	boxed() []any
+	copy() nativeStorage
+	slice(start, end int) nativeStorage
+	values() (data any, hasNulls bool)
+	appendValue(v any) bool
+	memory() int64
+}
+
+// nativeColumn stores the values of a column in a native slice. Null rows hold the zero value
+// and are marked in the null bitmap.
+type nativeColumn[V nativeType] struct {
+	kind  string
+	data  []V
+	nulls nullBitmap
+}
+
+func (n *nativeColumn[V]) dtype() string {
+	return n.kind
+}
+
+func (n *nativeColumn[V]) length() int {
+	return len(n.data)
+}
+
+func (n *nativeColumn[V]) at(i int) any {
+	if n.nulls.isNull(i) {
+		return nil
+	}
+	return n.data[i]
+}
+
+func (n *nativeColumn[V]) boxed() []any {
+	values := make([]any, len(n.data))
+	for i, v := range n.data {
+		if !n.nulls.isNull(i) {
+			values[i] = v
+		}
+	}
+	return values
+}
+
+func (n *nativeColumn[V]) copy() nativeStorage {
+	return &nativeColumn[V]{
+		kind:  n.kind,
+		data:  append([]V{}, n.data...),
+		nulls: append(nullBitmap{}, n.nulls...),
+	}
+}
+
+// values returns the native slice, e.g. []int64, and whether some of its rows are null
+func (n *nativeColumn[V]) values() (any, bool) {
+	return n.data, n.nulls.hasNulls()
+}
+
+// memory returns the bytes used by the native slice, the strings it points to and the null bitmap, see MemoryUsage
+func (n *nativeColumn[V]) memory() int64 {
+	bytes := int64(cap(n.data))*int64(reflect.TypeFor[V]().Size()) + int64(cap(n.nulls))*8
+	if strs, ok := any(n.data).([]string); ok {
+		for _, s := range strs {
+			bytes += int64(len(s))
+		}
+	}
+	return bytes
+}
+
+// slice returns a view of rows [start, end) sharing the native slice, see Column.Slice
+func (n *nativeColumn[V]) slice(start, end int) nativeStorage {
+	return &nativeColumn[V]{
+		kind:  n.kind,
+		data:  n.data[start:end:end],
+		nulls: n.nulls.slice(start, end),
+	}
+}
+
+// appendValue appends a value if it can be stored in the column without changing its type
+func (n *nativeColumn[V]) appendValue(v any) bool {
+	if v == nil {
+		var zero V
+		n.data = append(n.data, zero)
+		n.nulls.setNull(len(n.data) - 1)
+		return true
+	}
+	value, ok := v.(V)
+	if !ok {
+		// other integer types are promoted to int64
+		converted, isSame := toNative([]any{v}).(*nativeColumn[V])
+		if !isSame || converted.kind != n.kind {
+			return false
+		}
+		value = converted.data[0]
+	}
+	n.data = append(n.data, value)
+	return true
+}
+
+// newNativeColumn builds a native column from boxed values, convert is only called for non-nil values
+func newNativeColumn[V nativeType](kind string, values []any, convert func(any) V) *nativeColumn[V] {
+	n := &nativeColumn[V]{kind: kind, data: make([]V, len(values))}
+	for i, v := range values {
+		if v == nil {
+			n.nulls.setNull(i)
+			continue
+		}
+		n.data[i] = convert(v)
+	}
+	return n
+}
+
+// toNative converts boxed values to native storage using the promotion rules:
+//   - signed and unsigned integers are stored as int64 (uint64 values above math.MaxInt64 promote to float64),
+//   - integers mixed with floats are stored as float64,
+//   - strings, bools and time.Time values are stored as their own type,
+//   - nil values become nulls.
+//
+// It returns nil when the values cannot be stored natively: mixed kinds (e.g. strings and numbers),
+// unsupported types, or a column holding only nil values.
+func toNative(values []any) nativeStorage {
+	kind := ""
+	promote := func(k string) bool {
+		switch {
+		case kind == "" || kind == k:
+			kind = k
+		case (kind == "int64" && k == "float64") || (kind == "float64" && k == "int64"):
+			kind = "float64"
+		default:
+			return false
+		}
+		return true
+	}
+
+	for _, v := range values {
+		ok := true
+		switch n := v.(type) {
+		case nil:
+			continue
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+			ok = promote("int64")
+		case uint64:
+			if n > math.MaxInt64 {
+				ok = promote("float64")
+			} else {
+				ok = promote("int64")
+			}
+		case float32, float64:
+			ok = promote("float64")
+		case string:
+			ok = promote("string")
+		case bool:
+			ok = promote("bool")
+		case time.Time:
+			ok = promote("time")
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	switch kind {
+	case "int64":
+		return newNativeColumn(kind, values, func(v any) int64 {
+			rv := reflect.ValueOf(v)
+			if rv.CanInt() {
+				return rv.Int()
+			}
+			return int64(rv.Uint())
+		})
+	case "float64":
+		return newNativeColumn(kind, values, func(v any) float64 {
+			f, _ := toFloat(v)
+			return f
+		})
+	case "string":
+		return newNativeColumn(kind, values, func(v any) string { return v.(string) })
+	case "bool":
+		return newNativeColumn(kind, values, func(v any) bool { return v.(bool) })
+	case "time":
+		return newNativeColumn(kind, values, func(v any) time.Time { return v.(time.Time) })
+	}
+	return nil
+}
+
+// fromAny converts boxed values to a []T, nil values become the zero value of T
+func fromAny[T any](values []any) []T {
+	if typed, ok := any(values).([]T); ok {
+		return typed
+	}
+	result := make([]T, len(values))
+	for i, v := range values {
+		if v != nil {
+			result[i] = v.(T)
+		}
+	}
+	return result
+}
+
+// NewTypedDataFrame creates a new empty DataFrame with typed column storage. Columns added to it
+// are stored in native slices (int64, float64, string, bool or time.Time) with a null bitmap
+// instead of boxed []any values, which makes aggregations such as Sum and Mean faster and
+// reduces memory usage.
+//
+// Values are promoted while storing: every integer type becomes int64, integers mixed with floats
+// become float64 and nil values become nulls. Columns that cannot be stored natively
+// (e.g. strings mixed with numbers) keep the boxed storage.
+//
+// While a column is stored natively its Data field is nil, the values stay readable through
+// Len, At, Values and NativeValues. Operations that modify a column in place keep it typed.
+//
+// Returns:
+//   - *DataFrame: A pointer to the newly created DataFrame.
+func NewTypedDataFrame() *DataFrame {
+	df := NewDataFrame()
+	df.typed = true
+	return df
+}
+
+// ToTyped returns a copy of the DataFrame using typed column storage, see NewTypedDataFrame.
+func (df *DataFrame) ToTyped() *DataFrame {
+	typed := df.clone()
+	typed.typed = true
+	for _, col := range typed.Columns {
+		typed.storeNative(col)
+	}
+	return typed
+}
+
+// IsTyped reports whether the DataFrame uses typed column storage.
+func (df *DataFrame) IsTyped() bool {
+	return df.typed
+}
+
+// storeNative moves the data of a column of a typed DataFrame to native storage when possible
+func (df *DataFrame) storeNative(col *Column[any]) {
+	if !df.typed || col.encoded != nil || col.native != nil {
+		return
+	}
+	if native := toNative(col.Data); native != nil {
+		col.native = native
+		col.Data = nil
+	}
+}
+
+// appendValue appends a value to a column of the DataFrame. Natively stored columns stay typed
+// when the value fits, otherwise the column is promoted (e.g. int64 to float64) or falls back to boxed storage.
+func (df *DataFrame) appendValue(col *Column[any], value any) {
+	df.version.Add(1)
+	if col.native != nil && col.native.appendValue(value) {
+		return
+	}
+	wasNative := col.native != nil
+	col.materialize()
+	col.Data = append(col.Data, value)
+	if wasNative || len(col.Data) == 1 {
+		df.storeNative(col)
+	}
+}
+
+// setColumnData replaces the data of a column in place, keeping the column typed on typed DataFrames
+func (df *DataFrame) setColumnData(col *Column[any], data []any) {
+	df.version.Add(1)
+	col.encoded = nil
+	col.native = nil
+	col.Data = data
+	df.storeNative(col)
+}
+
+// DType returns the storage type of the column: "int64", "float64", "string", "bool" or "time"
+// for natively stored columns (see NewTypedDataFrame), "any" for boxed columns.
+func (c *Column[T]) DType() string {
+	if c.native != nil {
+		return c.native.dtype()
+	}
+	return "any"
+}
+
+// IsNull reports whether the value at the given row is nil.
+func (c *Column[T]) IsNull(index int) bool {
+	value, err := c.At(index)
+	return err == nil && any(value) == nil
+}
+
+// NativeValues returns the native slice of a natively stored column without boxing its values.
+// Null rows hold the zero value, use Column.IsNull to tell them apart.
+//
+// Parameters:
+//   - col: The column to read.
+//
+// Returns:
+//   - []V: The native slice, shared with the column.
+//   - bool: False if the column is not stored natively as V.
+func NativeValues[V nativeType](col *Column[any]) ([]V, bool) {
+	native, ok := col.native.(*nativeColumn[V])
+	if !ok {
+		return nil, false
+	}
+	return native.data, true
+}
+
+// ColumnAs returns the values of a column as a typed slice, converting and validating them in a
+// single pass instead of asserting the type of every value, e.g. ColumnAs[float64](df, "price").
+//
+// Values of type T are kept as is. Numbers are converted to another numeric type when the
+// conversion is exact, so an int column can be read as []float64 but 2.5 cannot be read as an int.
+// Nil values are only accepted when T is an interface type.
+//
+// Parameters:
+//   - df: The DataFrame holding the column.
+//   - name: The name of the column.
+//
+// Returns:
+//   - []T: A new slice with the values of the column.
+//   - error: An error if the column does not exist or a value cannot be converted to T.
+func ColumnAs[T any](df *DataFrame, name string) ([]T, error) {
+	col, exists := df.Columns[name]
+	if !exists {
+		return nil, fmt.Errorf("column '%s' does not exist", name)
+	}
+	if col.native != nil {
+		// natively stored columns of type T are copied without boxing
+		if data, hasNulls := col.native.values(); !hasNulls {
+			if typed, ok := data.([]T); ok {
+				return slices.Clone(typed), nil
+			}
+		}
+	}
+	values, err := convertValues[T](col.Values())
+	if err != nil {
+		return nil, fmt.Errorf("column '%s': %w", name, err)
+	}
+	return values, nil
+}
+
+// SeriesAs returns the values of a series as a typed slice, converting them like ColumnAs.
+//
+// Parameters:
+//   - s: The series.
+//
+// Returns:
+//   - []T: A new slice with the values of the series.
+//   - error: An error if a value cannot be converted to T.
+func SeriesAs[T any](s *Series) ([]T, error) {
+	values, err := convertValues[T](s.Data)
+	if err != nil {
+		return nil, fmt.Errorf("series '%s': %w", s.Name, err)
+	}
+	return values, nil
+}
+
+// convertValues converts boxed values to T, see ColumnAs
+func convertValues[T any](values []any) ([]T, error) {
+	target := reflect.TypeFor[T]()
+	result := make([]T, len(values))
+	for i, v := range values {
+		if value, ok := v.(T); ok {
+			result[i] = value
+			continue
+		}
+		if v == nil {
+			if target.Kind() == reflect.Interface {
+				continue
+			}
+			return nil, fmt.Errorf("cannot convert nil at row %d to %s", i, target)
+		}
+		converted, ok := convertNumber(reflect.ValueOf(v), target)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert value %v of type %T at row %d to %s", v, v, i, target)
+		}
+		result[i] = converted.Interface().(T)
+	}
+	return result, nil
+}
+
+// convertNumber converts a number to another numeric type, false if a type is not numeric or
+// the value changes (fraction, overflow or sign)
+func convertNumber(value reflect.Value, target reflect.Type) (reflect.Value, bool) {
+	if !isNumericKind(value.Kind()) || !isNumericKind(target.Kind()) {
+		return reflect.Value{}, false
+	}
+	if value.CanFloat() && reflect.Zero(target).CanFloat() {
+		return value.Convert(target), true
+	}
+	negative := (value.CanInt() && value.Int() < 0) || (value.CanFloat() && value.Float() < 0)
+	if negative && reflect.Zero(target).CanUint() {
+		return reflect.Value{}, false
+	}
+	converted := value.Convert(target)
+	if !converted.Convert(value.Type()).Equal(value) {
+		return reflect.Value{}, false
+	}
+	return converted, true
+}
+
+// isNumericKind reports whether a kind is an integer or floating point number
+func isNumericKind(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Uint64) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// materialize restores the plain Data slice of a compressed or natively stored column
+func (c *Column[T]) materialize() {
+	c.Decompress()
+	if c.native != nil {
+		c.Data = fromAny[T](c.native.boxed())
+		c.native = nil
+	}
+}
+
+// nativeFloats returns the values of an int64 or float64 column without boxing them.
+// It returns false if the column is not stored natively as a number, is empty or holds nulls,
+// so callers fall back to the boxed path and keep its behaviour.
+func (c *Column[T]) nativeFloats() ([]float64, bool) {
+	switch native := c.native.(type) {
+	case *nativeColumn[float64]:
+		if len(native.data) == 0 || native.nulls.hasNulls() {
+			return nil, false
+		}
+		return native.data, true
+	case *nativeColumn[int64]:
+		if len(native.data) == 0 || native.nulls.hasNulls() {
+			return nil, false
+		}
+		nums := make([]float64, len(native.data))
+		for i, v := range native.data {
+			nums[i] = float64(v)
+		}
+		return nums, true
+	}
+	return nil, false
+}
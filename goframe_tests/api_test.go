@@ -0,0 +1,52 @@
+package goframe_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kishyassin/goframe/internal/apisurface"
+)
+
+// TestAPISurface fails when the exported API of the dataframe package changes without
+// api/dataframe.txt being updated, so that breaking changes are reviewed on purpose.
+// Run GOFRAME_UPDATE_API=1 go test ./goframe_tests -run TestAPISurface to accept a change.
+func TestAPISurface(t *testing.T) {
+	lines, err := apisurface.Surface("../dataframe")
+	if err != nil {
+		t.Fatalf("Surface failed: %v", err)
+	}
+	actual := strings.Join(lines, "\n") + "\n"
+
+	if os.Getenv("GOFRAME_UPDATE_API") == "1" {
+		if err := os.WriteFile("../api/dataframe.txt", []byte(actual), 0o644); err != nil {
+			t.Fatalf("Failed to update api/dataframe.txt: %v", err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile("../api/dataframe.txt")
+	if err != nil {
+		t.Fatalf("Failed to read api/dataframe.txt: %v", err)
+	}
+	expected := strings.Split(strings.TrimSuffix(string(golden), "\n"), "\n")
+	current := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		current[line] = true
+	}
+	recorded := make(map[string]bool, len(expected))
+	for _, line := range expected {
+		recorded[line] = true
+		if !current[line] {
+			t.Errorf("removed or changed: %s", line)
+		}
+	}
+	for _, line := range lines {
+		if !recorded[line] {
+			t.Errorf("added: %s", line)
+		}
+	}
+	if t.Failed() {
+		t.Log("the exported API changed: keep a Deprecated shim for removed symbols, then run GOFRAME_UPDATE_API=1 go test ./goframe_tests -run TestAPISurface")
+	}
+}
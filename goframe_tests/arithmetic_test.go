@@ -0,0 +1,95 @@
+package goframe_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestSeriesArithmetic(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("price", []any{2.5, 4.0, nil, 10.0}))
+	df.AddColumn(goframe.NewColumn[any]("qty", []any{2, 3, 1, 0}))
+	df.AddColumn(goframe.NewColumn[any]("name", []any{"a", "b", "c", "d"}))
+
+	tests := []struct {
+		name     string
+		series   *goframe.Series
+		expected []any
+	}{
+		{"Mul", df.Col("price").Mul(df.Col("qty")), []any{5.0, 12.0, nil, 0.0}},
+		{"Add Scalar", df.Col("qty").Add(1), []any{3.0, 4.0, 2.0, 1.0}},
+		{"Sub", df.Col("price").Sub(df.Col("qty")), []any{0.5, 1.0, nil, 10.0}},
+		{"Div", df.Col("price").Div(df.Col("qty")), []any{1.25, 4.0 / 3, nil, math.Inf(1)}},
+		{"Concatenate", df.Col("name").Add("!"), []any{"a!", "b!", "c!", "d!"}},
+		{"Chain", df.Col("price").Mul(df.Col("qty")).Sub(2).Div(2), []any{1.5, 5.0, nil, -1.0}},
+		{"Gt", df.Col("price").Gt(3), []any{false, true, false, true}},
+		{"Le Series", df.Col("qty").Le(df.Col("price")), []any{true, true, false, true}},
+		{"Eq Mixed Types", df.Col("qty").Eq(3.0), []any{false, true, false, false}},
+		{"Ne", df.Col("name").Ne("b"), []any{true, false, true, true}},
+		{"Lt Strings", df.Col("name").Lt("c"), []any{true, true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.series.Err() != nil {
+				t.Fatalf("Unexpected error: %v", tt.series.Err())
+			}
+			if !reflect.DeepEqual(tt.series.Data, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tt.series.Data)
+			}
+		})
+	}
+
+	t.Run("Errors Carry Through Chains", func(t *testing.T) {
+		if err := df.Col("missing").Mul(2).Add(1).Err(); err == nil || !strings.Contains(err.Error(), "missing") {
+			t.Errorf("Expected the missing column error, got %v", err)
+		}
+		if err := df.Col("name").Mul(2).Err(); err == nil || !strings.Contains(err.Error(), "row 0") {
+			t.Errorf("Expected an error for a non-numeric value, got %v", err)
+		}
+		if err := df.Col("qty").Add(goframe.NewSeries("short", []any{1})).Err(); err == nil {
+			t.Error("Expected an error for a length mismatch")
+		}
+	})
+}
+
+func TestWithColumn(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("price", []any{2.5, 4.0}))
+	df.AddColumn(goframe.NewColumn[any]("qty", []any{2, 3}))
+
+	withTotal, err := df.WithColumn("total", df.Col("price").Mul(df.Col("qty")))
+	if err != nil {
+		t.Fatalf("WithColumn failed: %v", err)
+	}
+	if got := withTotal.ColumnNames(); !reflect.DeepEqual(got, []string{"price", "qty", "total"}) {
+		t.Errorf("Unexpected columns %v", got)
+	}
+	total, _ := withTotal.Select("total")
+	if !reflect.DeepEqual(total.Values(), []any{5.0, 12.0}) {
+		t.Errorf("Expected totals [5 12], got %v", total.Values())
+	}
+	if df.Ncols() != 2 {
+		t.Errorf("Expected the original DataFrame to be unchanged, got %d columns", df.Ncols())
+	}
+
+	// replacing keeps the position of the column
+	replaced, err := withTotal.WithColumn("price", withTotal.Col("price").Mul(2))
+	if err != nil {
+		t.Fatalf("WithColumn failed: %v", err)
+	}
+	price, _ := replaced.Select("price")
+	if got := replaced.ColumnNames(); got[0] != "price" || !reflect.DeepEqual(price.Values(), []any{5.0, 8.0}) {
+		t.Errorf("Expected price replaced in place, got %v with %v", got, price.Values())
+	}
+
+	if _, err := df.WithColumn("bad", df.Col("missing").Mul(2)); err == nil {
+		t.Error("Expected the error of the series")
+	}
+	if _, err := df.WithColumn("bad", goframe.NewSeries("bad", []any{1})); err == nil {
+		t.Error("Expected an error for a length mismatch")
+	}
+}
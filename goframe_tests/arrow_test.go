@@ -0,0 +1,124 @@
+package goframe_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestArrowRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{1, 2, int64(3)}))
+	df.AddColumn(goframe.NewColumn("score", []any{1.5, nil, 3}))
+	df.AddColumn(goframe.NewColumn("name", []any{"a", "b", nil}))
+	df.AddColumn(goframe.NewColumn("ok", []any{true, false, true}))
+	df.AddColumn(goframe.NewColumn("at", []any{ts, nil, ts}))
+	df.AddColumn(goframe.NewColumn("empty", []any{nil, nil, nil}))
+
+	record, err := df.ToArrowRecord(goframe.ArrowOption{Allocator: mem})
+	if err != nil {
+		t.Fatalf("ToArrowRecord failed: %v", err)
+	}
+	defer record.Release()
+
+	expectedTypes := []arrow.Type{arrow.INT64, arrow.FLOAT64, arrow.STRING, arrow.BOOL, arrow.TIMESTAMP, arrow.NULL}
+	for i, field := range record.Schema().Fields() {
+		if field.Type.ID() != expectedTypes[i] {
+			t.Errorf("Field '%s': expected type %s, got %s", field.Name, expectedTypes[i], field.Type)
+		}
+	}
+
+	back, err := goframe.FromArrowRecord(record)
+	if err != nil {
+		t.Fatalf("FromArrowRecord failed: %v", err)
+	}
+	if !reflect.DeepEqual(back.ColumnNames(), df.ColumnNames()) {
+		t.Fatalf("Expected columns %v, got %v", df.ColumnNames(), back.ColumnNames())
+	}
+	expected := map[string][]any{
+		"id":    {int64(1), int64(2), int64(3)},
+		"score": {1.5, nil, 3.0},
+		"name":  {"a", "b", nil},
+		"ok":    {true, false, true},
+		"empty": {nil, nil, nil},
+	}
+	for name, values := range expected {
+		col, _ := back.Select(name)
+		if !reflect.DeepEqual(col.Data, values) {
+			t.Errorf("Column '%s': expected %v, got %v", name, values, col.Data)
+		}
+	}
+	at, _ := back.Select("at")
+	if got, ok := at.Data[0].(time.Time); !ok || !got.Equal(ts) || at.Data[1] != nil {
+		t.Errorf("Expected timestamps [%v <nil> %v], got %v", ts, ts, at.Data)
+	}
+
+	t.Run("MixedTypes", func(t *testing.T) {
+		mixed := goframe.NewDataFrame()
+		mixed.AddColumn(goframe.NewColumn("v", []any{1.0, ""}))
+		if _, err := mixed.ToArrowRecord(goframe.ArrowOption{Allocator: mem}); err == nil {
+			t.Error("Expected error for mixed types, got nil")
+		}
+	})
+}
+
+func TestArrowRecordBatches(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	df := goframe.NewTypedDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("n", []int{1, 2, 3, 4, 5})))
+
+	records, err := df.ToArrowRecords(goframe.ArrowOption{Allocator: mem, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("ToArrowRecords failed: %v", err)
+	}
+	defer func() {
+		for _, record := range records {
+			record.Release()
+		}
+	}()
+	if len(records) != 3 || records[2].NumRows() != 1 {
+		t.Fatalf("Expected batches of 2, 2 and 1 rows, got %d batches", len(records))
+	}
+
+	back, err := goframe.FromArrowRecords(records)
+	if err != nil {
+		t.Fatalf("FromArrowRecords failed: %v", err)
+	}
+	col, _ := back.Select("n")
+	if !reflect.DeepEqual(col.Data, []any{int64(1), int64(2), int64(3), int64(4), int64(5)}) {
+		t.Errorf("Unexpected values %v", col.Data)
+	}
+
+	reader, err := array.NewRecordReader(records[0].Schema(), records)
+	if err != nil {
+		t.Fatalf("NewRecordReader failed: %v", err)
+	}
+	defer reader.Release()
+	streamed, err := goframe.FromArrowReader(reader)
+	if err != nil {
+		t.Fatalf("FromArrowReader failed: %v", err)
+	}
+	if streamed.Nrows() != 5 {
+		t.Errorf("Expected 5 rows, got %d", streamed.Nrows())
+	}
+
+	other := goframe.NewDataFrame()
+	other.AddColumn(goframe.NewColumn("n", []any{"x"}))
+	otherRecord, _ := other.ToArrowRecord(goframe.ArrowOption{Allocator: mem})
+	defer otherRecord.Release()
+	if _, err := goframe.FromArrowRecords([]arrow.Record{records[0], otherRecord}); err == nil {
+		t.Error("Expected error for differing schemas, got nil")
+	}
+}
@@ -0,0 +1,162 @@
+package goframe_test
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+// benchmarkFrame returns a frame of n rows whose "id" column has n/2 distinct values
+func benchmarkFrame(n int, valueColumn string) *goframe.DataFrame {
+	ids := make([]any, n)
+	values := make([]any, n)
+	for i := range ids {
+		ids[i] = float64(i / 2)
+		values[i] = float64(i)
+	}
+	df := goframe.NewDataFrame()
+	_ = df.AddColumn(goframe.NewColumn("id", ids))
+	_ = df.AddColumn(goframe.NewColumn(valueColumn, values))
+	return df
+}
+
+func BenchmarkJoin(b *testing.B) {
+	left := benchmarkFrame(100_000, "a")
+	right := benchmarkFrame(100_000, "b")
+	joins := map[string]func(*goframe.DataFrame, string) (*goframe.DataFrame, error){
+		"Inner": left.InnerJoin,
+		"Left":  left.LeftJoin,
+		"Right": left.RightJoin,
+		"Outer": left.OuterJoin,
+	}
+	for _, name := range []string{"Inner", "Left", "Right", "Outer"} {
+		b.Run(fmt.Sprintf("%s/100k", name), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := joins[name](right, "id"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	df := benchmarkFrame(1_000_000, "value")
+	b.Run("Map/1M", func(b *testing.B) {
+		for b.Loop() {
+			df.Filter(func(row map[string]any) bool { return row["value"].(float64) > 500_000 })
+		}
+	})
+	b.Run("Accessor/1M", func(b *testing.B) {
+		for b.Loop() {
+			df.FilterRows(func(get func(string) any) bool { return get("value").(float64) > 500_000 })
+		}
+	})
+}
+
+func BenchmarkQuery(b *testing.B) {
+	df := benchmarkFrame(1_000_000, "value")
+	b.Run("Accessor/1M", func(b *testing.B) {
+		for b.Loop() {
+			df.FilterRows(func(get func(string) any) bool {
+				id, value := get("id").(float64), get("value").(float64)
+				return value*2-id > 1_400_000 && math.Mod(id, 10) == 0
+			})
+		}
+	})
+	for name, frame := range map[string]*goframe.DataFrame{"Query/1M": df, "QueryTyped/1M": df.ToTyped()} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := frame.Query("value * 2 - id > 1400000 && id % 10 == 0"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAppendRows(b *testing.B) {
+	rows := make([]map[string]any, 10_000)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i, "name": fmt.Sprintf("row%d", i), "value": float64(i)}
+	}
+	b.Run("Append/10k", func(b *testing.B) {
+		for b.Loop() {
+			df := goframe.NewDataFrame()
+			for _, row := range rows {
+				if err := df.Append(row); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("AppendRows/10k", func(b *testing.B) {
+		for b.Loop() {
+			if err := goframe.NewDataFrame().AppendRows(rows); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("FromRows/10k", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := goframe.FromRows(rows, []string{"id", "name", "value"}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDuplicated(b *testing.B) {
+	df := benchmarkFrame(100_000, "value")
+	for b.Loop() {
+		if _, err := df.Duplicated([]string{"id"}, "first"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParallelism compares sequential and parallel row-wise operations, the speedup grows with the cores:
+// go test ./goframe_tests -run '^$' -bench Parallelism -cpu 1,4,8
+func BenchmarkParallelism(b *testing.B) {
+	sequential := benchmarkFrame(1_000_000, "value")
+	right := benchmarkFrame(1_000_000, "other")
+	frames := []struct {
+		name string
+		df   *goframe.DataFrame
+	}{
+		{"Sequential", sequential},
+		{"Parallel", sequential.WithParallelism(runtime.GOMAXPROCS(0))},
+	}
+	for _, frame := range frames {
+		name, df := frame.name, frame.df
+		b.Run("Filter/"+name+"/1M", func(b *testing.B) {
+			for b.Loop() {
+				df.Filter(func(row map[string]any) bool { return row["value"].(float64) > 500_000 })
+			}
+		})
+		b.Run("ApplyRows/"+name+"/1M", func(b *testing.B) {
+			for b.Loop() {
+				if _, err := df.ApplyRows(func(row goframe.Row) (any, error) { return row["value"].(float64) * 2, nil }); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run("Groupby/"+name+"/1M", func(b *testing.B) {
+			for b.Loop() {
+				if _, err := df.Groupby("id").Sum("value"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run("InnerJoin/"+name+"/1M", func(b *testing.B) {
+			for b.Loop() {
+				if _, err := df.InnerJoin(right, "id"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package goframe_test
+
+import (
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+type employee struct {
+	ID       int     `goframe:"id,required"`
+	Name     string  `goframe:"name,required"`
+	Salary   float64 // matched by name, case-insensitive
+	Manager  *string `goframe:"manager"`
+	Active   bool    `goframe:"active"`
+	Internal string  `goframe:"-"`
+}
+
+func TestBindAndValidate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("id", []float64{1, 2})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice", "Bob"})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("salary", []string{"5000.5", "6000"})))
+		df.AddColumn(&goframe.Column[any]{Name: "manager", Data: []any{nil, "Alice"}})
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("active", []string{"true", "false"})))
+
+		rows, err := goframe.BindAndValidate[employee](df)
+		if err != nil {
+			t.Fatalf("BindAndValidate failed: %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d", len(rows))
+		}
+		if rows[0].ID != 1 || rows[0].Name != "Alice" || rows[0].Salary != 5000.5 || !rows[0].Active {
+			t.Errorf("Unexpected first row: %+v", rows[0])
+		}
+		if rows[0].Manager != nil {
+			t.Errorf("Expected nil manager, got %v", *rows[0].Manager)
+		}
+		if rows[1].Manager == nil || *rows[1].Manager != "Alice" {
+			t.Errorf("Expected manager Alice, got %v", rows[1].Manager)
+		}
+	})
+
+	t.Run("MissingRequiredColumn", func(t *testing.T) {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice"})))
+
+		_, err := goframe.BindAndValidate[employee](df)
+		if err == nil || !strings.Contains(err.Error(), "required column 'id'") {
+			t.Errorf("Expected missing column error, got %v", err)
+		}
+	})
+
+	t.Run("InvalidCells", func(t *testing.T) {
+		df := goframe.NewDataFrame()
+		df.AddColumn(&goframe.Column[any]{Name: "id", Data: []any{1.5, 2, nil}})
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice", "Bob", "Charlie"})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("salary", []string{"1", "lots", "3"})))
+
+		_, err := goframe.BindAndValidate[employee](df)
+		if err == nil {
+			t.Fatalf("Expected validation errors, got nil")
+		}
+		for _, want := range []string{"row 0, column 'id'", "row 1, column 'salary'", "row 2, column 'id': required value is nil"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error to contain %q, got %v", want, err)
+			}
+		}
+	})
+
+	t.Run("NotAStruct", func(t *testing.T) {
+		if _, err := goframe.BindAndValidate[int](goframe.NewDataFrame()); err == nil {
+			t.Errorf("Expected error for non struct type, got nil")
+		}
+	})
+}
+
+func TestRows(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("id", []any{1, 2, 3}))
+	df.AddColumn(goframe.NewColumn[any]("name", []any{"Alice", nil, "Carol"}))
+	df.AddColumn(goframe.NewColumn[any]("salary", []any{5000.5, 6000, "unknown"}))
+
+	var names []string
+	for row := range goframe.Rows[employee](df) {
+		names = append(names, row.Name)
+	}
+	if strings.Join(names, ",") != "Alice,,Carol" {
+		t.Errorf("expected every row when ignoring errors, got %v", names)
+	}
+
+	var failed []int
+	for row, err := range goframe.Rows[employee](df) {
+		if err != nil {
+			failed = append(failed, row.ID)
+			continue
+		}
+		if row.ID != 1 || row.Salary != 5000.5 {
+			t.Errorf("unexpected row: %+v", row)
+		}
+	}
+	if len(failed) != 2 || failed[0] != 2 || failed[1] != 3 {
+		t.Errorf("expected rows 2 and 3 to fail validation, got %v", failed)
+	}
+
+	// stopping early does not visit the other rows
+	count := 0
+	for range goframe.Rows[employee](df) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected to stop after one row, got %d", count)
+	}
+
+	missing := goframe.NewDataFrame()
+	missing.AddColumn(goframe.NewColumn[any]("name", []any{"Alice"}))
+	for _, err := range goframe.Rows[employee](missing) {
+		if err == nil || !strings.Contains(err.Error(), "required column 'id'") {
+			t.Errorf("expected a missing column error, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package goframe_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+	"github.com/kishyassin/goframe/calendar"
+)
+
+func TestCalendarHolidays(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	list := func(holidays []calendar.Holiday) []string {
+		var lines []string
+		for _, h := range holidays {
+			lines = append(lines, fmt.Sprintf("%s %s", h.Date.Format("01-02"), h.Name))
+		}
+		return lines
+	}
+
+	us := list(calendar.USFederal().Holidays(2021))
+	expectedUS := []string{
+		"01-01 New Year's Day", "01-18 Martin Luther King Jr. Day", "02-15 Washington's Birthday", "05-31 Memorial Day",
+		"06-18 Juneteenth (observed)", "06-19 Juneteenth", "07-04 Independence Day", "07-05 Independence Day (observed)",
+		"09-06 Labor Day", "10-11 Columbus Day", "11-11 Veterans Day", "11-25 Thanksgiving Day",
+		"12-24 Christmas Day (observed)", "12-25 Christmas Day", "12-31 New Year's Day (observed)",
+	}
+	if !reflect.DeepEqual(us, expectedUS) {
+		t.Errorf("US 2021: expected %v, got %v", expectedUS, us)
+	}
+
+	// substitute days skip the holidays already taken
+	uk := calendar.UKEnglandWales()
+	for _, tt := range []struct {
+		year     int
+		expected []string
+	}{
+		{2021, []string{"12-25 Christmas Day", "12-26 Boxing Day", "12-27 Christmas Day (observed)", "12-28 Boxing Day (observed)"}},
+		{2022, []string{"12-25 Christmas Day", "12-26 Boxing Day", "12-27 Christmas Day (observed)"}},
+	} {
+		holidays := list(uk.Holidays(tt.year))
+		if got := holidays[len(holidays)-len(tt.expected):]; !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("UK %d: expected %v at the end, got %v", tt.year, tt.expected, holidays)
+		}
+	}
+	if name, ok := uk.Holiday(day(2024, time.March, 29)); !ok || name != "Good Friday" {
+		t.Errorf("expected Good Friday on 2024-03-29, got %q", name)
+	}
+	if name, ok := uk.Holiday(time.Date(2022, 1, 3, 15, 0, 0, 0, time.UTC)); !ok || name != "New Year's Day (observed)" {
+		t.Errorf("expected New Year's Day observed on 2022-01-03, got %q", name)
+	}
+
+	// a custom calendar with a Friday and Saturday weekend
+	custom := calendar.NewRuleCalendar("Custom",
+		calendar.Fixed("Founding Day", time.March, 1).Observed(calendar.NextWeekday).Years(2000, 2023),
+		calendar.NthWeekday("Harvest Day", time.October, time.Thursday, -2),
+		calendar.Custom("Jubilee", func(year int) []time.Time {
+			if year == 2025 {
+				return []time.Time{day(2025, time.June, 2)}
+			}
+			return nil
+		}),
+	)
+	custom.Weekend = []time.Weekday{time.Friday, time.Saturday}
+	expectedCustom := map[int][]string{
+		2019: {"03-01 Founding Day", "03-03 Founding Day (observed)", "10-24 Harvest Day"},
+		2025: {"06-02 Jubilee", "10-23 Harvest Day"},
+	}
+	for year, expected := range expectedCustom {
+		if got := list(custom.Holidays(year)); !reflect.DeepEqual(got, expected) {
+			t.Errorf("custom %d: expected %v, got %v", year, expected, got)
+		}
+	}
+	if calendar.BusinessDay(custom, day(2025, time.June, 6)) || !calendar.BusinessDay(custom, day(2025, time.June, 8)) {
+		t.Error("expected Friday to be a weekend day and Sunday a business day")
+	}
+}
+
+func TestCalendarFeatures(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("date", []any{
+		time.Date(2021, 7, 5, 9, 0, 0, 0, time.UTC), time.Date(2021, 7, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 7, 3, 0, 0, 0, 0, time.UTC), nil, "2021-07-07",
+	}))
+	df.AddColumn(goframe.NewColumn("orders", []any{5, 7, 1, 3, 2}))
+	us := calendar.USFederal()
+
+	tests := []struct {
+		name     string
+		series   *goframe.Series
+		expected []any
+	}{
+		{"IsHoliday", calendar.IsHoliday(df.Col("date"), us), []any{true, false, false, false, false}},
+		{"IsBusinessDay", calendar.IsBusinessDay(df.Col("date"), us), []any{false, true, false, false, false}},
+		{"HolidayName", calendar.HolidayName(df.Col("date"), us), []any{"Independence Day (observed)", nil, nil, nil, nil}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.series.Data, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.series.Data)
+		}
+	}
+
+	features, err := calendar.AddFeatures(df, "date", us)
+	if err != nil {
+		t.Fatalf("AddFeatures failed: %v", err)
+	}
+	busy, err := features.Query("date_is_business_day && orders > 1")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if got := busy.Columns["orders"].Data; !reflect.DeepEqual(got, []any{7}) {
+		t.Errorf("expected [7], got %v", got)
+	}
+	if _, err := calendar.AddFeatures(df, "missing", us); err == nil {
+		t.Error("expected an error for a missing column")
+	}
+}
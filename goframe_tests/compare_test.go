@@ -0,0 +1,135 @@
+package goframe_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+// recordingT records the failures of AssertFrameEqual
+type recordingT struct {
+	messages []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func TestCompare(t *testing.T) {
+	ids := make([]any, 20)
+	prices := make([]any, 20)
+	for i := range ids {
+		ids[i] = i
+		prices[i] = float64(i) + 0.5
+	}
+	prices[19] = math.NaN()
+	expected, _ := goframe.FromColumns(goframe.NewColumn("id", ids), goframe.NewColumn("price", prices))
+
+	same, _ := goframe.FromColumns(goframe.NewColumn("id", append([]any{}, ids...)), goframe.NewColumn("price", append([]any{}, prices...)))
+	if diff := expected.Compare(same); !diff.Equal() || diff.String() != "DataFrames are equal" {
+		t.Errorf("expected equal frames, got %s", diff)
+	}
+	if !goframe.AssertFrameEqual(t, expected, same.ToTyped()) {
+		t.Errorf("expected typed storage to compare equal")
+	}
+
+	changed := append([]any{}, prices...)
+	changed[2] = 2.5000001
+	changed[14] = "14.5"
+	actual, _ := goframe.FromColumns(goframe.NewColumn("price", changed), goframe.NewColumn("id", append([]any{}, ids...)))
+
+	diff := expected.Compare(actual)
+	if diff.Equal() || len(diff.Cells) != 2 || !diff.ColumnOrder {
+		t.Fatalf("expected 2 different cells and a different column order, got %+v", diff.Cells)
+	}
+	if cell := diff.Cells[1]; cell.Row != 14 || cell.Column != "price" || cell.Expected != 14.5 || cell.Actual != "14.5" {
+		t.Errorf("unexpected cell diff %+v", cell)
+	}
+	output := diff.String()
+	for _, want := range []string{
+		"column order: expected id, price, got price, id",
+		"2 different cell(s):",
+		`row 14, column 'price': expected 14.5 (float64), got "14.5"`,
+		"2.5 (float64) -> 2.5000001 (float64)",
+		"...",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected the diff to contain %q, got:\n%s", want, output)
+		}
+	}
+	// the differing rows are marked and shown among two rows of context, row 9 is too far from both
+	if !strings.Contains(output, ">   2") || !strings.Contains(output, "4.5\n") || strings.Contains(output, "9.5\n") {
+		t.Errorf("unexpected context rows:\n%s", output)
+	}
+
+	if diff := expected.Compare(actual, goframe.CompareOption{Tolerance: 1e-3, IgnoreColumnOrder: true}); len(diff.Cells) != 1 || diff.ColumnOrder {
+		t.Errorf("expected only the string cell to differ with a tolerance, got %s", diff)
+	}
+	strict, _ := goframe.FromColumns(goframe.NewColumn("id", []any{0.0}))
+	loose, _ := goframe.FromColumns(goframe.NewColumn("id", []any{0}))
+	if !strict.Compare(loose).Equal() || strict.Compare(loose, goframe.CompareOption{StrictTypes: true}).Equal() {
+		t.Errorf("expected 0 and 0.0 to be equal unless the types are strict")
+	}
+
+	shorter, _ := goframe.FromColumns(goframe.NewColumn("id", ids[:8]), goframe.NewColumn("name", make([]any, 8)))
+	recorder := &recordingT{}
+	if goframe.AssertFrameEqual(recorder, expected, shorter) || len(recorder.messages) != 1 {
+		t.Fatalf("expected AssertFrameEqual to fail once, got %v", recorder.messages)
+	}
+	for _, want := range []string{"rows: expected 20, got 8", "missing columns: price", "extra columns: name"} {
+		if !strings.Contains(recorder.messages[0], want) {
+			t.Errorf("expected the failure to contain %q, got:\n%s", want, recorder.messages[0])
+		}
+	}
+}
+
+func TestEqualsAndHash(t *testing.T) {
+	frame := func(ids, amounts []any) *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("id", ids))
+		df.AddColumn(goframe.NewColumn("amount", amounts))
+		return df
+	}
+	expected := frame([]any{1, 2, 3}, []any{10.0, math.NaN(), 30.0})
+	shuffled := frame([]any{3.0, 1.0, 2.0}, []any{30.0, 10.0, math.NaN()})
+
+	if equal, diff := expected.Equals(shuffled); equal || len(diff.Cells) == 0 {
+		t.Errorf("expected the rows in a different order to differ")
+	}
+	if equal, diff := expected.Equals(shuffled, goframe.CompareOption{IgnoreRowOrder: true, Keys: []string{"id"}}); !equal {
+		t.Errorf("expected equal DataFrames ignoring the row order, got %s", diff)
+	}
+	if equal, _ := expected.Equals(shuffled, goframe.CompareOption{IgnoreRowOrder: true}); !equal {
+		t.Errorf("expected equal DataFrames sorted by every column")
+	}
+
+	changed := frame([]any{3, 1, 2}, []any{30.0, 10.5, math.NaN()})
+	equal, diff := expected.Equals(changed, goframe.CompareOption{IgnoreRowOrder: true, Keys: []string{"id"}})
+	if equal || len(diff.Cells) != 1 || diff.Cells[0].Row != 0 || diff.Cells[0].Column != "amount" {
+		t.Errorf("expected one differing amount on the first sorted row, got %+v", diff.Cells)
+	}
+	if equal, _ := expected.Equals(changed, goframe.CompareOption{IgnoreRowOrder: true, Keys: []string{"id"}, Tolerance: 0.5}); !equal {
+		t.Errorf("expected equal DataFrames within the tolerance")
+	}
+
+	// equal DataFrames hash the same, whatever the number types
+	if expected.Hash() != frame([]any{1.0, int64(2), float32(3)}, []any{10, math.NaN(), 30}).Hash() {
+		t.Errorf("expected equal DataFrames to have the same hash")
+	}
+	if expected.Hash() == shuffled.Hash() || expected.Hash() == changed.Hash() {
+		t.Errorf("expected different DataFrames to have different hashes")
+	}
+	renamed := frame([]any{1, 2, 3}, []any{10.0, math.NaN(), 30.0})
+	renamed.RenameColumn("amount", "total")
+	if expected.Hash() == renamed.Hash() {
+		t.Errorf("expected the column names to change the hash")
+	}
+	if frame([]any{"1"}, []any{nil}).Hash() == frame([]any{1}, []any{nil}).Hash() {
+		t.Errorf("expected a string and a number to hash differently")
+	}
+}
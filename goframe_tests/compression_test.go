@@ -0,0 +1,95 @@
+package goframe_test
+
+import (
+	"reflect"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestColumnCompression(t *testing.T) {
+	for _, kind := range []string{"rle", "dict"} {
+		t.Run(kind, func(t *testing.T) {
+			values := []any{"IT", "IT", "HR", nil, "HR", "HR", "IT"}
+			col := &goframe.Column[any]{Name: "dept", Data: append([]any{}, values...)}
+
+			if err := col.Compress(kind); err != nil {
+				t.Fatalf("Compress failed: %v", err)
+			}
+			if !col.IsCompressed() {
+				t.Errorf("Expected column to be compressed")
+			}
+			if col.Len() != len(values) {
+				t.Errorf("Expected length %d, got %d", len(values), col.Len())
+			}
+			for i, want := range values {
+				got, err := col.At(i)
+				if err != nil {
+					t.Fatalf("At(%d) failed: %v", i, err)
+				}
+				if got != want {
+					t.Errorf("Index %d: expected %v, got %v", i, want, got)
+				}
+			}
+			if _, err := col.At(len(values)); err == nil {
+				t.Errorf("Expected error for out-of-bounds access, got nil")
+			}
+			if !reflect.DeepEqual(col.Values(), values) {
+				t.Errorf("Expected values %v, got %v", values, col.Values())
+			}
+
+			col.Decompress()
+			if col.IsCompressed() || !reflect.DeepEqual(col.Data, values) {
+				t.Errorf("Expected decompressed data %v, got %v", values, col.Data)
+			}
+		})
+	}
+
+	t.Run("DataFrame", func(t *testing.T) {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("dept", []string{"IT", "IT", "HR"})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("salary", []float64{10, 20, 30})))
+
+		if err := df.CompressColumns("dict"); err != nil {
+			t.Fatalf("CompressColumns failed: %v", err)
+		}
+		if !df.Columns["dept"].IsCompressed() || df.Columns["salary"].IsCompressed() {
+			t.Errorf("Expected only the string column to be compressed")
+		}
+
+		// readers using the accessor API keep working
+		row, err := df.Row(2)
+		if err != nil {
+			t.Fatalf("Row failed: %v", err)
+		}
+		if row["dept"] != "HR" {
+			t.Errorf("Expected dept HR, got %v", row["dept"])
+		}
+		if df.Head(2).Nrows() != 2 {
+			t.Errorf("Expected 2 rows from Head")
+		}
+		dept, _ := df.Select("dept")
+		if !reflect.DeepEqual(dept.Data, []any{"IT", "IT", "HR"}) {
+			t.Errorf("Expected Select to return the decoded data, got %v", dept.Data)
+		}
+		if !df.Columns["dept"].IsCompressed() {
+			t.Errorf("Expected Select to leave the column compressed")
+		}
+
+		df.DecompressColumns()
+		if !reflect.DeepEqual(df.Columns["dept"].Data, []any{"IT", "IT", "HR"}) {
+			t.Errorf("Unexpected data after decompressing: %v", df.Columns["dept"].Data)
+		}
+
+		if err := df.CompressColumns("zip", "dept"); err == nil {
+			t.Errorf("Expected error for unknown compression kind, got nil")
+		}
+	})
+
+	t.Run("NotComparable", func(t *testing.T) {
+		col := &goframe.Column[any]{Name: "tags", Data: []any{[]string{"a"}}}
+		if err := col.Compress("dict"); err == nil {
+			t.Errorf("Expected error for non comparable values, got nil")
+		}
+	})
+}
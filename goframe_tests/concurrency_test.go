@@ -0,0 +1,268 @@
+package goframe_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+// These tests are meant to run with the race detector: go test -race ./goframe_tests -run Concurrent
+
+// concurrencyFrame returns a frame of n rows indexed by its "id" column
+func concurrencyFrame(t *testing.T, n int) *goframe.DataFrame {
+	t.Helper()
+	ids := make([]any, n)
+	groups := make([]any, n)
+	values := make([]any, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id%d", i)
+		groups[i] = fmt.Sprintf("g%d", i%3)
+		values[i] = float64(i)
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", ids))
+	df.AddColumn(goframe.NewColumn("group", groups))
+	df.AddColumn(goframe.NewColumn("value", values))
+	if err := df.SetIndex("id"); err != nil {
+		t.Fatalf("SetIndex failed: %v", err)
+	}
+	return df
+}
+
+func TestConcurrentReaders(t *testing.T) {
+	df := concurrencyFrame(t, 200)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				label := fmt.Sprintf("id%d", (g*50+i)%200)
+				if value, err := df.At(label, "value"); err != nil || value != float64((g*50+i)%200) {
+					errs <- fmt.Errorf("At(%s) = %v, %v", label, value, err)
+					return
+				}
+				if _, err := df.Loc([]any{label}, []string{"value"}); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := df.Row(i); err != nil {
+					errs <- err
+					return
+				}
+				col, err := df.Select("value")
+				if err != nil || col.Len() != 200 {
+					errs <- fmt.Errorf("Select returned %v rows, %v", col.Len(), err)
+					return
+				}
+				df.Filter(func(row map[string]any) bool { return row["group"] == "g1" })
+				df.Head(5)
+				df.Tail(5)
+				if _, err := df.Groupby("group").Sum("value"); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestConcurrentDataFrame(t *testing.T) {
+	shared := goframe.NewConcurrentDataFrame(concurrencyFrame(t, 10))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 10; i < 110; i++ {
+			if err := shared.Append(map[string]any{"id": fmt.Sprintf("id%d", i), "group": "g0", "value": float64(i)}); err != nil {
+				t.Errorf("Append failed: %v", err)
+				return
+			}
+		}
+	}()
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				col, err := shared.Select("value")
+				if err != nil {
+					t.Errorf("Select failed: %v", err)
+					return
+				}
+				// the copy is consistent: values are the row positions
+				for i, v := range col.Data {
+					if v != float64(i) {
+						t.Errorf("row %d holds %v", i, v)
+						return
+					}
+				}
+				if _, err := shared.Row(0); err != nil {
+					t.Errorf("Row failed: %v", err)
+					return
+				}
+				shared.Filter(func(row map[string]any) bool { return row["value"].(float64) > 5 })
+				_ = shared.Read(func(df *goframe.DataFrame) error {
+					_, err := df.At("id3", "value")
+					return err
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if shared.Nrows() != 110 {
+		t.Errorf("Expected 110 rows, got %d", shared.Nrows())
+	}
+	snapshot := shared.Snapshot()
+	_ = shared.Write(func(df *goframe.DataFrame) error { return df.DropRow(0) })
+	if snapshot.Nrows() != 110 || shared.Nrows() != 109 {
+		t.Errorf("Expected the snapshot to keep 110 rows, got %d and %d", snapshot.Nrows(), shared.Nrows())
+	}
+}
+
+func TestViewsDoNotShareWrites(t *testing.T) {
+	df := concurrencyFrame(t, 5)
+	// appending grows the storage beyond the rows, views must not write into the spare capacity
+	if err := df.Append(map[string]any{"id": "id5", "group": "g2", "value": 5.0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	head := df.Head(3)
+	tail := df.Tail(3)
+
+	if err := head.Append(map[string]any{"id": "new", "group": "g9", "value": -1.0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := tail.Append(map[string]any{"id": "new", "group": "g9", "value": -2.0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := df.Append(map[string]any{"id": "id6", "group": "g0", "value": 6.0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := df.DropRow(0); err != nil {
+		t.Fatalf("DropRow failed: %v", err)
+	}
+
+	expect := map[string]struct {
+		df   *goframe.DataFrame
+		want []any
+	}{
+		"original": {df, []any{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}},
+		"head":     {head, []any{0.0, 1.0, 2.0, -1.0}},
+		"tail":     {tail, []any{3.0, 4.0, 5.0, -2.0}},
+	}
+	for name, e := range expect {
+		col, _ := e.df.Select("value")
+		if got := col.Values(); !reflect.DeepEqual(got, e.want) {
+			t.Errorf("%s: expected %v, got %v", name, e.want, got)
+		}
+	}
+}
+
+func TestParallelism(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	sequential := randomFrame(r, 1000, "a", "b")
+	other := randomFrame(r, 300, "c")
+	parallel := sequential.WithParallelism(4)
+	if sequential.Parallelism() != 1 || parallel.Parallelism() != 4 {
+		t.Fatalf("expected parallelism 1 and 4, got %d and %d", sequential.Parallelism(), parallel.Parallelism())
+	}
+
+	operations := map[string]func(df *goframe.DataFrame) (*goframe.DataFrame, error){
+		"Filter": func(df *goframe.DataFrame) (*goframe.DataFrame, error) {
+			return df.Filter(func(row map[string]any) bool { return row["key"] != nil }), nil
+		},
+		"FilterRows": func(df *goframe.DataFrame) (*goframe.DataFrame, error) {
+			return df.FilterRows(func(get func(string) any) bool { _, ok := get("a").(float64); return ok }), nil
+		},
+		"ApplyRows": func(df *goframe.DataFrame) (*goframe.DataFrame, error) {
+			series, err := df.ApplyRows(func(row goframe.Row) (any, error) { return fmt.Sprint(row["a"], row["b"]), nil })
+			if err != nil {
+				return nil, err
+			}
+			return df.WithColumn("ab", series)
+		},
+		"Groupby": func(df *goframe.DataFrame) (*goframe.DataFrame, error) {
+			return df.Groupby("key").Agg(map[string][]string{"a": {"sum", "first"}, "b": {"count"}})
+		},
+		"GroupbyList": func(df *goframe.DataFrame) (*goframe.DataFrame, error) {
+			return df.Groupby([]string{"key", "b"}).Sum("a")
+		},
+		"InnerJoin": func(df *goframe.DataFrame) (*goframe.DataFrame, error) { return df.InnerJoin(other, "key") },
+		"RightJoin": func(df *goframe.DataFrame) (*goframe.DataFrame, error) { return df.RightJoin(other, "key") },
+		"OuterJoin": func(df *goframe.DataFrame) (*goframe.DataFrame, error) {
+			return df.Join(other, []string{"key"}, "outer", [2]string{})
+		},
+	}
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			expected, err := operation(sequential)
+			if err != nil {
+				t.Fatalf("sequential %s failed: %v", name, err)
+			}
+			got, err := operation(parallel)
+			if err != nil {
+				t.Fatalf("parallel %s failed: %v", name, err)
+			}
+			if frameString(got) != frameString(expected) {
+				t.Errorf("results differ in parallel\nexpected:\n%s\ngot:\n%s", frameString(expected), frameString(got))
+			}
+		})
+	}
+
+	if filtered := parallel.Filter(func(map[string]any) bool { return true }); filtered.Parallelism() != 4 {
+		t.Errorf("expected the filtered rows to keep the parallelism, got %d", filtered.Parallelism())
+	}
+	_, err := parallel.ApplyRows(func(row goframe.Row) (any, error) {
+		if row["key"] == nil {
+			return nil, fmt.Errorf("no key")
+		}
+		return row["key"], nil
+	})
+	_, expected := sequential.ApplyRows(func(row goframe.Row) (any, error) {
+		if row["key"] == nil {
+			return nil, fmt.Errorf("no key")
+		}
+		return row["key"], nil
+	})
+	if err == nil || err.Error() != expected.Error() {
+		t.Errorf("expected the error of the first failing row %v, got %v", expected, err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != "boom" {
+			t.Errorf("expected the panic of the predicate, got %v", recovered)
+		}
+	}()
+	parallel.FilterRows(func(get func(string) any) bool { panic("boom") })
+}
+
+func TestWithParallelismCopyOnWrite(t *testing.T) {
+	// the columns are copied on the first write in place to either DataFrame
+	source := goframe.NewDataFrame()
+	source.AddColumn(goframe.NewColumn("v", []any{1.0, nil, 3.0}))
+	view := source.WithParallelism(2)
+	view.FillNa(0.0)
+	if got := source.Columns["v"].Data; !reflect.DeepEqual(got, []any{1.0, nil, 3.0}) {
+		t.Errorf("expected FillNa on the parallel DataFrame to leave the source unchanged, got %v", got)
+	}
+	view = source.WithParallelism(2)
+	if err := source.DropNa(); err != nil {
+		t.Fatalf("DropNa failed: %v", err)
+	}
+	if view.Nrows() != 3 || source.Nrows() != 2 {
+		t.Errorf("expected DropNa on the source to leave the parallel DataFrame unchanged, got %d and %d rows", view.Nrows(), source.Nrows())
+	}
+}
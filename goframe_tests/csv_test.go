@@ -0,0 +1,582 @@
+package goframe_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestFromCSVGlob(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"sales_2025-01-01.csv": "store,amount\nA,10\nB,20\n",
+		"sales_2025-01-02.csv": "store,amount,refund\nA,30,1\n",
+		"notes.txt":            "not,a,match\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	df, err := goframe.FromCSVGlob(filepath.Join(dir, "sales_*.csv"), goframe.CSVGlobOption{Workers: 2})
+	if err != nil {
+		t.Fatalf("FromCSVGlob failed: %v", err)
+	}
+
+	if df.Nrows() != 3 {
+		t.Fatalf("Expected 3 rows, got %d", df.Nrows())
+	}
+
+	sourceCol, err := df.Select("source_file")
+	if err != nil {
+		t.Fatalf("Expected a source_file column: %v", err)
+	}
+	expectedSources := []string{"sales_2025-01-01.csv", "sales_2025-01-01.csv", "sales_2025-01-02.csv"}
+	for i, want := range expectedSources {
+		if filepath.Base(sourceCol.Data[i].(string)) != want {
+			t.Errorf("Index %d: expected source %s, got %v", i, want, sourceCol.Data[i])
+		}
+	}
+
+	amountCol, _ := df.Select("amount")
+	expectedAmounts := []float64{10, 20, 30}
+	for i, want := range expectedAmounts {
+		if amountCol.Data[i] != want {
+			t.Errorf("Index %d: expected amount %v, got %v", i, want, amountCol.Data[i])
+		}
+	}
+
+	// the refund column only exists in the second file
+	refundCol, _ := df.Select("refund")
+	if refundCol.Data[0] != nil || refundCol.Data[1] != nil || refundCol.Data[2] != 1.0 {
+		t.Errorf("Expected refund column [nil nil 1], got %v", refundCol.Data)
+	}
+
+	t.Run("CustomSourceColumn", func(t *testing.T) {
+		df, err := goframe.FromCSVGlob(filepath.Join(dir, "sales_*.csv"), goframe.CSVGlobOption{SourceColumn: "file"})
+		if err != nil {
+			t.Fatalf("FromCSVGlob failed: %v", err)
+		}
+		if _, err := df.Select("file"); err != nil {
+			t.Errorf("Expected a file column: %v", err)
+		}
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		if _, err := goframe.FromCSVGlob(filepath.Join(dir, "*.parquet")); err == nil {
+			t.Errorf("Expected error when no file matches, got nil")
+		}
+	})
+}
+
+func TestFromCSVWithSchema(t *testing.T) {
+	schema := goframe.Schema{
+		"id":      {Kind: "int64"},
+		"price":   {Kind: "float64"},
+		"active":  {Kind: "bool"},
+		"created": {Kind: "time", Layout: "2006-01-02"},
+		"zip":     {Kind: "string"},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		data := "id,price,active,created,zip,note\n1,9.5,true,2025-01-02,00123,a\n2,,false,2025-02-03,04567,7\n"
+		df, err := goframe.FromCSVWithSchema(strings.NewReader(data), schema)
+		if err != nil {
+			t.Fatalf("FromCSVWithSchema failed: %v", err)
+		}
+
+		expected := map[string][]any{
+			"id":      {int64(1), int64(2)},
+			"price":   {9.5, nil},
+			"active":  {true, false},
+			"created": {time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)},
+			"zip":     {"00123", "04567"},
+			"note":    {"a", 7.0},
+		}
+		for name, want := range expected {
+			col, err := df.Select(name)
+			if err != nil {
+				t.Fatalf("Column %s missing: %v", name, err)
+			}
+			for i, v := range want {
+				if col.Data[i] != v {
+					t.Errorf("Column %s row %d: expected %v (%T), got %v (%T)", name, i, v, v, col.Data[i], col.Data[i])
+				}
+			}
+		}
+	})
+
+	t.Run("CellErrors", func(t *testing.T) {
+		data := "id,price,active,created,zip\n1,abc,true,2025-01-02,1\nx,1,maybe,2025-01-02,2\n"
+		_, err := goframe.FromCSVWithSchema(strings.NewReader(data), schema)
+		if err == nil {
+			t.Fatalf("Expected parse errors, got nil")
+		}
+		for _, want := range []string{
+			`row 0 (line 2), column 'price': cannot parse "abc" as float64`,
+			`row 1 (line 3), column 'id': cannot parse "x" as int64`,
+			`row 1 (line 3), column 'active': cannot parse "maybe" as bool`,
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected error to contain %q, got %v", want, err)
+			}
+		}
+	})
+
+	t.Run("MissingColumn", func(t *testing.T) {
+		_, err := goframe.FromCSVWithSchema(strings.NewReader("id\n1\n"), schema)
+		if err == nil {
+			t.Errorf("Expected error for schema column missing from header, got nil")
+		}
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := goframe.FromCSVWithSchema(strings.NewReader("id\n1\n"), goframe.Schema{"id": {Kind: "decimal"}})
+		if err == nil {
+			t.Errorf("Expected error for unsupported type, got nil")
+		}
+	})
+}
+
+func TestFromCSVParseBools(t *testing.T) {
+	input := "active,flag,count,label\nyes,1,1,true\nNo,0,2,maybe\n,1,0,false\n"
+
+	df, err := goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{ParseBools: true})
+	if err != nil {
+		t.Fatalf("FromCSVReader failed: %v", err)
+	}
+	expected := map[string][]any{
+		"active": {true, false, nil},
+		"flag":   {true, false, true},
+		"count":  {1.0, 2.0, 0.0},
+		"label":  {"true", "maybe", "false"},
+	}
+	for name, values := range expected {
+		col, _ := df.Select(name)
+		if !reflect.DeepEqual(col.Data, values) {
+			t.Errorf("Column '%s': expected %v, got %v", name, values, col.Data)
+		}
+	}
+
+	// custom values replace the defaults, so 0/1 columns stay numeric
+	df, err = goframe.FromCSVReader(strings.NewReader("flag,state\n1,on\n0,off\n"), goframe.CSVReadOption{
+		ParseBools: true,
+		BoolOption: goframe.BoolOption{TrueValues: []string{"on"}, FalseValues: []string{"off"}},
+	})
+	if err != nil {
+		t.Fatalf("FromCSVReader failed: %v", err)
+	}
+	flag, _ := df.Select("flag")
+	state, _ := df.Select("state")
+	if !reflect.DeepEqual(flag.Data, []any{1.0, 0.0}) || !reflect.DeepEqual(state.Data, []any{true, false}) {
+		t.Errorf("Unexpected columns flag=%v state=%v", flag.Data, state.Data)
+	}
+
+	// disabled by default
+	df, _ = goframe.FromCSVReader(strings.NewReader("active\nyes\n"))
+	active, _ := df.Select("active")
+	if !reflect.DeepEqual(active.Data, []any{"yes"}) {
+		t.Errorf("Expected string values without ParseBools, got %v", active.Data)
+	}
+}
+
+func TestSeriesAsBool(t *testing.T) {
+	s := goframe.NewSeries("s", []any{"Yes", "false", 1, 0.0, true, nil, ""})
+	result, err := s.AsBool()
+	if err != nil {
+		t.Fatalf("AsBool failed: %v", err)
+	}
+	expected := []any{true, false, true, false, true, nil, nil}
+	if !reflect.DeepEqual(result.Data, expected) || result.Name != "s" {
+		t.Errorf("Expected %v, got %v", expected, result.Data)
+	}
+
+	custom, err := goframe.NewSeries("s", []any{"Y", "N"}).AsBool(goframe.BoolOption{TrueValues: []string{"y"}, FalseValues: []string{"n"}})
+	if err != nil || !reflect.DeepEqual(custom.Data, []any{true, false}) {
+		t.Errorf("Expected [true false], got %v (%v)", custom, err)
+	}
+
+	for _, invalid := range []any{"maybe", 2, 0.5} {
+		if _, err := goframe.NewSeries("s", []any{invalid}).AsBool(); err == nil {
+			t.Errorf("Expected error converting %v, got nil", invalid)
+		}
+	}
+}
+
+func TestFromCSVNumberLocale(t *testing.T) {
+	input := "price,share,total,sci\n\"€ 1.234,56\",45%,-1.000,\"1,5e3\"\n\"12,5 €\",\"2,5 %\",250,2e-2\n"
+
+	csvOpts := goframe.CSVReadOption{NumberOption: goframe.NumberOption{
+		DecimalSeparator:   ',',
+		ThousandsSeparator: '.',
+		CurrencySymbols:    []string{"€"},
+		Percent:            true,
+	}}
+	df, err := goframe.FromCSVReader(strings.NewReader(input), csvOpts)
+	if err != nil {
+		t.Fatalf("FromCSVReader failed: %v", err)
+	}
+	expected := map[string][]any{
+		"price": {1234.56, 12.5},
+		"share": {0.45, 0.025},
+		"total": {-1000.0, 250.0},
+		"sci":   {1500.0, 0.02},
+	}
+	for name, values := range expected {
+		col, _ := df.Select(name)
+		if len(col.Data) != len(values) {
+			t.Fatalf("Column '%s': expected %v, got %v", name, values, col.Data)
+		}
+		for i, v := range values {
+			if got, ok := col.Data[i].(float64); !ok || math.Abs(got-v.(float64)) > 1e-9 {
+				t.Errorf("Column '%s' row %d: expected %v, got %v", name, i, v, col.Data[i])
+			}
+		}
+	}
+
+	_, err = goframe.FromCSVReader(strings.NewReader("a\n1\n"), goframe.CSVReadOption{NumberOption: goframe.NumberOption{ThousandsSeparator: '.'}})
+	if err == nil {
+		t.Error("Expected error for identical separators, got nil")
+	}
+}
+
+func TestSeriesAsNumeric(t *testing.T) {
+	s := goframe.NewSeries("amount", []any{"$1,234", "45%", "1.5e2", 7, nil, ""})
+	result, err := s.AsNumeric(goframe.NumberOption{ThousandsSeparator: ',', CurrencySymbols: []string{"$"}, Percent: true})
+	if err != nil {
+		t.Fatalf("AsNumeric failed: %v", err)
+	}
+	expected := []any{1234.0, 0.45, 150.0, 7.0, nil, nil}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Errorf("Expected %v, got %v", expected, result.Data)
+	}
+
+	if _, err := goframe.NewSeries("s", []any{"1,234"}).AsNumeric(); err == nil {
+		t.Error("Expected error without a thousands separator, got nil")
+	}
+}
+
+func TestFromCSVReadOptions(t *testing.T) {
+	input := "id,amount,joined,note\n007,1.5,2024-01-02,NA\n042,N/A,,ok\n100,3,2024-03-04 10:00:00,\n"
+
+	t.Run("DTypesAndDates", func(t *testing.T) {
+		df, err := goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{
+			DTypes:     goframe.Schema{"id": {Kind: "string"}},
+			ParseDates: true,
+			NAValues:   []string{"NA", "N/A"},
+		})
+		if err != nil {
+			t.Fatalf("FromCSVReader failed: %v", err)
+		}
+		expected := map[string][]any{
+			"id":     {"007", "042", "100"},
+			"amount": {1.5, nil, 3.0},
+			"joined": {
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				nil,
+				time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC),
+			},
+			"note": {nil, "ok", ""},
+		}
+		for name, values := range expected {
+			col, _ := df.Select(name)
+			if !reflect.DeepEqual(col.Data, values) {
+				t.Errorf("Column '%s': expected %v, got %v", name, values, col.Data)
+			}
+		}
+	})
+
+	t.Run("CustomDateLayouts", func(t *testing.T) {
+		df, err := goframe.FromCSVReader(strings.NewReader("day,code\n02/01/2024,20240101\n"), goframe.CSVReadOption{
+			ParseDates:        true,
+			CustomDateLayouts: []string{"02/01/2006", "20060102"},
+		})
+		if err != nil {
+			t.Fatalf("FromCSVReader failed: %v", err)
+		}
+		day, _ := df.Select("day")
+		code, _ := df.Select("code")
+		if !reflect.DeepEqual(day.Data, []any{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}) {
+			t.Errorf("Expected the custom layout to be used, got %v", day.Data)
+		}
+		if !reflect.DeepEqual(code.Data, []any{20240101.0}) {
+			t.Errorf("Expected numeric columns to stay numeric, got %v", code.Data)
+		}
+	})
+
+	t.Run("DisableInference", func(t *testing.T) {
+		df, err := goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{
+			DisableInference: true,
+			DTypes:           goframe.Schema{"amount": {Kind: "float64"}},
+			NAValues:         []string{"N/A"},
+		})
+		if err != nil {
+			t.Fatalf("FromCSVReader failed: %v", err)
+		}
+		id, _ := df.Select("id")
+		amount, _ := df.Select("amount")
+		if !reflect.DeepEqual(id.Data, []any{"007", "042", "100"}) || !reflect.DeepEqual(amount.Data, []any{1.5, nil, 3.0}) {
+			t.Errorf("Unexpected columns id=%v amount=%v", id.Data, amount.Data)
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		_, err := goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{Strict: true})
+		if err == nil || !strings.Contains(err.Error(), "column 'amount'") || !strings.Contains(err.Error(), "line 3") {
+			t.Errorf("Expected a mixed type error for column 'amount' on line 3, got %v", err)
+		}
+
+		_, err = goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{Strict: true, NAValues: []string{"N/A"}})
+		if err != nil {
+			t.Errorf("Expected NA cells not to count as mixed types, got %v", err)
+		}
+	})
+
+	t.Run("InvalidDTypes", func(t *testing.T) {
+		_, err := goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{DTypes: goframe.Schema{"missing": {Kind: "int64"}}})
+		if err == nil {
+			t.Errorf("Expected error for missing column, got nil")
+		}
+		_, err = goframe.FromCSVReader(strings.NewReader(input), goframe.CSVReadOption{DTypes: goframe.Schema{"id": {Kind: "int64"}, "note": {Kind: "bool"}}})
+		if err == nil || !strings.Contains(err.Error(), "column 'note'") {
+			t.Errorf("Expected a parse error for column 'note', got %v", err)
+		}
+	})
+}
+
+func TestAppendCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.csv")
+	batch := func(ids ...any) *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("id", ids))
+		df.AddColumn(goframe.NewColumn("note", make([]any, len(ids))))
+		return df
+	}
+
+	// the first append writes the header
+	if err := batch(1, 2).AppendCSV(path); err != nil {
+		t.Fatalf("AppendCSV failed: %v", err)
+	}
+	if err := batch(3).AppendCSV(path); err != nil {
+		t.Fatalf("AppendCSV failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "id,note\n1,\n2,\n3,\n" {
+		t.Errorf("unexpected file content %q", content)
+	}
+
+	// a file without a final line break gets one before the appended rows
+	if err := os.WriteFile(path, []byte("id,note\n1,a"), 0o644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if err := batch(2).AppendCSV(path); err != nil {
+		t.Fatalf("AppendCSV failed: %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "id,note\n1,a\n2,\n" {
+		t.Errorf("unexpected file content %q", content)
+	}
+
+	other := goframe.NewDataFrame()
+	other.AddColumn(goframe.NewColumn("note", []any{"x"}))
+	if err := other.AppendCSV(path); err == nil || !strings.Contains(err.Error(), "do not match the header") {
+		t.Errorf("expected a header mismatch error, got %v", err)
+	}
+
+	t.Run("WriteError", func(t *testing.T) {
+		// every write to /dev/full fails with ENOSPC, after the rows were buffered
+		if _, err := os.Stat("/dev/full"); err != nil {
+			t.Skip("/dev/full is not available")
+		}
+		if err := batch(1).AppendCSV("/dev/full"); err == nil {
+			t.Errorf("expected the write error of a full device, got nil")
+		}
+	})
+
+	t.Run("AtomicWrite", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "out.csv")
+		if err := os.WriteFile(path, []byte("old\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+		if err := batch(1, 2).ToCSV(path, goframe.CSVWriteOption{AtomicWrite: true}); err != nil {
+			t.Fatalf("ToCSV failed: %v", err)
+		}
+		if content, _ := os.ReadFile(path); string(content) != "id,note\n1,\n2,\n" {
+			t.Errorf("unexpected file content %q", content)
+		}
+		if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+			t.Errorf("expected the permissions of the replaced file, got %v", info.Mode().Perm())
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Errorf("expected the temporary file to be renamed, found %d files", len(entries))
+		}
+
+		if err := batch(1).ToCSV(filepath.Join(dir, "missing", "out.csv"), goframe.CSVWriteOption{AtomicWrite: true}); err == nil {
+			t.Errorf("expected an error for a missing directory")
+		}
+	})
+}
+
+// failingWriter fails every write, like a full disk or a closed connection
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestToCSVWriterError(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{1, 2}))
+
+	// the rows fit in the buffer of the CSV writer, the write fails on the final flush
+	if err := df.ToCSVWriter(failingWriter{}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("expected the error of the writer, got %v", err)
+	}
+	if err := df.ToCSVWriter(failingWriter{}, goframe.CSVWriteOption{WriteSchema: true}); err == nil {
+		t.Errorf("expected the error of the writer with a schema, got nil")
+	}
+}
+
+func TestToCSVSplit(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{1, 2, 3, 4, 5}))
+	df.AddColumn(goframe.NewColumn("name", []any{"a", "bb", "ccc", "dddd", "eeeee"}))
+	read := func(paths []string) []string {
+		contents := make([]string, len(paths))
+		for i, path := range paths {
+			content, _ := os.ReadFile(path)
+			contents[i] = string(content)
+		}
+		return contents
+	}
+
+	dir := filepath.Join(t.TempDir(), "parts")
+	paths, err := df.ToCSVSplit(dir, goframe.CSVSplitOption{MaxRows: 2})
+	if err != nil {
+		t.Fatalf("ToCSVSplit failed: %v", err)
+	}
+	if len(paths) != 3 || filepath.Base(paths[0]) != "part-00001.csv" || filepath.Base(paths[2]) != "part-00003.csv" {
+		t.Fatalf("expected part-00001.csv to part-00003.csv, got %v", paths)
+	}
+	expected := []string{"id,name\n1,a\n2,bb\n", "id,name\n3,ccc\n4,dddd\n", "id,name\n5,eeeee\n"}
+	if got := read(paths); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	// the header takes 8 bytes, so "3,ccc\n" does not fit after "1,a\n2,bb\n"
+	paths, err = df.ToCSVSplit(dir, goframe.CSVSplitOption{MaxBytes: 20, Prefix: "small"})
+	if err != nil {
+		t.Fatalf("ToCSVSplit failed: %v", err)
+	}
+	expected = []string{"id,name\n1,a\n2,bb\n", "id,name\n3,ccc\n", "id,name\n4,dddd\n", "id,name\n5,eeeee\n"}
+	if got := read(paths); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	for _, path := range paths {
+		if info, _ := os.Stat(path); info.Size() > 20 || !strings.HasPrefix(filepath.Base(path), "small-") {
+			t.Errorf("%s: expected a small- file of at most 20 bytes, got %d bytes", path, info.Size())
+		}
+	}
+
+	empty := goframe.NewDataFrame()
+	empty.AddColumn(goframe.NewColumn("id", []any{}))
+	paths, err = empty.ToCSVSplit(t.TempDir(), goframe.CSVSplitOption{MaxRows: 10})
+	if err != nil || len(paths) != 1 {
+		t.Errorf("expected one file for an empty DataFrame, got %v and %v", paths, err)
+	}
+
+	if _, err := df.ToCSVSplit(dir, goframe.CSVSplitOption{}); err == nil {
+		t.Errorf("expected an error without limits")
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{"007", "042", nil}))
+	df.AddColumn(goframe.NewColumn("count", []any{1, nil, 3}))
+	df.AddColumn(goframe.NewColumn("ratio", []any{0.1 + 0.2, 1e21, math.NaN()}))
+	df.AddColumn(goframe.NewColumn("small", []any{float32(1.5), float32(0.1), nil}))
+	df.AddColumn(goframe.NewColumn("active", []any{true, false, nil}))
+	df.AddColumn(goframe.NewColumn("at", []any{time.Date(2024, 3, 1, 10, 0, 0, 123456789, zone), nil, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}))
+	df.AddColumn(goframe.NewColumn("mixed", []any{"a", 2.0, "c"}))
+	df.AddColumn(goframe.NewColumn("note", []any{"", nil, `\N`}))
+
+	var b strings.Builder
+	if err := df.ToCSVWriter(&b, goframe.CSVWriteOption{WriteSchema: true}); err != nil {
+		t.Fatalf("ToCSVWriter failed: %v", err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	if schema := lines[1]; schema != "string,int,float64,float32,bool,time:"+time.RFC3339Nano+",,string" {
+		t.Errorf("unexpected schema line %q", schema)
+	}
+	// nil and the empty string differ in string columns
+	if !strings.HasSuffix(lines[2], ",") || !strings.HasSuffix(lines[3], `,\N`) || !strings.HasSuffix(lines[4], `,\\N`) {
+		t.Errorf("expected an empty cell, \\N and \\\\N in the note column, got %q", lines[2:5])
+	}
+	read, err := goframe.FromCSVReader(strings.NewReader(b.String()), goframe.CSVReadOption{ReadSchema: true})
+	if err != nil {
+		t.Fatalf("FromCSVReader failed: %v", err)
+	}
+	goframe.AssertFrameEqual(t, df, read, goframe.CompareOption{StrictTypes: true})
+	if got := read.Columns["note"].Data; !reflect.DeepEqual(got, []any{"", nil, `\N`}) {
+		t.Errorf("expected the round trip to keep the empty strings apart from nil, got %q", got)
+	}
+	if got := read.Columns["at"].Data[0].(time.Time); !got.Equal(df.Columns["at"].Data[0].(time.Time)) || got.Nanosecond() != 123456789 {
+		t.Errorf("expected the time to keep its nanoseconds, got %v", got)
+	}
+
+	t.Run("Formats", func(t *testing.T) {
+		var b strings.Builder
+		err := df.ToCSVWriter(&b, goframe.CSVWriteOption{
+			FloatFormat: "%.2f",
+			TimeLayout:  "2006-01-02",
+			TrueValue:   "Y",
+			FalseValue:  "N",
+			Formatters:  map[string]func(any) string{"count": func(v any) string { return fmt.Sprintf("#%v", v) }},
+		})
+		if err != nil {
+			t.Fatalf("ToCSVWriter failed: %v", err)
+		}
+		// without a schema nil and the empty string are both empty cells
+		expected := "id,count,ratio,small,active,at,mixed,note\n007,#1,0.30,1.50,Y,2024-03-01,a,\n042,,1000000000000000000000.00,0.10,N,,2.00,\n,#3,NaN,,,2024-03-02,c,\\N\n"
+		if b.String() != expected {
+			t.Errorf("expected %q, got %q", expected, b.String())
+		}
+
+		// the layout of the schema line is used to read the times back
+		b.Reset()
+		df.ToCSVWriter(&b, goframe.CSVWriteOption{WriteSchema: true, TimeLayout: "2006-01-02", TrueValue: "Y", FalseValue: "N"})
+		read, err := goframe.FromCSVReader(strings.NewReader(b.String()), goframe.CSVReadOption{
+			ReadSchema: true,
+			BoolOption: goframe.BoolOption{TrueValues: []string{"Y"}, FalseValues: []string{"N"}},
+		})
+		if err != nil {
+			t.Fatalf("FromCSVReader failed: %v", err)
+		}
+		if got := read.Columns["at"].Data[2]; got != time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC) {
+			t.Errorf("expected 2024-03-02, got %v", got)
+		}
+		if got := read.Columns["active"].Data; !reflect.DeepEqual(got, []any{true, false, nil}) {
+			t.Errorf("expected [true false <nil>], got %v", got)
+		}
+	})
+
+	t.Run("InvalidSchema", func(t *testing.T) {
+		_, err := goframe.FromCSVReader(strings.NewReader("a,b\nint,uuid\n1,x\n"), goframe.CSVReadOption{ReadSchema: true})
+		if err == nil || !strings.Contains(err.Error(), "unsupported type 'uuid' for column 'b'") {
+			t.Errorf("expected an unsupported type error, got %v", err)
+		}
+		_, err = goframe.FromCSVReader(strings.NewReader("a\nint\n1\nx\n"), goframe.CSVReadOption{ReadSchema: true})
+		if err == nil || !strings.Contains(err.Error(), "cannot parse 'x' as int") {
+			t.Errorf("expected a parse error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package goframe_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestCumulative(t *testing.T) {
+	values := goframe.NewSeries("x", []any{2, nil, 3.0, math.NaN(), 1, 5})
+
+	tests := []struct {
+		name     string
+		got      *goframe.Series
+		expected []any
+	}{
+		{"CumSum", values.CumSum(), []any{2.0, nil, 5.0, nil, 6.0, 11.0}},
+		{"CumProd", values.CumProd(), []any{2.0, nil, 6.0, nil, 6.0, 30.0}},
+		{"CumMax", values.CumMax(), []any{2.0, nil, 3.0, nil, 3.0, 5.0}},
+		{"CumMin", values.CumMin(), []any{2.0, nil, 2.0, nil, 1.0, 1.0}},
+	}
+	for _, tt := range tests {
+		if tt.got.Err() != nil || !reflect.DeepEqual(tt.got.Data, tt.expected) {
+			t.Errorf("%s: expected %v, got %v (error %v)", tt.name, tt.expected, tt.got.Data, tt.got.Err())
+		}
+	}
+	if got := goframe.NewSeries("name", []any{"a"}).CumSum(); got.Err() == nil || !strings.Contains(got.Err().Error(), "is not numeric") {
+		t.Errorf("expected an error for strings, got %v", got.Err())
+	}
+
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("customer", []any{"a", "b", "a", "b", "a"}))
+	df.AddColumn(goframe.NewColumn("amount", []any{10, 1, 20, 2, 5}))
+	df.AddColumn(goframe.NewColumn("price", []any{100.0, 90.0, 80.0, 95.0, 120.0}))
+
+	t.Run("DataFrame", func(t *testing.T) {
+		totals, err := df.CumSum()
+		if err != nil {
+			t.Fatalf("CumSum failed: %v", err)
+		}
+		expected := goframe.NewDataFrame()
+		expected.AddColumn(goframe.NewColumn("customer", []any{"a", "b", "a", "b", "a"}))
+		expected.AddColumn(goframe.NewColumn("amount", []any{10.0, 11.0, 31.0, 33.0, 38.0}))
+		expected.AddColumn(goframe.NewColumn("price", []any{100.0, 190.0, 270.0, 365.0, 485.0}))
+		goframe.AssertFrameEqual(t, expected, totals)
+
+		// the drawdown from the running peak
+		peaks, err := df.CumMax("price")
+		if err != nil {
+			t.Fatalf("CumMax failed: %v", err)
+		}
+		drawdown := df.Col("price").Div(peaks.Col("price")).Sub(1.0)
+		if got := drawdown.Data[2].(float64); math.Abs(got+0.2) > 1e-12 {
+			t.Errorf("expected a drawdown of -0.2, got %v", got)
+		}
+		if got := peaks.Columns["amount"].Data; !reflect.DeepEqual(got, []any{10, 1, 20, 2, 5}) {
+			t.Errorf("expected the other columns to be copied, got %v", got)
+		}
+
+		if _, err := df.CumMin("customer"); err == nil || !strings.Contains(err.Error(), "is not numeric") {
+			t.Errorf("expected an error for a string column, got %v", err)
+		}
+		if _, err := df.CumProd("missing"); err == nil {
+			t.Errorf("expected an error for a missing column")
+		}
+	})
+
+	t.Run("Groupby", func(t *testing.T) {
+		totals, err := df.Groupby("customer").CumSum()
+		if err != nil {
+			t.Fatalf("CumSum failed: %v", err)
+		}
+		expected := goframe.NewDataFrame()
+		expected.AddColumn(goframe.NewColumn("customer", []any{"a", "b", "a", "b", "a"}))
+		expected.AddColumn(goframe.NewColumn("amount", []any{10.0, 1.0, 30.0, 3.0, 35.0}))
+		expected.AddColumn(goframe.NewColumn("price", []any{100.0, 90.0, 180.0, 185.0, 300.0}))
+		goframe.AssertFrameEqual(t, expected, totals)
+
+		lows, err := df.Groupby("customer").CumMin("price")
+		if err != nil {
+			t.Fatalf("CumMin failed: %v", err)
+		}
+		if got := lows.Columns["price"].Data; !reflect.DeepEqual(got, []any{100.0, 90.0, 80.0, 90.0, 80.0}) {
+			t.Errorf("unexpected running minimums %v", got)
+		}
+		if _, err := df.Groupby("amount").CumSum("customer"); err == nil || !strings.Contains(err.Error(), "is not numeric") {
+			t.Errorf("expected an error for a string column, got %v", err)
+		}
+	})
+}
@@ -2,14 +2,15 @@ package goframe_test
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	goframe "github.com/kishyassin/goframe"
 )
@@ -96,6 +97,33 @@ func TestDataFrameAddDropColumn(t *testing.T) {
 	}
 }
 
+func TestDataFrameShape(t *testing.T) {
+	df := goframe.NewDataFrame()
+	if rows, cols := df.Shape(); rows != 0 || cols != 0 || !df.Empty() || df.Size() != 0 {
+		t.Errorf("expected an empty 0x0 DataFrame, got %dx%d", rows, cols)
+	}
+	df.AddColumn(goframe.NewColumn[any]("a", []any{}))
+	if !df.Empty() || df.Ncols() != 1 {
+		t.Errorf("expected a DataFrame with a column and no rows to be empty")
+	}
+
+	df, _ = goframe.FromColumns(
+		goframe.NewColumn[any]("a", []any{1, 2, 3}),
+		goframe.NewColumn[any]("b", []any{"x", "y", "z"}),
+	)
+	if rows, cols := df.Shape(); rows != 3 || cols != 2 || df.Empty() || df.Size() != 6 {
+		t.Errorf("expected a 3x2 DataFrame of 6 cells, got %dx%d of %d cells", rows, cols, df.Size())
+	}
+
+	// a column shortened behind the DataFrame's back does not hide rows
+	df.Columns["b"].Data = df.Columns["b"].Data[:1]
+	for range 10 {
+		if df.Nrows() != 3 {
+			t.Fatalf("expected the length of the longest column, got %d", df.Nrows())
+		}
+	}
+}
+
 func TestFromCSVReader(t *testing.T) {
 	cases := []struct {
 		input       string
@@ -108,7 +136,7 @@ func TestFromCSVReader(t *testing.T) {
 					Laptop,3,
 					Mouse,10,
 					Keyboard,5,`,
-			wantColumns: []string{"quantity", "product", "discount"},
+			wantColumns: []string{"product", "quantity", "discount"},
 			wantData: map[string][]any{
 				"quantity": {3.0, 10.0, 5.0},
 				"product":  {"Laptop", "Mouse", "Keyboard"},
@@ -120,7 +148,7 @@ func TestFromCSVReader(t *testing.T) {
 					Neo,7,1200
 					Trinity,12,3400
 					Morpheus,20,5600`,
-			wantColumns: []string{"level", "player", "points"},
+			wantColumns: []string{"player", "level", "points"},
 			wantData: map[string][]any{
 				"level":  {7.0, 12.0, 20.0},
 				"player": {"Neo", "Trinity", "Morpheus"},
@@ -132,7 +160,7 @@ func TestFromCSVReader(t *testing.T) {
 					Berlin,18,
 					Paris,,55
 					,21,60`,
-			wantColumns: []string{"city", "humidity", "temp"},
+			wantColumns: []string{"city", "temp", "humidity"},
 			wantData: map[string][]any{
 				"temp":     {18.0, "", 21.0},
 				"city":     {"Berlin", "Paris", ""},
@@ -182,8 +210,6 @@ func TestFromCSVReader(t *testing.T) {
 		cols := df.ColumnNames()
 		t.Logf("Actual column names: %v", cols)
 
-		sort.Strings(tc.wantColumns)
-
 		if !reflect.DeepEqual(cols, tc.wantColumns) {
 			t.Errorf("Expected columns %v, got %v", tc.wantColumns, cols)
 		}
@@ -419,6 +445,231 @@ func TestDataFrameJoin(t *testing.T) {
 		}
 		t.Errorf("Expected 4 rows in outer join, got %d", outerJoin.Nrows())
 	}
+
+	// Test outer join row order and shared columns: the rows follow df1, then the unmatched rows of
+	// df2, and df1 wins for columns present in both
+	df1.AddColumn(goframe.NewColumn[any]("note", []any{"a1", "a2", "a3"}))
+	df2.AddColumn(goframe.NewColumn[any]("note", []any{"b2", "b3", "b4"}))
+	outerJoin, err = df1.OuterJoin(df2, "id")
+	if err != nil {
+		t.Fatalf("Unexpected error during outer join: %v", err)
+	}
+	ids, _ := outerJoin.Select("id")
+	notes, _ := outerJoin.Select("note")
+	values2, _ := outerJoin.Select("value2")
+	if !reflect.DeepEqual(ids.Data, []any{1, 2, 3, 4}) ||
+		!reflect.DeepEqual(notes.Data, []any{"a1", "a2", "a3", "b4"}) ||
+		!reflect.DeepEqual(values2.Data, []any{nil, "X", "Y", "Z"}) {
+		t.Errorf("Unexpected outer join: id %v, note %v, value2 %v", ids.Data, notes.Data, values2.Data)
+	}
+}
+
+func TestDataFrameJoinMultiKey(t *testing.T) {
+	sales := goframe.NewDataFrame()
+	sales.AddColumn(goframe.NewColumn[any]("store", []any{"A", "A", "B", "B"}))
+	sales.AddColumn(goframe.NewColumn[any]("year", []any{2024, 2025, 2024, 2025}))
+	sales.AddColumn(goframe.NewColumn[any]("amount", []any{10, 20, 30, 40}))
+
+	targets := goframe.NewDataFrame()
+	targets.AddColumn(goframe.NewColumn[any]("store", []any{"A", "B", "C"}))
+	targets.AddColumn(goframe.NewColumn[any]("year", []any{2025, 2024, 2025}))
+	targets.AddColumn(goframe.NewColumn[any]("amount", []any{25, 35, 50}))
+
+	t.Run("Inner With Default Suffixes", func(t *testing.T) {
+		joined, err := sales.Join(targets, []string{"store", "year"}, "inner", [2]string{})
+		if err != nil {
+			t.Fatalf("Join failed: %v", err)
+		}
+		if got := joined.ColumnNames(); !reflect.DeepEqual(got, []string{"store", "year", "amount_x", "amount_y"}) {
+			t.Fatalf("Unexpected columns %v", got)
+		}
+		stores, _ := joined.Select("store")
+		actual, _ := joined.Select("amount_x")
+		target, _ := joined.Select("amount_y")
+		if !reflect.DeepEqual(stores.Data, []any{"A", "B"}) || !reflect.DeepEqual(actual.Data, []any{20, 30}) ||
+			!reflect.DeepEqual(target.Data, []any{25, 35}) {
+			t.Errorf("Unexpected join: store %v, amount_x %v, amount_y %v", stores.Data, actual.Data, target.Data)
+		}
+	})
+
+	t.Run("Outer With Custom Suffixes", func(t *testing.T) {
+		joined, err := sales.Join(targets, []string{"store", "year"}, "outer", [2]string{"_actual", "_target"})
+		if err != nil {
+			t.Fatalf("Join failed: %v", err)
+		}
+		if joined.Nrows() != 5 {
+			t.Fatalf("Expected 5 rows, got %d", joined.Nrows())
+		}
+		stores, _ := joined.Select("store")
+		target, _ := joined.Select("amount_target")
+		if !reflect.DeepEqual(stores.Data, []any{"A", "A", "B", "B", "C"}) ||
+			!reflect.DeepEqual(target.Data, []any{nil, 25, 35, nil, 50}) {
+			t.Errorf("Unexpected join: store %v, amount_target %v", stores.Data, target.Data)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := sales.Join(targets, []string{"store"}, "cross", [2]string{}); err == nil {
+			t.Error("Expected an error for an unknown join type")
+		}
+		if _, err := sales.Join(targets, []string{"region"}, "inner", [2]string{}); err == nil {
+			t.Error("Expected an error for a missing key")
+		}
+		if _, err := sales.Join(targets, []string{"store"}, "inner", [2]string{"_a", "_a"}); err == nil {
+			t.Error("Expected an error for identical suffixes")
+		}
+	})
+}
+
+func TestMergeAsOf(t *testing.T) {
+	at := func(seconds int) any { return time.Date(2025, 1, 1, 9, 30, seconds, 0, time.UTC) }
+
+	trades := goframe.NewDataFrame()
+	trades.AddColumn(goframe.NewColumn[any]("time", []any{at(5), at(1), at(10), nil, at(30)}))
+	trades.AddColumn(goframe.NewColumn[any]("price", []any{101.0, 100.0, 102.0, 99.0, 103.0}))
+
+	// quotes are not sorted, two share a timestamp
+	quotes := goframe.NewDataFrame()
+	quotes.AddColumn(goframe.NewColumn[any]("time", []any{at(4), at(0), at(8), at(4)}))
+	quotes.AddColumn(goframe.NewColumn[any]("price", []any{100.8, 99.5, 101.9, 100.9}))
+	quotes.AddColumn(goframe.NewColumn[any]("venue", []any{"A", "B", "C", "D"}))
+
+	tests := []struct {
+		direction string
+		tolerance time.Duration
+		venues    []any
+	}{
+		{"backward", -1, []any{"D", "B", "C", nil, "C"}},
+		{"forward", -1, []any{"C", "A", nil, nil, nil}},
+		{"nearest", -1, []any{"D", "B", "C", nil, "C"}},
+		{"backward", 2 * time.Second, []any{"D", "B", "C", nil, nil}},
+		{"nearest", 500 * time.Millisecond, []any{nil, nil, nil, nil, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.direction, tt.tolerance), func(t *testing.T) {
+			merged, err := trades.MergeAsOf(quotes, "time", tt.tolerance, tt.direction)
+			if err != nil {
+				t.Fatalf("MergeAsOf failed: %v", err)
+			}
+			if got := merged.ColumnNames(); !reflect.DeepEqual(got, []string{"time", "price_x", "price_y", "venue"}) {
+				t.Fatalf("Unexpected columns %v", got)
+			}
+			venues, _ := merged.Select("venue")
+			if !reflect.DeepEqual(venues.Data, tt.venues) {
+				t.Errorf("Expected venues %v, got %v", tt.venues, venues.Data)
+			}
+			prices, _ := merged.Select("price_x")
+			if !reflect.DeepEqual(prices.Data, []any{101.0, 100.0, 102.0, 99.0, 103.0}) {
+				t.Errorf("Expected the trades in order, got %v", prices.Data)
+			}
+		})
+	}
+
+	// a tolerance of 0 matches equal timestamps only
+	exact := goframe.NewDataFrame()
+	exact.AddColumn(goframe.NewColumn[any]("time", []any{at(4), at(10)}))
+	exact.AddColumn(goframe.NewColumn[any]("venue", []any{"A", "B"}))
+	for _, direction := range []string{"backward", "forward", "nearest"} {
+		merged, err := trades.MergeAsOf(exact, "time", 0, direction)
+		if err != nil {
+			t.Fatalf("MergeAsOf failed: %v", err)
+		}
+		if venues, _ := merged.Select("venue"); !reflect.DeepEqual(venues.Data, []any{nil, nil, "B", nil, nil}) {
+			t.Errorf("%s: expected only the equal timestamp to match, got %v", direction, venues.Data)
+		}
+	}
+
+	if _, err := trades.MergeAsOf(quotes, "time", -1, "sideways"); err == nil {
+		t.Error("Expected an error for an unknown direction")
+	}
+	if _, err := trades.MergeAsOf(quotes, "price", -1, "backward"); err == nil {
+		t.Error("Expected an error for a column without timestamps")
+	}
+}
+
+func TestConcat(t *testing.T) {
+	jan := goframe.NewDataFrame()
+	jan.AddColumn(goframe.NewColumn[any]("store", []any{"A", "B"}))
+	jan.AddColumn(goframe.NewColumn[any]("sales", []any{10, 20}))
+	feb := goframe.NewDataFrame()
+	feb.AddColumn(goframe.NewColumn[any]("store", []any{"A"}))
+	feb.AddColumn(goframe.NewColumn[any]("returns", []any{1}))
+	feb.AddColumn(goframe.NewColumn[any]("sales", []any{30}))
+
+	t.Run("Rows", func(t *testing.T) {
+		combined, err := goframe.Concat([]*goframe.DataFrame{jan, feb}, 0, false)
+		if err != nil {
+			t.Fatalf("Concat failed: %v", err)
+		}
+		if got := combined.ColumnNames(); !reflect.DeepEqual(got, []string{"store", "sales", "returns"}) {
+			t.Fatalf("Unexpected columns %v", got)
+		}
+		sales, _ := combined.Select("sales")
+		returns, _ := combined.Select("returns")
+		if !reflect.DeepEqual(sales.Data, []any{10, 20, 30}) || !reflect.DeepEqual(returns.Data, []any{nil, nil, 1}) {
+			t.Errorf("Unexpected rows: sales %v, returns %v", sales.Data, returns.Data)
+		}
+		// the result owns its data
+		sales.Data[0] = 99
+		if first, _ := jan.Columns["sales"].At(0); first != 10 {
+			t.Errorf("Expected the inputs to be unchanged, got %v", first)
+		}
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		extra := goframe.NewDataFrame()
+		extra.AddColumn(goframe.NewColumn[any]("region", []any{"north", "south"}))
+		combined, err := goframe.Concat([]*goframe.DataFrame{jan, extra}, 1, false)
+		if err != nil {
+			t.Fatalf("Concat failed: %v", err)
+		}
+		if got := combined.ColumnNames(); !reflect.DeepEqual(got, []string{"store", "sales", "region"}) || combined.Nrows() != 2 {
+			t.Errorf("Unexpected result %v with %d rows", got, combined.Nrows())
+		}
+		if _, err := goframe.Concat([]*goframe.DataFrame{jan, feb}, 1, false); err == nil {
+			t.Error("Expected an error for different numbers of rows")
+		}
+		if _, err := goframe.Concat([]*goframe.DataFrame{jan, jan}, 1, false); err == nil {
+			t.Error("Expected an error for duplicate column names")
+		}
+	})
+
+	t.Run("Index", func(t *testing.T) {
+		left := goframe.NewDataFrame()
+		left.AddColumn(goframe.NewColumn[any]("id", []any{"x", "y"}))
+		left.AddColumn(goframe.NewColumn[any]("a", []any{1, 2}))
+		left.SetIndex("id")
+		right := goframe.NewDataFrame()
+		right.AddColumn(goframe.NewColumn[any]("id", []any{"x", "y"}))
+		right.AddColumn(goframe.NewColumn[any]("b", []any{3, 4}))
+		right.SetIndex("id")
+
+		combined, err := goframe.Concat([]*goframe.DataFrame{left, right}, 1, false)
+		if err != nil {
+			t.Fatalf("Concat failed: %v", err)
+		}
+		if got := combined.ColumnNames(); !reflect.DeepEqual(got, []string{"id", "a", "b"}) || combined.IndexName() != "id" {
+			t.Errorf("Expected the shared index once, got %v indexed by '%s'", got, combined.IndexName())
+		}
+
+		stacked, err := goframe.Concat([]*goframe.DataFrame{left, jan}, 0, true)
+		if err != nil {
+			t.Fatalf("Concat failed: %v", err)
+		}
+		if stacked.IndexName() != "" || stacked.Nrows() != 4 {
+			t.Errorf("Expected a range index over 4 rows, got '%s' over %d", stacked.IndexName(), stacked.Nrows())
+		}
+		if _, err := goframe.Concat([]*goframe.DataFrame{left, jan}, 0, false); err == nil {
+			t.Error("Expected an error for different indexes")
+		}
+	})
+
+	if _, err := goframe.Concat(nil, 0, false); err == nil {
+		t.Error("Expected an error for no DataFrames")
+	}
+	if _, err := goframe.Concat([]*goframe.DataFrame{jan}, 2, false); err == nil {
+		t.Error("Expected an error for an invalid axis")
+	}
 }
 
 func TestAdvancedIndexing(t *testing.T) {
@@ -564,6 +815,90 @@ func TestFillNa(t *testing.T) {
 
 }
 
+func TestFillStrategies(t *testing.T) {
+	nan := math.NaN()
+	newFrame := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn[any]("value", []any{nil, 1, nil, nil, nil, 5.0, nan}))
+		df.AddColumn(goframe.NewColumn[any]("label", []any{"a", nil, "b", nil, nil, nil, "c"}))
+		return df
+	}
+
+	tests := []struct {
+		name  string
+		fill  func(df *goframe.DataFrame) error
+		value []any
+		label []any
+	}{
+		{"Forward", func(df *goframe.DataFrame) error { return df.FillNaForward() },
+			[]any{nil, 1, 1, 1, 1, 5.0, 5.0}, []any{"a", "a", "b", "b", "b", "b", "c"}},
+		{"ForwardLimit", func(df *goframe.DataFrame) error { return df.FillNaForward(goframe.FillOption{Limit: 1}) },
+			[]any{nil, 1, 1, nil, nil, 5.0, 5.0}, []any{"a", "a", "b", "b", nil, nil, "c"}},
+		{"Backward", func(df *goframe.DataFrame) error {
+			return df.FillNaBackward(goframe.FillOption{Columns: []string{"value"}})
+		}, []any{1, 1, 5.0, 5.0, 5.0, 5.0, nan}, []any{"a", nil, "b", nil, nil, nil, "c"}},
+		{"Map", func(df *goframe.DataFrame) error {
+			return df.FillNaMap(map[string]any{"value": 0, "label": "?"}, goframe.FillOption{Limit: 2})
+		}, []any{0, 1, 0, 0, nil, 5.0, 0}, []any{"a", "?", "b", "?", "?", nil, "c"}},
+		{"Linear", func(df *goframe.DataFrame) error { return df.Interpolate("linear") },
+			[]any{nil, 1, 2.0, 3.0, 4.0, 5.0, nan}, []any{"a", nil, "b", nil, nil, nil, "c"}},
+		{"LinearLimit", func(df *goframe.DataFrame) error { return df.Interpolate("linear", goframe.FillOption{Limit: 2}) },
+			[]any{nil, 1, 2.0, 3.0, nil, 5.0, nan}, []any{"a", nil, "b", nil, nil, nil, "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := newFrame()
+			if err := tt.fill(df); err != nil {
+				t.Fatalf("fill returned an error: %v", err)
+			}
+			// %v prints NaN, which reflect.DeepEqual does not match
+			if got := fmt.Sprint(df.Columns["value"].Values()); got != fmt.Sprint(tt.value) {
+				t.Errorf("value: expected %v, got %v", tt.value, got)
+			}
+			if got := df.Columns["label"].Values(); !reflect.DeepEqual(got, tt.label) {
+				t.Errorf("label: expected %v, got %v", tt.label, got)
+			}
+		})
+	}
+
+	// time interpolation spaces the rows by the index, and keeps typed columns typed
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	df := goframe.NewTypedDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("date", []any{day, day.AddDate(0, 0, 1), day.AddDate(0, 0, 4)}))
+	df.AddColumn(goframe.NewColumn[any]("temp", []any{10.0, nil, 20.0}))
+	df.SetIndex("date")
+	if err := df.Interpolate("time"); err != nil {
+		t.Fatalf("Interpolate returned an error: %v", err)
+	}
+	if got := df.Columns["temp"].Values(); !reflect.DeepEqual(got, []any{10.0, 12.5, 20.0}) || df.Columns["temp"].DType() != "float64" {
+		t.Errorf("expected typed [10 12.5 20], got %v (%s)", got, df.Columns["temp"].DType())
+	}
+
+	// views are copied before they are filled
+	original := newFrame()
+	head := original.Head(3)
+	if err := head.FillNaForward(); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := original.Columns["value"].At(2); value != nil {
+		t.Errorf("expected the original to be unchanged, got %v", value)
+	}
+
+	errors := map[string]error{
+		"unknown interpolation method: cubic": newFrame().Interpolate("cubic"),
+		"column 'missing' does not exist":     newFrame().FillNaForward(goframe.FillOption{Columns: []string{"missing"}}),
+		"limit must not be negative":          newFrame().FillNaBackward(goframe.FillOption{Limit: -1}),
+		"value a at row 0 is not numeric":     newFrame().Interpolate("linear", goframe.FillOption{Columns: []string{"label"}}),
+		"needs a time column":                 newFrame().Interpolate("time"),
+		"is not a time.Time at row 0":         newFrame().Interpolate("time", goframe.FillOption{TimeColumn: "label"}),
+	}
+	for message, err := range errors {
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("expected an error containing %q, got %v", message, err)
+		}
+	}
+}
+
 func TestDropNa(t *testing.T) {
 
 	df := goframe.NewDataFrame()
@@ -617,6 +952,98 @@ func TestDropNa(t *testing.T) {
 
 }
 
+func TestDropNaOptions(t *testing.T) {
+	newFrame := func() *goframe.DataFrame {
+		df, _ := goframe.FromColumns(
+			goframe.NewColumn[any]("id", []any{1, 2, 3, 4}),
+			goframe.NewColumn[any]("a", []any{nil, 1, nil, 3}),
+			goframe.NewColumn[any]("b", []any{nil, nil, 2, 3}),
+			goframe.NewColumn[any]("empty", []any{nil, nil, nil, 1}),
+		)
+		return df
+	}
+
+	rows := []struct {
+		name    string
+		options goframe.DropNaOption
+		ids     []any
+	}{
+		{"Any", goframe.DropNaOption{}, []any{4}},
+		{"AllSubset", goframe.DropNaOption{How: "all", Subset: []string{"a", "b"}}, []any{2, 3, 4}},
+		{"AnySubset", goframe.DropNaOption{Subset: []string{"b"}}, []any{3, 4}},
+		{"Thresh", goframe.DropNaOption{Thresh: 2}, []any{2, 3, 4}},
+		{"ThreshSubset", goframe.DropNaOption{Thresh: 1, Subset: []string{"a", "empty"}}, []any{2, 4}},
+	}
+	for _, tt := range rows {
+		t.Run(tt.name, func(t *testing.T) {
+			df := newFrame()
+			if err := df.DropNa(tt.options); err != nil {
+				t.Fatalf("DropNa returned an error: %v", err)
+			}
+			if got := df.Columns["id"].Values(); !reflect.DeepEqual(got, tt.ids) {
+				t.Errorf("expected ids %v, got %v", tt.ids, got)
+			}
+		})
+	}
+
+	columns := []struct {
+		name    string
+		options goframe.DropNaOption
+		names   []string
+	}{
+		{"Any", goframe.DropNaOption{Axis: 1}, []string{"id"}},
+		{"Thresh", goframe.DropNaOption{Axis: 1, Thresh: 2}, []string{"id", "a", "b"}},
+		{"Subset", goframe.DropNaOption{Axis: 1, Subset: []string{"empty"}}, []string{"id", "a", "b"}},
+		{"All", goframe.DropNaOption{Axis: 1, How: "all"}, []string{"id", "a", "b", "empty"}},
+	}
+	for _, tt := range columns {
+		t.Run("Columns"+tt.name, func(t *testing.T) {
+			df := newFrame()
+			if err := df.DropNa(tt.options); err != nil {
+				t.Fatalf("DropNa returned an error: %v", err)
+			}
+			if got := df.ColumnNames(); !reflect.DeepEqual(got, tt.names) || df.Nrows() != 4 {
+				t.Errorf("expected columns %v and 4 rows, got %v and %d rows", tt.names, got, df.Nrows())
+			}
+		})
+	}
+
+	// NaN counts as missing, as for FillNa
+	newNaNFrame := func() *goframe.DataFrame {
+		df, _ := goframe.FromColumns(
+			goframe.NewColumn[any]("id", []any{1, 2, 3, 4, 5}),
+			goframe.NewColumn[any]("v", []any{1.0, nil, 3.0, math.NaN(), 5.0}),
+		)
+		return df
+	}
+	nan := newNaNFrame()
+	if err := nan.DropNa(goframe.DropNaOption{Subset: []string{"v"}}); err != nil {
+		t.Fatalf("DropNa returned an error: %v", err)
+	}
+	if got := nan.Columns["id"].Values(); !reflect.DeepEqual(got, []any{1, 3, 5}) {
+		t.Errorf("expected the nil and NaN rows to be dropped, got ids %v", got)
+	}
+	nan = newNaNFrame()
+	if err := nan.DropNa(goframe.DropNaOption{Axis: 1, Thresh: 4}); err != nil {
+		t.Fatalf("DropNa returned an error: %v", err)
+	}
+	if got := nan.ColumnNames(); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Errorf("expected v with 3 values to be dropped, got columns %v", got)
+	}
+
+	errors := map[string]goframe.DropNaOption{
+		"axis must be 0 or 1":             {Axis: 2},
+		"unknown how: some":               {How: "some"},
+		"thresh must not be negative":     {Thresh: -1},
+		"column 'missing' does not exist": {Subset: []string{"missing"}},
+	}
+	for message, options := range errors {
+		if err := newFrame().DropNa(options); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("expected an error containing %q, got %v", message, err)
+		}
+	}
+}
+
 func TestAstype(t *testing.T) {
 
 	t.Run("Float64ToInt", func(t *testing.T) {
@@ -718,6 +1145,126 @@ func TestAstype(t *testing.T) {
 		}
 	})
 
+	t.Run("ParseAndNullable", func(t *testing.T) {
+		day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		tests := []struct {
+			target   string
+			values   []any
+			options  goframe.AstypeOption
+			expected []any
+		}{
+			{"int", []any{"42", " -7 ", nil, 3.9, true, int8(5)}, goframe.AstypeOption{}, []any{42, -7, nil, 3, 1, 5}},
+			{"int64", []any{"42", uint(3), -2.5}, goframe.AstypeOption{}, []any{int64(42), int64(3), int64(-2)}},
+			{"float64", []any{"4.5", 2, nil, false}, goframe.AstypeOption{}, []any{4.5, 2.0, nil, 0.0}},
+			{"float32", []any{"0.5", int64(3)}, goframe.AstypeOption{}, []any{float32(0.5), float32(3)}},
+			{"bool", []any{"true", "F", "1", 0, 2.5, nil}, goframe.AstypeOption{}, []any{true, false, true, false, true, nil}},
+			{"time", []any{"2024-03-01", "2024-03-01T00:00:00Z", int64(day.Unix()), day}, goframe.AstypeOption{}, []any{day, day, day, day}},
+			{"time", []any{"01/03/2024"}, goframe.AstypeOption{Layout: "02/01/2006"}, []any{day}},
+			{"string", []any{day, 1, nil}, goframe.AstypeOption{Layout: "2006-01-02"}, []any{"2024-03-01", "1", nil}},
+			{"int", []any{"42", "abc", "4.2", math.NaN(), 1e30}, goframe.AstypeOption{Errors: "coerce"}, []any{42, nil, nil, nil, nil}},
+			{"time", []any{"yesterday", "2024-03-01"}, goframe.AstypeOption{Errors: "coerce"}, []any{nil, day}},
+		}
+		for _, tt := range tests {
+			df := goframe.NewDataFrame()
+			df.AddColumn(goframe.NewColumn("col", tt.values))
+			if err := df.Astype("col", tt.target, tt.options); err != nil {
+				t.Fatalf("Astype(%v, %s) failed: %v", tt.values, tt.target, err)
+			}
+			if got := df.Columns["col"].Values(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Astype(%v, %s): expected %#v, got %#v", tt.values, tt.target, tt.expected, got)
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("col", []any{"1", "x"}))
+		errors := []struct {
+			target  string
+			options goframe.AstypeOption
+			message string
+		}{
+			{"int", goframe.AstypeOption{}, "cannot convert value 'x' of type string to int at row 1"},
+			{"complex", goframe.AstypeOption{}, "unsupported target type 'complex'"},
+			{"int", goframe.AstypeOption{Errors: "ignore"}, "unknown errors mode"},
+		}
+		for _, tt := range errors {
+			if err := df.Astype("col", tt.target, tt.options); err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("expected an error containing %q, got %v", tt.message, err)
+			}
+		}
+		if got := df.Columns["col"].Values(); !reflect.DeepEqual(got, []any{"1", "x"}) {
+			t.Errorf("expected the column to be unchanged after an error, got %v", got)
+		}
+	})
+}
+
+func TestAstypeMapAndConvertDtypes(t *testing.T) {
+	newFrame := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("id", []any{"1", "2", nil}))
+		df.AddColumn(goframe.NewColumn("price", []any{"1.5", 2, "x"}))
+		df.AddColumn(goframe.NewColumn("count", []any{1.0, math.NaN(), 3.0}))
+		df.AddColumn(goframe.NewColumn("active", []any{"true", "F", true}))
+		df.AddColumn(goframe.NewColumn("day", []any{"2024-01-02", nil, "2024-01-03"}))
+		df.AddColumn(goframe.NewColumn("name", []any{"a", "b", "c"}))
+		df.AddColumn(goframe.NewColumn("mixed", []any{"a", 1, true}))
+		return df
+	}
+
+	df := newFrame()
+	err := df.AstypeMap(map[string]string{"id": "int64", "price": "float64", "name": "int", "missing": "int", "day": "complex"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, message := range []string{
+		"column 'price': cannot convert value 'x' of type string to float64 at row 2",
+		"column 'name': cannot convert value 'a' of type string to int at row 0 (and 2 more values)",
+		"column 'missing' does not exist",
+		"column 'day': unsupported target type 'complex'",
+	} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("expected the error to contain %q, got %v", message, err)
+		}
+	}
+	if got := df.Columns["id"].Values(); !reflect.DeepEqual(got, []any{"1", "2", nil}) {
+		t.Errorf("expected no column to be converted after an error, got %v", got)
+	}
+
+	if err := df.AstypeMap(map[string]string{"id": "int64", "price": "float64"}, goframe.AstypeOption{Errors: "coerce"}); err != nil {
+		t.Fatalf("AstypeMap failed: %v", err)
+	}
+	if got := df.Columns["id"].Values(); !reflect.DeepEqual(got, []any{int64(1), int64(2), nil}) {
+		t.Errorf("unexpected ids %#v", got)
+	}
+	if got := df.Columns["price"].Values(); !reflect.DeepEqual(got, []any{1.5, 2.0, nil}) {
+		t.Errorf("unexpected prices %#v", got)
+	}
+
+	df = newFrame()
+	plan, err := df.ConvertDtypes()
+	if err != nil {
+		t.Fatalf("ConvertDtypes failed: %v", err)
+	}
+	expected := map[string]string{"id": "int64", "count": "int64", "active": "bool", "day": "time"}
+	if !reflect.DeepEqual(plan, expected) {
+		t.Errorf("expected the plan %v, got %v", expected, plan)
+	}
+	if got := df.Columns["count"].Values(); !reflect.DeepEqual(got, []any{int64(1), nil, int64(3)}) {
+		t.Errorf("expected NaN to become nil in an integer column, got %#v", got)
+	}
+	if got := df.Columns["active"].Values(); !reflect.DeepEqual(got, []any{true, false, true}) {
+		t.Errorf("unexpected booleans %#v", got)
+	}
+	if got, _ := df.Columns["day"].At(0); got != time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("unexpected date %v", got)
+	}
+	if got := df.Columns["mixed"].Values(); !reflect.DeepEqual(got, []any{"a", 1, true}) {
+		t.Errorf("expected a mixed column to be left alone, got %v", got)
+	}
+	if plan := df.InferDtypes(); len(plan) != 0 {
+		t.Errorf("expected nothing left to convert, got %v", plan)
+	}
 }
 
 func TestGroupBy(t *testing.T) {
@@ -1320,6 +1867,126 @@ func TestApply(t *testing.T) {
 }
 
 // MARK: SortValues Test
+func TestApplyTo(t *testing.T) {
+	df, _ := goframe.FromColumns(
+		goframe.NewColumn[any]("name", []any{" ann ", "Bob", nil}),
+		goframe.NewColumn[any]("amount", []any{"1.5", "2", "x"}),
+	)
+	trim := func(value any) (any, error) {
+		if s, ok := value.(string); ok {
+			return strings.ToUpper(strings.TrimSpace(s)), nil
+		}
+		return value, nil
+	}
+
+	result, err := df.ApplyTo("name", trim, false)
+	if err != nil {
+		t.Fatalf("ApplyTo returned an error: %v", err)
+	}
+	if got := result.Columns["name"].Values(); !reflect.DeepEqual(got, []any{"ANN", "BOB", nil}) {
+		t.Errorf("expected [ANN BOB <nil>], got %v", got)
+	}
+	if got := df.Columns["name"].Values(); got[0] != " ann " {
+		t.Errorf("expected the original to be unchanged, got %v", got)
+	}
+	if !reflect.DeepEqual(result.ColumnNames(), []string{"name", "amount"}) {
+		t.Errorf("expected the column order to be kept, got %v", result.ColumnNames())
+	}
+
+	if result, err := df.ApplyTo("name", trim, true); err != nil || result != df || df.Columns["name"].Values()[1] != "BOB" {
+		t.Errorf("expected the column to be replaced in place, got %v (error %v)", df.Columns["name"].Values(), err)
+	}
+
+	parse := func(value any) (any, error) { return strconv.ParseFloat(value.(string), 64) }
+	if _, err := df.ApplyTo("amount", parse, true); err == nil || !strings.Contains(err.Error(), "column 'amount' at row 2") {
+		t.Errorf("expected an error at row 2, got %v", err)
+	}
+	if got := df.Columns["amount"].Values(); got[0] != "1.5" {
+		t.Errorf("expected a failed ApplyTo to leave the column unchanged, got %v", got)
+	}
+	if _, err := df.ApplyTo("name", func(value any) (any, error) { return value.(string), nil }, false); err == nil || !strings.Contains(err.Error(), "row 2") {
+		t.Errorf("expected the panic to be returned as an error, got %v", err)
+	}
+	if _, err := df.ApplyTo("missing", trim, false); err == nil || !strings.Contains(err.Error(), "column 'missing' does not exist") {
+		t.Errorf("expected a missing column error, got %v", err)
+	}
+}
+
+func TestApplyRowsAndColumns(t *testing.T) {
+	df, _ := goframe.FromColumns(
+		goframe.NewColumn[any]("price", []any{2.0, 3.5, 1.0}),
+		goframe.NewColumn[any]("qty", []any{3, 2, nil}),
+	)
+
+	total, err := df.ApplyRows(func(row goframe.Row) (any, error) {
+		price, _ := goframe.RowGetFloat(row, "price")
+		qty, ok := goframe.RowGetFloat(row, "qty")
+		if !ok {
+			return nil, nil
+		}
+		return price * qty, nil
+	})
+	if err != nil {
+		t.Fatalf("ApplyRows failed: %v", err)
+	}
+	if !reflect.DeepEqual(total.Data, []any{6.0, 7.0, nil}) {
+		t.Errorf("expected [6 7 <nil>], got %v", total.Data)
+	}
+	_, err = df.ApplyRows(func(row goframe.Row) (any, error) {
+		if row["qty"] == nil {
+			return nil, fmt.Errorf("no quantity")
+		}
+		return row["qty"], nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "at row 2: no quantity") {
+		t.Errorf("expected the error of row 2, got %v", err)
+	}
+	if _, err := df.ApplyRows(func(row goframe.Row) (any, error) { return row["qty"].(int), nil }); err == nil || !strings.Contains(err.Error(), "row 2") {
+		t.Errorf("expected the panic to be returned as an error, got %v", err)
+	}
+
+	scaled, err := df.ApplyColumns(func(col *goframe.Column[any]) (*goframe.Column[any], error) {
+		if col.Name == "qty" {
+			return &goframe.Column[any]{Name: "units", Data: col.Data}, nil
+		}
+		for i, v := range col.Data {
+			col.Data[i] = v.(float64) * 10
+		}
+		return col, nil
+	})
+	if err != nil {
+		t.Fatalf("ApplyColumns failed: %v", err)
+	}
+	if !reflect.DeepEqual(scaled.ColumnNames(), []string{"price", "units"}) || !reflect.DeepEqual(scaled.Columns["price"].Data, []any{20.0, 35.0, 10.0}) {
+		t.Errorf("unexpected result %v %v", scaled.ColumnNames(), scaled.Columns["price"].Data)
+	}
+	if got := df.Columns["price"].Data; got[0] != 2.0 {
+		t.Errorf("expected the original to be unchanged, got %v", got)
+	}
+
+	counts, err := df.ApplyColumns(func(col *goframe.Column[any]) (*goframe.Column[any], error) {
+		return goframe.NewColumn[any]("", []any{col.Len()}), nil
+	})
+	if err != nil || counts.Nrows() != 1 || counts.Columns["qty"].Data[0] != 3 {
+		t.Errorf("expected one row of lengths, got %v (error %v)", counts, err)
+	}
+	_, err = df.ApplyColumns(func(col *goframe.Column[any]) (*goframe.Column[any], error) {
+		if col.Name == "qty" {
+			return col, nil
+		}
+		return goframe.NewColumn[any]("", []any{1}), nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "column 'qty' has 3 rows, expected 1") {
+		t.Errorf("expected a length mismatch error, got %v", err)
+	}
+	_, err = df.ApplyColumns(func(col *goframe.Column[any]) (*goframe.Column[any], error) {
+		return nil, fmt.Errorf("boom")
+	})
+	if err == nil || !strings.Contains(err.Error(), "column 'price': boom") {
+		t.Errorf("expected the error of the first column, got %v", err)
+	}
+}
+
 func TestDataFrameSortValues(t *testing.T) {
 	df := goframe.NewDataFrame()
 	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Charlie", "Alice", "Bob"})))
@@ -1456,6 +2123,49 @@ func TestDataFrameSortValues(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("Mixed Directions", func(t *testing.T) {
+		df3 := goframe.NewDataFrame()
+		df3.AddColumn(goframe.NewColumn("dept", []any{"ops", "dev", "ops", "dev", "dev"}))
+		df3.AddColumn(goframe.NewColumn("salary", []any{50.0, 70.0, 60.0, 70.0, 80.0}))
+		df3.AddColumn(goframe.NewColumn("name", []any{"a", "b", "c", "d", "e"}))
+
+		// dept ascending, salary descending, ties keep their row order
+		sortedDf, err := df3.SortValues([]string{"dept", "salary"}, true, false)
+		if err != nil {
+			t.Fatalf("SortValues failed: %v", err)
+		}
+		nameCol, _ := sortedDf.Select("name")
+		if expected := []any{"e", "b", "d", "c", "a"}; !reflect.DeepEqual(nameCol.Data, expected) {
+			t.Errorf("Expected names %v, got %v", expected, nameCol.Data)
+		}
+
+		if _, err := df3.SortValues([]string{"dept", "salary"}, true, false, true); err == nil {
+			t.Error("Expected error for mismatched directions, got nil")
+		}
+		if _, err := df3.SortValues([]string{"missing"}); err == nil {
+			t.Error("Expected error for missing column, got nil")
+		}
+	})
+
+	t.Run("Nulls First", func(t *testing.T) {
+		df4 := goframe.NewDataFrame()
+		df4.AddColumn(goframe.NewColumn("score", []any{95.0, nil, 88.0, math.NaN()}))
+
+		for _, ascending := range []bool{true, false} {
+			sortedDf, err := df4.SortValuesWithOption([]string{"score"}, goframe.SortOption{Ascending: []bool{ascending}, NullsFirst: true})
+			if err != nil {
+				t.Fatalf("SortValuesWithOption failed: %v", err)
+			}
+			scoreCol, _ := sortedDf.Select("score")
+			if scoreCol.Data[0] != nil || !math.IsNaN(scoreCol.Data[1].(float64)) {
+				t.Errorf("Expected missing values first (ascending=%v), got %v", ascending, scoreCol.Data)
+			}
+			if first := scoreCol.Data[2].(float64); (ascending && first != 88.0) || (!ascending && first != 95.0) {
+				t.Errorf("Unexpected order of values (ascending=%v): %v", ascending, scoreCol.Data)
+			}
+		}
+	})
 }
 
 // MARK: DropDuplicates
@@ -1747,6 +2457,53 @@ func TestDropDuplicates(t *testing.T) {
 
 }
 
+func TestDuplicated(t *testing.T) {
+	df, _ := goframe.FromColumns(
+		goframe.NewColumn[any]("id", []any{1, 1.0, "1", nil, nil, math.NaN(), math.NaN()}),
+		goframe.NewColumn[any]("group", []any{"a", "a", "a", "b", "b", "c", "d"}),
+	)
+
+	tests := []struct {
+		subset   []string
+		keep     string
+		expected []any
+	}{
+		{nil, "", []any{false, true, false, false, true, false, false}},
+		{nil, "last", []any{true, false, false, true, false, false, false}},
+		{nil, "none", []any{true, true, false, true, true, false, false}},
+		{[]string{"id"}, "first", []any{false, true, false, false, true, false, true}},
+		{[]string{"group"}, "none", []any{true, true, true, true, true, false, false}},
+	}
+	for _, tt := range tests {
+		duplicated, err := df.Duplicated(tt.subset, tt.keep)
+		if err != nil {
+			t.Fatalf("Duplicated(%v, %q) returned an error: %v", tt.subset, tt.keep, err)
+		}
+		if !reflect.DeepEqual(duplicated.Data, tt.expected) {
+			t.Errorf("Duplicated(%v, %q): expected %v, got %v", tt.subset, tt.keep, tt.expected, duplicated.Data)
+		}
+	}
+
+	// DropDuplicates keeps the column order and compares values the same way
+	dropped, err := df.DropDuplicates(goframe.DropDuplicatesOption{Subset: []string{"id"}, Keep: "last"})
+	if err != nil {
+		t.Fatalf("DropDuplicates returned an error: %v", err)
+	}
+	if got := dropped.Columns["group"].Values(); !reflect.DeepEqual(got, []any{"a", "a", "b", "d"}) {
+		t.Errorf("expected groups [a a b d], got %v", got)
+	}
+	if !reflect.DeepEqual(dropped.ColumnNames(), []string{"id", "group"}) {
+		t.Errorf("expected the column order to be kept, got %v", dropped.ColumnNames())
+	}
+
+	if _, err := df.Duplicated([]string{"missing"}, "first"); err == nil || !strings.Contains(err.Error(), "column 'missing' does not exist") {
+		t.Errorf("expected an error for a missing column, got %v", err)
+	}
+	if _, err := df.DropDuplicates(goframe.DropDuplicatesOption{Keep: "middle"}); err == nil || !strings.Contains(err.Error(), "unknown keep") {
+		t.Errorf("expected an error for an unknown keep, got %v", err)
+	}
+}
+
 func TestRowSlice(t *testing.T) {
 	df := goframe.NewDataFrame()
 
@@ -1789,6 +2546,384 @@ func TestRowSlice(t *testing.T) {
 
 }
 
+func TestCopyAndHeadAreIndependent(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("id", []any{1, 2, 3, 4}))
+	df.AddColumn(goframe.NewColumn[any]("name", []any{"a", "b", "c", "d"}))
+	df.SetIndex("id")
+
+	for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+		copied := frame.Copy()
+		if !reflect.DeepEqual(copied.ColumnNames(), frame.ColumnNames()) || !reflect.DeepEqual(copied.IndexNames(), []string{"id"}) || copied.IsTyped() != frame.IsTyped() {
+			t.Errorf("expected Copy to keep the columns, index and storage, got %v %v typed %v", copied.ColumnNames(), copied.IndexNames(), copied.IsTyped())
+		}
+		if head := frame.Head(2); head.IsTyped() != frame.IsTyped() || !reflect.DeepEqual(head.Columns["name"].Values(), []any{"a", "b"}) {
+			t.Errorf("expected Head to keep the storage, got %v typed %v", head.Columns["name"].Values(), head.IsTyped())
+		}
+	}
+
+	// writing directly to the data of the results does not reach the original
+	copied, head, tail := df.Copy(), df.Head(2), df.Tail(2)
+	filtered := df.Filter(func(row map[string]any) bool { return true })
+	for _, result := range []*goframe.DataFrame{copied, head, tail, filtered} {
+		result.Columns["name"].Data[0] = "changed"
+	}
+	if got := df.Columns["name"].Values(); !reflect.DeepEqual(got, []any{"a", "b", "c", "d"}) {
+		t.Errorf("expected the original to be unchanged, got %v", got)
+	}
+	if got := tail.Columns["name"].Values(); !reflect.DeepEqual(got, []any{"changed", "d"}) {
+		t.Errorf("expected the tail to be modified, got %v", got)
+	}
+
+	// and the other way around
+	df.Columns["name"].Data[1] = "changed"
+	if got, _ := head.Columns["name"].At(1); got != "b" {
+		t.Errorf("expected the head to be unchanged by writes to the original, got %v", got)
+	}
+
+	// a view still writes through
+	view, _ := df.SliceRows(0, 2)
+	view.Columns["name"].Data[0] = "through"
+	if got, _ := df.Columns["name"].At(0); got != "through" {
+		t.Errorf("expected SliceRows to share the storage, got %v", got)
+	}
+}
+
+func TestSliceRows(t *testing.T) {
+	ids := make([]any, 200)
+	scores := make([]any, 200)
+	for i := range ids {
+		ids[i] = i
+		if i%3 != 0 {
+			scores[i] = float64(i)
+		}
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("id", ids))
+	df.AddColumn(goframe.NewColumn[any]("score", scores))
+
+	// typed storage shares the native slices, the null rows are shifted to the view
+	for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+		view, err := frame.SliceRows(70, 140)
+		if err != nil {
+			t.Fatalf("SliceRows returned an error: %v", err)
+		}
+		if view.Nrows() != 70 || view.IsTyped() != frame.IsTyped() {
+			t.Fatalf("expected 70 rows with the storage of the original, got %d rows, typed %v", view.Nrows(), view.IsTyped())
+		}
+		if got := view.Columns["score"].Values(); !reflect.DeepEqual(got, scores[70:140]) {
+			t.Errorf("expected %v, got %v", scores[70:140], got)
+		}
+
+		view.FillNa(-1.0)
+		if err := view.Append(map[string]any{"id": 1000, "score": nil}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+		if got := frame.Columns["score"].Values(); !reflect.DeepEqual(got, scores) {
+			t.Errorf("expected the original to be unchanged by writes to the view, got %v", got[70:140])
+		}
+		if value, _ := view.Columns["score"].At(2); value != -1.0 {
+			t.Errorf("expected the view to be filled, got %v", value)
+		}
+		if !view.Columns["score"].IsNull(70) {
+			t.Errorf("expected the appended row to be null")
+		}
+	}
+
+	// writes to the original do not reach the view either
+	other := df.ToTyped()
+	head := other.Head(4)
+	other.FillNa(0.0)
+	if got := head.Columns["score"].Values(); !reflect.DeepEqual(got, []any{nil, 1.0, 2.0, nil}) {
+		t.Errorf("expected the view to be unchanged by writes to the original, got %v", got)
+	}
+
+	col, err := df.Columns["id"].Slice(198, 200)
+	if err != nil || !reflect.DeepEqual(col.Values(), []any{198, 199}) {
+		t.Errorf("expected [198 199], got %v (error %v)", col.Values(), err)
+	}
+	for _, bounds := range [][2]int{{-1, 2}, {5, 4}, {0, 201}} {
+		if _, err := df.SliceRows(bounds[0], bounds[1]); err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("SliceRows(%d, %d): expected an out of range error, got %v", bounds[0], bounds[1], err)
+		}
+	}
+	if df.Head(-1).Nrows() != 0 || df.Tail(500).Nrows() != 200 {
+		t.Errorf("expected Head and Tail to clamp n")
+	}
+}
+
+func TestAppendRowsFromRows(t *testing.T) {
+	rows := []map[string]any{
+		{"id": 1, "name": "Ann"},
+		{"id": 2, "score": 9.5},
+		{"id": 3, "name": "Cid", "score": 7.0},
+	}
+
+	for _, df := range []*goframe.DataFrame{goframe.NewDataFrame(), goframe.NewTypedDataFrame()} {
+		if err := df.Append(map[string]any{"id": 0}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+		if err := df.AppendRows(rows); err != nil {
+			t.Fatalf("AppendRows failed: %v", err)
+		}
+		if got := df.ColumnNames(); !reflect.DeepEqual(got, []string{"id", "name", "score"}) {
+			t.Errorf("expected columns [id name score], got %v", got)
+		}
+		expected := map[string][]any{
+			"id":    {0, 1, 2, 3},
+			"name":  {nil, "Ann", nil, "Cid"},
+			"score": {nil, nil, 9.5, 7.0},
+		}
+		if df.IsTyped() {
+			expected["id"] = []any{int64(0), int64(1), int64(2), int64(3)}
+		}
+		for name, want := range expected {
+			if got := df.Columns[name].Values(); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: expected %v, got %v", name, want, got)
+			}
+		}
+	}
+
+	df, err := goframe.FromRows(rows, []string{"score", "id", "name"})
+	if err != nil {
+		t.Fatalf("FromRows returned an error: %v", err)
+	}
+	if got := df.ColumnNames(); !reflect.DeepEqual(got, []string{"score", "id", "name"}) {
+		t.Errorf("expected the given column order, got %v", got)
+	}
+	if got := df.Columns["name"].Values(); !reflect.DeepEqual(got, []any{"Ann", nil, "Cid"}) {
+		t.Errorf("expected [Ann <nil> Cid], got %v", got)
+	}
+	if df, _ := goframe.FromRows(rows, nil); !reflect.DeepEqual(df.ColumnNames(), []string{"id", "name", "score"}) {
+		t.Errorf("expected the columns in alphabetical order, got %v", df.ColumnNames())
+	}
+	if _, err := goframe.FromRows(rows, []string{"id", "name"}); err == nil || !strings.Contains(err.Error(), "row 1 has column 'score'") {
+		t.Errorf("expected an error for a column missing from the order, got %v", err)
+	}
+
+	df, err = goframe.FromRecords([][]any{{1, "Ann"}, {2, nil}}, []string{"id", "name"})
+	if err != nil {
+		t.Fatalf("FromRecords returned an error: %v", err)
+	}
+	if got := df.Columns["name"].Values(); df.Nrows() != 2 || !reflect.DeepEqual(got, []any{"Ann", nil}) {
+		t.Errorf("expected 2 rows and names [Ann <nil>], got %d rows and %v", df.Nrows(), got)
+	}
+	if _, err := goframe.FromRecords([][]any{{1, "Ann"}, {2}}, []string{"id", "name"}); err == nil || !strings.Contains(err.Error(), "record 1 has 1 values, expected 2") {
+		t.Errorf("expected a shape error, got %v", err)
+	}
+	if _, err := goframe.FromRecords(nil, []string{"id", "id"}); err == nil || !strings.Contains(err.Error(), "duplicate column 'id'") {
+		t.Errorf("expected a duplicate column error, got %v", err)
+	}
+}
+
+func TestFromMapFromColumns(t *testing.T) {
+	df, err := goframe.FromMap(map[string][]any{"name": {"a", "b"}, "id": {1, 2}})
+	if err != nil {
+		t.Fatalf("FromMap returned an error: %v", err)
+	}
+	if got := df.ColumnNames(); !reflect.DeepEqual(got, []string{"id", "name"}) || df.Nrows() != 2 {
+		t.Errorf("expected columns [id name] and 2 rows, got %v and %d rows", got, df.Nrows())
+	}
+	if _, err := goframe.FromMap(map[string][]any{"a": {1, 2}, "b": {1}}); err == nil || !strings.Contains(err.Error(), "column 'b' has 1 rows, expected 2") {
+		t.Errorf("expected a length error, got %v", err)
+	}
+	if df, err := goframe.FromMap(nil); err != nil || df.Nrows() != 0 || len(df.ColumnNames()) != 0 {
+		t.Errorf("expected an empty DataFrame, got %v (error %v)", df, err)
+	}
+
+	df, err = goframe.FromColumns(
+		goframe.NewColumn[any]("score", []any{1.5, 2.5}),
+		goframe.ConvertToAnyColumn(goframe.NewColumn("id", []int{1, 2})),
+	)
+	if err != nil {
+		t.Fatalf("FromColumns returned an error: %v", err)
+	}
+	if got := df.ColumnNames(); !reflect.DeepEqual(got, []string{"score", "id"}) {
+		t.Errorf("expected the given column order, got %v", got)
+	}
+	if got := df.Columns["id"].Values(); !reflect.DeepEqual(got, []any{1, 2}) {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+
+	errors := map[string][]*goframe.Column[any]{
+		"column 1 is nil":                   {goframe.NewColumn[any]("a", []any{1}), nil},
+		"duplicate column 'a'":              {goframe.NewColumn[any]("a", []any{1}), goframe.NewColumn[any]("a", []any{2})},
+		"column 'b' has 2 rows, expected 1": {goframe.NewColumn[any]("a", []any{1}), goframe.NewColumn[any]("b", []any{1, 2})},
+	}
+	for message, cols := range errors {
+		if _, err := goframe.FromColumns(cols...); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("expected an error containing %q, got %v", message, err)
+		}
+	}
+}
+
+func TestSeriesStatistics(t *testing.T) {
+	series := goframe.NewSeries("x", []any{2, 8.0, nil, 0, 4, 1, 9, 9, math.NaN()})
+
+	near := func(name string, got float64, err error, want float64) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("%s returned an error: %v", name, err)
+		} else if math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", name, want, got)
+		}
+	}
+	median, err := series.Median()
+	near("Median", median, err, 4)
+	variance, err := series.Var()
+	near("Var", variance, err, 15.238095238095237)
+	variance, err = series.Var(goframe.AggOption{Population: true})
+	near("Var population", variance, err, 13.061224489795919)
+	std, err := series.Std()
+	near("Std", std, err, 3.903600291794133)
+	std, err = series.Std(goframe.AggOption{Population: true})
+	near("Std population", std, err, 3.614031611621005)
+	quantile, err := series.Quantile(0.25)
+	near("Quantile", quantile, err, 1.5)
+	skew, err := series.Skew()
+	near("Skew", skew, err, 0.06484398531583804)
+	kurtosis, err := series.Kurtosis()
+	near("Kurtosis", kurtosis, err, -2.324929687500001)
+
+	if got, _ := series.Median(goframe.AggOption{KeepNaN: true}); !math.IsNaN(got) {
+		t.Errorf("expected KeepNaN to propagate NaN, got %v", got)
+	}
+	if got, _ := goframe.NewSeries("x", []any{1, 2}).Skew(); !math.IsNaN(got) {
+		t.Errorf("expected NaN skewness for two values, got %v", got)
+	}
+	if got, _ := goframe.NewSeries("x", []any{3, 3, 3, 3}).Kurtosis(); got != 0 {
+		t.Errorf("expected 0 kurtosis for equal values, got %v", got)
+	}
+	if _, err := series.Quantile(1.5); err == nil || !strings.Contains(err.Error(), "outside [0, 1]") {
+		t.Errorf("expected an error for a quantile above 1, got %v", err)
+	}
+	if _, err := goframe.NewSeries("x", []any{"a"}).Median(); err == nil {
+		t.Errorf("expected an error for a non-numeric series")
+	}
+	if got := series.Mode(); !reflect.DeepEqual(got, []any{9}) {
+		t.Errorf("expected mode [9], got %v", got)
+	}
+	if got := goframe.NewSeries("x", []any{"b", 1.0, "b", 1, nil, nil}).Mode(); !reflect.DeepEqual(got, []any{1.0, "b"}) {
+		t.Errorf("expected modes [1 b], got %v", got)
+	}
+
+	// the DataFrame methods give the same results for boxed and typed storage
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("x", []any{2, 8, 0, 4, 1, 9, 9}))
+	df.AddColumn(goframe.NewColumn("y", []any{1.0, nil, 3.0, nil, nil, 5.0, 5.0}))
+	for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+		stds, err := frame.Std()
+		if err != nil {
+			t.Fatalf("Std returned an error: %v", err)
+		}
+		near("DataFrame Std", stds["x"], nil, 3.903600291794133)
+		near("DataFrame Std", stds["y"], nil, math.Sqrt(11.0/3))
+		medians, _ := frame.Median()
+		near("DataFrame Median", medians["y"], nil, 4)
+		quantiles, _ := frame.Quantile(0.25)
+		near("DataFrame Quantile", quantiles["x"], nil, 1.5)
+		skews, _ := frame.Skew()
+		near("DataFrame Skew", skews["x"], nil, 0.06484398531583804)
+		kurtosis, _ := frame.Kurtosis()
+		near("DataFrame Kurtosis", kurtosis["x"], nil, -2.324929687500001)
+		if modes := frame.Mode(); len(modes["x"]) != 1 || len(modes["y"]) != 1 {
+			t.Errorf("expected one mode per column, got %v", modes)
+		}
+	}
+}
+
+func TestSeriesChanges(t *testing.T) {
+	price := goframe.NewSeries("price", []any{10, 12.0, nil, 15, 0, 3})
+
+	if got := price.Shift(1).Data; !reflect.DeepEqual(got, []any{nil, 10, 12.0, nil, 15, 0}) {
+		t.Errorf("Shift: unexpected values %v", got)
+	}
+	if got := price.Lag(2, -1).Data; !reflect.DeepEqual(got, []any{-1, -1, 10, 12.0, nil, 15}) {
+		t.Errorf("Lag: unexpected values %v", got)
+	}
+	if got := price.Lead(1).Data; !reflect.DeepEqual(got, []any{12.0, nil, 15, 0, 3, nil}) {
+		t.Errorf("Lead: unexpected values %v", got)
+	}
+	if got := price.Diff(1).Data; !reflect.DeepEqual(got, []any{nil, 2.0, nil, nil, -15.0, 3.0}) {
+		t.Errorf("Diff: unexpected values %v", got)
+	}
+	if got := price.Diff(-1).Data; !reflect.DeepEqual(got, []any{-2.0, nil, nil, 15.0, -3.0, nil}) {
+		t.Errorf("Diff(-1): unexpected values %v", got)
+	}
+	changes := price.PctChange(1).Data
+	if changes[0] != nil || changes[1] != 0.2 || changes[2] != nil || changes[4] != -1.0 || !math.IsInf(changes[5].(float64), 1) {
+		t.Errorf("PctChange: unexpected values %v", changes)
+	}
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	times := goframe.NewSeries("time", []any{day, day.Add(90 * time.Minute)})
+	if got := times.Diff(1).Data; !reflect.DeepEqual(got, []any{nil, 90 * time.Minute}) {
+		t.Errorf("Diff of times: unexpected values %v", got)
+	}
+	if got := times.PctChange(1); got.Err() == nil {
+		t.Errorf("PctChange: expected an error for times")
+	}
+	if got := goframe.NewSeries("name", []any{"a", "b"}).Diff(1); got.Err() == nil || !strings.Contains(got.Err().Error(), "is not numeric") {
+		t.Errorf("Diff: expected an error for strings, got %v", got.Err())
+	}
+
+	// the new columns are added next to the original one
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("price", price.Data))
+	withChange, err := df.WithColumn("change", df.Col("price").Diff(1))
+	if err != nil {
+		t.Fatalf("WithColumn failed: %v", err)
+	}
+	if got := withChange.ColumnNames(); !reflect.DeepEqual(got, []string{"price", "change"}) {
+		t.Errorf("expected columns [price change], got %v", got)
+	}
+}
+
+func TestValueCounts(t *testing.T) {
+	series := goframe.NewSeries("dept", []any{"IT", "HR", nil, "IT", math.NaN(), "Sales", "HR", "IT", nil, 1, 1.0})
+
+	counts := series.ValueCounts()
+	if got := counts.ColumnNames(); !reflect.DeepEqual(got, []string{"dept", "count"}) {
+		t.Errorf("expected columns [dept count], got %v", got)
+	}
+	if got := counts.Columns["dept"].Values(); !reflect.DeepEqual(got, []any{"IT", "HR", 1, "Sales"}) {
+		t.Errorf("expected values [IT HR 1 Sales], got %v", got)
+	}
+	if got := counts.Columns["count"].Values(); !reflect.DeepEqual(got, []any{3, 2, 2, 1}) {
+		t.Errorf("expected counts [3 2 2 1], got %v", got)
+	}
+
+	withNil := series.ValueCounts(goframe.UniqueOption{KeepNil: true})
+	if got := withNil.Columns["count"].Values(); !reflect.DeepEqual(got, []any{3, 2, 2, 2, 1, 1}) {
+		t.Errorf("expected counts [3 2 2 2 1 1], got %v", got)
+	}
+	if value, _ := withNil.Columns["dept"].At(2); value != nil {
+		t.Errorf("expected nil to be counted third, got %v", value)
+	}
+
+	if got := series.Unique(); !reflect.DeepEqual(got, []any{"IT", "HR", "Sales", 1}) {
+		t.Errorf("expected unique values [IT HR Sales 1], got %v", got)
+	}
+	if got := series.NUnique(); got != 4 {
+		t.Errorf("expected 4 unique values, got %d", got)
+	}
+	if got := series.NUnique(goframe.UniqueOption{KeepNil: true}); got != 6 {
+		t.Errorf("expected 6 unique values with nil and NaN, got %d", got)
+	}
+
+	col := goframe.NewColumn("id", []int{3, 1, 3, 2, 1})
+	if got := col.Unique(); !reflect.DeepEqual(got, []int{3, 1, 2}) || col.NUnique() != 3 {
+		t.Errorf("expected unique values [3 1 2], got %v", got)
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("dept", series.Data))
+	for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+		if got := frame.Columns["dept"].NUnique(); got != 4 {
+			t.Errorf("expected 4 unique values in the column, got %d", got)
+		}
+	}
+}
+
 // MARK: Helper Functions
 
 /*
@@ -1888,3 +3023,1270 @@ func toFloat(v any) (float64, bool) {
 	}
 	return 0, false
 }
+
+func TestColumnOrder(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("zeta", []int{1, 2})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("alpha", []string{"a", "b"})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("mid", []float64{1.5, 2.5})))
+
+	expected := []string{"zeta", "alpha", "mid"}
+	if !reflect.DeepEqual(df.ColumnNames(), expected) {
+		t.Fatalf("Expected insertion order %v, got %v", expected, df.ColumnNames())
+	}
+	if header := strings.SplitN(df.String(), "\n", 3)[1]; header != "zeta\talpha\tmid" {
+		t.Errorf("Expected String header in insertion order, got %q", header)
+	}
+
+	t.Run("RenameAndDrop", func(t *testing.T) {
+		copyDf := df.Head(2)
+		if err := copyDf.RenameColumn("alpha", "beta"); err != nil {
+			t.Fatalf("RenameColumn failed: %v", err)
+		}
+		if err := copyDf.DropColumn("zeta"); err != nil {
+			t.Fatalf("DropColumn failed: %v", err)
+		}
+		if !reflect.DeepEqual(copyDf.ColumnNames(), []string{"beta", "mid"}) {
+			t.Errorf("Expected [beta mid], got %v", copyDf.ColumnNames())
+		}
+	})
+
+	t.Run("CSVRoundTrip", func(t *testing.T) {
+		var buf strings.Builder
+		if err := df.ToCSVWriter(&buf); err != nil {
+			t.Fatalf("ToCSVWriter failed: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "zeta,alpha,mid\n") {
+			t.Errorf("Expected CSV header in insertion order, got %q", buf.String())
+		}
+		read, err := goframe.FromCSVReader(strings.NewReader("b,a,c\n1,2,3\n"))
+		if err != nil {
+			t.Fatalf("FromCSVReader failed: %v", err)
+		}
+		if !reflect.DeepEqual(read.ColumnNames(), []string{"b", "a", "c"}) {
+			t.Errorf("Expected CSV header order [b a c], got %v", read.ColumnNames())
+		}
+	})
+
+	t.Run("Join", func(t *testing.T) {
+		right := goframe.NewDataFrame()
+		right.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("zeta", []int{1, 2})))
+		right.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("extra", []string{"x", "y"})))
+		joined, err := df.InnerJoin(right, "zeta")
+		if err != nil {
+			t.Fatalf("InnerJoin failed: %v", err)
+		}
+		if !reflect.DeepEqual(joined.ColumnNames(), []string{"zeta", "alpha", "mid", "extra"}) {
+			t.Errorf("Expected left columns before right columns, got %v", joined.ColumnNames())
+		}
+	})
+
+	t.Run("ReorderColumns", func(t *testing.T) {
+		reordered := df.Head(2)
+		if err := reordered.ReorderColumns([]string{"mid", "zeta"}); err != nil {
+			t.Fatalf("ReorderColumns failed: %v", err)
+		}
+		if !reflect.DeepEqual(reordered.ColumnNames(), []string{"mid", "zeta", "alpha"}) {
+			t.Errorf("Expected [mid zeta alpha], got %v", reordered.ColumnNames())
+		}
+		if err := reordered.ReorderColumns([]string{"missing"}); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if err := reordered.ReorderColumns([]string{"mid", "mid"}); err == nil {
+			t.Error("Expected error for duplicate column, got nil")
+		}
+	})
+}
+
+func TestRenameColumns(t *testing.T) {
+	newFrame := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("First Name", []string{"a", "b"})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("Total Amount", []int{1, 2})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("id", []int{7, 8})))
+		return df
+	}
+
+	t.Run("Map", func(t *testing.T) {
+		df := newFrame()
+		if err := df.RenameColumns(map[string]string{"First Name": "id", "id": "first_name"}); err != nil {
+			t.Fatalf("RenameColumns failed: %v", err)
+		}
+		if !reflect.DeepEqual(df.ColumnNames(), []string{"id", "Total Amount", "first_name"}) {
+			t.Errorf("Expected swapped names in place, got %v", df.ColumnNames())
+		}
+		col, _ := df.Select("first_name")
+		if col.Name != "first_name" || !reflect.DeepEqual(col.Data, []any{7, 8}) {
+			t.Errorf("Expected renamed column to keep its data, got %s=%v", col.Name, col.Data)
+		}
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		df := newFrame()
+		snake := func(name string) string { return strings.ToLower(strings.ReplaceAll(name, " ", "_")) }
+		if err := df.RenameColumnsFunc(snake); err != nil {
+			t.Fatalf("RenameColumnsFunc failed: %v", err)
+		}
+		if !reflect.DeepEqual(df.ColumnNames(), []string{"first_name", "total_amount", "id"}) {
+			t.Errorf("Expected snake_case names, got %v", df.ColumnNames())
+		}
+	})
+
+	t.Run("Index", func(t *testing.T) {
+		df := newFrame()
+		df.SetIndex("id")
+		if _, err := df.LocRow(8); err != nil {
+			t.Fatalf("LocRow failed: %v", err)
+		}
+		if err := df.RenameColumn("id", "key"); err != nil {
+			t.Fatalf("RenameColumn failed: %v", err)
+		}
+		if df.IndexName() != "key" {
+			t.Errorf("expected the index to follow the renamed column, got %q", df.IndexName())
+		}
+		if row, err := df.LocRow(8); err != nil || row["First Name"] != "b" {
+			t.Errorf("expected LocRow to find the row by the renamed index, got %v (error %v)", row, err)
+		}
+
+		if err := df.RenameColumns(map[string]string{"key": "id", "First Name": "name"}); err != nil {
+			t.Fatalf("RenameColumns failed: %v", err)
+		}
+		if df.IndexName() != "id" {
+			t.Errorf("expected the index to follow the renamed column, got %q", df.IndexName())
+		}
+		if row, err := df.LocRow(7); err != nil || row["name"] != "a" {
+			t.Errorf("expected LocRow to find the row by the renamed index, got %v (error %v)", row, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		df := newFrame()
+		if err := df.RenameColumns(map[string]string{"missing": "x"}); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if err := df.RenameColumns(map[string]string{"First Name": "id"}); err == nil {
+			t.Error("Expected error for duplicate name, got nil")
+		}
+		if err := df.RenameColumnsFunc(func(string) string { return "same" }); err == nil {
+			t.Error("Expected error when the function maps two columns to one name, got nil")
+		}
+		if !reflect.DeepEqual(df.ColumnNames(), []string{"First Name", "Total Amount", "id"}) {
+			t.Errorf("Expected DataFrame unchanged after errors, got %v", df.ColumnNames())
+		}
+	})
+}
+
+func TestGroupByAggregations(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("dept", []any{"IT", "HR", "IT", "IT", "HR", "OPS"}))
+	df.AddColumn(goframe.NewColumn("salary", []any{int64(100), 50.0, 300, math.NaN(), nil, "n/a"}))
+	df.AddColumn(goframe.NewColumn("name", []any{nil, "Tim", "Sam", "Ann", math.NaN(), nil}))
+
+	nan := math.NaN()
+	cases := []struct {
+		name     string
+		agg      func(...string) (*goframe.DataFrame, error)
+		expected []any
+	}{
+		{"Sum", df.Groupby("dept").Sum, []any{400.0, 50.0, 0.0}},
+		{"Mean", df.Groupby("dept").Mean, []any{200.0, 50.0, nan}},
+		{"Min", df.Groupby("dept").Min, []any{100.0, 50.0, nan}},
+		{"Max", df.Groupby("dept").Max, []any{300.0, 50.0, nan}},
+		{"Median", df.Groupby("dept").Median, []any{200.0, 50.0, nan}},
+		{"Var", df.Groupby("dept").Var, []any{20000.0, nan, nan}},
+		{"Std", df.Groupby("dept").Std, []any{math.Sqrt(20000), nan, nan}},
+		{"First", df.Groupby("dept").First, []any{int64(100), 50.0, "n/a"}},
+		{"Last", df.Groupby("dept").Last, []any{300, 50.0, "n/a"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.agg("salary")
+			if err != nil {
+				t.Fatalf("%s failed: %v", tc.name, err)
+			}
+			if !reflect.DeepEqual(result.ColumnNames(), []string{"GroupKey", "salary"}) {
+				t.Fatalf("Expected columns [GroupKey salary], got %v", result.ColumnNames())
+			}
+			keys, _ := result.Select("GroupKey")
+			if !reflect.DeepEqual(keys.Data, []any{"IT", "HR", "OPS"}) {
+				t.Errorf("Expected groups in order of appearance, got %v", keys.Data)
+			}
+			salary, _ := result.Select("salary")
+			for i, want := range tc.expected {
+				got := salary.Data[i]
+				if f, ok := want.(float64); ok && math.IsNaN(f) {
+					if g, ok := got.(float64); !ok || !math.IsNaN(g) {
+						t.Errorf("Group %v: expected NaN, got %v", keys.Data[i], got)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(got, want) && !almostEqual(got, want) {
+					t.Errorf("Group %v: expected %v (%T), got %v (%T)", keys.Data[i], want, want, got, got)
+				}
+			}
+		})
+	}
+
+	t.Run("FirstSkipsMissing", func(t *testing.T) {
+		result, err := df.Groupby("dept").First()
+		if err != nil {
+			t.Fatalf("First failed: %v", err)
+		}
+		if !reflect.DeepEqual(result.ColumnNames(), []string{"GroupKey", "salary", "name"}) {
+			t.Fatalf("Expected all columns except the key, got %v", result.ColumnNames())
+		}
+		name, _ := result.Select("name")
+		if !reflect.DeepEqual(name.Data, []any{"Sam", "Tim", nil}) {
+			t.Errorf("Expected first non-missing names, got %v", name.Data)
+		}
+	})
+
+	t.Run("UnknownColumn", func(t *testing.T) {
+		if _, err := df.Groupby("dept").Median("missing"); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if _, err := df.Groupby("missing").Std(); err == nil {
+			t.Error("Expected error for unknown key, got nil")
+		}
+	})
+}
+
+func TestAddPrefixSuffix(t *testing.T) {
+	newFrame := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("id", []int{1, 2})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("sales", []float64{1.5, 2.5})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("cost", []float64{0.5, 1.5})))
+		return df
+	}
+
+	df := newFrame()
+	if err := df.AddPrefix("q1_"); err != nil {
+		t.Fatalf("AddPrefix failed: %v", err)
+	}
+	if !reflect.DeepEqual(df.ColumnNames(), []string{"q1_id", "q1_sales", "q1_cost"}) {
+		t.Errorf("Expected prefixed names, got %v", df.ColumnNames())
+	}
+
+	df = newFrame()
+	if err := df.AddSuffix("_2024", "sales", "cost"); err != nil {
+		t.Fatalf("AddSuffix failed: %v", err)
+	}
+	if !reflect.DeepEqual(df.ColumnNames(), []string{"id", "sales_2024", "cost_2024"}) {
+		t.Errorf("Expected suffixed names for selected columns, got %v", df.ColumnNames())
+	}
+
+	if err := df.AddPrefix("x_", "missing"); err == nil {
+		t.Error("Expected error for unknown column, got nil")
+	}
+}
+
+func TestDropColumnsVariants(t *testing.T) {
+	newFrame := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("id", []any{1, 2, 3}))
+		df.AddColumn(goframe.NewColumn("tmp_a", []any{1, 2, 3}))
+		df.AddColumn(goframe.NewColumn("empty", []any{nil, nil, nil}))
+		df.AddColumn(goframe.NewColumn("const", []any{"x", "x", "x"}))
+		df.AddColumn(goframe.NewColumn("tmp_b", []any{4, 5, 6}))
+		return df
+	}
+
+	t.Run("DropColumns", func(t *testing.T) {
+		df := newFrame()
+		if err := df.DropColumns("tmp_a", "empty"); err != nil {
+			t.Fatalf("DropColumns failed: %v", err)
+		}
+		if !reflect.DeepEqual(df.ColumnNames(), []string{"id", "const", "tmp_b"}) {
+			t.Errorf("Unexpected columns %v", df.ColumnNames())
+		}
+		if err := df.DropColumns("id", "missing"); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if _, exists := df.Columns["id"]; !exists {
+			t.Error("Expected no column removed after an error")
+		}
+	})
+
+	t.Run("DropColumnsMatching", func(t *testing.T) {
+		df := newFrame()
+		dropped, err := df.DropColumnsMatching("^tmp_")
+		if err != nil {
+			t.Fatalf("DropColumnsMatching failed: %v", err)
+		}
+		if !reflect.DeepEqual(dropped, []string{"tmp_a", "tmp_b"}) || len(df.Columns) != 3 {
+			t.Errorf("Expected tmp columns dropped, got dropped=%v remaining=%v", dropped, df.ColumnNames())
+		}
+		if _, err := df.DropColumnsMatching("("); err == nil {
+			t.Error("Expected error for invalid pattern, got nil")
+		}
+	})
+
+	t.Run("DropColumnsIf", func(t *testing.T) {
+		df := newFrame()
+		constant := func(_ string, col *goframe.Column[any]) bool {
+			for _, v := range col.Data[1:] {
+				if v != col.Data[0] {
+					return false
+				}
+			}
+			return true
+		}
+		dropped := df.DropColumnsIf(constant)
+		if !reflect.DeepEqual(dropped, []string{"empty", "const"}) {
+			t.Errorf("Expected constant columns dropped, got %v", dropped)
+		}
+		if !reflect.DeepEqual(df.ColumnNames(), []string{"id", "tmp_a", "tmp_b"}) {
+			t.Errorf("Unexpected columns %v", df.ColumnNames())
+		}
+	})
+}
+
+func TestGroupByAgg(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("dept", []any{"IT", "HR", "IT"}))
+	df.AddColumn(goframe.NewColumn("age", []any{30, 40, 50}))
+	df.AddColumn(goframe.NewColumn("salary", []any{100.0, 50.0, nil}))
+
+	result, err := df.Groupby("dept").Agg(map[string][]string{
+		"salary": {"sum", "mean", "count", "size"},
+		"age":    {"max", "spread"},
+	}, goframe.GroupAggOption{Funcs: map[string]func([]any) any{
+		"spread": func(values []any) any {
+			return values[len(values)-1].(int) - values[0].(int)
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Agg failed: %v", err)
+	}
+
+	expectedNames := []string{"GroupKey", "age_max", "age_spread", "salary_sum", "salary_mean", "salary_count", "salary_size"}
+	if !reflect.DeepEqual(result.ColumnNames(), expectedNames) {
+		t.Fatalf("Expected columns %v, got %v", expectedNames, result.ColumnNames())
+	}
+	expected := map[string][]any{
+		"GroupKey":     {"IT", "HR"},
+		"age_max":      {50.0, 40.0},
+		"age_spread":   {20, 0},
+		"salary_sum":   {100.0, 50.0},
+		"salary_mean":  {100.0, 50.0},
+		"salary_count": {1, 1},
+		"salary_size":  {2, 1},
+	}
+	for name, values := range expected {
+		col, _ := result.Select(name)
+		if !reflect.DeepEqual(col.Data, values) {
+			t.Errorf("Column '%s': expected %v, got %v", name, values, col.Data)
+		}
+	}
+
+	t.Run("Separator", func(t *testing.T) {
+		result, err := df.Groupby("dept").Agg(map[string][]string{"age": {"min"}}, goframe.GroupAggOption{Separator: "."})
+		if err != nil {
+			t.Fatalf("Agg failed: %v", err)
+		}
+		if !reflect.DeepEqual(result.ColumnNames(), []string{"GroupKey", "age.min"}) {
+			t.Errorf("Expected [GroupKey age.min], got %v", result.ColumnNames())
+		}
+	})
+
+	t.Run("SortBy", func(t *testing.T) {
+		result, err := df.Groupby("dept").Agg(map[string][]string{"age": {"sum"}}, goframe.GroupAggOption{SortBy: "GroupKey"})
+		if err != nil {
+			t.Fatalf("Agg failed: %v", err)
+		}
+		if got := result.Columns["GroupKey"].Data; !reflect.DeepEqual(got, []any{"HR", "IT"}) {
+			t.Errorf("Expected [HR IT], got %v", got)
+		}
+		result, err = df.Groupby("dept").Agg(map[string][]string{"age": {"sum"}}, goframe.GroupAggOption{SortBy: "age_sum", Descending: true})
+		if err != nil {
+			t.Fatalf("Agg failed: %v", err)
+		}
+		if got := result.Columns["age_sum"].Data; !reflect.DeepEqual(got, []any{80.0, 40.0}) {
+			t.Errorf("Expected [80 40], got %v", got)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := df.Groupby("dept").Agg(map[string][]string{"age": {"sum"}}, goframe.GroupAggOption{SortBy: "age"}); err == nil {
+			t.Error("Expected error for a SortBy column that is not an output column, got nil")
+		}
+		if _, err := df.Groupby("dept").Agg(map[string][]string{"missing": {"sum"}}); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if _, err := df.Groupby("dept").Agg(map[string][]string{"age": {"mode"}}); err == nil {
+			t.Error("Expected error for unknown aggregation, got nil")
+		}
+		if _, err := df.Groupby("dept").Agg(map[string][]string{"age": {"sum", "sum"}}); err == nil {
+			t.Error("Expected error for duplicate output column, got nil")
+		}
+	})
+}
+
+func TestGroupBySortKeys(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("dept", []any{"IT", "HR", nil, "IT", "Ops"}))
+	df.AddColumn(goframe.NewColumn("level", []any{10, 9, 1, 2, 9}))
+	df.AddColumn(goframe.NewColumn("salary", []any{100, 50, 10, 70, 60}))
+
+	tests := []struct {
+		name      string
+		grouped   *goframe.GroupedDataFrame
+		expected  []any
+		salarySum []any
+	}{
+		{"Ascending", df.Groupby("dept").SortKeys(), []any{"HR", "IT", "Ops", nil}, []any{50.0, 170.0, 60.0, 10.0}},
+		{"Descending", df.Groupby("dept").SortKeys(false), []any{"Ops", "IT", "HR", nil}, []any{60.0, 170.0, 50.0, 10.0}},
+		// numbers in multi-column keys are compared numerically, not as text
+		{"Columns", df.Groupby([]string{"level", "dept"}).SortKeys(), []any{"1|<nil>", "2|IT", "9|HR", "9|Ops", "10|IT"}, []any{10.0, 70.0, 50.0, 60.0, 100.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.grouped.Sum("salary")
+			if err != nil {
+				t.Fatalf("Sum failed: %v", err)
+			}
+			if got := result.Columns["GroupKey"].Data; !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected keys %v, got %v", tt.expected, got)
+			}
+			if got := result.Columns["salary"].Data; !reflect.DeepEqual(got, tt.salarySum) {
+				t.Errorf("Expected sums %v, got %v", tt.salarySum, got)
+			}
+		})
+	}
+
+	if err := df.Groupby("dept").SortKeys(true, false).Error(); err == nil {
+		t.Error("Expected an error for two directions, got nil")
+	}
+}
+
+func TestConstantAndEmptyColumns(t *testing.T) {
+	newFrame := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("id", []any{1, 2, 3}))
+		df.AddColumn(goframe.NewColumn("empty", []any{nil, math.NaN(), nil}))
+		df.AddColumn(goframe.NewColumn("const", []any{1, nil, 1.0}))
+		df.AddColumn(goframe.NewColumn("label", []any{"a", "a", "a"}))
+		df.AddColumn(goframe.NewColumn("mixed", []any{"1", 1, 1}))
+		return df
+	}
+
+	df := newFrame()
+	if empty := df.EmptyColumns(); !reflect.DeepEqual(empty, []string{"empty"}) {
+		t.Errorf("Expected [empty], got %v", empty)
+	}
+	if constant := df.ConstantColumns(); !reflect.DeepEqual(constant, []string{"const", "label"}) {
+		t.Errorf("Expected [const label], got %v", constant)
+	}
+
+	dropped := df.DropConstant()
+	if !reflect.DeepEqual(dropped, []string{"empty", "const", "label"}) {
+		t.Errorf("Expected [empty const label] dropped, got %v", dropped)
+	}
+	if !reflect.DeepEqual(df.ColumnNames(), []string{"id", "mixed"}) {
+		t.Errorf("Expected [id mixed] remaining, got %v", df.ColumnNames())
+	}
+
+	typed := newFrame().ToTyped()
+	if constant := typed.ConstantColumns(); !reflect.DeepEqual(constant, []string{"const", "label"}) {
+		t.Errorf("Expected [const label] on typed storage, got %v", constant)
+	}
+}
+
+func TestGroupByApplyTransform(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("dept", []any{"IT", "HR", "IT", "HR", "IT"}))
+	df.AddColumn(goframe.NewColumn("salary", []any{100.0, 40.0, 200.0, 60.0, 300.0}))
+
+	t.Run("Apply", func(t *testing.T) {
+		top, err := df.Groupby("dept").Apply(func(group *goframe.DataFrame) *goframe.DataFrame {
+			sorted, _ := group.SortValues([]string{"salary"}, false)
+			return sorted.Head(1)
+		})
+		if err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		if !reflect.DeepEqual(top.ColumnNames(), []string{"dept", "salary"}) {
+			t.Errorf("Expected [dept salary], got %v", top.ColumnNames())
+		}
+		salary, _ := top.Select("salary")
+		if !reflect.DeepEqual(salary.Data, []any{300.0, 60.0}) {
+			t.Errorf("Expected the top salary of each group, got %v", salary.Data)
+		}
+
+		_, err = df.Groupby("dept").Apply(func(group *goframe.DataFrame) *goframe.DataFrame {
+			panic("boom")
+		})
+		if err == nil || !strings.Contains(err.Error(), "group IT") {
+			t.Errorf("Expected a recovered panic mentioning the group, got %v", err)
+		}
+	})
+
+	t.Run("Transform", func(t *testing.T) {
+		zscore := func(values *goframe.Series) *goframe.Series {
+			mean, _ := values.Mean()
+			nums, _ := values.AsFloat64()
+			variance := 0.0
+			for _, v := range nums {
+				variance += (v - mean) * (v - mean)
+			}
+			std := math.Sqrt(variance / float64(len(nums)-1))
+			result := make([]any, len(nums))
+			for i, v := range nums {
+				result[i] = (v - mean) / std
+			}
+			return goframe.NewSeries(values.Name, result)
+		}
+
+		scores, err := df.Groupby("dept").Transform("salary", zscore)
+		if err != nil {
+			t.Fatalf("Transform failed: %v", err)
+		}
+		expected := []any{-1.0, -math.Sqrt2 / 2, 0.0, math.Sqrt2 / 2, 1.0}
+		if scores.Name != "salary" || len(scores.Data) != len(expected) {
+			t.Fatalf("Expected 5 salary values, got %s=%v", scores.Name, scores.Data)
+		}
+		for i, want := range expected {
+			if !almostEqual(scores.Data[i], want) {
+				t.Errorf("Row %d: expected %v, got %v", i, want, scores.Data[i])
+			}
+		}
+
+		_, err = df.Groupby("dept").Transform("salary", func(values *goframe.Series) *goframe.Series {
+			return goframe.NewSeries("s", []any{1.0})
+		})
+		if err == nil {
+			t.Error("Expected error for a result of the wrong length, got nil")
+		}
+		if _, err := df.Groupby("dept").Transform("missing", zscore); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+	})
+}
+
+func TestGroupByFill(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("sensor", []any{"A", "B", "A", "A", "B", "B"}))
+	df.AddColumn(goframe.NewColumn("time", []any{3, 1, 1, 2, 2, 3}))
+	df.AddColumn(goframe.NewColumn("reading", []any{nil, 10, 5, nil, math.NaN(), 30}))
+	df.AddColumn(goframe.NewColumn("status", []any{"ok", nil, nil, "x", "y", nil}))
+
+	tests := []struct {
+		name     string
+		fill     func() (*goframe.DataFrame, error)
+		expected map[string][]any
+	}{
+		{"FFillByTime", func() (*goframe.DataFrame, error) {
+			return df.Groupby("sensor").FFill([]string{"reading"}, goframe.FillOption{TimeColumn: "time"})
+		}, map[string][]any{"reading": {5, 10, 5, 5, 10, 30}, "status": {"ok", nil, nil, "x", "y", nil}}},
+		{"FFillByRow", func() (*goframe.DataFrame, error) {
+			return df.Groupby("sensor").FFill([]string{"reading"})
+		}, map[string][]any{"reading": {nil, 10, 5, 5, 10, 30}}},
+		{"BFillByTime", func() (*goframe.DataFrame, error) {
+			return df.Groupby("sensor").BFill([]string{"reading"}, goframe.FillOption{TimeColumn: "time"})
+		}, map[string][]any{"reading": {nil, 10, 5, nil, 30, 30}}},
+		{"FFillLimit", func() (*goframe.DataFrame, error) {
+			return df.Groupby("sensor").FFill(nil, goframe.FillOption{TimeColumn: "time", Limit: 1})
+		}, map[string][]any{"reading": {nil, 10, 5, 5, 10, 30}, "status": {"ok", nil, nil, "x", "y", "y"}, "time": {3, 1, 1, 2, 2, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fill()
+			if err != nil {
+				t.Fatalf("fill failed: %v", err)
+			}
+			if !reflect.DeepEqual(result.ColumnNames(), df.ColumnNames()) {
+				t.Errorf("expected columns %v, got %v", df.ColumnNames(), result.ColumnNames())
+			}
+			for name, expected := range tt.expected {
+				if got := result.Columns[name].Data; !reflect.DeepEqual(got, expected) {
+					t.Errorf("column '%s': expected %v, got %v", name, expected, got)
+				}
+			}
+		})
+	}
+	if got := df.Columns["reading"].Data[4]; !math.IsNaN(got.(float64)) {
+		t.Errorf("expected the grouped DataFrame to be unchanged, got %v", got)
+	}
+
+	if _, err := df.Groupby("sensor").FFill([]string{"missing"}); err == nil {
+		t.Error("expected an error for a missing column")
+	}
+	if _, err := df.Groupby("sensor").BFill(nil, goframe.FillOption{TimeColumn: "when"}); err == nil {
+		t.Error("expected an error for a missing time column")
+	}
+	if _, err := df.Groupby("sensor").FFill(nil, goframe.FillOption{Limit: -1}); err == nil {
+		t.Error("expected an error for a negative limit")
+	}
+}
+
+func TestHorizontalBarAndParetoPlots(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("defect", []any{"scratch", "dent", "crack", "stain"}))
+	df.AddColumn(goframe.NewColumn("count", []any{12.0, 45, 7.0, 30.0}))
+	df.AddColumn(goframe.NewColumn("delta", []any{1.0, -2.0, 3.0, 0.5}))
+
+	isPNG := func(b []byte) bool { return strings.HasPrefix(string(b), "\x89PNG") }
+
+	var buf strings.Builder
+	if err := df.BarPlotWriter("delta", &buf, goframe.PlotOption{Horizontal: true}); err != nil {
+		t.Fatalf("BarPlotWriter failed: %v", err)
+	}
+	if !isPNG([]byte(buf.String())) {
+		t.Error("Expected PNG output for the horizontal bar plot")
+	}
+
+	paretoFile := filepath.Join(t.TempDir(), "pareto.png")
+	if err := df.ParetoPlot("defect", "count", paretoFile); err != nil {
+		t.Fatalf("ParetoPlot failed: %v", err)
+	}
+	if data, err := os.ReadFile(paretoFile); err != nil || !isPNG(data) {
+		t.Errorf("Expected a PNG file, got err=%v", err)
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		if err := df.ParetoPlotWriter("defect", "delta", io.Discard); err == nil {
+			t.Error("Expected error for negative values, got nil")
+		}
+		if err := df.ParetoPlotWriter("defect", "defect", io.Discard); err == nil {
+			t.Error("Expected error for non-numeric values, got nil")
+		}
+		if err := df.ParetoPlotWriter("missing", "count", io.Discard); err == nil {
+			t.Error("Expected error for unknown column, got nil")
+		}
+		if err := df.ParetoPlot("defect", "delta", filepath.Join(t.TempDir(), "x.png")); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
+func TestLinePlotSecondaryAxis(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("month", []any{1.0, 2.0, 3.0}))
+	df.AddColumn(goframe.NewColumn("revenue", []any{12000.0, 45000.0, 30000.0}))
+	df.AddColumn(goframe.NewColumn("conversion", []any{0.021, 0.034, 0.028}))
+	df.AddColumn(goframe.NewColumn("label", []any{"a", "b", "c"}))
+
+	opts := goframe.PlotOption{SecondaryColumn: "conversion", XLabel: "Month", YLabel: "Revenue", SecondaryYLabel: "Conversion rate"}
+	var buf strings.Builder
+	if err := df.LinePlotWriter("month", "revenue", &buf, opts); err != nil {
+		t.Fatalf("LinePlotWriter failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "\x89PNG") {
+		t.Error("Expected PNG output")
+	}
+
+	if err := df.LinePlotWriter("month", "revenue", io.Discard, goframe.PlotOption{SecondaryColumn: "missing"}); err == nil {
+		t.Error("Expected error for unknown secondary column, got nil")
+	}
+	if err := df.LinePlotWriter("month", "revenue", io.Discard, goframe.PlotOption{SecondaryColumn: "label"}); err == nil {
+		t.Error("Expected error for non-numeric secondary column, got nil")
+	}
+}
+
+func TestLinePlotAnnotations(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("t", []any{1.0, 2.0, 3.0, 4.0}))
+	df.AddColumn(goframe.NewColumn("latency", []any{120.0, 450.0, 380.0, 150.0}))
+
+	opts := goframe.PlotOption{
+		HLines:      []goframe.ReferenceLine{{Value: 300, Label: "SLO"}, {Value: 500, Color: "#0074d9"}},
+		VLines:      []goframe.ReferenceLine{{Value: 1.5, Label: "deploy"}},
+		XRegions:    []goframe.PlotRegion{{From: 3.5, To: 2.5}},
+		YRegions:    []goframe.PlotRegion{{From: 100, To: 200, Color: "green"}},
+		Annotations: []goframe.PlotAnnotation{{X: 2, Y: 450, Text: "peak"}},
+	}
+	var buf strings.Builder
+	if err := df.LinePlotWriter("t", "latency", &buf, opts); err != nil {
+		t.Fatalf("LinePlotWriter failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "\x89PNG") {
+		t.Error("Expected PNG output")
+	}
+}
+
+func TestEWM(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("day", []any{"mon", "tue", "wed", "thu"}))
+	df.AddColumn(goframe.NewColumn("price", []any{1.0, 2, nil, 3.0}))
+
+	at := func(frame *goframe.DataFrame, column string, row int) any {
+		col, _ := frame.Select(column)
+		value, _ := col.At(row)
+		return value
+	}
+
+	window, err := df.EWM(goframe.EWMOption{Alpha: 0.5})
+	if err != nil {
+		t.Fatalf("EWM failed: %v", err)
+	}
+	mean, err := window.Mean()
+	if err != nil {
+		t.Fatalf("Mean failed: %v", err)
+	}
+	if !reflect.DeepEqual(mean.ColumnNames(), []string{"day", "price"}) {
+		t.Errorf("Expected columns [day price], got %v", mean.ColumnNames())
+	}
+	if day := at(mean, "day", 3); day != "thu" {
+		t.Errorf("Expected non-numeric column to be copied, got %v", day)
+	}
+	// weights (1-alpha)^age over the rows seen so far, the missing row ages the earlier values
+	expected := []float64{1, 5.0 / 3, 5.0 / 3, (0.125 + 0.5 + 3) / (0.125 + 0.25 + 1)}
+	for i, want := range expected {
+		got := at(mean, "price", i)
+		if math.Abs(got.(float64)-want) > 1e-9 {
+			t.Errorf("Mean row %d: expected %v, got %v", i, want, got)
+		}
+	}
+
+	std, err := window.Std("price")
+	if err != nil {
+		t.Fatalf("Std failed: %v", err)
+	}
+	if first := at(std, "price", 0); first != nil {
+		t.Errorf("Expected nil std for a single value, got %v", first)
+	}
+	if got := at(std, "price", 1); math.Abs(got.(float64)-math.Sqrt(0.5)) > 1e-9 {
+		t.Errorf("Expected std %v, got %v", math.Sqrt(0.5), got)
+	}
+
+	spanWindow, _ := df.EWM(goframe.EWMOption{Span: 3, MinPeriods: 2})
+	spanMean, _ := spanWindow.Mean("price")
+	if first := at(spanMean, "price", 0); first != nil {
+		t.Errorf("Expected nil before min periods, got %v", first)
+	}
+
+	for _, opts := range []goframe.EWMOption{{}, {Span: 2, Alpha: 0.5}, {Alpha: 1.5}, {Span: 0.5}} {
+		if _, err := df.EWM(opts); err == nil {
+			t.Errorf("Expected error for %+v, got nil", opts)
+		}
+	}
+	if _, err := window.Mean("day"); err == nil {
+		t.Error("Expected error for non-numeric column, got nil")
+	}
+	if _, err := window.Mean("missing"); err == nil {
+		t.Error("Expected error for missing column, got nil")
+	}
+}
+
+func TestResampleOptions(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("date", []any{at(1, 31, 0), at(1, 31, 12), at(2, 1, 0), at(2, 5, 0), at(2, 29, 0)}))
+	df.AddColumn(goframe.NewColumn("value", []any{1, 2, 3, 4, 5}))
+	sum := func(values []any) any {
+		total := 0
+		for _, v := range values {
+			total += v.(int)
+		}
+		return total
+	}
+
+	tests := []struct {
+		freq    string
+		options goframe.ResampleOption
+		labels  []any
+		sums    []any
+	}{
+		{"M", goframe.ResampleOption{}, []any{at(1, 1, 0), at(2, 1, 0)}, []any{3, 12}},
+		{"M", goframe.ResampleOption{Label: "right"}, []any{at(2, 1, 0), at(3, 1, 0)}, []any{3, 12}},
+		{"M", goframe.ResampleOption{Label: "right", Closed: "right"}, []any{at(2, 1, 0), at(3, 1, 0)}, []any{6, 9}},
+		{"M-end", goframe.ResampleOption{}, []any{at(1, 31, 0), at(2, 29, 0)}, []any{1, 14}},
+		{"W-MON", goframe.ResampleOption{}, []any{at(2, 5, 0), at(3, 4, 0)}, []any{10, 5}},
+		{"W-MON", goframe.ResampleOption{Label: "left", Closed: "left"}, []any{at(1, 29, 0), at(2, 5, 0), at(2, 26, 0)}, []any{6, 4, 5}},
+		{"W", goframe.ResampleOption{}, []any{at(2, 4, 0), at(2, 11, 0), at(3, 3, 0)}, []any{6, 4, 5}},
+		{"D", goframe.ResampleOption{Label: "right", Closed: "right"}, []any{at(1, 31, 0), at(2, 1, 0), at(2, 5, 0), at(2, 29, 0)}, []any{1, 5, 4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %+v", tt.freq, tt.options), func(t *testing.T) {
+			result, err := df.Resample("date", tt.freq, sum, tt.options)
+			if err != nil {
+				t.Fatalf("Resample failed: %v", err)
+			}
+			if got := result.Columns["date"].Data; !reflect.DeepEqual(got, tt.labels) {
+				t.Errorf("expected labels %v, got %v", tt.labels, got)
+			}
+			if got := result.Columns["value"].Data; !reflect.DeepEqual(got, tt.sums) {
+				t.Errorf("expected sums %v, got %v", tt.sums, got)
+			}
+		})
+	}
+
+	errors := []struct {
+		freq    string
+		options goframe.ResampleOption
+		message string
+	}{
+		{"QS", goframe.ResampleOption{}, "unknown frequency 'QS'"},
+		{"W-XYZ", goframe.ResampleOption{}, "unknown frequency 'W-XYZ'"},
+		{"D", goframe.ResampleOption{Label: "middle"}, "unknown label: middle"},
+		{"D", goframe.ResampleOption{Closed: "both"}, "unknown closed: both"},
+		{"D", goframe.ResampleOption{Fill: "zero"}, "unknown fill: zero"},
+	}
+	for _, tt := range errors {
+		if _, err := df.Resample("date", tt.freq, sum, tt.options); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("expected an error containing %q, got %v", tt.message, err)
+		}
+	}
+}
+
+func TestResampleAgg(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 3, 1, hour, 30, 0, 0, time.UTC) }
+	hour := func(hour int) time.Time { return time.Date(2024, 3, 1, hour, 0, 0, 0, time.UTC) }
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("price", []any{10.0, 12.0, 20.0, 30.0}))
+	df.AddColumn(goframe.NewColumn("date", []any{at(9), at(9), at(10), at(13)}))
+	df.AddColumn(goframe.NewColumn("volume", []any{1, 2, 5, 7}))
+	df.AddColumn(goframe.NewColumn("note", []any{"a", "b", "c", "d"}))
+	aggregations := map[string]string{"price": "mean", "volume": "sum"}
+
+	result, err := df.ResampleAgg("date", "H", aggregations)
+	if err != nil {
+		t.Fatalf("ResampleAgg failed: %v", err)
+	}
+	expected := goframe.NewDataFrame()
+	expected.AddColumn(goframe.NewColumn("price", []any{11.0, 20.0, 30.0}))
+	expected.AddColumn(goframe.NewColumn("date", []any{hour(9), hour(10), hour(13)}))
+	expected.AddColumn(goframe.NewColumn("volume", []any{3.0, 5.0, 7.0}))
+	goframe.AssertFrameEqual(t, expected, result)
+
+	t.Run("fill", func(t *testing.T) {
+		filled, err := df.ResampleAgg("date", "H", aggregations, goframe.ResampleOption{Fill: "nil"})
+		if err != nil {
+			t.Fatalf("ResampleAgg failed: %v", err)
+		}
+		expected := goframe.NewDataFrame()
+		expected.AddColumn(goframe.NewColumn("price", []any{11.0, 20.0, nil, nil, 30.0}))
+		expected.AddColumn(goframe.NewColumn("date", []any{hour(9), hour(10), hour(11), hour(12), hour(13)}))
+		expected.AddColumn(goframe.NewColumn("volume", []any{3.0, 5.0, nil, nil, 7.0}))
+		goframe.AssertFrameEqual(t, expected, filled)
+
+		filled, err = df.ResampleAgg("date", "H", aggregations, goframe.ResampleOption{Fill: "ffill", Label: "right"})
+		if err != nil {
+			t.Fatalf("ResampleAgg failed: %v", err)
+		}
+		expected = goframe.NewDataFrame()
+		expected.AddColumn(goframe.NewColumn("price", []any{11.0, 20.0, 20.0, 20.0, 30.0}))
+		expected.AddColumn(goframe.NewColumn("date", []any{hour(10), hour(11), hour(12), hour(13), hour(14)}))
+		expected.AddColumn(goframe.NewColumn("volume", []any{3.0, 5.0, 5.0, 5.0, 7.0}))
+		goframe.AssertFrameEqual(t, expected, filled)
+
+		// a user-defined aggregation sees the same gaps
+		count := func(values []any) any { return len(values) }
+		counted, err := df.Resample("date", "H", count, goframe.ResampleOption{Fill: "nil"})
+		if err != nil {
+			t.Fatalf("Resample failed: %v", err)
+		}
+		if got := counted.Columns["note"].Data; !reflect.DeepEqual(got, []any{2, 1, nil, nil, 1}) {
+			t.Errorf("expected counts [2 1 <nil> <nil> 1], got %v", got)
+		}
+	})
+
+	errors := []struct {
+		aggregations map[string]string
+		message      string
+	}{
+		{map[string]string{"missing": "sum"}, "column 'missing' does not exist"},
+		{map[string]string{"price": "mode"}, "unknown aggregation 'mode' for column 'price'"},
+	}
+	for _, tt := range errors {
+		if _, err := df.ResampleAgg("date", "H", tt.aggregations); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("expected an error containing %q, got %v", tt.message, err)
+		}
+	}
+}
+
+func TestResampleNamed(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 3, 1, hour, 30, 0, 0, time.UTC) }
+	hour := func(hour int) time.Time { return time.Date(2024, 3, 1, hour, 0, 0, 0, time.UTC) }
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("price", []any{10.0, 12.0, 20.0, nil}))
+	df.AddColumn(goframe.NewColumn("date", []any{at(9), at(9), at(10), at(12)}))
+	df.AddColumn(goframe.NewColumn("volume", []any{1, 2, 5, 7}))
+
+	aggregations := map[string]goframe.NamedAgg{
+		"n":         {Column: "*", Agg: "count"},
+		"max_price": {Column: "price", Agg: "max"},
+		"avg_price": {Column: "price", Agg: "mean"},
+		"volume":    {Column: "volume", Agg: "sum"},
+	}
+	result, err := df.ResampleNamed("date", "H", aggregations, goframe.ResampleOption{Fill: "nil"})
+	if err != nil {
+		t.Fatalf("ResampleNamed failed: %v", err)
+	}
+	expected := goframe.NewDataFrame()
+	expected.AddColumn(goframe.NewColumn("date", []any{hour(9), hour(10), hour(11), hour(12)}))
+	expected.AddColumn(goframe.NewColumn("avg_price", []any{11.0, 20.0, nil, math.NaN()}))
+	expected.AddColumn(goframe.NewColumn("max_price", []any{12.0, 20.0, nil, math.NaN()}))
+	expected.AddColumn(goframe.NewColumn("volume", []any{3.0, 5.0, nil, 7.0}))
+	expected.AddColumn(goframe.NewColumn("n", []any{2, 1, nil, 1}))
+	goframe.AssertFrameEqual(t, expected, result)
+
+	errors := []struct {
+		aggregations map[string]goframe.NamedAgg
+		message      string
+	}{
+		{map[string]goframe.NamedAgg{"x": {Column: "missing", Agg: "sum"}}, "column 'missing' does not exist"},
+		{map[string]goframe.NamedAgg{"x": {Column: "price", Agg: "mode"}}, "unknown aggregation 'mode' for column 'price'"},
+		{map[string]goframe.NamedAgg{"x": {Column: "*", Agg: "sum"}}, "only supports the 'count' and 'size' aggregations"},
+		{map[string]goframe.NamedAgg{"date": {Column: "price", Agg: "sum"}}, "duplicate column name 'date'"},
+		{map[string]goframe.NamedAgg{"": {Column: "price", Agg: "sum"}}, "must not be empty"},
+	}
+	for _, tt := range errors {
+		if _, err := df.ResampleNamed("date", "H", tt.aggregations); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("expected an error containing %q, got %v", tt.message, err)
+		}
+	}
+}
+
+func TestTimeZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("time", []any{time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), nil}))
+
+	if err := df.TzLocalize("time", newYork); err != nil {
+		t.Fatalf("TzLocalize failed: %v", err)
+	}
+	localized := df.Columns["time"].Data[0].(time.Time)
+	if localized.Hour() != 9 || localized.Location() != newYork || df.Columns["time"].Data[1] != nil {
+		t.Errorf("expected 09:00 in New York and nil, got %v", df.Columns["time"].Data)
+	}
+	if err := df.TzConvert("time", time.UTC); err != nil {
+		t.Fatalf("TzConvert failed: %v", err)
+	}
+	if converted := df.Columns["time"].Data[0].(time.Time); !converted.Equal(localized) || converted.Hour() != 14 {
+		t.Errorf("expected 14:00 UTC, got %v", converted)
+	}
+
+	other := goframe.NewDataFrame()
+	other.AddColumn(goframe.NewColumn("time", []any{"2024-01-02"}))
+	if err := other.TzConvert("time", time.UTC); err == nil || !strings.Contains(err.Error(), "is not a time.Time") {
+		t.Errorf("expected an error for a string value, got %v", err)
+	}
+	if err := other.TzLocalize("missing", time.UTC); err == nil {
+		t.Errorf("expected an error for a missing column")
+	}
+
+	t.Run("Resample", func(t *testing.T) {
+		// 01:30 happens twice on 2024-11-03 in New York, once in each offset
+		first := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC)
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.NewColumn("time", []any{first, first.In(newYork).Add(time.Hour), first.Add(2 * time.Hour)}))
+		df.AddColumn(goframe.NewColumn("value", []any{1, 2, 3}))
+
+		result, err := df.ResampleAgg("time", "H", map[string]string{"value": "sum"}, goframe.ResampleOption{Location: newYork})
+		if err != nil {
+			t.Fatalf("ResampleAgg failed: %v", err)
+		}
+		labels := result.Columns["time"].Data
+		if len(labels) != 3 {
+			t.Fatalf("expected 3 hourly buckets, got %v", labels)
+		}
+		for i, label := range labels {
+			want := first.Add(time.Duration(i) * time.Hour).Add(-30 * time.Minute)
+			if got := label.(time.Time); !got.Equal(want) || got.Location() != newYork {
+				t.Errorf("bucket %d: expected %v in New York, got %v", i, want, got)
+			}
+		}
+	})
+}
+
+func TestFrequencies(t *testing.T) {
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	// 2024-03-01 is a Friday
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("date", []any{at(3, 1, 9, 5), at(3, 1, 9, 20), at(3, 2, 10, 0), at(3, 4, 9, 44), at(4, 2, 0, 0)}))
+	df.AddColumn(goframe.NewColumn("value", []any{1, 2, 3, 4, 5}))
+	sum := func(values []any) any {
+		total := 0
+		for _, v := range values {
+			total += v.(int)
+		}
+		return total
+	}
+
+	tests := []struct {
+		freq   string
+		labels []any
+		sums   []any
+	}{
+		{"15T", []any{at(3, 1, 9, 0), at(3, 1, 9, 15), at(3, 2, 10, 0), at(3, 4, 9, 30), at(4, 2, 0, 0)}, []any{1, 2, 3, 4, 5}},
+		{"4H", []any{at(3, 1, 8, 0), at(3, 2, 8, 0), at(3, 4, 8, 0), at(4, 2, 0, 0)}, []any{3, 3, 4, 5}},
+		{"B", []any{at(3, 1, 0, 0), at(3, 4, 0, 0), at(4, 2, 0, 0)}, []any{6, 4, 5}},
+		{"Q", []any{at(1, 1, 0, 0), at(4, 1, 0, 0)}, []any{10, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.freq, func(t *testing.T) {
+			result, err := df.Resample("date", tt.freq, sum)
+			if err != nil {
+				t.Fatalf("Resample failed: %v", err)
+			}
+			if got := result.Columns["date"].Data; !reflect.DeepEqual(got, tt.labels) {
+				t.Errorf("expected labels %v, got %v", tt.labels, got)
+			}
+			if got := result.Columns["value"].Data; !reflect.DeepEqual(got, tt.sums) {
+				t.Errorf("expected sums %v, got %v", tt.sums, got)
+			}
+		})
+	}
+	for _, freq := range []string{"0T", "2D", "15X"} {
+		if _, err := df.Resample("date", freq, sum); err == nil || !strings.Contains(err.Error(), "unknown frequency") {
+			t.Errorf("%s: expected an unknown frequency error, got %v", freq, err)
+		}
+	}
+
+	t.Run("DateRange", func(t *testing.T) {
+		days, err := goframe.DateRange(at(3, 1, 12, 0), at(3, 6, 0, 0), "B")
+		if err != nil {
+			t.Fatalf("DateRange failed: %v", err)
+		}
+		expected := []time.Time{at(3, 4, 0, 0), at(3, 5, 0, 0), at(3, 6, 0, 0)}
+		if !reflect.DeepEqual(days, expected) {
+			t.Errorf("expected %v, got %v", expected, days)
+		}
+		ends, _ := goframe.DateRange(at(1, 15, 0, 0), at(3, 31, 0, 0), "M-end")
+		if len(ends) != 3 || !ends[0].Equal(at(1, 31, 0, 0)) || !ends[2].Equal(at(3, 31, 0, 0)) {
+			t.Errorf("expected the ends of January to March, got %v", ends)
+		}
+		if _, err := goframe.DateRange(at(1, 1, 0, 0), at(2, 1, 0, 0), "fortnight"); err == nil {
+			t.Errorf("expected an error for an unknown frequency")
+		}
+	})
+
+	t.Run("ShiftTimes", func(t *testing.T) {
+		shifted, err := df.ShiftTimes("date", 1, "B")
+		if err != nil {
+			t.Fatalf("ShiftTimes failed: %v", err)
+		}
+		expected := []any{at(3, 4, 9, 5), at(3, 4, 9, 20), at(3, 4, 10, 0), at(3, 5, 9, 44), at(4, 3, 0, 0)}
+		if got := shifted.Columns["date"].Data; !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+		if !reflect.DeepEqual(shifted.Columns["value"].Data, df.Columns["value"].Data) {
+			t.Errorf("expected the other columns to keep their values")
+		}
+
+		ends, _ := df.ShiftTimes("date", -1, "M-end")
+		if got := ends.Columns["date"].Data[0]; got != at(2, 29, 0, 0) {
+			t.Errorf("expected the end of February, got %v", got)
+		}
+		if _, err := df.ShiftTimes("value", 1, "D"); err == nil {
+			t.Errorf("expected an error for a column that does not hold times")
+		}
+	})
+}
+
+func TestTimeWeightedMean(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("time", []any{at(6), at(0), at(24), at(3), at(4), nil}))
+	df.AddColumn(goframe.NewColumn("balance", []any{200, 100.0, 50, nil, math.NaN(), 1000}))
+
+	tests := []struct {
+		name     string
+		options  goframe.TimeWeightOption
+		expected float64
+	}{
+		{"Previous", goframe.TimeWeightOption{}, (100*6 + 200*18) / 24.0},
+		{"Linear", goframe.TimeWeightOption{Method: "linear"}, (150*6 + 125*18) / 24.0},
+		{"End", goframe.TimeWeightOption{End: at(36)}, (100*6 + 200*18 + 50*12) / 36.0},
+	}
+	for _, tt := range tests {
+		got, err := df.TimeWeightedMean("balance", "time", tt.options)
+		if err != nil {
+			t.Fatalf("%s: TimeWeightedMean failed: %v", tt.name, err)
+		}
+		if math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	// equal times fall back to the plain mean, no values give NaN
+	single := goframe.NewDataFrame()
+	single.AddColumn(goframe.NewColumn("time", []any{at(1), at(1), at(2)}))
+	single.AddColumn(goframe.NewColumn("value", []any{4, 8, nil}))
+	if got, _ := single.TimeWeightedMean("value", "time"); got != 6 {
+		t.Errorf("expected the plain mean 6 for equal times, got %v", got)
+	}
+	single.Columns["value"].Data = []any{nil, nil, nil}
+	if got, _ := single.TimeWeightedMean("value", "time"); !math.IsNaN(got) {
+		t.Errorf("expected NaN without values, got %v", got)
+	}
+
+	errors := []struct {
+		valueCol, timeCol string
+		options           goframe.TimeWeightOption
+		message           string
+	}{
+		{"missing", "time", goframe.TimeWeightOption{}, "column 'missing' does not exist"},
+		{"time", "balance", goframe.TimeWeightOption{}, "is not a time.Time"},
+		{"balance", "time", goframe.TimeWeightOption{Method: "spline"}, "unknown method: spline"},
+		{"balance", "time", goframe.TimeWeightOption{End: at(12)}, "is before the last time"},
+	}
+	for _, tt := range errors {
+		if _, err := df.TimeWeightedMean(tt.valueCol, tt.timeCol, tt.options); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("expected an error containing %q, got %v", tt.message, err)
+		}
+	}
+}
+
+func TestPlotThemes(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("x", []any{1.0, 2.0, 3.0}))
+	df.AddColumn(goframe.NewColumn("y", []any{3.0, 1.0, 2.0}))
+	df.AddColumn(goframe.NewColumn("label", []any{"a", "b", "c"}))
+
+	render := func() string {
+		var buf strings.Builder
+		if err := df.LinePlotWriter("x", "y", &buf); err != nil {
+			t.Fatalf("LinePlotWriter failed: %v", err)
+		}
+		return buf.String()
+	}
+	plain := render()
+
+	corporate := &goframe.Theme{Background: "#102030", TextColor: "white", Palette: []string{"#ff8800"}, GridLines: true, FontSize: 12}
+	goframe.SetDefaultTheme(corporate)
+	defer goframe.SetDefaultTheme(nil)
+	if goframe.DefaultTheme() != corporate {
+		t.Error("Expected the registered theme to be returned")
+	}
+	themed := render()
+	if themed == plain {
+		t.Error("Expected the default theme to change the rendered plot")
+	}
+
+	var perPlot strings.Builder
+	if err := df.LinePlotWriter("x", "y", &perPlot, goframe.PlotOption{Theme: &goframe.Theme{Palette: []string{"green"}}}); err != nil {
+		t.Fatalf("LinePlotWriter failed: %v", err)
+	}
+	if perPlot.String() == themed {
+		t.Error("Expected PlotOption.Theme to override the default theme")
+	}
+
+	for name, plot := range map[string]func(io.Writer) error{
+		"bar":        func(w io.Writer) error { return df.BarPlotWriter("y", w) },
+		"horizontal": func(w io.Writer) error { return df.BarPlotWriter("y", w, goframe.PlotOption{Horizontal: true}) },
+		"pareto":     func(w io.Writer) error { return df.ParetoPlotWriter("label", "y", w) },
+	} {
+		var buf strings.Builder
+		if err := plot(&buf); err != nil {
+			t.Errorf("%s plot failed: %v", name, err)
+		} else if !strings.HasPrefix(buf.String(), "\x89PNG") {
+			t.Errorf("Expected PNG output for %s plot", name)
+		}
+	}
+
+	goframe.SetDefaultTheme(nil)
+	if render() != plain {
+		t.Error("Expected SetDefaultTheme(nil) to restore the default look")
+	}
+}
+
+func TestPlotExportData(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("month", []any{1.0, 2.0, 3.0}))
+	df.AddColumn(goframe.NewColumn("revenue", []any{10.0, 30.0, 20.0}))
+	df.AddColumn(goframe.NewColumn("region", []any{"north", "south", "east"}))
+	dir := t.TempDir()
+
+	if err := df.LinePlot("month", "revenue", filepath.Join(dir, "line.png"), goframe.PlotOption{ExportData: "csv"}); err != nil {
+		t.Fatalf("LinePlot failed: %v", err)
+	}
+	csvData, err := os.ReadFile(filepath.Join(dir, "line.csv"))
+	if err != nil {
+		t.Fatalf("Expected the plotted data next to the image: %v", err)
+	}
+	if got := string(csvData); got != "month,revenue\n1,10\n2,30\n3,20\n" {
+		t.Errorf("Unexpected line plot data:\n%s", got)
+	}
+
+	if err := df.ParetoPlot("region", "revenue", filepath.Join(dir, "pareto.png"), goframe.PlotOption{ExportData: "json"}); err != nil {
+		t.Fatalf("ParetoPlot failed: %v", err)
+	}
+	pareto, err := goframe.FromJSON(filepath.Join(dir, "pareto.json"))
+	if err != nil {
+		t.Fatalf("Failed to read exported Pareto data: %v", err)
+	}
+	if !reflect.DeepEqual(pareto.ColumnNames(), []string{"region", "revenue", "cumulative"}) {
+		t.Errorf("Expected columns [region revenue cumulative], got %v", pareto.ColumnNames())
+	}
+	if first, _ := pareto.Columns["region"].At(0); first != "south" {
+		t.Errorf("Expected the largest category first, got %v", first)
+	}
+	if last, _ := pareto.Columns["cumulative"].At(2); last != 1.0 {
+		t.Errorf("Expected a cumulative share of 1 for the last category, got %v", last)
+	}
+
+	if err := df.BarPlot("revenue", filepath.Join(dir, "bar.png"), goframe.PlotOption{ExportData: "csv"}); err != nil {
+		t.Fatalf("BarPlot failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bar.csv")); err != nil {
+		t.Errorf("Expected bar plot data: %v", err)
+	}
+
+	if err := df.BarPlot("revenue", filepath.Join(dir, "bad.png"), goframe.PlotOption{ExportData: "xml"}); err == nil {
+		t.Error("Expected error for unsupported export format, got nil")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bad.png")); err == nil {
+		t.Error("Expected no image to be written on error")
+	}
+}
+
+func TestGroupedPlot(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("region", []any{"north", "south", "north", "south", "north"}))
+	df.AddColumn(goframe.NewColumn("date", []any{day(2), day(1), day(1), day(2), day(2)}))
+	df.AddColumn(goframe.NewColumn("sales", []any{10, 4, 5.0, 6, 2}))
+	df.AddColumn(goframe.NewColumn("note", []any{"a", "b", "c", "d", "e"}))
+	dir := t.TempDir()
+
+	if err := df.Groupby("region").Plot("bar", "sales", filepath.Join(dir, "bar.png"), goframe.PlotOption{ExportData: "csv"}); err != nil {
+		t.Fatalf("bar Plot failed: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "bar.csv")); err != nil || string(data) != "GroupKey,sales\nnorth,17\nsouth,10\n" {
+		t.Errorf("unexpected bar plot data %q (error %v)", data, err)
+	}
+	if image, err := os.ReadFile(filepath.Join(dir, "bar.png")); err != nil || !strings.HasPrefix(string(image), "\x89PNG") {
+		t.Errorf("expected a PNG file, got error %v", err)
+	}
+
+	opts := goframe.PlotOption{XColumn: "date", Aggregation: "mean", ExportData: "json"}
+	if err := df.Groupby("region").Plot("line", "sales", filepath.Join(dir, "line.png"), opts); err != nil {
+		t.Fatalf("line Plot failed: %v", err)
+	}
+	line, err := goframe.FromJSON(filepath.Join(dir, "line.json"))
+	if err != nil {
+		t.Fatalf("failed to read the exported line data: %v", err)
+	}
+	if got := line.Columns["sales"].Data; !reflect.DeepEqual(got, []any{5.0, 6.0, 4.0, 6.0}) {
+		t.Errorf("expected the daily means of each region sorted by date, got %v", got)
+	}
+
+	var buf strings.Builder
+	if err := df.Groupby("region").PlotWriter("bar", "sales", &buf, goframe.PlotOption{Horizontal: true, Aggregation: "count"}); err != nil || !strings.HasPrefix(buf.String(), "\x89PNG") {
+		t.Errorf("expected a horizontal bar chart, got error %v", err)
+	}
+
+	errors := map[string]error{
+		"unknown plot kind":                df.Groupby("region").PlotWriter("pie", "sales", io.Discard),
+		"unknown aggregation 'mode'":       df.Groupby("region").PlotWriter("bar", "sales", io.Discard, goframe.PlotOption{Aggregation: "mode"}),
+		"XColumn must be set":              df.Groupby("region").PlotWriter("line", "sales", io.Discard),
+		"has no numeric values":            df.Groupby("region").PlotWriter("bar", "note", io.Discard, goframe.PlotOption{Aggregation: "mean"}),
+		"non-numeric data found in column": df.Groupby("region").PlotWriter("line", "sales", io.Discard, goframe.PlotOption{XColumn: "note"}),
+		"column 'missing' does not exist":  df.Groupby("region").PlotWriter("bar", "missing", io.Discard),
+	}
+	for expected, err := range errors {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected an error containing %q, got %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package goframe_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestDatetimeAccessor(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	first := time.Date(2024, 2, 29, 13, 45, 10, 0, time.UTC) // a Thursday
+	second := time.Date(2023, 12, 31, 23, 30, 0, 0, paris)   // a Sunday
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("date", []any{first, nil, second, "2024-01-01"}))
+	date := df.Col("date")
+
+	tests := []struct {
+		name     string
+		series   *goframe.Series
+		expected []any
+	}{
+		{"Year", date.Dt().Year(), []any{2024, nil, 2023, nil}},
+		{"Month", date.Dt().Month(), []any{2, nil, 12, nil}},
+		{"Day", date.Dt().Day(), []any{29, nil, 31, nil}},
+		{"Weekday", date.Dt().Weekday(), []any{4, nil, 0, nil}},
+		{"Hour", date.Dt().Hour(), []any{13, nil, 23, nil}},
+		{"Date", date.Dt().Date(), []any{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), nil, time.Date(2023, 12, 31, 0, 0, 0, 0, paris), nil}},
+		{"FloorHour", date.Dt().Floor("H"), []any{time.Date(2024, 2, 29, 13, 0, 0, 0, time.UTC), nil, time.Date(2023, 12, 31, 23, 0, 0, 0, paris), nil}},
+		{"FloorMonth", date.Dt().Floor("M"), []any{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), nil, time.Date(2023, 12, 1, 0, 0, 0, 0, paris), nil}},
+		{"FloorWeek", date.Dt().Floor("W-MON"), []any{time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), nil, time.Date(2023, 12, 25, 0, 0, 0, 0, paris), nil}},
+		{"Format", date.Dt().Format("2006-01-02 15:04 MST"), []any{"2024-02-29 13:45 UTC", nil, "2023-12-31 23:30 CET", nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.series.Err() != nil {
+				t.Fatalf("unexpected error: %v", tt.series.Err())
+			}
+			if !reflect.DeepEqual(tt.series.Data, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, tt.series.Data)
+			}
+		})
+	}
+
+	col, _ := df.Select("date")
+	result, err := df.WithColumn("month", col.Dt().Month())
+	if err != nil {
+		t.Fatalf("WithColumn failed: %v", err)
+	}
+	if got := result.Columns["month"].Data; !reflect.DeepEqual(got, []any{2, nil, 12, nil}) {
+		t.Errorf("unexpected months %v", got)
+	}
+
+	if series := date.Dt().Floor("QS"); series.Err() == nil || !strings.Contains(series.Err().Error(), "unknown frequency 'QS'") {
+		t.Errorf("expected an unknown frequency error, got %v", series.Err())
+	}
+}
@@ -0,0 +1,116 @@
+package goframe_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+	"github.com/xuri/excelize/v2"
+)
+
+func setupExcelDF() *goframe.DataFrame {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("amount", []float64{1234.5, 99})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("date", []time.Time{
+		time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+	})))
+	df.AddColumn(&goframe.Column[any]{Name: "name", Data: []any{"Alice", nil}})
+	return df
+}
+
+func TestToExcel(t *testing.T) {
+	t.Run("Plain", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := setupExcelDF().ToExcelWriter(&buf); err != nil {
+			t.Fatalf("ToExcelWriter failed: %v", err)
+		}
+
+		f, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatalf("Failed to open workbook: %v", err)
+		}
+		defer f.Close()
+
+		rows, err := f.GetRows("Sheet1")
+		if err != nil {
+			t.Fatalf("Failed to read rows: %v", err)
+		}
+		if len(rows) != 3 {
+			t.Fatalf("Expected 3 rows (header + 2), got %d", len(rows))
+		}
+		if rows[0][0] != "amount" || rows[0][1] != "date" || rows[0][2] != "name" {
+			t.Errorf("Unexpected header: %v", rows[0])
+		}
+		if rows[1][2] != "Alice" {
+			t.Errorf("Expected Alice, got %v", rows[1][2])
+		}
+		if len(rows[2]) > 2 && rows[2][2] != "" {
+			t.Errorf("Expected empty cell for nil, got %q", rows[2][2])
+		}
+	})
+
+	t.Run("Styled", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "report.xlsx")
+		err := setupExcelDF().ToExcel(filename, goframe.ExcelOption{
+			SheetName:     "Sales",
+			HeaderBold:    true,
+			HeaderFill:    "#DDEBF7",
+			ColumnWidths:  map[string]float64{"name": 30},
+			AutoWidth:     true,
+			NumberFormats: map[string]string{"amount": "#,##0.00", "date": "yyyy-mm-dd"},
+			FreezeRows:    1,
+			AutoFilter:    true,
+		})
+		if err != nil {
+			t.Fatalf("ToExcel failed: %v", err)
+		}
+
+		f, err := excelize.OpenFile(filename)
+		if err != nil {
+			t.Fatalf("Failed to open workbook: %v", err)
+		}
+		defer f.Close()
+
+		if sheets := f.GetSheetList(); len(sheets) != 1 || sheets[0] != "Sales" {
+			t.Fatalf("Expected a single Sales sheet, got %v", sheets)
+		}
+
+		amount, _ := f.GetCellValue("Sales", "A2")
+		if amount != "1,234.50" {
+			t.Errorf("Expected formatted amount 1,234.50, got %s", amount)
+		}
+		date, _ := f.GetCellValue("Sales", "B2")
+		if date != "2025-01-02" {
+			t.Errorf("Expected formatted date 2025-01-02, got %s", date)
+		}
+
+		styleID, _ := f.GetCellStyle("Sales", "A1")
+		style, err := f.GetStyle(styleID)
+		if err != nil || style.Font == nil || !style.Font.Bold {
+			t.Errorf("Expected bold header, got %+v (%v)", style, err)
+		}
+
+		if width, _ := f.GetColWidth("Sales", "C"); width != 30 {
+			t.Errorf("Expected width 30 for column C, got %v", width)
+		}
+		if width, _ := f.GetColWidth("Sales", "A"); width != 8 {
+			t.Errorf("Expected auto width 8 for column A, got %v", width)
+		}
+
+		panes, err := f.GetPanes("Sales")
+		if err != nil || !panes.Freeze || panes.YSplit != 1 {
+			t.Errorf("Expected frozen header row, got %+v (%v)", panes, err)
+		}
+	})
+
+	t.Run("UnknownColumn", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := setupExcelDF().ToExcelWriter(&buf, goframe.ExcelOption{NumberFormats: map[string]string{"missing": "0"}})
+		if err == nil {
+			t.Errorf("Expected error for unknown column, got nil")
+		}
+	})
+}
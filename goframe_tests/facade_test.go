@@ -0,0 +1,24 @@
+package goframe_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kishyassin/goframe/internal/facade"
+)
+
+// TestFacadeInSync fails when an exported type or function of the dataframe package is missing
+// from the root package: run go generate ./... to update facade_gen.go.
+func TestFacadeInSync(t *testing.T) {
+	expected, err := facade.Generate("../dataframe")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	actual, err := os.ReadFile("../facade_gen.go")
+	if err != nil {
+		t.Fatalf("Failed to read facade_gen.go: %v", err)
+	}
+	if string(actual) != string(expected) {
+		t.Error("facade_gen.go is out of date, run go generate ./...")
+	}
+}
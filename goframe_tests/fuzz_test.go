@@ -0,0 +1,83 @@
+package goframe_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+// FuzzFromCSVReader checks that parsing arbitrary CSV never panics and that a parsed frame is a
+// fixed point of the CSV round trip: writing it, reading it back and writing it again gives the same bytes.
+// Run go test ./goframe_tests -fuzz FuzzFromCSVReader to explore beyond the seed corpus.
+func FuzzFromCSVReader(f *testing.F) {
+	f.Add("name,age\nAlice,30\nBob,\n")
+	f.Add("a,b\n\"x,y\",1.5\n\" z \",NaN\n")
+	f.Add("a\n\n\"\"\n")
+	f.Add("a,a\n1,2\n")
+	f.Add("x,y\n1e300,-0\n+Inf,0x1p-2\n")
+	f.Add("s\n\"multi\nline\"\n")
+
+	f.Fuzz(func(t *testing.T, data string) {
+		df, err := goframe.FromCSVReader(strings.NewReader(data))
+		if err != nil {
+			return
+		}
+		first := writeCSV(t, df)
+		reread, err := goframe.FromCSVReader(bytes.NewReader(first))
+		if err != nil {
+			t.Fatalf("reading back %q failed: %v", first, err)
+		}
+		if reread.Nrows() != df.Nrows() || reread.Ncols() != df.Ncols() {
+			t.Fatalf("shape changed from %dx%d to %dx%d for %q", df.Nrows(), df.Ncols(), reread.Nrows(), reread.Ncols(), first)
+		}
+		if second := writeCSV(t, reread); !bytes.Equal(first, second) {
+			t.Fatalf("round trip changed the CSV:\n%q\n%q", first, second)
+		}
+	})
+}
+
+// FuzzFromJSONReader checks that parsing arbitrary JSON never panics and that a parsed frame
+// survives the JSON round trip in both orientations.
+func FuzzFromJSONReader(f *testing.F) {
+	f.Add(`[{"name":"Alice","age":30},{"name":"Bob"}]`)
+	f.Add(`{"a":[1,null,3],"b":["x","y","z"]}`)
+	f.Add(`[{"user":{"id":1,"tags":["a","b"]}}]`)
+	f.Add(`[]`)
+
+	f.Fuzz(func(t *testing.T, data string) {
+		df, err := goframe.FromJSONReader(strings.NewReader(data))
+		if err != nil {
+			return
+		}
+		for _, orient := range []string{"records", "columns"} {
+			opts := goframe.JSONOption{Orient: orient}
+			var first bytes.Buffer
+			if err := df.ToJSONWriter(&first, opts); err != nil {
+				t.Fatalf("ToJSONWriter(%s) failed: %v", orient, err)
+			}
+			reread, err := goframe.FromJSONReader(bytes.NewReader(first.Bytes()), opts)
+			if err != nil {
+				t.Fatalf("reading back %s failed: %v", first.String(), err)
+			}
+			var second bytes.Buffer
+			if err := reread.ToJSONWriter(&second, opts); err != nil {
+				t.Fatalf("ToJSONWriter(%s) failed: %v", orient, err)
+			}
+			if first.String() != second.String() {
+				t.Fatalf("%s round trip changed the JSON:\n%s\n%s", orient, first.String(), second.String())
+			}
+		}
+	})
+}
+
+// writeCSV returns the CSV encoding of a frame
+func writeCSV(t *testing.T, df *goframe.DataFrame) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := df.ToCSVWriter(&buf); err != nil {
+		t.Fatalf("ToCSVWriter failed: %v", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,372 @@
+package goframe_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestLabelLookup(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("id", []string{"u1", "u2", "u3"})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("age", []int{25, 30, 35})))
+
+	if _, err := df.LocRow("u1"); err == nil {
+		t.Errorf("Expected error when the DataFrame has no index, got nil")
+	}
+
+	if err := df.SetIndex("missing"); err == nil {
+		t.Errorf("Expected error for missing index column, got nil")
+	}
+	if err := df.SetIndex("id"); err != nil {
+		t.Fatalf("SetIndex failed: %v", err)
+	}
+	if df.IndexName() != "id" {
+		t.Errorf("Expected index name 'id', got %q", df.IndexName())
+	}
+
+	row, err := df.LocRow("u2")
+	if err != nil {
+		t.Fatalf("LocRow failed: %v", err)
+	}
+	if row["age"] != 30 {
+		t.Errorf("Expected age 30, got %v", row["age"])
+	}
+
+	age, err := df.At("u3", "age")
+	if err != nil {
+		t.Fatalf("At failed: %v", err)
+	}
+	if age != 35 {
+		t.Errorf("Expected age 35, got %v", age)
+	}
+
+	if _, err := df.At("u9", "age"); err == nil {
+		t.Errorf("Expected error for unknown label, got nil")
+	}
+	if _, err := df.At("u1", "salary"); err == nil {
+		t.Errorf("Expected error for unknown column, got nil")
+	}
+
+	t.Run("AfterModification", func(t *testing.T) {
+		df.AppendRow(df, map[string]any{"id": "u4", "age": 40})
+		age, err := df.At("u4", "age")
+		if err != nil || age != 40 {
+			t.Errorf("Expected age 40 for appended row, got %v (err: %v)", age, err)
+		}
+
+		df.DropRow(0)
+		age, err = df.At("u2", "age")
+		if err != nil || age != 30 {
+			t.Errorf("Expected age 30 after dropping a row, got %v (err: %v)", age, err)
+		}
+		if _, err := df.At("u1", "age"); err == nil {
+			t.Errorf("Expected error for dropped label, got nil")
+		}
+	})
+
+	t.Run("NumericLabels", func(t *testing.T) {
+		numeric := goframe.NewDataFrame()
+		numeric.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("index", []float64{1, 2, 3})))
+		numeric.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("value", []string{"A", "B", "C"})))
+
+		// the "index" column is used without SetIndex, and 2 matches 2.0
+		value, err := numeric.At(2, "value")
+		if err != nil || value != "B" {
+			t.Errorf("Expected B, got %v (err: %v)", value, err)
+		}
+	})
+}
+
+func TestBetweenAndIsIn(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice", "Bob", "Charlie", "Diana"})))
+	df.AddColumn(&goframe.Column[any]{Name: "age", Data: []any{25, 30.0, nil, 41}})
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("dept", []string{"IT", "HR", "IT", "Sales"})))
+
+	ageCol, _ := df.Select("age")
+	ages := goframe.NewSeries("age", ageCol.Data)
+
+	between := ages.Between(25, 30)
+	expected := []any{true, true, false, false}
+	if !reflect.DeepEqual(between.Data, expected) {
+		t.Errorf("Between: expected %v, got %v", expected, between.Data)
+	}
+
+	isIn := ages.IsIn(30, 41.0)
+	expected = []any{false, true, false, true}
+	if !reflect.DeepEqual(isIn.Data, expected) {
+		t.Errorf("IsIn: expected %v, got %v", expected, isIn.Data)
+	}
+
+	names, _ := df.Select("name")
+	if got := goframe.NewSeries("name", names.Data).Between("B", "C"); !reflect.DeepEqual(got.Data, []any{false, true, false, false}) {
+		t.Errorf("Between on strings: unexpected mask %v", got.Data)
+	}
+
+	t.Run("FilterByMask", func(t *testing.T) {
+		filtered, err := df.FilterByMask(between)
+		if err != nil {
+			t.Fatalf("FilterByMask failed: %v", err)
+		}
+		if filtered.Nrows() != 2 {
+			t.Errorf("Expected 2 rows, got %d", filtered.Nrows())
+		}
+
+		if _, err := df.FilterByMask(goframe.NewSeries("mask", []any{true})); err == nil {
+			t.Errorf("Expected error for mask length mismatch, got nil")
+		}
+		if _, err := df.FilterByMask(goframe.NewSeries("mask", []any{1, 0, 1, 0})); err == nil {
+			t.Errorf("Expected error for non boolean mask, got nil")
+		}
+	})
+
+	t.Run("FilterIn", func(t *testing.T) {
+		filtered, err := df.FilterIn("dept", "IT", "Sales")
+		if err != nil {
+			t.Fatalf("FilterIn failed: %v", err)
+		}
+		nameCol, _ := filtered.Select("name")
+		if !reflect.DeepEqual(nameCol.Data, []any{"Alice", "Charlie", "Diana"}) {
+			t.Errorf("Unexpected names: %v", nameCol.Data)
+		}
+
+		if _, err := df.FilterIn("missing", "IT"); err == nil {
+			t.Errorf("Expected error for missing column, got nil")
+		}
+	})
+}
+
+func TestColumnConditions(t *testing.T) {
+	df, _ := goframe.FromColumns(
+		goframe.NewColumn[any]("name", []any{"ann", "bob", "cid", "dan", "eve"}),
+		goframe.NewColumn[any]("dept", []any{"IT", "HR", "IT", "IT", nil}),
+		goframe.NewColumn[any]("salary", []any{120, 90, 80.0, nil, 150}),
+		goframe.NewColumn[any]("bonus", []any{10, 20, 5, 0, 200}),
+	)
+	names := func(mask *goframe.Mask) []any {
+		t.Helper()
+		filtered, err := df.Where(mask)
+		if err != nil {
+			t.Fatalf("Where failed: %v", err)
+		}
+		return filtered.Columns["name"].Data
+	}
+
+	tests := []struct {
+		name     string
+		mask     *goframe.Mask
+		expected []any
+	}{
+		{"And", df.C("salary").Gt(100).And(df.C("dept").Eq("IT")), []any{"ann"}},
+		{"Or", df.C("salary").Le(80).Or(df.C("dept").Eq("HR")), []any{"bob", "cid"}},
+		{"Not", df.C("dept").Eq("IT").Not(), []any{"bob", "eve"}},
+		{"Columns", df.C("bonus").Gt(df.C("salary")), []any{"eve"}},
+		{"Between", df.C("salary").Between(90, 120), []any{"ann", "bob"}},
+		{"IsIn", df.C("name").IsIn("bob", "eve"), []any{"bob", "eve"}},
+		{"IsNull", df.C("salary").IsNull().Or(df.C("dept").IsNull()), []any{"dan", "eve"}},
+		{"NotNull", df.C("dept").NotNull().And(df.C("bonus").Ne(0)), []any{"ann", "bob", "cid"}},
+	}
+	for _, tt := range tests {
+		if got := names(tt.mask); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	mask := df.C("salary").Ge(100)
+	if filtered, err := df.FilterByMask(mask.Series); err != nil || filtered.Nrows() != 2 {
+		t.Errorf("expected the mask to feed FilterByMask, got %v (error %v)", filtered, err)
+	}
+	if _, err := df.Where(df.C("missing").Gt(1).And(df.C("salary").Gt(1))); err == nil || err.Error() != "column 'missing' does not exist" {
+		t.Errorf("expected the missing column error to be carried, got %v", err)
+	}
+	short, _ := goframe.FromColumns(goframe.NewColumn[any]("x", []any{1}))
+	if _, err := df.Where(df.C("salary").Gt(1).Or(short.C("x").Gt(0))); err == nil {
+		t.Errorf("expected a length mismatch error")
+	}
+}
+
+func TestIndexSubsystem(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{"c", "a", "b"}))
+	df.AddColumn(goframe.NewColumn("value", []any{3.0, 1.0, 2.0}))
+
+	// range index by default
+	if !reflect.DeepEqual(df.Index().Data, []any{0, 1, 2}) {
+		t.Errorf("Expected range index labels, got %v", df.Index().Data)
+	}
+	if v, err := df.At(2, "id"); err != nil || v != "b" {
+		t.Errorf("Expected positional lookup on the range index, got %v (%v)", v, err)
+	}
+	if _, err := df.At(3, "id"); err == nil {
+		t.Error("Expected error for a label outside the range index, got nil")
+	}
+
+	df.SetIndex("id")
+	for name, result := range map[string]*goframe.DataFrame{
+		"Filter": df.Filter(func(row map[string]any) bool { return row["value"].(float64) > 1 }),
+		"Head":   df.Head(2),
+		"Tail":   df.Tail(2),
+		"Shift":  df.Shift(1),
+	} {
+		if result.IndexName() != "id" {
+			t.Errorf("Expected %s to keep the index, got %q", name, result.IndexName())
+		}
+	}
+	shifted := df.Shift(1)
+	if v, _ := shifted.At("a", "value"); v != 3.0 {
+		t.Errorf("Expected the index to stay in place on Shift, got %v", v)
+	}
+
+	sorted, err := df.SortIndex()
+	if err != nil {
+		t.Fatalf("SortIndex failed: %v", err)
+	}
+	if !reflect.DeepEqual(sorted.Index().Data, []any{"a", "b", "c"}) || sorted.IndexName() != "id" {
+		t.Errorf("Expected sorted labels [a b c] on index 'id', got %v on %q", sorted.Index().Data, sorted.IndexName())
+	}
+	desc, _ := df.SortIndex(false)
+	if !reflect.DeepEqual(desc.Index().Data, []any{"c", "b", "a"}) {
+		t.Errorf("Expected labels [c b a], got %v", desc.Index().Data)
+	}
+
+	loc, err := df.Loc([]any{"a", "c"}, []string{"value"})
+	if err != nil {
+		t.Fatalf("Loc failed: %v", err)
+	}
+	if values, _ := loc.Select("value"); !reflect.DeepEqual(values.Data, []any{3.0, 1.0}) {
+		t.Errorf("Expected Loc to use the index, got %v", values.Data)
+	}
+
+	other := goframe.NewDataFrame()
+	other.AddColumn(goframe.NewColumn("id", []any{"a", "b"}))
+	other.AddColumn(goframe.NewColumn("label", []any{"first", "second"}))
+	if _, err := df.InnerJoin(other, ""); err == nil {
+		t.Error("Expected error when joining on the index without a shared index, got nil")
+	}
+	other.SetIndex("id")
+	joined, err := df.InnerJoin(other, "")
+	if err != nil {
+		t.Fatalf("InnerJoin on the index failed: %v", err)
+	}
+	if joined.IndexName() != "id" || joined.Nrows() != 2 {
+		t.Errorf("Expected 2 rows indexed by 'id', got %d rows indexed by %q", joined.Nrows(), joined.IndexName())
+	}
+	if v, _ := joined.At("b", "label"); v != "second" {
+		t.Errorf("Expected label 'second' for b, got %v", v)
+	}
+
+	df.ResetIndex(false)
+	if df.IndexName() != "" || df.Ncols() != 2 {
+		t.Errorf("Expected a range index and the id column kept, got %q and %d columns", df.IndexName(), df.Ncols())
+	}
+	df.SetIndex("id")
+	df.ResetIndex(true)
+	if _, exists := df.Columns["id"]; exists {
+		t.Error("Expected ResetIndex(true) to drop the index column")
+	}
+	if !reflect.DeepEqual(df.Index().Data, []any{0, 1, 2}) {
+		t.Errorf("Expected range index labels, got %v", df.Index().Data)
+	}
+}
+
+func TestMultiIndex(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("region", []any{"EU", "EU", "US", "US", "EU"}))
+	df.AddColumn(goframe.NewColumn("year", []any{2023, 2024, 2023, 2024, 2025}))
+	df.AddColumn(goframe.NewColumn("sales", []any{10.0, 12.0, 20.0, 25.0, 14.0}))
+
+	if df.MultiIndex() != nil {
+		t.Error("Expected no multi-level index before SetMultiIndex")
+	}
+	if err := df.SetMultiIndex("region", "missing"); err == nil {
+		t.Error("Expected error for missing index column, got nil")
+	}
+	if err := df.SetMultiIndex("region", "year"); err != nil {
+		t.Fatalf("SetMultiIndex failed: %v", err)
+	}
+
+	mi := df.MultiIndex()
+	if !reflect.DeepEqual(mi.Levels[0], []any{"EU", "US"}) || !reflect.DeepEqual(mi.Labels[0], []int{0, 0, 1, 1, 0}) {
+		t.Errorf("Unexpected outer level %v with labels %v", mi.Levels[0], mi.Labels[0])
+	}
+
+	if v, err := df.At([]any{"US", 2024}, "sales"); err != nil || v != 25.0 {
+		t.Errorf("Expected 25 for (US, 2024), got %v (%v)", v, err)
+	}
+	if _, err := df.At("US", "sales"); err == nil {
+		t.Error("Expected error for a scalar label on a multi-level index, got nil")
+	}
+
+	eu, err := df.Loc([]any{"EU"}, []string{"sales"})
+	if err != nil {
+		t.Fatalf("Loc failed: %v", err)
+	}
+	if values, _ := eu.Select("sales"); !reflect.DeepEqual(values.Data, []any{10.0, 12.0, 14.0}) {
+		t.Errorf("Expected every EU row for a partial key, got %v", values.Data)
+	}
+	exact, _ := df.Loc([]any{[]any{"EU", 2024}, []any{"US", 2023}}, []string{"sales"})
+	if values, _ := exact.Select("sales"); !reflect.DeepEqual(values.Data, []any{12.0, 20.0}) {
+		t.Errorf("Expected the rows of the tuple keys, got %v", values.Data)
+	}
+
+	totals, err := df.GroupbyLevel(0).Sum("sales")
+	if err != nil {
+		t.Fatalf("GroupbyLevel failed: %v", err)
+	}
+	if totals.Nrows() != 2 {
+		t.Errorf("Expected 2 groups for the region level, got %d", totals.Nrows())
+	}
+	if df.GroupbyLevel(5).Error() == nil {
+		t.Error("Expected error for an out of range level, got nil")
+	}
+
+	wide, err := df.Unstack(-1)
+	if err != nil {
+		t.Fatalf("Unstack failed: %v", err)
+	}
+	if wide.IndexName() != "region" || wide.Nrows() != 2 {
+		t.Errorf("Expected 2 rows indexed by region, got %d rows indexed by %q", wide.Nrows(), wide.IndexName())
+	}
+	if !reflect.DeepEqual(wide.ColumnLevels("sales|2025"), []string{"sales", "2025"}) {
+		t.Errorf("Expected a two-level header for sales × 2025, got %v", wide.ColumnLevels("sales|2025"))
+	}
+	if v, _ := wide.At("US", "sales|2025"); v != nil {
+		t.Errorf("Expected nil for a missing combination, got %v", v)
+	}
+	if v, _ := wide.At("EU", "sales|2024"); v != 12.0 {
+		t.Errorf("Expected 12 for EU in 2024, got %v", v)
+	}
+
+	flat := goframe.NewDataFrame()
+	flat.AddColumn(goframe.NewColumn("x", []any{1}))
+	if _, err := flat.Unstack(0); err == nil {
+		t.Error("Expected error when unstacking without a multi-level index, got nil")
+	}
+}
+
+func TestLabelIndexUpdates(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{"a", nil, "c"}))
+	df.AddColumn(goframe.NewColumn("value", []any{1.0, 2.0, 3.0}))
+	df.SetIndex("id")
+
+	for range 3 {
+		if _, err := df.At("x", "value"); err == nil || !strings.Contains(err.Error(), "does not exist in index 'id'") {
+			t.Fatalf("expected a missing label error, got %v", err)
+		}
+	}
+	// the label map follows the methods writing rows in place
+	df.FillNa("x")
+	if v, err := df.At("x", "value"); err != nil || v != 2.0 {
+		t.Errorf("expected 2 for the filled label, got %v (%v)", v, err)
+	}
+	if err := df.Append(map[string]any{"id": "d", "value": 4.0}); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if v, err := df.At("d", "value"); err != nil || v != 4.0 {
+		t.Errorf("expected 4 for the appended label, got %v (%v)", v, err)
+	}
+}
@@ -0,0 +1,173 @@
+package goframe_test
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestFromJSONReader(t *testing.T) {
+	t.Run("Records", func(t *testing.T) {
+		input := `[
+			{"name": "Alice", "age": 30, "address": {"city": "Paris", "zip": "75001"}},
+			{"name": "Bob", "active": true, "address": {"city": "Berlin"}, "tags": ["a", "b"]}
+		]`
+		df, err := goframe.FromJSONReader(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("FromJSONReader failed: %v", err)
+		}
+
+		expectedCols := []string{"name", "age", "address.city", "address.zip", "active", "tags"}
+		if !reflect.DeepEqual(df.ColumnNames(), expectedCols) {
+			t.Fatalf("Expected columns %v, got %v", expectedCols, df.ColumnNames())
+		}
+		expected := map[string][]any{
+			"name":         {"Alice", "Bob"},
+			"age":          {30.0, nil},
+			"address.city": {"Paris", "Berlin"},
+			"address.zip":  {"75001", nil},
+			"active":       {nil, true},
+			"tags":         {nil, []any{"a", "b"}},
+		}
+		for name, values := range expected {
+			col, _ := df.Select(name)
+			if !reflect.DeepEqual(col.Data, values) {
+				t.Errorf("Column '%s': expected %v, got %v", name, values, col.Data)
+			}
+		}
+	})
+
+	t.Run("ColumnsWithSeparator", func(t *testing.T) {
+		input := `{"id": [1, 2], "score": {"math": [90.5, null]}}`
+		df, err := goframe.FromJSONReader(strings.NewReader(input), goframe.JSONOption{Orient: "columns", Separator: "_"})
+		if err != nil {
+			t.Fatalf("FromJSONReader failed: %v", err)
+		}
+		if !reflect.DeepEqual(df.ColumnNames(), []string{"id", "score_math"}) {
+			t.Fatalf("Unexpected columns %v", df.ColumnNames())
+		}
+		col, _ := df.Select("score_math")
+		if !reflect.DeepEqual(col.Data, []any{90.5, nil}) {
+			t.Errorf("Expected [90.5 <nil>], got %v", col.Data)
+		}
+	})
+
+	errorCases := []struct {
+		name    string
+		input   string
+		options goframe.JSONOption
+		wantErr string
+	}{
+		{"Empty", ``, goframe.JSONOption{}, "error reading JSON"},
+		{"NotArray", `{"a": [1]}`, goframe.JSONOption{}, "requires a JSON array"},
+		{"NotObjectRecord", `[1]`, goframe.JSONOption{}, "record 0 is not a JSON object"},
+		{"UnevenColumns", `{"a": [1, 2], "b": [1]}`, goframe.JSONOption{Orient: "columns"}, "column 'b' has 1 values, expected 2"},
+		{"ScalarColumn", `{"a": 1}`, goframe.JSONOption{Orient: "columns"}, "column 'a' is not a JSON array"},
+		{"InvalidOrient", `[]`, goframe.JSONOption{Orient: "index"}, "invalid orient"},
+		{"TrailingData", `[] []`, goframe.JSONOption{}, "unexpected data"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := goframe.FromJSONReader(strings.NewReader(tc.input), tc.options)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice", "Bob"})))
+	df.AddColumn(goframe.NewColumn("score", []any{1.5, math.NaN()}))
+
+	var records strings.Builder
+	if err := df.ToJSONWriter(&records); err != nil {
+		t.Fatalf("ToJSONWriter failed: %v", err)
+	}
+	if expected := `[{"name":"Alice","score":1.5},{"name":"Bob","score":null}]`; records.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, records.String())
+	}
+
+	var columns strings.Builder
+	if err := df.ToJSONWriter(&columns, goframe.JSONOption{Orient: "columns"}); err != nil {
+		t.Fatalf("ToJSONWriter failed: %v", err)
+	}
+	if expected := `{"name":["Alice","Bob"],"score":[1.5,null]}`; columns.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, columns.String())
+	}
+
+	// round trip through a file
+	path := filepath.Join(t.TempDir(), "frame.json")
+	if err := df.ToJSON(path, goframe.JSONOption{Orient: "columns"}); err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	read, err := goframe.FromJSON(path, goframe.JSONOption{Orient: "columns"})
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(read.ColumnNames(), df.ColumnNames()) {
+		t.Errorf("Expected columns %v, got %v", df.ColumnNames(), read.ColumnNames())
+	}
+	col, _ := read.Select("score")
+	if !reflect.DeepEqual(col.Data, []any{1.5, nil}) {
+		t.Errorf("Expected [1.5 <nil>], got %v", col.Data)
+	}
+}
+
+func TestJSONLines(t *testing.T) {
+	input := `{"id": 1, "user": {"name": "Alice"}, "legacy": "x"}
+{"id": 2, "user": {"name": "Bob"}, "legacy": "y"}
+
+{"id": 3, "user": {"name": "Carol", "plan": "pro"}}
+`
+	df, report, err := goframe.FromJSONLinesReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromJSONLinesReader failed: %v", err)
+	}
+	expected := goframe.NewDataFrame()
+	expected.AddColumn(goframe.NewColumn("id", []any{1.0, 2.0, 3.0}))
+	expected.AddColumn(goframe.NewColumn("user.name", []any{"Alice", "Bob", "Carol"}))
+	expected.AddColumn(goframe.NewColumn("legacy", []any{"x", "y", nil}))
+	expected.AddColumn(goframe.NewColumn("user.plan", []any{nil, nil, "pro"}))
+	goframe.AssertFrameEqual(t, expected, df)
+
+	if report.Records != 3 || !reflect.DeepEqual(report.Added(), []string{"user.plan"}) || !reflect.DeepEqual(report.Removed(), []string{"legacy"}) {
+		t.Errorf("expected user.plan added and legacy removed in 3 records, got %+v", report)
+	}
+	if field := report.Fields[2]; field != (goframe.JSONField{Name: "legacy", FirstRecord: 0, LastRecord: 1, Count: 2}) {
+		t.Errorf("unexpected legacy field %+v", field)
+	}
+
+	if _, _, err := goframe.FromJSONLinesReader(strings.NewReader(input), goframe.JSONOption{StrictFields: true}); err == nil || !strings.Contains(err.Error(), "record 2: unexpected field 'user.plan'") {
+		t.Errorf("expected an unexpected field error, got %v", err)
+	}
+	if _, _, err := goframe.FromJSONLinesReader(strings.NewReader("{\"id\": 1}\n[1]\n")); err == nil || !strings.Contains(err.Error(), "record 1 is not a JSON object") {
+		t.Errorf("expected an error for a line that is not an object, got %v", err)
+	}
+
+	// the strict check also applies to the records orientation
+	if _, err := goframe.FromJSONReader(strings.NewReader(`[{"a": 1, "b": 2}, {"a": 3}]`), goframe.JSONOption{StrictFields: true}); err == nil || !strings.Contains(err.Error(), "record 1: missing field 'b'") {
+		t.Errorf("expected a missing field error, got %v", err)
+	}
+
+	// round trip through a file
+	path := filepath.Join(t.TempDir(), "frame.jsonl")
+	if err := df.ToJSON(path, goframe.JSONOption{Orient: "lines"}); err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var b strings.Builder
+	df.ToJSONWriter(&b, goframe.JSONOption{Orient: "lines"})
+	if lines := strings.Split(b.String(), "\n"); len(lines) != 4 || lines[2] != `{"id":3,"user.name":"Carol","legacy":null,"user.plan":"pro"}` {
+		t.Errorf("unexpected JSON Lines %q", b.String())
+	}
+	read, err := goframe.FromJSON(path, goframe.JSONOption{Orient: "lines"})
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	goframe.AssertFrameEqual(t, df, read)
+}
@@ -0,0 +1,101 @@
+package goframe_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestLazyFrame(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("name", []any{"Ann", "Bob", "Cid", "Dee", "Eve", "Fay"}))
+	df.AddColumn(goframe.NewColumn("dept", []any{"IT", "HR", "IT", "Ops", "IT", "Ops"}))
+	df.AddColumn(goframe.NewColumn("salary", []any{120, 90, 80, 150, 200, 110}))
+	df.AddColumn(goframe.NewColumn("age", []any{30, 45, 25, 50, 35, 28}))
+
+	lazy := df.Lazy().
+		Filter("salary > 100").
+		Select("dept", "salary").
+		Filter("dept != 'HR'").
+		GroupBy("dept").
+		Agg(map[string][]string{"salary": {"sum", "count"}})
+
+	plan, err := lazy.Explain()
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	expectedPlan := "scan [dept salary]\nfilter (salary > 100) && (dept != 'HR')\naggregate map[salary:[sum count]] by [dept]"
+	if plan != expectedPlan {
+		t.Errorf("expected the filters to be fused and pushed down and only dept and salary scanned, got\n%s", plan)
+	}
+
+	got, err := lazy.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	filtered, _ := df.Query("salary > 100 && dept != 'HR'")
+	expected, err := filtered.Groupby("dept").Agg(map[string][]string{"salary": {"sum", "count"}})
+	if err != nil {
+		t.Fatalf("eager Agg failed: %v", err)
+	}
+	goframe.AssertFrameEqual(t, expected, got)
+
+	// operations after the aggregation work on its result
+	having, err := lazy.Filter("salary_sum > 300").Collect()
+	if err != nil {
+		t.Fatalf("Collect with a filter on the aggregate failed: %v", err)
+	}
+	if keys := having.Columns["GroupKey"].Data; !reflect.DeepEqual(keys, []any{"IT"}) {
+		t.Errorf("expected only IT above 300, got %v", keys)
+	}
+
+	t.Run("Projection", func(t *testing.T) {
+		selected := df.Lazy().Filter("age < 40").Select("name", "salary")
+		plan, _ := selected.Explain()
+		if plan != "scan [name salary age]\nfilter age < 40\nselect [name salary]" {
+			t.Errorf("unexpected plan\n%s", plan)
+		}
+		got, err := selected.Collect()
+		if err != nil {
+			t.Fatalf("Collect failed: %v", err)
+		}
+		if names := got.ColumnNames(); !reflect.DeepEqual(names, []string{"name", "salary"}) {
+			t.Errorf("expected the selected columns only, got %v", names)
+		}
+		if values := got.Columns["name"].Data; !reflect.DeepEqual(values, []any{"Ann", "Cid", "Eve", "Fay"}) {
+			t.Errorf("unexpected rows %v", values)
+		}
+
+		// without a filter the result is still a copy
+		copied, _ := df.Lazy().Select("age").Collect()
+		copied.Columns["age"].Data[0] = 99
+		if df.Columns["age"].Data[0] != 30 {
+			t.Errorf("expected Collect not to share data with the source")
+		}
+		if all, _ := df.Lazy().Collect(); all.Nrows() != 6 || all.Ncols() != 4 {
+			t.Errorf("expected an empty pipeline to return every row and column, got %dx%d", all.Nrows(), all.Ncols())
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			lf   *goframe.LazyFrame
+			want string
+		}{
+			{"parse", df.Lazy().Filter("salary >").Select("name"), "expected"},
+			{"missing column", df.Lazy().Filter("bonus > 1"), "column 'bonus' does not exist"},
+			{"dropped column", df.Lazy().Select("name").Filter("salary > 1"), "column 'salary' does not exist"},
+			{"duplicate", df.Lazy().Select("name", "name"), "duplicate column name 'name'"},
+			{"no keys", df.Lazy().GroupBy().Agg(map[string][]string{"salary": {"sum"}}), "at least one key"},
+			{"unknown aggregation", df.Lazy().GroupBy("dept").Agg(map[string][]string{"salary": {"mode"}}), "unknown aggregation"},
+		}
+		for _, tt := range tests {
+			if _, err := tt.lf.Collect(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.want, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package goframe_test
+
+import (
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestMaskColumns(t *testing.T) {
+	setupDF := func() *goframe.DataFrame {
+		df := goframe.NewDataFrame()
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice", "Bob", "Alice"})))
+		df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("card", []string{"4111111111111111", "5500000000000004", "4111111111111111"})))
+		df.AddColumn(&goframe.Column[any]{Name: "email", Data: []any{"a@x.com", nil, "c@x.com"}})
+		return df
+	}
+
+	t.Run("Hash", func(t *testing.T) {
+		df := setupDF()
+		masked, err := df.MaskColumns([]string{"name"}, "hash", goframe.MaskOption{Salt: "pepper"})
+		if err != nil {
+			t.Fatalf("MaskColumns failed: %v", err)
+		}
+		nameCol, _ := masked.Select("name")
+		if nameCol.Data[0] != nameCol.Data[2] {
+			t.Errorf("Expected equal values to hash equally, got %v and %v", nameCol.Data[0], nameCol.Data[2])
+		}
+		if nameCol.Data[0] == nameCol.Data[1] {
+			t.Errorf("Expected different values to hash differently")
+		}
+		if len(nameCol.Data[0].(string)) != 64 {
+			t.Errorf("Expected a 64 character hex digest, got %v", nameCol.Data[0])
+		}
+
+		// the original DataFrame must be untouched
+		original, _ := df.Select("name")
+		if original.Data[0] != "Alice" {
+			t.Errorf("Original DataFrame was modified, got %v", original.Data[0])
+		}
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		masked, err := setupDF().MaskColumns([]string{"email"}, "redact")
+		if err != nil {
+			t.Fatalf("MaskColumns failed: %v", err)
+		}
+		emailCol, _ := masked.Select("email")
+		expected := []any{"[REDACTED]", nil, "[REDACTED]"}
+		for i, v := range emailCol.Data {
+			if v != expected[i] {
+				t.Errorf("Index %d: expected %v, got %v", i, expected[i], v)
+			}
+		}
+	})
+
+	t.Run("Fake", func(t *testing.T) {
+		masked, err := setupDF().MaskColumns([]string{"name"}, "fake")
+		if err != nil {
+			t.Fatalf("MaskColumns failed: %v", err)
+		}
+		nameCol, _ := masked.Select("name")
+		expected := []string{"name_1", "name_2", "name_1"}
+		for i, v := range nameCol.Data {
+			if v != expected[i] {
+				t.Errorf("Index %d: expected %v, got %v", i, expected[i], v)
+			}
+		}
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		masked, err := setupDF().MaskColumns([]string{"card"}, "partial")
+		if err != nil {
+			t.Fatalf("MaskColumns failed: %v", err)
+		}
+		cardCol, _ := masked.Select("card")
+		expected := strings.Repeat("*", 12) + "1111"
+		if cardCol.Data[0] != expected {
+			t.Errorf("Expected %v, got %v", expected, cardCol.Data[0])
+		}
+
+		none := 0
+		masked, err = setupDF().MaskColumns([]string{"card"}, "partial", goframe.MaskOption{VisibleChars: &none})
+		if err != nil {
+			t.Fatalf("MaskColumns failed: %v", err)
+		}
+		if cardCol, _ := masked.Select("card"); cardCol.Data[0] != strings.Repeat("*", 16) {
+			t.Errorf("Expected every character hidden, got %v", cardCol.Data[0])
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		df := setupDF()
+		if _, err := df.MaskColumns([]string{"missing"}, "hash", goframe.MaskOption{Salt: "pepper"}); err == nil {
+			t.Errorf("Expected error for missing column, got nil")
+		}
+		if _, err := df.MaskColumns([]string{"name"}, "scramble"); err == nil {
+			t.Errorf("Expected error for unknown strategy, got nil")
+		}
+		if _, err := df.MaskColumns([]string{"name"}, "hash"); err == nil || !strings.Contains(err.Error(), "requires a salt") {
+			t.Errorf("Expected error for a hash without salt, got %v", err)
+		}
+		negative := -1
+		if _, err := df.MaskColumns([]string{"card"}, "partial", goframe.MaskOption{VisibleChars: &negative}); err == nil {
+			t.Errorf("Expected error for negative visible characters, got nil")
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package goframe_test
+
+import (
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestMemoryUsageAndInfo(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", []any{1, 2, 3, 4}))
+	df.AddColumn(goframe.NewColumn("name", []any{"ab", "c", nil, "de"}))
+	df.AddColumn(goframe.NewColumn("flag", []any{true, "yes", nil, nil}))
+
+	usage := df.MemoryUsage()
+	// an interface header per row, plus 8 bytes per int and a header and the characters per string
+	expected := map[string]int64{"id": 4*16 + 4*8, "name": 4*16 + 3*16 + 5, "flag": 4*16 + 16 + 3}
+	for name, bytes := range expected {
+		if usage[name] != bytes {
+			t.Errorf("column '%s': expected %d bytes, got %d", name, bytes, usage[name])
+		}
+	}
+	if typed := df.ToTyped().MemoryUsage(); typed["id"] != 4*8 {
+		t.Errorf("expected 8 bytes per row for a native int64 column, got %d", typed["id"])
+	}
+
+	if err := df.CompressColumns("dict", "name"); err != nil {
+		t.Fatalf("CompressColumns failed: %v", err)
+	}
+	df.SetIndex("id")
+	info := df.Info()
+	for _, want := range []string{
+		"DataFrame: 4 rows, 3 columns",
+		"Index: id",
+		"#  Column  Non-Null  Dtype   Storage  Memory",
+		"0  id      4         int     boxed    96 B",
+		"1  name    3         string  dict",
+		"2  flag    2         mixed   boxed    83 B",
+		"Memory: ",
+	} {
+		if !strings.Contains(info, want) {
+			t.Errorf("expected the summary to contain %q, got\n%s", want, info)
+		}
+	}
+}
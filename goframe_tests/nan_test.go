@@ -0,0 +1,130 @@
+package goframe_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestNaNAggregations(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	cases := []struct {
+		name                string
+		data                []any
+		mean, sum, min, max float64
+		keepMean, keepSum   float64
+		keepMin, keepMax    float64
+	}{
+		{"SkipNaN", []any{1.0, nan, 3.0}, 2, 4, 1, 3, nan, nan, nan, nan},
+		{"LeadingNaN", []any{nan, 5.0, 2.0}, 3.5, 7, 2, 5, nan, nan, nan, nan},
+		{"AllNaN", []any{nan, nan}, nan, 0, nan, nan, nan, nan, nan, nan},
+		{"Infinity", []any{1.0, inf, -2.0}, inf, inf, -2, inf, inf, inf, -2, inf},
+		{"OppositeInfinities", []any{inf, -inf}, nan, nan, -inf, inf, nan, nan, -inf, inf},
+	}
+
+	same := func(a, b float64) bool {
+		return a == b || (math.IsNaN(a) && math.IsNaN(b))
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := goframe.NewSeries("v", tc.data)
+			keep := goframe.AggOption{KeepNaN: true}
+
+			mean, _ := s.Mean()
+			sum, _ := s.Sum()
+			min, _ := s.Min()
+			max, _ := s.Max()
+			if !same(mean, tc.mean) || !same(sum, tc.sum) || !same(min, tc.min) || !same(max, tc.max) {
+				t.Errorf("skipna: expected mean=%v sum=%v min=%v max=%v, got %v %v %v %v", tc.mean, tc.sum, tc.min, tc.max, mean, sum, min, max)
+			}
+
+			mean, _ = s.Mean(keep)
+			sum, _ = s.Sum(keep)
+			min, _ = s.Min(keep)
+			max, _ = s.Max(keep)
+			if !same(mean, tc.keepMean) || !same(sum, tc.keepSum) || !same(min, tc.keepMin) || !same(max, tc.keepMax) {
+				t.Errorf("KeepNaN: expected mean=%v sum=%v min=%v max=%v, got %v %v %v %v", tc.keepMean, tc.keepSum, tc.keepMin, tc.keepMax, mean, sum, min, max)
+			}
+
+			// boxed and typed DataFrames agree with the Series
+			df := goframe.NewDataFrame()
+			df.AddColumn(goframe.NewColumn("v", append([]any{}, tc.data...)))
+			for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+				means, _ := frame.Mean()
+				maxes, _ := frame.Max(keep)
+				if !same(means["v"], tc.mean) || !same(maxes["v"], tc.keepMax) {
+					t.Errorf("DataFrame (typed=%v): expected mean=%v max=%v, got %v %v", frame.IsTyped(), tc.mean, tc.keepMax, means["v"], maxes["v"])
+				}
+			}
+		})
+	}
+}
+
+func TestNaNSortJoinAndReplace(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("key", []any{2.0, nan, -inf, nil, inf}))
+	df.AddColumn(goframe.NewColumn("label", []any{"two", "nan", "-inf", "nil", "inf"}))
+
+	for _, ascending := range []bool{true, false} {
+		sorted, err := df.SortValues([]string{"key"}, ascending)
+		if err != nil {
+			t.Fatalf("SortValues failed: %v", err)
+		}
+		labels, _ := sorted.Select("label")
+		want := []any{"-inf", "two", "inf"}
+		if !ascending {
+			want = []any{"inf", "two", "-inf"}
+		}
+		if !reflect.DeepEqual(labels.Data[:3], want) {
+			t.Errorf("ascending=%v: expected %v first, got %v", ascending, want, labels.Data)
+		}
+		if rest := labels.Data[3:]; !(reflect.DeepEqual(rest, []any{"nan", "nil"}) || reflect.DeepEqual(rest, []any{"nil", "nan"})) {
+			t.Errorf("ascending=%v: expected NaN and nil last, got %v", ascending, labels.Data)
+		}
+	}
+
+	other := goframe.NewDataFrame()
+	other.AddColumn(goframe.NewColumn("key", []any{nan, inf}))
+	other.AddColumn(goframe.NewColumn("extra", []any{"x", "y"}))
+	joined, err := df.InnerJoin(other, "key")
+	if err != nil {
+		t.Fatalf("InnerJoin failed: %v", err)
+	}
+	if extra, _ := joined.Select("extra"); !reflect.DeepEqual(extra.Data, []any{"y"}) {
+		t.Errorf("Expected only the +Inf key to match, got %v", extra.Data)
+	}
+
+	df.ReplaceInf(nil)
+	key, _ := df.Select("key")
+	if key.Data[2] != nil || key.Data[4] != nil || key.Data[0] != 2.0 || !math.IsNaN(key.Data[1].(float64)) {
+		t.Errorf("Expected infinities replaced by nil, got %v", key.Data)
+	}
+}
+
+func TestNaNSQLWrite(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("v", []any{math.NaN(), 1.5}))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM (.+)").WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectExec("CREATE TABLE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare("INSERT INTO").ExpectExec().WithArgs(nil, 1.5).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	if err := df.ToSQL(db, "t", goframe.SQLWriteOption{Dialect: "postgres"}); err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package goframe_test
+
+import (
+	"reflect"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestNullSafeOperations(t *testing.T) {
+	a := goframe.NewSeries("a", []any{1, nil, nil, "x", 2.0})
+	b := goframe.NewSeries("b", []any{1.0, nil, 5, "x", 3})
+
+	t.Run("NullEq", func(t *testing.T) {
+		eq, err := a.NullEq(b)
+		if err != nil {
+			t.Fatalf("NullEq failed: %v", err)
+		}
+		expected := []any{true, true, false, true, false}
+		if !reflect.DeepEqual(eq.Data, expected) {
+			t.Errorf("Expected %v, got %v", expected, eq.Data)
+		}
+
+		if _, err := a.NullEq(goframe.NewSeries("short", []any{1})); err == nil {
+			t.Errorf("Expected error for length mismatch, got nil")
+		}
+	})
+
+	t.Run("Coalesce", func(t *testing.T) {
+		fallback := goframe.NewSeries("c", []any{9, 8, nil, 6, 5})
+		coalesced, err := goframe.Coalesce(a, b, fallback)
+		if err != nil {
+			t.Fatalf("Coalesce failed: %v", err)
+		}
+		expected := []any{1, 8, 5, "x", 2.0}
+		if !reflect.DeepEqual(coalesced.Data, expected) {
+			t.Errorf("Expected %v, got %v", expected, coalesced.Data)
+		}
+
+		if _, err := goframe.Coalesce(); err == nil {
+			t.Errorf("Expected error for no series, got nil")
+		}
+	})
+
+	t.Run("IfNull", func(t *testing.T) {
+		df := goframe.NewDataFrame()
+		df.AddColumn(&goframe.Column[any]{Name: "discount", Data: []any{0.1, nil, 0.3}})
+
+		filled, err := df.IfNull("discount", 0.0)
+		if err != nil {
+			t.Fatalf("IfNull failed: %v", err)
+		}
+		if !reflect.DeepEqual(filled.Data, []any{0.1, 0.0, 0.3}) {
+			t.Errorf("Unexpected values: %v", filled.Data)
+		}
+
+		// the DataFrame itself is not modified
+		if df.Columns["discount"].Data[1] != nil {
+			t.Errorf("Expected original column to keep its nil value")
+		}
+
+		if _, err := df.IfNull("missing", 0); err == nil {
+			t.Errorf("Expected error for missing column, got nil")
+		}
+	})
+
+	t.Run("ThreeValuedLogic", func(t *testing.T) {
+		x := goframe.NewSeries("x", []any{true, true, false, nil, nil, false})
+		y := goframe.NewSeries("y", []any{true, nil, nil, false, nil, true})
+
+		and, err := x.And(y)
+		if err != nil {
+			t.Fatalf("And failed: %v", err)
+		}
+		if expected := []any{true, nil, false, false, nil, false}; !reflect.DeepEqual(and.Data, expected) {
+			t.Errorf("And: expected %v, got %v", expected, and.Data)
+		}
+
+		or, err := x.Or(y)
+		if err != nil {
+			t.Fatalf("Or failed: %v", err)
+		}
+		if expected := []any{true, true, nil, nil, nil, true}; !reflect.DeepEqual(or.Data, expected) {
+			t.Errorf("Or: expected %v, got %v", expected, or.Data)
+		}
+
+		not, err := x.Not()
+		if err != nil {
+			t.Fatalf("Not failed: %v", err)
+		}
+		if expected := []any{false, false, true, nil, nil, true}; !reflect.DeepEqual(not.Data, expected) {
+			t.Errorf("Not: expected %v, got %v", expected, not.Data)
+		}
+
+		if _, err := x.And(goframe.NewSeries("bad", []any{1, 2, 3, 4, 5, 6})); err == nil {
+			t.Errorf("Expected error for non boolean values, got nil")
+		}
+	})
+}
@@ -0,0 +1,202 @@
+package goframe_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+	"pgregory.net/rapid"
+)
+
+// randomFrame returns a frame of rows rows with a "key" column drawn from a few float64 values and nils,
+// so that joins find duplicates and missing keys, and one column per name in columns mixing floats, nils
+// and strings that need CSV quoting (without surrounding spaces, which the CSV reader trims).
+func randomFrame(r *rand.Rand, rows int, columns ...string) *goframe.DataFrame {
+	df := goframe.NewDataFrame()
+	keys := make([]any, rows)
+	for i := range keys {
+		if r.Intn(8) > 0 {
+			keys[i] = float64(r.Intn(4))
+		}
+	}
+	_ = df.AddColumn(goframe.NewColumn("key", keys))
+	for _, name := range columns {
+		values := make([]any, rows)
+		for i := range values {
+			switch r.Intn(4) {
+			case 0:
+				values[i] = r.NormFloat64()
+			case 1:
+				values[i] = float64(r.Intn(3))
+			case 2:
+				values[i] = fmt.Sprintf("s%d,\n\"%d\"", r.Intn(3), r.Intn(3))
+			}
+		}
+		_ = df.AddColumn(goframe.NewColumn(name, values))
+	}
+	return df
+}
+
+// rowMultiset returns the sorted string forms of the rows of a frame, restricted to columns
+func rowMultiset(df *goframe.DataFrame, columns ...string) []string {
+	rows := make([]string, df.Nrows())
+	for i := range rows {
+		row, _ := df.Row(i)
+		var cells []string
+		for _, name := range columns {
+			cells = append(cells, fmt.Sprintf("%s=%#v", name, row[name]))
+		}
+		rows[i] = strings.Join(cells, " ")
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+// keyGen draws the keys of frameGen: a few float64 values and nils, so that joins find duplicates
+// and missing keys
+var keyGen = rapid.OneOf(rapid.Just[any](nil), rapid.Map(rapid.IntRange(0, 3), func(k int) any { return float64(k) }))
+
+// cellGen draws the other cells of frameGen, like randomFrame: floats, nils and strings that need CSV
+// quoting (without surrounding spaces, which the CSV reader trims)
+var cellGen = rapid.OneOf(
+	rapid.Just[any](nil),
+	rapid.Map(rapid.Float64Range(-1e6, 1e6), func(f float64) any { return f }),
+	rapid.Map(rapid.IntRange(0, 2), func(n int) any { return float64(n) }),
+	rapid.Map(rapid.StringMatching(`s([a-c,"\n]{0,3}[a-c,"])?`), func(s string) any { return s }),
+)
+
+// frameGen draws frames of at most maxRows rows with a "key" column and one column per name in columns
+func frameGen(maxRows int, columns ...string) *rapid.Generator[*goframe.DataFrame] {
+	return rapid.Custom(func(t *rapid.T) *goframe.DataFrame {
+		rows := rapid.IntRange(0, maxRows).Draw(t, "rows")
+		df := goframe.NewDataFrame()
+		_ = df.AddColumn(goframe.NewColumn("key", rapid.SliceOfN(keyGen, rows, rows).Draw(t, "key")))
+		for _, name := range columns {
+			_ = df.AddColumn(goframe.NewColumn(name, rapid.SliceOfN(cellGen, rows, rows).Draw(t, name)))
+		}
+		return df
+	})
+}
+
+func TestPropertyCSVRoundTrip(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		df := frameGen(20, "a", "b").Draw(t, "df")
+		var first strings.Builder
+		if err := df.ToCSVWriter(&first); err != nil {
+			t.Fatal(err)
+		}
+		reread, err := goframe.FromCSVReader(strings.NewReader(first.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reread.Nrows() != df.Nrows() {
+			t.Fatalf("expected %d rows, got %d", df.Nrows(), reread.Nrows())
+		}
+		var second strings.Builder
+		if err := reread.ToCSVWriter(&second); err != nil {
+			t.Fatal(err)
+		}
+		if first.String() != second.String() {
+			t.Fatalf("round trip changed the CSV:\n%q\n%q", first.String(), second.String())
+		}
+	})
+}
+
+func TestPropertyJoinLaws(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		a := frameGen(12, "a").Draw(t, "a")
+		b := frameGen(12, "b").Draw(t, "b")
+		columns := []string{"key", "a", "b"}
+
+		// count the matching pairs per key
+		countA, countB := map[any]int{}, map[any]int{}
+		keysA, _ := a.Select("key")
+		keysB, _ := b.Select("key")
+		for _, k := range keysA.Data {
+			countA[k]++
+		}
+		for _, k := range keysB.Data {
+			countB[k]++
+		}
+		pairs, unmatchedA, unmatchedB := 0, 0, 0
+		for k, n := range countA {
+			pairs += n * countB[k]
+			if countB[k] == 0 {
+				unmatchedA += n
+			}
+		}
+		for k, n := range countB {
+			if countA[k] == 0 {
+				unmatchedB += n
+			}
+		}
+
+		inner, err := a.InnerJoin(b, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		swapped, err := b.InnerJoin(a, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		left, err := a.LeftJoin(b, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		right, err := b.RightJoin(a, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		outer, err := a.OuterJoin(b, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		switch {
+		case inner.Nrows() != pairs:
+			t.Fatalf("inner join has %d rows, expected %d matching pairs", inner.Nrows(), pairs)
+		case !reflect.DeepEqual(rowMultiset(inner, columns...), rowMultiset(swapped, columns...)):
+			t.Fatalf("inner join is not commutative")
+		case left.Nrows() != pairs+unmatchedA:
+			t.Fatalf("left join has %d rows, expected %d", left.Nrows(), pairs+unmatchedA)
+		case !reflect.DeepEqual(rowMultiset(left, columns...), rowMultiset(right, columns...)):
+			t.Fatalf("a left join b differs from b right join a")
+		case outer.Nrows() != pairs+unmatchedA+unmatchedB:
+			t.Fatalf("outer join has %d rows, expected %d", outer.Nrows(), pairs+unmatchedA+unmatchedB)
+		}
+	})
+}
+
+func TestPropertySortIdempotence(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		df := frameGen(30, "a").Draw(t, "df")
+		ascending := rapid.SliceOfN(rapid.Bool(), 2, 2).Draw(t, "ascending")
+		options := goframe.SortOption{Ascending: ascending, NullsFirst: rapid.Bool().Draw(t, "nullsFirst")}
+		by := []string{"key", "a"}
+
+		once, err := df.SortValuesWithOption(by, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		twice, err := once.SortValuesWithOption(by, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		columns := []string{"key", "a"}
+		if !reflect.DeepEqual(rowMultiset(once, columns...), rowMultiset(df, columns...)) {
+			t.Fatalf("sorting changed the rows")
+		}
+		// a stable sort of sorted rows keeps them in place
+		for i := 0; i < once.Nrows(); i++ {
+			rowOnce, _ := once.Row(i)
+			rowTwice, _ := twice.Row(i)
+			if fmt.Sprintf("%#v", rowOnce) != fmt.Sprintf("%#v", rowTwice) {
+				t.Fatalf("sorting twice moved row %d: %v != %v", i, rowOnce, rowTwice)
+			}
+		}
+	})
+}
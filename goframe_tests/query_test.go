@@ -0,0 +1,170 @@
+package goframe_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestQuery(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("name", []any{"Ann", "Bob", "Cid", "Dee", "Eve"}))
+	df.AddColumn(goframe.NewColumn[any]("age", []any{25, 35, 45, nil, 31}))
+	df.AddColumn(goframe.NewColumn[any]("dept", []any{"IT", "IT", "HR", "IT", "Sales"}))
+	df.AddColumn(goframe.NewColumn[any]("unit price", []any{1.5, 2.0, 10.0, 3.0, 4.0}))
+	df.AddColumn(goframe.NewColumn[any]("qty", []any{10, 1, 2, 5, 0}))
+	df.AddColumn(goframe.NewColumn[any]("active", []any{true, false, true, true, false}))
+
+	tests := []struct {
+		expr     string
+		expected []any
+	}{
+		{"age > 30 && dept == 'IT'", []any{"Bob"}},
+		{"age > 30 and dept == \"IT\"", []any{"Bob"}},
+		{"age >= 45 || dept == 'Sales'", []any{"Cid", "Eve"}},
+		{"!(dept == 'IT')", []any{"Cid", "Eve"}},
+		{"not active", []any{"Bob", "Eve"}},
+		{"active && age < 40", []any{"Ann"}},
+		{"`unit price` * qty >= 15", []any{"Ann", "Cid", "Dee"}},
+		{"qty % 2 == 0 && -qty < 0", []any{"Ann", "Cid"}},
+		{"(age + 5) / 10 == 3", []any{"Ann"}},
+		{"1 + 2 * 3 == 7", []any{"Ann", "Bob", "Cid", "Dee", "Eve"}},
+		{"dept in ('HR', 'Sales')", []any{"Cid", "Eve"}},
+		{"dept not in ('HR', 'Sales') && age != null", []any{"Ann", "Bob"}},
+		{"age == null", []any{"Dee"}},
+		{"age != 35", []any{"Ann", "Cid", "Eve"}},
+		{"name + '!' == 'Eve!'", []any{"Eve"}},
+		{"age > 1e1 && name < 'C'", []any{"Ann", "Bob"}},
+		{"round(`unit price` / 4) == 1", []any{"Bob", "Dee", "Eve"}},
+	}
+	// typed storage goes through the native slices, the same results are expected
+	for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				result, err := frame.Query(tt.expr)
+				if err != nil {
+					t.Fatalf("Query returned an error: %v", err)
+				}
+				if got := result.Columns["name"].Values(); !reflect.DeepEqual(got, tt.expected) {
+					t.Errorf("expected %v, got %v", tt.expected, got)
+				}
+			})
+		}
+	}
+
+	errors := []struct {
+		expr, message string
+	}{
+		{"salary > 10", "column 'salary' does not exist"},
+		{"age > ", "unexpected end of expression"},
+		{"age > 30)", "unexpected ')'"},
+		{"(age > 30", "expected ')'"},
+		{"dept == 'IT", "unterminated '"},
+		{"age # 3", "unexpected character '#'"},
+		{"age = 3", "use == to compare"},
+		{"abs(age, 1) > 3", "function abs takes 1 argument(s)"},
+		{"median(age) > 3", "unknown function 'median'"},
+		{"age + 1", "query must evaluate to a boolean"},
+		{"dept * 2 > 1", "operator * expects numbers"},
+		{"age && active", "operator && expects booleans"},
+		{"age not 3", "expected 'in' after 'not'"},
+	}
+	for _, tt := range errors {
+		if _, err := df.Query(tt.expr); err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("Query(%q): expected error containing %q, got %v", tt.expr, tt.message, err)
+		}
+	}
+}
+
+func TestQueryMixedTypes(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("id", []any{1, 2, 3, 4}))
+	df.AddColumn(goframe.NewColumn[any]("start", []any{day, day, day.AddDate(0, 0, 2), nil}))
+	df.AddColumn(goframe.NewColumn[any]("end", []any{day.AddDate(0, 0, 1), day, day, day}))
+	df.AddColumn(goframe.NewColumn[any]("score", []any{math.NaN(), 1.5, 2, 3}))
+	df.AddColumn(goframe.NewColumn[any]("code", []any{"1", 2, "x", nil}))
+
+	tests := []struct {
+		expr     string
+		expected []any
+	}{
+		{"start < end", []any{1}},
+		{"start <= end && score == score", []any{2}},
+		{"score != 1.5", []any{1, 3, 4}},
+		{"score > 0 || code == 'x'", []any{2, 3, 4}},
+		{"code in (2, 'x')", []any{2, 3}},
+		{"code not in (2, 'x')", []any{1}},
+		{"id in (1, score + 1)", []any{1, 3, 4}},
+		{"start == null || code == null", []any{4}},
+		{"id >= 1 || id * 'x' > 0", []any{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			result, err := df.Query(tt.expr)
+			if err != nil {
+				t.Fatalf("Query returned an error: %v", err)
+			}
+			if got := result.Columns["id"].Data; !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("revenue", []any{100, 250.5, nil, 80}))
+	df.AddColumn(goframe.NewColumn[any]("cost", []any{40, 300, 10, 80}))
+	df.AddColumn(goframe.NewColumn[any]("region", []any{"north", "south", "east", "west"}))
+
+	tests := []struct {
+		expr     string
+		column   string
+		expected []any
+	}{
+		{"profit = revenue - cost", "profit", []any{60.0, -49.5, nil, 0.0}},
+		{"margin = round((revenue - cost) / revenue * 100, 1)", "margin", []any{60.0, -19.8, nil, 0.0}},
+		{"loss = abs(revenue - cost) * (revenue < cost)", "", nil},
+		{"`log cost` = log(cost)", "log cost", []any{math.Log(40), math.Log(300), math.Log(10), math.Log(80)}},
+		{"big = revenue > 90 || cost >= 300", "big", []any{true, true, false, false}},
+		{"label = region + '-' + region", "label", []any{"north-north", "south-south", "east-east", "west-west"}},
+		{"cost = sqrt(pow(cost, 2)) + 1", "cost", []any{41.0, 301.0, 11.0, 81.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			result, err := df.Eval(tt.expr)
+			if tt.expected == nil {
+				if err == nil || !strings.Contains(err.Error(), "at row 0") {
+					t.Fatalf("expected an error mentioning the row, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Eval returned an error: %v", err)
+			}
+			if got := result.Columns[tt.column].Data; !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	// the original is not modified and replaced columns keep their position
+	result, _ := df.Eval("cost = cost * 2")
+	if !reflect.DeepEqual(result.ColumnNames(), df.ColumnNames()) {
+		t.Errorf("expected columns %v, got %v", df.ColumnNames(), result.ColumnNames())
+	}
+	if df.Columns["cost"].Data[0] != 40 {
+		t.Errorf("expected the original DataFrame to be unchanged, got %v", df.Columns["cost"].Data)
+	}
+
+	for _, expr := range []string{"revenue - cost", "= cost", "x = ", "x = missing + 1"} {
+		if _, err := df.Eval(expr); err == nil {
+			t.Errorf("Eval(%q): expected an error", expr)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package goframe_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestRankAndClip(t *testing.T) {
+	scores := goframe.NewSeries("score", []any{30, 10, nil, 30.0, 20, math.NaN(), 30})
+
+	tests := []struct {
+		options  goframe.RankOption
+		expected []any
+	}{
+		{goframe.RankOption{}, []any{4.0, 1.0, nil, 4.0, 2.0, nil, 4.0}},
+		{goframe.RankOption{Method: "min"}, []any{3.0, 1.0, nil, 3.0, 2.0, nil, 3.0}},
+		{goframe.RankOption{Method: "max"}, []any{5.0, 1.0, nil, 5.0, 2.0, nil, 5.0}},
+		{goframe.RankOption{Method: "first"}, []any{3.0, 1.0, nil, 4.0, 2.0, nil, 5.0}},
+		{goframe.RankOption{Method: "dense"}, []any{3.0, 1.0, nil, 3.0, 2.0, nil, 3.0}},
+		{goframe.RankOption{Method: "dense", Descending: true}, []any{1.0, 3.0, nil, 1.0, 2.0, nil, 1.0}},
+	}
+	for _, tt := range tests {
+		got := scores.Rank(tt.options)
+		if got.Err() != nil || !reflect.DeepEqual(got.Data, tt.expected) {
+			t.Errorf("%+v: expected %v, got %v (error %v)", tt.options, tt.expected, got.Data, got.Err())
+		}
+	}
+	if got := goframe.NewSeries("name", []any{"b", "a", "c"}).Rank(); !reflect.DeepEqual(got.Data, []any{2.0, 1.0, 3.0}) {
+		t.Errorf("expected strings to rank lexically, got %v", got.Data)
+	}
+	if got := scores.Rank(goframe.RankOption{Method: "median"}); got.Err() == nil || !strings.Contains(got.Err().Error(), "unknown rank method") {
+		t.Errorf("expected an unknown method error, got %v", got.Err())
+	}
+
+	if got := scores.Clip(15, 25); got.Err() != nil || !reflect.DeepEqual(got.Data, []any{25.0, 15.0, nil, 25.0, 20.0, nil, 25.0}) {
+		t.Errorf("Clip: unexpected values %v (error %v)", got.Data, got.Err())
+	}
+	if got := scores.Clip(math.Inf(-1), 20); !reflect.DeepEqual(got.Data, []any{20.0, 10.0, nil, 20.0, 20.0, nil, 20.0}) {
+		t.Errorf("Clip without lower bound: unexpected values %v", got.Data)
+	}
+	if got := scores.Clip(2, 1); got.Err() == nil || !strings.Contains(got.Err().Error(), "is above upper bound") {
+		t.Errorf("expected an error for inverted bounds, got %v", got.Err())
+	}
+
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("name", []any{"c", "a", "b"}))
+	df.AddColumn(goframe.NewColumn("score", []any{3, 1, 2}))
+
+	t.Run("DataFrame", func(t *testing.T) {
+		ranked, err := df.Rank(nil, goframe.RankOption{Descending: true})
+		if err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+		if got := ranked.Columns["score"].Data; !reflect.DeepEqual(got, []any{1.0, 3.0, 2.0}) {
+			t.Errorf("unexpected ranks %v", got)
+		}
+		if got := ranked.Columns["name"].Data; !reflect.DeepEqual(got, []any{"c", "a", "b"}) {
+			t.Errorf("expected the string column to be copied, got %v", got)
+		}
+		if ranked, _ := df.Rank([]string{"name"}); !reflect.DeepEqual(ranked.Columns["name"].Data, []any{3.0, 1.0, 2.0}) {
+			t.Errorf("expected a listed string column to be ranked, got %v", ranked.Columns["name"].Data)
+		}
+
+		clipped, err := df.Clip(1.5, 2.5)
+		if err != nil {
+			t.Fatalf("Clip failed: %v", err)
+		}
+		if got := clipped.Columns["score"].Data; !reflect.DeepEqual(got, []any{2.5, 1.5, 2.0}) {
+			t.Errorf("unexpected clipped values %v", got)
+		}
+		if _, err := df.Clip(0, 1, "name"); err == nil || !strings.Contains(err.Error(), "is not numeric") {
+			t.Errorf("expected an error for a string column, got %v", err)
+		}
+		if _, err := df.Rank([]string{"missing"}); err == nil {
+			t.Errorf("expected an error for a missing column")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package goframe_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+	"github.com/xuri/excelize/v2"
+)
+
+func setupReport(t *testing.T) *goframe.ReportWriter {
+	t.Helper()
+
+	sales := goframe.NewDataFrame()
+	sales.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("x", []float64{1, 2, 3})))
+	sales.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("y", []float64{10, 20, 15})))
+
+	people := goframe.NewDataFrame()
+	people.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"<Alice>", "Bob"})))
+
+	var plot bytes.Buffer
+	if err := sales.LinePlotWriter("x", "y", &plot); err != nil {
+		t.Fatalf("LinePlotWriter failed: %v", err)
+	}
+
+	report := goframe.NewReportWriter("Monthly report")
+	if err := report.AddFrame("Sales", sales, goframe.ExcelOption{HeaderBold: true}); err != nil {
+		t.Fatalf("AddFrame failed: %v", err)
+	}
+	if err := report.AddFrame("People", people); err != nil {
+		t.Fatalf("AddFrame failed: %v", err)
+	}
+	if err := report.AddPlot("Trend", plot.Bytes()); err != nil {
+		t.Fatalf("AddPlot failed: %v", err)
+	}
+	return report
+}
+
+func TestReportWriter(t *testing.T) {
+	t.Run("Excel", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "report.xlsx")
+		if err := setupReport(t).ToExcel(filename); err != nil {
+			t.Fatalf("ToExcel failed: %v", err)
+		}
+
+		f, err := excelize.OpenFile(filename)
+		if err != nil {
+			t.Fatalf("Failed to open workbook: %v", err)
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if strings.Join(sheets, ",") != "Sales,People,Trend" {
+			t.Fatalf("Expected sheets Sales,People,Trend, got %v", sheets)
+		}
+		if value, _ := f.GetCellValue("People", "A2"); value != "<Alice>" {
+			t.Errorf("Expected <Alice>, got %s", value)
+		}
+		pictures, err := f.GetPictures("Trend", "A1")
+		if err != nil || len(pictures) != 1 {
+			t.Errorf("Expected one picture on the Trend sheet, got %d (%v)", len(pictures), err)
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := setupReport(t).ToHTMLWriter(&buf); err != nil {
+			t.Fatalf("ToHTMLWriter failed: %v", err)
+		}
+
+		html := buf.String()
+		for _, want := range []string{"<h1>Monthly report</h1>", "<h2>Sales</h2>", "<th>y</th>", "&lt;Alice&gt;", "data:image/png;base64,"} {
+			if !strings.Contains(html, want) {
+				t.Errorf("Expected HTML to contain %q", want)
+			}
+		}
+		if strings.Index(html, "<h2>Sales</h2>") > strings.Index(html, "<h2>Trend</h2>") {
+			t.Errorf("Expected sections in insertion order")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		report := goframe.NewReportWriter("Empty")
+		if err := report.ToExcelWriter(&bytes.Buffer{}); err == nil {
+			t.Errorf("Expected error for empty report, got nil")
+		}
+		if err := report.AddFrame("", goframe.NewDataFrame()); err == nil {
+			t.Errorf("Expected error for empty section name, got nil")
+		}
+		report.AddFrame("A", goframe.NewDataFrame())
+		if err := report.AddPlot("A", []byte{1}); err == nil {
+			t.Errorf("Expected error for duplicate section name, got nil")
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package goframe_test
+
+import (
+	"reflect"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func setupSalesDF() *goframe.DataFrame {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("dept", []string{"IT", "IT", "HR", "HR", "IT"})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("month", []string{"2024-01", "2024-02", "2024-01", "2024-02", "2024-01"})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("salary", []float64{100, 110, 80, 85, 50})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("bonus", []float64{10, 11, 8, 9, 5})))
+	return df
+}
+
+func TestPivotMultiLevelColumns(t *testing.T) {
+	df := setupSalesDF()
+
+	pivoted, err := df.Pivot("dept", "month", []string{"salary", "bonus"}, "sum")
+	if err != nil {
+		t.Fatalf("Pivot failed: %v", err)
+	}
+
+	if pivoted.Nrows() != 2 || pivoted.Ncols() != 5 {
+		t.Fatalf("Expected 2 rows x 5 columns, got %d x %d", pivoted.Nrows(), pivoted.Ncols())
+	}
+	if pivoted.NLevels() != 2 {
+		t.Errorf("Expected 2 header levels, got %d", pivoted.NLevels())
+	}
+	if !reflect.DeepEqual(pivoted.ColumnLevels("salary|2024-01"), []string{"salary", "2024-01"}) {
+		t.Errorf("Unexpected levels: %v", pivoted.ColumnLevels("salary|2024-01"))
+	}
+	if !reflect.DeepEqual(pivoted.ColumnLevels("dept"), []string{"dept"}) {
+		t.Errorf("Expected single level for index column, got %v", pivoted.ColumnLevels("dept"))
+	}
+
+	t.Run("SelectLevel0", func(t *testing.T) {
+		salary, err := pivoted.SelectLevel("salary")
+		if err != nil {
+			t.Fatalf("SelectLevel failed: %v", err)
+		}
+		expectedCols := []string{"dept", "2024-01", "2024-02"}
+		if !reflect.DeepEqual(salary.ColumnNames(), expectedCols) {
+			t.Fatalf("Expected columns %v, got %v", expectedCols, salary.ColumnNames())
+		}
+		jan, _ := salary.Select("2024-01")
+		if !reflect.DeepEqual(jan.Data, []any{150.0, 80.0}) {
+			t.Errorf("Expected [150 80], got %v", jan.Data)
+		}
+	})
+
+	t.Run("SelectLevel1", func(t *testing.T) {
+		feb, err := pivoted.SelectLevel("2024-02", 1)
+		if err != nil {
+			t.Fatalf("SelectLevel failed: %v", err)
+		}
+		bonus, err := feb.Select("bonus")
+		if err != nil {
+			t.Fatalf("Expected a bonus column: %v", err)
+		}
+		if !reflect.DeepEqual(bonus.Data, []any{11.0, 9.0}) {
+			t.Errorf("Expected [11 9], got %v", bonus.Data)
+		}
+
+		if _, err := pivoted.SelectLevel("2030-01", 1); err == nil {
+			t.Errorf("Expected error for unknown label, got nil")
+		}
+	})
+
+	t.Run("Flatten", func(t *testing.T) {
+		flat, err := df.Pivot("dept", "month", []string{"salary"})
+		if err != nil {
+			t.Fatalf("Pivot failed: %v", err)
+		}
+		if err := flat.FlattenColumns(); err != nil {
+			t.Fatalf("FlattenColumns failed: %v", err)
+		}
+		expectedCols := []string{"dept", "salary_2024-01", "salary_2024-02"}
+		if !reflect.DeepEqual(flat.ColumnNames(), expectedCols) {
+			t.Errorf("Expected columns %v, got %v", expectedCols, flat.ColumnNames())
+		}
+		if flat.NLevels() != 1 {
+			t.Errorf("Expected 1 header level after flattening, got %d", flat.NLevels())
+		}
+
+		// default aggregation keeps the first value
+		jan, _ := flat.Select("salary_2024-01")
+		if jan.Data[0] != 100.0 {
+			t.Errorf("Expected first value 100, got %v", jan.Data[0])
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := df.Pivot("missing", "month", []string{"salary"}); err == nil {
+			t.Errorf("Expected error for missing index column, got nil")
+		}
+		if _, err := df.Pivot("dept", "month", []string{"salary"}, "bogus"); err == nil {
+			t.Errorf("Expected error for unsupported aggregation, got nil")
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package goframe_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestRowAccessors(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	row := map[string]any{"ts": ts, "price": 9.5, "qty": 3, "name": "apple", "active": true, "missing": nil}
+
+	if got, ok := goframe.RowGetTime(row, "ts"); !ok || !got.Equal(ts) {
+		t.Errorf("RowGetTime: expected %v, got %v (ok: %v)", ts, got, ok)
+	}
+	if _, ok := goframe.RowGetTime(row, "missing"); ok {
+		t.Errorf("RowGetTime: expected ok=false for nil cell")
+	}
+	if got, ok := goframe.RowGetFloat(row, "qty"); !ok || got != 3 {
+		t.Errorf("RowGetFloat: expected 3, got %v (ok: %v)", got, ok)
+	}
+	if _, ok := goframe.RowGetFloat(row, "name"); ok {
+		t.Errorf("RowGetFloat: expected ok=false for string cell")
+	}
+	if got, ok := goframe.RowGetInt(row, "qty"); !ok || got != 3 {
+		t.Errorf("RowGetInt: expected 3, got %v (ok: %v)", got, ok)
+	}
+	if _, ok := goframe.RowGetInt(row, "price"); ok {
+		t.Errorf("RowGetInt: expected ok=false for fractional value")
+	}
+	if got, ok := goframe.RowGetString(row, "name"); !ok || got != "apple" {
+		t.Errorf("RowGetString: expected apple, got %v (ok: %v)", got, ok)
+	}
+	if got, ok := goframe.RowGetBool(row, "active"); !ok || !got {
+		t.Errorf("RowGetBool: expected true, got %v (ok: %v)", got, ok)
+	}
+	if _, ok := goframe.RowGetBool(row, "unknown"); ok {
+		t.Errorf("RowGetBool: expected ok=false for unknown column")
+	}
+}
+
+func TestPanicFreeRowLoops(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(&goframe.Column[any]{Name: "age", Data: []any{25, nil, 35}})
+
+	t.Run("FilterSafe", func(t *testing.T) {
+		_, err := df.FilterSafe(func(row map[string]any) bool {
+			return row["age"].(int) > 30 // panics on the nil cell
+		})
+		if err == nil || !strings.Contains(err.Error(), "row 1") {
+			t.Errorf("Expected error mentioning row 1, got %v", err)
+		}
+
+		filtered, err := df.FilterSafe(func(row map[string]any) bool {
+			age, ok := goframe.RowGetInt(row, "age")
+			return ok && age > 30
+		})
+		if err != nil {
+			t.Fatalf("FilterSafe failed: %v", err)
+		}
+		if filtered.Nrows() != 1 {
+			t.Errorf("Expected 1 row, got %d", filtered.Nrows())
+		}
+	})
+
+	t.Run("ApplyRowWise", func(t *testing.T) {
+		_, err := df.Apply(func(values []any) any {
+			return values[0].(int) * 2
+		}, 1)
+		if err == nil {
+			t.Errorf("Expected error from panicking row function, got nil")
+		}
+	})
+
+	t.Run("Resample", func(t *testing.T) {
+		ts := goframe.NewDataFrame()
+		ts.AddColumn(&goframe.Column[any]{Name: "date", Data: []any{time.Now(), nil}})
+		ts.AddColumn(&goframe.Column[any]{Name: "value", Data: []any{1.0, 2.0}})
+
+		_, err := ts.Resample("date", "D", func(values []any) any { return len(values) })
+		if err == nil || !strings.Contains(err.Error(), "row 1") {
+			t.Errorf("Expected error mentioning row 1, got %v", err)
+		}
+
+		ts.Columns["date"].Data[1] = time.Now()
+		_, err = ts.Resample("date", "D", func(values []any) any {
+			return values[5] // out of range
+		})
+		if err == nil {
+			t.Errorf("Expected error from panicking aggregation function, got nil")
+		}
+	})
+}
+
+func TestFilterRows(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(&goframe.Column[any]{Name: "name", Data: []any{"a", "b", "c", "d"}})
+	df.AddColumn(&goframe.Column[any]{Name: "age", Data: []any{25, nil, 35, 45}})
+	if err := df.SetIndex("name"); err != nil {
+		t.Fatalf("SetIndex failed: %v", err)
+	}
+
+	filtered := df.FilterRows(func(get func(string) any) bool {
+		age, ok := get("age").(int)
+		return ok && age > 30 && get("unknown") == nil
+	})
+	names, _ := filtered.Select("name")
+	if filtered.Nrows() != 2 || names.Data[0] != "c" || names.Data[1] != "d" {
+		t.Errorf("Expected rows c and d, got %v", names.Data)
+	}
+	if filtered.IndexName() != "name" {
+		t.Errorf("Expected the index to be kept, got '%s'", filtered.IndexName())
+	}
+
+	// Filter is an adapter over FilterRows and keeps its map based signature
+	mapped := df.Filter(func(row map[string]any) bool {
+		age, ok := goframe.RowGetInt(row, "age")
+		return ok && age > 30
+	})
+	if mapped.String() != filtered.String() {
+		t.Errorf("Expected Filter and FilterRows to agree:\n%v\n%v", mapped, filtered)
+	}
+}
@@ -0,0 +1,100 @@
+package goframe_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestSampleAndSplit(t *testing.T) {
+	ids := make([]any, 20)
+	labels := make([]any, 20)
+	for i := range ids {
+		ids[i] = i
+		labels[i] = "a"
+		if i%4 == 0 {
+			labels[i] = "b"
+		}
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", ids))
+	df.AddColumn(goframe.NewColumn("label", labels))
+
+	t.Run("Sample", func(t *testing.T) {
+		sample, err := df.Sample(goframe.SampleOption{N: 5, Seed: 42})
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		if sample.Nrows() != 5 {
+			t.Fatalf("expected 5 rows, got %d", sample.Nrows())
+		}
+		seen := map[any]bool{}
+		for _, id := range sample.Columns["id"].Data {
+			if seen[id] {
+				t.Errorf("row %v drawn twice without replacement", id)
+			}
+			seen[id] = true
+		}
+		again, _ := df.Sample(goframe.SampleOption{N: 5, Seed: 42})
+		if !reflect.DeepEqual(sample.Columns["id"].Data, again.Columns["id"].Data) {
+			t.Errorf("expected the same seed to draw the same rows, got %v and %v", sample.Columns["id"].Data, again.Columns["id"].Data)
+		}
+
+		if frac, _ := df.Sample(goframe.SampleOption{Frac: 0.25, Seed: 1}); frac.Nrows() != 5 {
+			t.Errorf("expected a quarter of the rows, got %d", frac.Nrows())
+		}
+		if boot, err := df.Sample(goframe.SampleOption{Frac: 2, Replace: true}); err != nil || boot.Nrows() != 40 {
+			t.Errorf("expected 40 rows with replacement, got %v (error %v)", boot, err)
+		}
+		if _, err := df.Sample(goframe.SampleOption{N: 21}); err == nil || !strings.Contains(err.Error(), "without replacement") {
+			t.Errorf("expected an error for too many rows, got %v", err)
+		}
+		if _, err := df.Sample(goframe.SampleOption{N: 2, Frac: 0.5}); err == nil {
+			t.Errorf("expected an error when both N and Frac are set")
+		}
+	})
+
+	t.Run("TrainTestSplit", func(t *testing.T) {
+		train, test, err := df.TrainTestSplit(0.25, goframe.SplitOption{Seed: 7, StratifyBy: "label"})
+		if err != nil {
+			t.Fatalf("TrainTestSplit failed: %v", err)
+		}
+		if train.Nrows() != 15 || test.Nrows() != 5 {
+			t.Fatalf("expected 15 and 5 rows, got %d and %d", train.Nrows(), test.Nrows())
+		}
+		count := map[any]int{}
+		for _, label := range test.Columns["label"].Data {
+			count[label]++
+		}
+		if count["a"] != 4 || count["b"] != 1 {
+			t.Errorf("expected the classes to keep their share, got %v", count)
+		}
+		all := map[any]bool{}
+		for _, split := range []*goframe.DataFrame{train, test} {
+			previous := -1
+			for _, id := range split.Columns["id"].Data {
+				if id.(int) <= previous {
+					t.Errorf("expected the rows in their original order, got %v", split.Columns["id"].Data)
+				}
+				previous = id.(int)
+				all[id] = true
+			}
+		}
+		if len(all) != 20 {
+			t.Errorf("expected every row in exactly one set, got %d rows", len(all))
+		}
+
+		_, again, _ := df.TrainTestSplit(0.25, goframe.SplitOption{Seed: 7, StratifyBy: "label"})
+		if !reflect.DeepEqual(test.Columns["id"].Data, again.Columns["id"].Data) {
+			t.Errorf("expected the same seed to give the same split")
+		}
+		if _, _, err := df.TrainTestSplit(1); err == nil || !strings.Contains(err.Error(), "between 0 and 1") {
+			t.Errorf("expected an error for a test fraction of 1, got %v", err)
+		}
+		if _, _, err := df.TrainTestSplit(0.5, goframe.SplitOption{StratifyBy: "missing"}); err == nil {
+			t.Errorf("expected an error for a missing column")
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package goframe_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("name", []string{"Alice", "Bob"})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("age", []int{25, 30})))
+	df.AddColumn(&goframe.Column[any]{Name: "salary", Data: []any{50000.5, nil}})
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("joined", []time.Time{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+	})))
+
+	checkEqual := func(t *testing.T, loaded *goframe.DataFrame) {
+		if !reflect.DeepEqual(loaded.ColumnNames(), df.ColumnNames()) {
+			t.Fatalf("Expected columns %v, got %v", df.ColumnNames(), loaded.ColumnNames())
+		}
+		for name, col := range df.Columns {
+			if !reflect.DeepEqual(loaded.Columns[name].Data, col.Data) {
+				t.Errorf("Column %s: expected %v, got %v", name, col.Data, loaded.Columns[name].Data)
+			}
+		}
+	}
+
+	t.Run("Plain", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "frame.gfs")
+		if err := df.Save(path); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		loaded, err := goframe.Load(path)
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		checkEqual(t, loaded)
+	})
+
+	t.Run("Passphrase", func(t *testing.T) {
+		opts := goframe.SnapshotOption{Passphrase: "correct horse", KDFIterations: 1000}
+		var buf bytes.Buffer
+		if err := df.SaveWriter(&buf, opts); err != nil {
+			t.Fatalf("SaveWriter failed: %v", err)
+		}
+		if bytes.Contains(buf.Bytes(), []byte("Alice")) {
+			t.Errorf("Expected encrypted snapshot to not contain plaintext values")
+		}
+
+		loaded, err := goframe.LoadReader(bytes.NewReader(buf.Bytes()), goframe.SnapshotOption{Passphrase: "correct horse"})
+		if err != nil {
+			t.Fatalf("LoadReader failed: %v", err)
+		}
+		checkEqual(t, loaded)
+
+		if _, err := goframe.LoadReader(bytes.NewReader(buf.Bytes()), goframe.SnapshotOption{Passphrase: "wrong"}); err == nil {
+			t.Errorf("Expected error for wrong passphrase, got nil")
+		}
+		if _, err := goframe.LoadReader(bytes.NewReader(buf.Bytes())); err == nil {
+			t.Errorf("Expected error when loading an encrypted snapshot without a passphrase, got nil")
+		}
+	})
+
+	t.Run("RawKey", func(t *testing.T) {
+		key := bytes.Repeat([]byte{7}, 32)
+		var buf bytes.Buffer
+		if err := df.SaveWriter(&buf, goframe.SnapshotOption{Key: key}); err != nil {
+			t.Fatalf("SaveWriter failed: %v", err)
+		}
+		loaded, err := goframe.LoadReader(&buf, goframe.SnapshotOption{Key: key})
+		if err != nil {
+			t.Fatalf("LoadReader failed: %v", err)
+		}
+		checkEqual(t, loaded)
+
+		if err := df.SaveWriter(&bytes.Buffer{}, goframe.SnapshotOption{Key: []byte("short")}); err == nil {
+			t.Errorf("Expected error for invalid key length, got nil")
+		}
+	})
+
+	t.Run("FailedSave", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "frame.gfs")
+		if err := df.Save(path); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := df.Save(path, goframe.SnapshotOption{Key: []byte("short")}); err == nil {
+			t.Fatal("Expected error for invalid key length, got nil")
+		}
+		loaded, err := goframe.Load(path)
+		if err != nil {
+			t.Fatalf("Expected the previous snapshot to stay readable, got %v", err)
+		}
+		checkEqual(t, loaded)
+	})
+
+	t.Run("IterationLimit", func(t *testing.T) {
+		if err := df.SaveWriter(&bytes.Buffer{}, goframe.SnapshotOption{Passphrase: "p", KDFIterations: 1 << 30}); err == nil {
+			t.Errorf("Expected error for too many iterations, got nil")
+		}
+		var buf bytes.Buffer
+		if err := df.SaveWriter(&buf, goframe.SnapshotOption{Passphrase: "p", KDFIterations: 1000}); err != nil {
+			t.Fatalf("SaveWriter failed: %v", err)
+		}
+		// a crafted header asking for 2^32-1 iterations is rejected before deriving the key
+		crafted := buf.Bytes()
+		copy(crafted[len("GFSNAP")+2:], []byte{0xff, 0xff, 0xff, 0xff})
+		if _, err := goframe.LoadReader(bytes.NewReader(crafted), goframe.SnapshotOption{Passphrase: "p"}); err == nil || !strings.Contains(err.Error(), "invalid key derivation iterations") {
+			t.Errorf("Expected an invalid iterations error, got %v", err)
+		}
+	})
+}
+
+func TestSnapshotMetadata(t *testing.T) {
+	df := goframe.NewTypedDataFrame()
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("id", []string{"a", "b"})))
+	df.AddColumn(goframe.ConvertToAnyColumn(goframe.NewColumn("sales", []float64{1.5, 2.5})))
+	df.SetIndex("id")
+	df.SetColumnLevels("sales", "2024", "sales")
+
+	var buf bytes.Buffer
+	if err := df.SaveWriter(&buf); err != nil {
+		t.Fatalf("SaveWriter failed: %v", err)
+	}
+	loaded, err := goframe.LoadReader(&buf)
+	if err != nil {
+		t.Fatalf("LoadReader failed: %v", err)
+	}
+	if loaded.IndexName() != "id" || !loaded.IsTyped() {
+		t.Errorf("Expected a typed DataFrame indexed by 'id', got index %q and typed %v", loaded.IndexName(), loaded.IsTyped())
+	}
+	if v, err := loaded.At("b", "sales"); err != nil || v != 2.5 {
+		t.Errorf("Expected 2.5 at label b, got %v (%v)", v, err)
+	}
+	if levels := loaded.ColumnLevels("sales"); !reflect.DeepEqual(levels, []string{"2024", "sales"}) {
+		t.Errorf("Expected the column levels [2024 sales], got %v", levels)
+	}
+}
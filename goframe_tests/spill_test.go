@@ -0,0 +1,197 @@
+package goframe_test
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+// frameString prints the columns and values of a frame, NaN values compare equal unlike reflect.DeepEqual
+func frameString(df *goframe.DataFrame) string {
+	var b strings.Builder
+	for _, name := range df.ColumnNames() {
+		fmt.Fprintf(&b, "%s: %#v\n", name, df.Columns[name].Values())
+	}
+	return b.String()
+}
+
+func TestSpill(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	left := randomFrame(r, 500, "a", "b")
+
+	operations := map[string]func() (*goframe.DataFrame, error){
+		"SortValues": func() (*goframe.DataFrame, error) {
+			return left.SortValues([]string{"key", "a"}, true, false)
+		},
+		"SortValuesNullsFirst": func() (*goframe.DataFrame, error) {
+			return left.SortValuesWithOption([]string{"b"}, goframe.SortOption{NullsFirst: true})
+		},
+		"GroupbyAgg": func() (*goframe.DataFrame, error) {
+			return left.Groupby("key").Agg(map[string][]string{"a": {"sum", "mean", "first"}, "b": {"count", "size"}})
+		},
+		"GroupbySortKeys": func() (*goframe.DataFrame, error) {
+			return left.Groupby("key").SortKeys(false).Agg(map[string][]string{"a": {"sum", "first"}})
+		},
+		"GroupbyListSum": func() (*goframe.DataFrame, error) {
+			return left.Groupby([]string{"key", "b"}).Sum("a")
+		},
+		"GroupbyCount": func() (*goframe.DataFrame, error) {
+			return left.Groupby("key").Count("a")
+		},
+		"GroupbyCumSum": func() (*goframe.DataFrame, error) {
+			return left.Groupby("a").CumSum("key")
+		},
+		"GroupbyApply": func() (*goframe.DataFrame, error) {
+			return left.Groupby("key").Apply(func(group *goframe.DataFrame) *goframe.DataFrame { return group.Head(2) })
+		},
+		"GroupbyTransform": func() (*goframe.DataFrame, error) {
+			series, err := left.Groupby("key").Transform("a", func(values *goframe.Series) *goframe.Series { return values })
+			if err != nil {
+				return nil, err
+			}
+			return left.WithColumn("a", series)
+		},
+	}
+
+	expected := map[string]string{}
+	for name, operation := range operations {
+		result, err := operation()
+		if err != nil {
+			t.Fatalf("%s returned an error: %v", name, err)
+		}
+		expected[name] = frameString(result)
+	}
+
+	dir := t.TempDir()
+	goframe.SetSpillOption(goframe.SpillOption{MemoryBudget: 4096, Dir: dir})
+	defer goframe.SetSpillOption(goframe.SpillOption{})
+
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			result, err := operation()
+			if err != nil {
+				t.Fatalf("returned an error with spilling: %v", err)
+			}
+			if got := frameString(result); got != expected[name] {
+				t.Errorf("results differ with spilling\nexpected:\n%s\ngot:\n%s", expected[name], got)
+			}
+		})
+	}
+
+	// sorts remove their files, a spilled Groupby keeps them while it is reachable
+	dir = t.TempDir()
+	goframe.SetSpillOption(goframe.SpillOption{MemoryBudget: 4096, Dir: dir})
+	if _, err := left.SortValues([]string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected sorts to remove their spill files, found %d", len(entries))
+	}
+	grouped := left.Groupby("key")
+	if grouped.Err != nil || grouped.Groups != nil {
+		t.Fatalf("expected a spilled Groupby, got error %v and %d in-memory groups", grouped.Err, len(grouped.Groups))
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) == 0 {
+		t.Errorf("expected the spill files of the Groupby")
+	}
+	runtime.KeepAlive(grouped)
+
+	goframe.SetSpillOption(goframe.SpillOption{MemoryBudget: 4096, Dir: dir + "/missing"})
+	if _, err := left.SortValues([]string{"a"}); err == nil || !strings.Contains(err.Error(), "error creating spill file") {
+		t.Errorf("SortValues: expected an error for a missing spill directory, got %v", err)
+	}
+	if err := left.Groupby("key").Error(); err == nil || !strings.Contains(err.Error(), "error creating spill file") {
+		t.Errorf("Groupby: expected an error for a missing spill directory, got %v", err)
+	}
+}
+
+func TestSpillFileLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spills 200k rows")
+	}
+	n := 200_000
+	keys, values := make([]any, n), make([]any, n)
+	for i := range keys {
+		keys[i], values[i] = float64((i*7919)%1000), float64(i)
+	}
+	df, _ := goframe.FromColumns(goframe.NewColumn("key", keys), goframe.NewColumn("value", values))
+
+	// a budget of 1 KiB would split the data into thousands of chunks, one open file each
+	dir := t.TempDir()
+	goframe.SetSpillOption(goframe.SpillOption{MemoryBudget: 1024, Dir: dir})
+	defer goframe.SetSpillOption(goframe.SpillOption{})
+
+	sorted, err := df.SortValues([]string{"key", "value"})
+	if err != nil {
+		t.Fatalf("SortValues failed: %v", err)
+	}
+	if first, last := sorted.Columns["key"].Data[0], sorted.Columns["key"].Data[n-1]; first != 0.0 || last != 999.0 {
+		t.Errorf("expected keys from 0 to 999, got %v to %v", first, last)
+	}
+	grouped := df.Groupby("key")
+	if grouped.Err != nil || grouped.Groups != nil {
+		t.Fatalf("expected a spilled Groupby, got error %v and %d in-memory groups", grouped.Err, len(grouped.Groups))
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) > 256 {
+		t.Errorf("expected at most 256 spill files, found %d", len(entries))
+	}
+	sums, err := grouped.Sum("value")
+	if err != nil {
+		t.Fatalf("Sum failed: %v", err)
+	}
+	if sums.Nrows() != 1000 {
+		t.Errorf("expected 1000 groups, got %d", sums.Nrows())
+	}
+}
+
+func TestGroupbyLimits(t *testing.T) {
+	ids, categories, values := make([]any, 200), make([]any, 200), make([]any, 200)
+	for i := range ids {
+		ids[i], categories[i], values[i] = fmt.Sprintf("id-%d", i), i%3, float64(i)
+	}
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("id", ids))
+	df.AddColumn(goframe.NewColumn("category", categories))
+	df.AddColumn(goframe.NewColumn("value", values))
+
+	if err := df.Groupby("category", goframe.GroupbyOption{MaxGroups: 3, MaxMemory: 1 << 20}).Error(); err != nil {
+		t.Errorf("expected 3 groups to be within the limits, got %v", err)
+	}
+	if err := df.Groupby("id", goframe.GroupbyOption{MaxGroups: 100}).Error(); err == nil || !strings.Contains(err.Error(), "more than MaxGroups (100) groups") {
+		t.Errorf("expected a MaxGroups error, got %v", err)
+	}
+	if err := df.Groupby([]string{"category", "id"}, goframe.GroupbyOption{MaxGroups: 100}).Error(); err == nil {
+		t.Errorf("expected a MaxGroups error for a list of keys")
+	}
+	if err := df.Groupby("category", goframe.GroupbyOption{MaxMemory: 1024}).Error(); err == nil || !strings.Contains(err.Error(), "more than MaxMemory (1024)") {
+		t.Errorf("expected a MaxMemory error, got %v", err)
+	}
+
+	expected, err := df.Groupby("id").Sum("value")
+	if err != nil {
+		t.Fatalf("Sum failed: %v", err)
+	}
+	dir := t.TempDir()
+	goframe.SetSpillOption(goframe.SpillOption{Dir: dir})
+	defer goframe.SetSpillOption(goframe.SpillOption{})
+	grouped := df.Groupby("id", goframe.GroupbyOption{MaxMemory: 4096, Spill: true})
+	if grouped.Err != nil || grouped.Groups != nil {
+		t.Fatalf("expected a spilled Groupby, got error %v and %d in-memory groups", grouped.Err, len(grouped.Groups))
+	}
+	got, err := grouped.Sum("value")
+	if err != nil {
+		t.Fatalf("Sum of the spilled Groupby failed: %v", err)
+	}
+	if frameString(got) != frameString(expected) {
+		t.Errorf("results differ with the spill fallback\nexpected:\n%s\ngot:\n%s", frameString(expected), frameString(got))
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) == 0 {
+		t.Errorf("expected the spill files in the SetSpillOption directory")
+	}
+	runtime.KeepAlive(grouped)
+}
@@ -223,16 +223,19 @@ func TestDialect_QuoteIdentifier(t *testing.T) {
 		{"SQLite simple", &dataframe.SQLiteDialect{}, "users", `"users"`},
 		{"SQLite with space", &dataframe.SQLiteDialect{}, "user name", `"user name"`},
 		{"SQLite with underscore", &dataframe.SQLiteDialect{}, "user_id", `"user_id"`},
+		{"SQLite with quote", &dataframe.SQLiteDialect{}, `a"b`, `"a""b"`},
 
 		// PostgreSQL
 		{"PostgreSQL simple", &dataframe.PostgresDialect{}, "users", `"users"`},
 		{"PostgreSQL with space", &dataframe.PostgresDialect{}, "user name", `"user name"`},
 		{"PostgreSQL with underscore", &dataframe.PostgresDialect{}, "user_id", `"user_id"`},
+		{"PostgreSQL with quote", &dataframe.PostgresDialect{}, `a"b`, `"a""b"`},
 
 		// MySQL
 		{"MySQL simple", &dataframe.MySQLDialect{}, "users", "`users`"},
 		{"MySQL with space", &dataframe.MySQLDialect{}, "user name", "`user name`"},
 		{"MySQL with underscore", &dataframe.MySQLDialect{}, "user_id", "`user_id`"},
+		{"MySQL with backtick", &dataframe.MySQLDialect{}, "a`b", "`a``b`"},
 	}
 
 	for _, tt := range tests {
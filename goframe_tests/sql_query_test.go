@@ -0,0 +1,155 @@
+package goframe_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/kishyassin/goframe"
+)
+
+func TestQueryBuilder_Build(t *testing.T) {
+	query := goframe.Table("users").
+		Select("id", "name").
+		Where("age", ">=", 18).
+		Where("country", "in", []string{"DE", "FR"}).
+		Where("deleted_at", "IS NULL").
+		OrderBy("name").
+		OrderBy("id", true).
+		Limit(10).
+		Offset(20)
+
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{"sqlite", `SELECT "id", "name" FROM "users" WHERE "age" >= ? AND "country" IN (?, ?) AND "deleted_at" IS NULL ORDER BY "name", "id" DESC LIMIT 10 OFFSET 20`},
+		{"postgres", `SELECT "id", "name" FROM "users" WHERE "age" >= $1 AND "country" IN ($2, $3) AND "deleted_at" IS NULL ORDER BY "name", "id" DESC LIMIT 10 OFFSET 20`},
+		{"mysql", "SELECT `id`, `name` FROM `users` WHERE `age` >= ? AND `country` IN (?, ?) AND `deleted_at` IS NULL ORDER BY `name`, `id` DESC LIMIT 10 OFFSET 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			sqlText, args, err := query.Build(tt.dialect)
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			if sqlText != tt.expected {
+				t.Errorf("Build() = %s, want %s", sqlText, tt.expected)
+			}
+			if !reflect.DeepEqual(args, []any{18, "DE", "FR"}) {
+				t.Errorf("Unexpected args: %v", args)
+			}
+		})
+	}
+
+	t.Run("SelectAllQualified", func(t *testing.T) {
+		sqlText, args, err := goframe.Table("sales.orders").Offset(5).Build("sqlite")
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		if sqlText != `SELECT * FROM "sales"."orders" LIMIT -1 OFFSET 5` || len(args) != 0 {
+			t.Errorf("Unexpected query: %s %v", sqlText, args)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		invalid := map[string]*goframe.QueryBuilder{
+			"empty table":      goframe.Table(""),
+			"unknown operator": goframe.Table("users").Where("age", "~", 1),
+			"missing value":    goframe.Table("users").Where("age", ">"),
+			"value for null":   goframe.Table("users").Where("age", "IS NULL", 1),
+			"in without slice": goframe.Table("users").Where("age", "IN", 1),
+			"empty in":         goframe.Table("users").Where("age", "IN", []int{}),
+			"negative limit":   goframe.Table("users").Limit(-1),
+		}
+		for name, query := range invalid {
+			if _, _, err := query.Build("sqlite"); err == nil {
+				t.Errorf("%s: expected error, got nil", name)
+			}
+		}
+		if _, _, err := goframe.Table("users").Build("oracle"); err == nil {
+			t.Errorf("Expected error for unknown dialect, got nil")
+		}
+		if _, _, err := goframe.Table("users").Build(""); err == nil {
+			t.Errorf("Expected error for missing dialect, got nil")
+		}
+	})
+}
+
+func TestFromSQLQuery(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	rows := sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("id").OfType("INT", int64(0)),
+		sqlmock.NewColumn("name").OfType("TEXT", ""),
+	).AddRow(int64(2), "Bob")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id", "name" FROM "users" WHERE "name" = $1`)).
+		WithArgs("Bob").
+		WillReturnRows(rows)
+
+	df, err := goframe.FromSQLQuery(db, goframe.Table("users").Select("id", "name").Where("name", "=", "Bob"), "postgres")
+	if err != nil {
+		t.Fatalf("FromSQLQuery failed: %v", err)
+	}
+	if df.Nrows() != 1 {
+		t.Errorf("Expected 1 row, got %d", df.Nrows())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLazyFromSQLTable(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	lazy := goframe.LazyFromSQLTable(db, "emp", "postgres").
+		Filter("100 < salary && dept in ('IT', 'Ops')").
+		Filter("salary + age > 140").
+		Filter("age != null").
+		Select("dept", "salary")
+
+	plan, err := lazy.Explain()
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	expectedPlan := `sql SELECT "dept", "salary", "age" FROM "emp" WHERE "salary" > $1 AND "dept" IN ($2, $3) AND "age" IS NOT NULL [100 IT Ops]` +
+		"\nfilter salary + age > 140\nselect [dept salary]"
+	if plan != expectedPlan {
+		t.Errorf("expected the filters on literals to be pushed into the query, got\n%s", plan)
+	}
+
+	rows := sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("dept").OfType("TEXT", ""),
+		sqlmock.NewColumn("salary").OfType("INT", int64(0)),
+		sqlmock.NewColumn("age").OfType("INT", int64(0)),
+	).AddRow("IT", int64(120), int64(30)).AddRow("Ops", int64(110), int64(28))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "dept", "salary", "age" FROM "emp" WHERE "salary" > $1 AND "dept" IN ($2, $3) AND "age" IS NOT NULL`)).
+		WithArgs(int64(100), "IT", "Ops").
+		WillReturnRows(rows)
+
+	df, err := lazy.Collect()
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if names := df.ColumnNames(); !reflect.DeepEqual(names, []string{"dept", "salary"}) {
+		t.Errorf("expected the selected columns only, got %v", names)
+	}
+	if depts := df.Columns["dept"].Data; !reflect.DeepEqual(depts, []any{"IT"}) {
+		t.Errorf("expected the remaining filter to run on the rows read, got %v", depts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+
+	// without a pushable filter every column is read
+	plan, _ = goframe.LazyFromSQLTable(db, "emp", "sqlite").Filter("salary * 2 > 100").Explain()
+	if plan != "sql SELECT * FROM \"emp\"\nfilter salary * 2 > 100" {
+		t.Errorf("unexpected plan\n%s", plan)
+	}
+}
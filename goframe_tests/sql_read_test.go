@@ -1542,3 +1542,98 @@ func TestFromSQLContext_Direct(t *testing.T) {
 		})
 	}
 }
+
+// TestFromSQL_TimeTypes tests reading temporal columns back symmetrically with ToSQL
+func TestFromSQL_TimeTypes(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	// the driver attaches its own location to values stored without an offset
+	local := time.FixedZone("EST", -5*3600)
+	rows := sqlmock.NewRowsWithColumnDefinition(
+		sqlmock.NewColumn("day").OfType("DATE", time.Time{}),
+		sqlmock.NewColumn("logged").OfType("TIMESTAMP", time.Time{}),
+		sqlmock.NewColumn("at").OfType("TIMESTAMPTZ", time.Time{}),
+		sqlmock.NewColumn("text_day").OfType("TEXT", ""),
+	).AddRow(
+		time.Date(2024, 3, 10, 0, 0, 0, 0, local),
+		time.Date(2024, 3, 10, 22, 30, 0, 0, local),
+		time.Date(2024, 3, 10, 17, 30, 0, 0, local),
+		"2024-03-10",
+	)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	df, err := goframe.FromSQL(db, "SELECT * FROM events", nil, goframe.SQLReadOption{
+		TimeTypes: map[string]string{"day": "date", "logged": "timestamp", "at": "timestamptz", "text_day": "date"},
+		TimeUTC:   true,
+	})
+	if err != nil {
+		t.Fatalf("FromSQL failed: %v", err)
+	}
+
+	expected := map[string]time.Time{
+		"day":      time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		"logged":   time.Date(2024, 3, 10, 22, 30, 0, 0, time.UTC),
+		"at":       time.Date(2024, 3, 10, 22, 30, 0, 0, time.UTC),
+		"text_day": time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+	}
+	for name, want := range expected {
+		col, _ := df.Select(name)
+		got, ok := col.Data[0].(time.Time)
+		if !ok || !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("Column '%s': expected %v, got %v", name, want, col.Data[0])
+		}
+	}
+
+	_, err = goframe.FromSQL(db, "SELECT 1", nil, goframe.SQLReadOption{TimeTypes: map[string]string{"day": "time"}})
+	if err == nil || !strings.Contains(err.Error(), "invalid TimeTypes") {
+		t.Errorf("Expected invalid TimeTypes error, got %v", err)
+	}
+}
+
+// TestFromSQL_DateLayouts tests per column layouts, custom layouts and the date location
+func TestFromSQL_DateLayouts(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	newRows := func() *sqlmock.Rows {
+		return sqlmock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("created").OfType("TEXT", ""),
+			sqlmock.NewColumn("shipped").OfType("TEXT", ""),
+		).AddRow("25/12/2023 18:45", "2023.12.26")
+	}
+
+	mock.ExpectQuery("SELECT").WillReturnRows(newRows())
+	df, err := goframe.FromSQL(db, "SELECT * FROM orders", nil, goframe.SQLReadOption{
+		DateLayouts:       map[string]string{"created": "02/01/2006 15:04"},
+		ParseDates:        []string{"shipped"},
+		CustomDateLayouts: []string{"2006.01.02"},
+		DateLocation:      tokyo,
+	})
+	if err != nil {
+		t.Fatalf("FromSQL failed: %v", err)
+	}
+
+	created, _ := df.Select("created")
+	if want := time.Date(2023, 12, 25, 18, 45, 0, 0, tokyo); !want.Equal(created.Data[0].(time.Time)) {
+		t.Errorf("Expected created %v, got %v", want, created.Data[0])
+	}
+	shipped, _ := df.Select("shipped")
+	if want := time.Date(2023, 12, 26, 0, 0, 0, 0, tokyo); !want.Equal(shipped.Data[0].(time.Time)) {
+		t.Errorf("Expected shipped %v, got %v", want, shipped.Data[0])
+	}
+
+	// a per column layout is strict
+	mock.ExpectQuery("SELECT").WillReturnRows(newRows())
+	_, err = goframe.FromSQL(db, "SELECT * FROM orders", nil, goframe.SQLReadOption{
+		DateLayouts: map[string]string{"shipped": "02/01/2006"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "with layout 02/01/2006") {
+		t.Errorf("Expected layout error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
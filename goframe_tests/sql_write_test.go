@@ -3,6 +3,7 @@ package goframe_test
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,7 +39,7 @@ func TestToSQL_CreateAndInsert(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT (3 rows, 3 columns = 9 values)
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 3))
 
 			// Mock transaction Commit
@@ -83,7 +84,7 @@ func TestToSQL_IfExistsFail(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -147,7 +148,7 @@ func TestToSQL_IfExistsReplace(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -190,7 +191,7 @@ func TestToSQL_IfExistsAppend(t *testing.T) {
 				WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("test_table"))
 
 			// Mock INSERT (no CREATE TABLE for append)
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -281,7 +282,7 @@ func TestToSQL_WithNilValues(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT (3 rows with nil values handled via sql.Null* types)
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 3))
 
 			// Mock Commit
@@ -334,7 +335,7 @@ func TestToSQL_AllTypes(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT (2 rows, 6 columns each)
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -381,7 +382,7 @@ func TestToSQL_CustomTypeMap(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -439,9 +440,10 @@ func TestToSQL_BatchInsert(t *testing.T) {
 			mock.ExpectExec("CREATE TABLE").
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
-			// Mock 5 batch INSERTs (5000 rows / 1000 batch size = 5 batches)
+			// Mock 5 batch INSERTs (5000 rows / 1000 batch size = 5 batches) sharing one prepared statement
+			insert := mock.ExpectPrepare("INSERT INTO")
 			for i := 0; i < 5; i++ {
-				mock.ExpectExec("INSERT INTO").
+				insert.ExpectExec().
 					WillReturnResult(sqlmock.NewResult(0, batchSize))
 			}
 
@@ -488,7 +490,7 @@ func TestToSQLContext(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -536,7 +538,7 @@ func TestToSQLTx(t *testing.T) {
 			mock.ExpectExec("CREATE TABLE").
 				WillReturnResult(sqlmock.NewResult(0, 0))
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock operations for second DataFrame (table2)
@@ -547,7 +549,7 @@ func TestToSQLTx(t *testing.T) {
 			mock.ExpectExec("CREATE TABLE").
 				WillReturnResult(sqlmock.NewResult(0, 0))
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -608,7 +610,7 @@ func TestToSQLTx_Rollback(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Rollback
@@ -694,7 +696,7 @@ func TestToSQL_ExplicitDialect(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnResult(sqlmock.NewResult(0, 2))
 
 			// Mock Commit
@@ -863,7 +865,7 @@ func TestToSQL_ErrorInsertFails(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock INSERT failure
-			mock.ExpectExec("INSERT INTO").
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
 				WillReturnError(sql.ErrTxDone)
 
 			// Mock Rollback
@@ -924,10 +926,11 @@ func TestToSQL_BatchSizeOne(t *testing.T) {
 			mock.ExpectExec("CREATE TABLE").
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
-			// Mock 3 separate INSERTs (batch size = 1)
-			mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 1))
-			mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 1))
-			mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 1))
+			// Mock 3 separate INSERTs (batch size = 1) sharing one prepared statement
+			insert := mock.ExpectPrepare("INSERT INTO")
+			insert.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+			insert.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+			insert.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
 
 			// Mock Commit
 			mock.ExpectCommit()
@@ -976,7 +979,7 @@ func TestToSQL_BatchSizeExactMatch(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock single INSERT (all 10 rows in one batch)
-			mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 10))
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 10))
 
 			// Mock Commit
 			mock.ExpectCommit()
@@ -1021,7 +1024,7 @@ func TestToSQL_BatchSizeLargerThanData(t *testing.T) {
 				WillReturnResult(sqlmock.NewResult(0, 0))
 
 			// Mock single INSERT (all 5 rows)
-			mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(0, 5))
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 5))
 
 			// Mock Commit
 			mock.ExpectCommit()
@@ -1042,3 +1045,147 @@ func TestToSQL_BatchSizeLargerThanData(t *testing.T) {
 		})
 	}
 }
+
+// TestToSQL_ColumnOrder tests that CREATE TABLE and INSERT keep the DataFrame column order
+func TestToSQL_ColumnOrder(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	df := dataframe.NewDataFrame()
+	df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("zeta", []int{1})))
+	df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("alpha", []string{"a"})))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM (.+)").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectExec(`CREATE TABLE "t" \("zeta" .+, "alpha" .+\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare(`INSERT INTO "t" \("zeta", "alpha"\)`).ExpectExec().
+		WithArgs(1, "a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := df.ToSQL(db, "t", dataframe.SQLWriteOption{Dialect: "sqlite"}); err != nil {
+		t.Fatalf("ToSQL failed: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+// TestToSQL_PreparedStatementReuse tests that the INSERT is prepared once per batch shape
+func TestToSQL_PreparedStatementReuse(t *testing.T) {
+	for _, dialect := range getDialects() {
+		t.Run(dialect.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			defer db.Close()
+
+			// 5 rows with batch size 2: two full batches and a remainder of 1 row
+			df := dataframe.NewDataFrame()
+			df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("id", []int{1, 2, 3, 4, 5})))
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT (.+) FROM (.+)").
+				WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			mock.ExpectExec("CREATE TABLE").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			full := mock.ExpectPrepare(`INSERT INTO .+ VALUES \(.+\), \(.+\)$`).WillBeClosed()
+			full.ExpectExec().WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+			full.ExpectExec().WithArgs(3, 4).WillReturnResult(sqlmock.NewResult(0, 2))
+			remainder := mock.ExpectPrepare(`INSERT INTO .+ VALUES \([^()]+\)$`).WillBeClosed()
+			remainder.ExpectExec().WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			err := df.ToSQL(db, "test_table", dataframe.SQLWriteOption{
+				Dialect:   dialect.name,
+				BatchSize: 2,
+			})
+			if err != nil {
+				t.Fatalf("ToSQL failed: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+// TestToSQL_TimeTypes tests per column temporal types and UTC normalization
+func TestToSQL_TimeTypes(t *testing.T) {
+	paris := time.FixedZone("CET", 3600)
+	day := time.Date(2024, 3, 10, 23, 30, 0, 0, paris)
+
+	cases := []struct {
+		dialect   string
+		createSQL string
+	}{
+		{"sqlite", `CREATE TABLE "events" \("day" DATE, "at" TIMESTAMP, "logged" TIMESTAMP\)`},
+		{"postgres", `CREATE TABLE "events" \("day" DATE, "at" TIMESTAMPTZ, "logged" TIMESTAMP\)`},
+		{"mysql", "CREATE TABLE `events` \\(`day` DATE, `at` TIMESTAMP, `logged` DATETIME\\)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.dialect, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			defer db.Close()
+
+			df := dataframe.NewDataFrame()
+			df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("day", []time.Time{day})))
+			df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("at", []time.Time{day})))
+			df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("logged", []time.Time{day})))
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT (.+) FROM (.+)").
+				WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			mock.ExpectExec(tc.createSQL).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			// every value is normalized to UTC, the date is truncated to the UTC day
+			mock.ExpectPrepare("INSERT INTO").ExpectExec().
+				WithArgs(
+					time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+					time.Date(2024, 3, 10, 22, 30, 0, 0, time.UTC),
+					time.Date(2024, 3, 10, 22, 30, 0, 0, time.UTC),
+				).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			err := df.ToSQL(db, "events", dataframe.SQLWriteOption{
+				Dialect:   tc.dialect,
+				TimeTypes: map[string]string{"day": "date", "at": "timestamptz", "logged": "timestamp"},
+				TimeUTC:   true,
+			})
+			if err != nil {
+				t.Fatalf("ToSQL failed: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unfulfilled expectations: %v", err)
+			}
+		})
+	}
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		df := dataframe.NewDataFrame()
+		df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("day", []time.Time{day})))
+		df.AddColumn(dataframe.ConvertToAnyColumn(dataframe.NewColumn("name", []string{"a"})))
+
+		invalid := []map[string]string{
+			{"day": "datetime"},
+			{"missing": "date"},
+			{"name": "date"},
+		}
+		for _, timeTypes := range invalid {
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			err := df.ToSQL(db, "events", dataframe.SQLWriteOption{Dialect: "sqlite", TimeTypes: timeTypes})
+			if err == nil || !strings.Contains(err.Error(), "TimeTypes") {
+				t.Errorf("Expected TimeTypes error for %v, got %v", timeTypes, err)
+			}
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package goframe_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestStringAccessor(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn("email", []any{"  Ann@Example.com ", "bob@test.org", nil, 42, "Ünal@Test.org"}))
+	email := df.Col("email")
+
+	tests := []struct {
+		name     string
+		series   *goframe.Series
+		expected []any
+	}{
+		{"Lower", email.Str().Lower(), []any{"  ann@example.com ", "bob@test.org", nil, nil, "ünal@test.org"}},
+		{"Upper", email.Str().Upper(), []any{"  ANN@EXAMPLE.COM ", "BOB@TEST.ORG", nil, nil, "ÜNAL@TEST.ORG"}},
+		{"StripLower", email.Str().Strip().Str().Lower(), []any{"ann@example.com", "bob@test.org", nil, nil, "ünal@test.org"}},
+		{"Len", email.Str().Len(), []any{18, 12, nil, nil, 13}},
+		{"Contains", email.Str().Contains("test"), []any{false, true, false, false, false}},
+		{"StartsWith", email.Str().StartsWith("bob"), []any{false, true, false, false, false}},
+		{"EndsWith", email.Str().EndsWith(".org"), []any{false, true, false, false, true}},
+		{"Replace", email.Str().Replace(".org", ".com"), []any{"  Ann@Example.com ", "bob@test.com", nil, nil, "Ünal@Test.com"}},
+		{"Split", email.Str().Strip().Str().Split("@"), []any{[]string{"Ann", "Example.com"}, []string{"bob", "test.org"}, nil, nil, []string{"Ünal", "Test.org"}}},
+		{"Match", email.Str().Match(`(?i)^[a-z]+@test\.org$`), []any{false, true, false, false, false}},
+		{"ExtractGroup", email.Str().Extract(`@(\w+)\.`), []any{"Example", "test", nil, nil, "Test"}},
+		{"ExtractMatch", email.Str().Extract(`\.[a-z]+$`), []any{nil, ".org", nil, nil, ".org"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.series.Err() != nil {
+				t.Fatalf("unexpected error: %v", tt.series.Err())
+			}
+			if tt.series.Name != "email" {
+				t.Errorf("expected the series to keep its name, got %q", tt.series.Name)
+			}
+			if !reflect.DeepEqual(tt.series.Data, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, tt.series.Data)
+			}
+		})
+	}
+
+	// columns have the same methods, and the results are added back with WithColumn
+	col, _ := df.Select("email")
+	result, err := df.WithColumn("domain", col.Str().Extract(`@(.+)$`).Str().Strip())
+	if err != nil {
+		t.Fatalf("WithColumn failed: %v", err)
+	}
+	if got := result.Columns["domain"].Data; !reflect.DeepEqual(got, []any{"Example.com", "test.org", nil, nil, "Test.org"}) {
+		t.Errorf("unexpected domains %v", got)
+	}
+	if got := goframe.NewColumn("codes", []string{"a1", "b2"}).Str().Upper().Data; !reflect.DeepEqual(got, []any{"A1", "B2"}) {
+		t.Errorf("expected typed columns to work, got %v", got)
+	}
+
+	for _, series := range []*goframe.Series{email.Str().Match("("), email.Str().Extract("[")} {
+		if series.Err() == nil || !strings.Contains(series.Err().Error(), "invalid pattern") {
+			t.Errorf("expected an invalid pattern error, got %v", series.Err())
+		}
+	}
+	if _, err := df.WithColumn("x", email.Str().Match("(").Str().Lower()); err == nil {
+		t.Error("expected the error to be carried through the chain")
+	}
+}
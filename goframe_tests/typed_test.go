@@ -0,0 +1,180 @@
+package goframe_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	goframe "github.com/kishyassin/goframe"
+)
+
+func TestTypedDataFrame(t *testing.T) {
+	df := goframe.NewTypedDataFrame()
+	df.AddColumn(&goframe.Column[any]{Name: "ints", Data: []any{1, int32(2), nil, int64(4)}})
+	df.AddColumn(&goframe.Column[any]{Name: "mixed_numbers", Data: []any{1, 2.5, 3, 4}})
+	df.AddColumn(&goframe.Column[any]{Name: "names", Data: []any{"a", "b", nil, "d"}})
+	df.AddColumn(&goframe.Column[any]{Name: "flags", Data: []any{true, false, true, false}})
+	df.AddColumn(&goframe.Column[any]{Name: "dates", Data: []any{time.Unix(0, 0), time.Unix(1, 0), time.Unix(2, 0), time.Unix(3, 0)}})
+	df.AddColumn(&goframe.Column[any]{Name: "objects", Data: []any{"a", 1, nil, true}})
+
+	t.Run("DTypes", func(t *testing.T) {
+		expected := map[string]string{
+			"ints": "int64", "mixed_numbers": "float64", "names": "string",
+			"flags": "bool", "dates": "time", "objects": "any",
+		}
+		for name, want := range expected {
+			if got := df.Columns[name].DType(); got != want {
+				t.Errorf("Column %s: expected dtype %s, got %s", name, want, got)
+			}
+		}
+		if !df.IsTyped() {
+			t.Errorf("Expected a typed DataFrame")
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		ints := df.Columns["ints"]
+		if ints.Len() != 4 {
+			t.Fatalf("Expected 4 rows, got %d", ints.Len())
+		}
+		expected := []any{int64(1), int64(2), nil, int64(4)}
+		for i, v := range ints.Values() {
+			if v != expected[i] {
+				t.Errorf("Index %d: expected %v (%T), got %v (%T)", i, expected[i], expected[i], v, v)
+			}
+		}
+		if !ints.IsNull(2) || ints.IsNull(0) {
+			t.Errorf("Expected only row 2 to be null")
+		}
+		if v, _ := df.Columns["names"].At(3); v != "d" {
+			t.Errorf("Expected d, got %v", v)
+		}
+
+		native, ok := goframe.NativeValues[int64](ints)
+		if !ok || len(native) != 4 || native[3] != 4 {
+			t.Errorf("Expected native int64 slice, got %v (%v)", native, ok)
+		}
+		if _, ok := goframe.NativeValues[string](ints); ok {
+			t.Errorf("Expected NativeValues to fail for a different type")
+		}
+	})
+
+	t.Run("Aggregations", func(t *testing.T) {
+		numbers, _ := df.MultiSelect("mixed_numbers")
+		typed := numbers.ToTyped()
+		if typed.Columns["mixed_numbers"].DType() != "float64" {
+			t.Fatalf("Expected ToTyped to store the column natively")
+		}
+		for _, agg := range []func(*goframe.DataFrame, ...goframe.AggOption) (map[string]float64, error){
+			(*goframe.DataFrame).Sum, (*goframe.DataFrame).Mean, (*goframe.DataFrame).Min, (*goframe.DataFrame).Max,
+		} {
+			want, err := agg(numbers)
+			if err != nil {
+				t.Fatalf("Boxed aggregation failed: %v", err)
+			}
+			got, err := agg(typed)
+			if err != nil {
+				t.Fatalf("Typed aggregation failed: %v", err)
+			}
+			if got["mixed_numbers"] != want["mixed_numbers"] {
+				t.Errorf("Typed result %v differs from boxed result %v", got, want)
+			}
+		}
+	})
+
+	t.Run("Mutations", func(t *testing.T) {
+		frame := goframe.NewTypedDataFrame()
+		frame.AppendRow(frame, map[string]any{"x": 1, "y": "a"})
+		frame.AppendRow(frame, map[string]any{"x": 2})
+		if frame.Columns["x"].DType() != "int64" || frame.Columns["y"].DType() != "string" {
+			t.Fatalf("Expected appended columns to be typed, got %s and %s", frame.Columns["x"].DType(), frame.Columns["y"].DType())
+		}
+
+		frame.AppendRow(frame, map[string]any{"x": 2.5, "y": "c"})
+		if frame.Columns["x"].DType() != "float64" {
+			t.Errorf("Expected x to be promoted to float64, got %s", frame.Columns["x"].DType())
+		}
+
+		frame.FillNa("b")
+		if v, _ := frame.Columns["y"].At(1); v != "b" || frame.Columns["y"].DType() != "string" {
+			t.Errorf("Expected FillNa to keep the column typed, got %v (%s)", v, frame.Columns["y"].DType())
+		}
+
+		if err := frame.DropRow(0); err != nil {
+			t.Fatalf("DropRow failed: %v", err)
+		}
+		if frame.Nrows() != 2 || frame.Columns["x"].DType() != "float64" {
+			t.Errorf("Expected 2 typed rows after DropRow, got %d (%s)", frame.Nrows(), frame.Columns["x"].DType())
+		}
+	})
+}
+
+func TestColumnAs(t *testing.T) {
+	df := goframe.NewDataFrame()
+	df.AddColumn(goframe.NewColumn[any]("id", []any{1, 2, 3}))
+	df.AddColumn(goframe.NewColumn[any]("price", []any{1.5, 2.0, 3.25}))
+	df.AddColumn(goframe.NewColumn[any]("name", []any{"a", nil, "c"}))
+	df.AddColumn(goframe.NewColumn[any]("signed", []any{1, -2, 3}))
+
+	for _, frame := range []*goframe.DataFrame{df, df.ToTyped()} {
+		ids, err := goframe.ColumnAs[float64](frame, "id")
+		if err != nil || !reflect.DeepEqual(ids, []float64{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (error %v)", ids, err)
+		}
+		prices, err := goframe.ColumnAs[float64](frame, "price")
+		if err != nil || !reflect.DeepEqual(prices, []float64{1.5, 2.0, 3.25}) {
+			t.Errorf("expected [1.5 2 3.25], got %v (error %v)", prices, err)
+		}
+		// the result is a copy, writing to it does not change the column
+		prices[0] = 100
+		if value, _ := frame.Columns["price"].At(0); value != 1.5 {
+			t.Errorf("expected the column to be unchanged, got %v", value)
+		}
+		small, err := goframe.ColumnAs[int8](frame, "id")
+		if err != nil || !reflect.DeepEqual(small, []int8{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (error %v)", small, err)
+		}
+		names, err := goframe.ColumnAs[any](frame, "name")
+		if err != nil || !reflect.DeepEqual(names, []any{"a", nil, "c"}) {
+			t.Errorf("expected [a <nil> c], got %v (error %v)", names, err)
+		}
+	}
+
+	errors := map[string]func() error{
+		"column 'price': cannot convert value 1.5 of type float64 at row 0 to int": func() error {
+			_, err := goframe.ColumnAs[int](df, "price")
+			return err
+		},
+		"cannot convert nil at row 1 to string": func() error {
+			_, err := goframe.ColumnAs[string](df, "name")
+			return err
+		},
+		"cannot convert value -2 of type int at row 1 to uint": func() error {
+			_, err := goframe.ColumnAs[uint](df, "signed")
+			return err
+		},
+		"cannot convert value a of type string at row 0 to float64": func() error {
+			_, err := goframe.ColumnAs[float64](df, "name")
+			return err
+		},
+		"column 'missing' does not exist": func() error {
+			_, err := goframe.ColumnAs[int](df, "missing")
+			return err
+		},
+		"series 'flags': cannot convert value 1 of type int at row 1 to bool": func() error {
+			_, err := goframe.SeriesAs[bool](goframe.NewSeries("flags", []any{true, 1}))
+			return err
+		},
+	}
+	for message, fn := range errors {
+		if err := fn(); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("expected an error containing %q, got %v", message, err)
+		}
+	}
+
+	times, err := goframe.SeriesAs[time.Time](goframe.NewSeries("t", []any{time.Unix(0, 0).UTC()}))
+	if err != nil || !times[0].Equal(time.Unix(0, 0)) {
+		t.Errorf("expected the epoch, got %v (error %v)", times, err)
+	}
+}
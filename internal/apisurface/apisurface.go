@@ -0,0 +1,205 @@
+// Package apisurface lists the exported API of a package, one declaration per line, so that
+// changes to the public surface show up in review as a diff of a golden file (api/dataframe.txt).
+package apisurface
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Surface returns the sorted exported declarations of the package in dir: constants, variables,
+// types with their exported fields and interface methods, functions and methods. Parameter names are
+// left out since renaming them is not a breaking change. Declarations whose doc comment has a
+// "Deprecated:" or "Experimental:" paragraph are suffixed with "// deprecated" or "// experimental".
+//
+// Parameters:
+//   - dir: The directory of the package.
+//
+// Returns:
+//   - []string: One line per exported declaration.
+//   - error: An error if the package cannot be parsed.
+func Surface(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading package directory: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	var lines []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if line, ok := funcLine(fset, d); ok {
+					lines = append(lines, line+status(d.Doc))
+				}
+			case *ast.GenDecl:
+				lines = append(lines, genLines(fset, d)...)
+			}
+		}
+	}
+	slices.Sort(lines)
+	return lines, nil
+}
+
+// funcLine returns the line of an exported function or of an exported method of an exported type
+func funcLine(fset *token.FileSet, decl *ast.FuncDecl) (string, bool) {
+	if !decl.Name.IsExported() {
+		return "", false
+	}
+	if decl.Recv == nil {
+		return "func " + decl.Name.Name + typeParams(fset, decl.Type.TypeParams) + signature(fset, decl.Type), true
+	}
+
+	recv := expr(fset, decl.Recv.List[0].Type)
+	base := strings.TrimPrefix(recv, "*")
+	if i := strings.Index(base, "["); i >= 0 {
+		base = base[:i]
+	}
+	if !ast.IsExported(base) {
+		return "", false
+	}
+	return fmt.Sprintf("method (%s) %s%s", recv, decl.Name.Name, signature(fset, decl.Type)), true
+}
+
+// genLines returns the lines of the exported constants, variables and types of a declaration
+func genLines(fset *token.FileSet, decl *ast.GenDecl) []string {
+	var lines []string
+	for _, spec := range decl.Specs {
+		switch s := spec.(type) {
+		case *ast.ValueSpec:
+			doc := s.Doc
+			if doc == nil {
+				doc = decl.Doc
+			}
+			for _, name := range s.Names {
+				if !name.IsExported() {
+					continue
+				}
+				line := decl.Tok.String() + " " + name.Name
+				if s.Type != nil {
+					line += " " + expr(fset, s.Type)
+				}
+				lines = append(lines, line+status(doc))
+			}
+		case *ast.TypeSpec:
+			if !s.Name.IsExported() {
+				continue
+			}
+			doc := s.Doc
+			if doc == nil {
+				doc = decl.Doc
+			}
+			name := s.Name.Name
+			switch t := s.Type.(type) {
+			case *ast.StructType:
+				lines = append(lines, "type "+name+typeParams(fset, s.TypeParams)+" struct"+status(doc))
+				for _, field := range t.Fields.List {
+					for _, fieldName := range field.Names {
+						if fieldName.IsExported() {
+							lines = append(lines, fmt.Sprintf("field %s.%s %s", name, fieldName.Name, expr(fset, field.Type)))
+						}
+					}
+				}
+			case *ast.InterfaceType:
+				lines = append(lines, "type "+name+typeParams(fset, s.TypeParams)+" interface"+status(doc))
+				for _, method := range t.Methods.List {
+					if fn, ok := method.Type.(*ast.FuncType); ok && len(method.Names) > 0 && method.Names[0].IsExported() {
+						lines = append(lines, fmt.Sprintf("method (%s) %s%s", name, method.Names[0].Name, signature(fset, fn)))
+					}
+				}
+			default:
+				alias := ""
+				if s.Assign.IsValid() {
+					alias = "= "
+				}
+				lines = append(lines, "type "+name+typeParams(fset, s.TypeParams)+" "+alias+expr(fset, s.Type)+status(doc))
+			}
+		}
+	}
+	return lines
+}
+
+// signature prints the parameter and result types of a function
+func signature(fset *token.FileSet, fn *ast.FuncType) string {
+	params := fieldTypes(fset, fn.Params)
+	results := fieldTypes(fset, fn.Results)
+	line := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		line += " " + results[0]
+	default:
+		line += " (" + strings.Join(results, ", ") + ")"
+	}
+	return line
+}
+
+// typeParams prints a type parameter list, with the names since the type arguments refer to them
+func typeParams(fset *token.FileSet, list *ast.FieldList) string {
+	if list == nil || len(list.List) == 0 {
+		return ""
+	}
+	var params []string
+	for _, field := range list.List {
+		var names []string
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+expr(fset, field.Type))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// fieldTypes returns the type of every parameter or result of a list, repeated for grouped names
+func fieldTypes(fset *token.FileSet, list *ast.FieldList) []string {
+	if list == nil {
+		return nil
+	}
+	var types []string
+	for _, field := range list.List {
+		for range max(len(field.Names), 1) {
+			types = append(types, expr(fset, field.Type))
+		}
+	}
+	return types
+}
+
+// expr prints an expression on a single line
+func expr(fset *token.FileSet, node ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, node)
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// status returns the marker of a deprecated or experimental declaration
+func status(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	for _, paragraph := range strings.Split(doc.Text(), "\n\n") {
+		switch {
+		case strings.HasPrefix(paragraph, "Deprecated:"):
+			return " // deprecated"
+		case strings.HasPrefix(paragraph, "Experimental:"):
+			return " // experimental"
+		}
+	}
+	return ""
+}
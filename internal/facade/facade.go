@@ -0,0 +1,354 @@
+// Package facade generates the root goframe package, which re-exports the public API of the
+// dataframe package: an alias for every exported type and a wrapper for every exported function.
+// Methods need no wrapper, they come with the type aliases.
+package facade
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ImportPath is the import path of the re-exported package, imported as df by the façade
+const ImportPath = "github.com/kishyassin/goframe/dataframe"
+
+// source is a parsed file of the re-exported package
+type source struct {
+	file    *ast.File
+	imports map[string]string // package name -> import path
+}
+
+// generator collects the declarations of the façade
+type generator struct {
+	fset       *token.FileSet
+	unexported map[string]*ast.TypeSpec // unexported types, inlined when used as constraints
+	specFiles  map[*ast.TypeSpec]*source
+	imports    map[string]string // import path -> package name
+	types      []string
+	consts     []string
+	funcs      []string
+}
+
+// Generate returns the gofmt-ed source of the façade for the package in dir. The output only
+// depends on the sources, so it can be compared with the committed file to detect drift.
+//
+// Parameters:
+//   - dir: The directory of the dataframe package.
+//
+// Returns:
+//   - []byte: The source of the root package.
+//   - error: An error if the package cannot be parsed or an exported signature cannot be re-exported.
+func Generate(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading package directory: %w", err)
+	}
+
+	g := &generator{
+		fset:       token.NewFileSet(),
+		unexported: make(map[string]*ast.TypeSpec),
+		specFiles:  make(map[*ast.TypeSpec]*source),
+		imports:    map[string]string{ImportPath: "df"},
+	}
+	var sources []*source
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		src := &source{file: file, imports: make(map[string]string)}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			pkg := defaultName(path)
+			if spec.Name != nil {
+				pkg = spec.Name.Name
+			}
+			src.imports[pkg] = path
+		}
+		sources = append(sources, src)
+	}
+
+	// unexported types first, signatures may use them before their declaration
+	for _, src := range sources {
+		for _, decl := range src.file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if !ts.Name.IsExported() {
+						g.unexported[ts.Name.Name] = ts
+						g.specFiles[ts] = src
+					}
+				}
+			}
+		}
+	}
+
+	for _, src := range sources {
+		for _, decl := range src.file.Decls {
+			var err error
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				err = g.addGenDecl(src, d)
+			case *ast.FuncDecl:
+				if d.Recv == nil && d.Name.IsExported() {
+					err = g.addFunc(src, d)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return g.render()
+}
+
+// addGenDecl adds the aliases of the exported types and constants of a declaration
+func (g *generator) addGenDecl(src *source, decl *ast.GenDecl) error {
+	for _, spec := range decl.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			if !s.Name.IsExported() {
+				continue
+			}
+			params, args, err := g.typeParams(src, s.TypeParams)
+			if err != nil {
+				return fmt.Errorf("cannot re-export type %s: %w", s.Name.Name, err)
+			}
+			g.types = append(g.types, fmt.Sprintf("type %s%s = df.%s%s", s.Name.Name, params, s.Name.Name, args))
+		case *ast.ValueSpec:
+			if decl.Tok != token.CONST {
+				continue
+			}
+			for _, name := range s.Names {
+				if name.IsExported() {
+					g.consts = append(g.consts, fmt.Sprintf("const %s = df.%s", name.Name, name.Name))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addFunc adds the wrapper of an exported function, documented with the first paragraph of its doc comment
+func (g *generator) addFunc(src *source, decl *ast.FuncDecl) error {
+	name := decl.Name.Name
+	params, typeArgs, err := g.typeParams(src, decl.Type.TypeParams)
+	if err != nil {
+		return fmt.Errorf("cannot re-export function %s: %w", name, err)
+	}
+
+	var in, args []string
+	helper := ""
+	for i, field := range decl.Type.Params.List {
+		typ, err := g.expr(src, field.Type)
+		if err != nil {
+			return fmt.Errorf("cannot re-export function %s: %w", name, err)
+		}
+		names := []string{}
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+		}
+		if len(names) == 0 {
+			names = []string{fmt.Sprintf("arg%d", i)}
+		}
+		for _, paramName := range names {
+			// df is the package name of the re-exported package in the façade
+			if paramName == "df" {
+				paramName = "frame"
+			} else if paramName == "_" {
+				paramName = fmt.Sprintf("arg%d", len(in))
+			}
+			in = append(in, paramName+" "+typ)
+			// test helpers such as AssertFrameEqual report the line of their caller, not the wrapper
+			if typ == "TestingT" {
+				helper = paramName + ".Helper()\n\t"
+			}
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				paramName += "..."
+			}
+			args = append(args, paramName)
+		}
+	}
+
+	results := ""
+	if decl.Type.Results != nil {
+		var out []string
+		for _, field := range decl.Type.Results.List {
+			typ, err := g.expr(src, field.Type)
+			if err != nil {
+				return fmt.Errorf("cannot re-export function %s: %w", name, err)
+			}
+			for range max(len(field.Names), 1) {
+				out = append(out, typ)
+			}
+		}
+		results = " " + strings.Join(out, ", ")
+		if len(out) > 1 {
+			results = " (" + strings.Join(out, ", ") + ")"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(docComment(name, decl.Doc))
+	fmt.Fprintf(&b, "func %s%s(%s)%s {\n\t", name, params, strings.Join(in, ", "), results)
+	b.WriteString(helper)
+	if results != "" {
+		b.WriteString("return ")
+	}
+	fmt.Fprintf(&b, "df.%s%s(%s)\n}", name, typeArgs, strings.Join(args, ", "))
+	g.funcs = append(g.funcs, b.String())
+	return nil
+}
+
+// typeParams returns the type parameter list of a declaration and the matching type argument list
+func (g *generator) typeParams(src *source, list *ast.FieldList) (string, string, error) {
+	if list == nil || len(list.List) == 0 {
+		return "", "", nil
+	}
+	var params, args []string
+	for _, field := range list.List {
+		constraint, err := g.constraint(src, field.Type)
+		if err != nil {
+			return "", "", err
+		}
+		var names []string
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+constraint)
+		args = append(args, names...)
+	}
+	return "[" + strings.Join(params, ", ") + "]", "[" + strings.Join(args, ", ") + "]", nil
+}
+
+// constraint prints a type constraint, inlining the type sets of unexported constraint interfaces
+func (g *generator) constraint(src *source, expr ast.Expr) (string, error) {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if spec, found := g.unexported[ident.Name]; found {
+			iface, ok := spec.Type.(*ast.InterfaceType)
+			if !ok || len(iface.Methods.List) != 1 || len(iface.Methods.List[0].Names) != 0 {
+				return "", fmt.Errorf("unexported constraint %s is not a type set", ident.Name)
+			}
+			return g.expr(g.specFiles[spec], iface.Methods.List[0].Type)
+		}
+	}
+	return g.expr(src, expr)
+}
+
+// expr prints a type expression, registering the imports it needs. Unexported types cannot be re-exported.
+func (g *generator) expr(src *source, expr ast.Expr) (string, error) {
+	var err error
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := x.X.(*ast.Ident); ok {
+				path, known := src.imports[pkg.Name]
+				if !known {
+					err = fmt.Errorf("unknown package %s", pkg.Name)
+				}
+				g.imports[path] = pkg.Name
+			}
+			return false
+		case *ast.Ident:
+			if _, found := g.unexported[x.Name]; found {
+				err = fmt.Errorf("uses unexported type %s", x.Name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, g.fset, expr); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// defaultName returns the package name of an import path without a version suffix such as /v2
+func defaultName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	return name
+}
+
+// docComment returns the first paragraph of a doc comment, or a pointer to the original declaration,
+// followed by its "Deprecated:" and "Experimental:" paragraphs
+func docComment(name string, doc *ast.CommentGroup) string {
+	text := ""
+	if doc != nil {
+		paragraphs := strings.Split(strings.TrimSpace(doc.Text()), "\n\n")
+		text = paragraphs[0]
+		for _, paragraph := range paragraphs[1:] {
+			if strings.HasPrefix(paragraph, "Deprecated:") || strings.HasPrefix(paragraph, "Experimental:") {
+				text += "\n\n" + paragraph
+			}
+		}
+	}
+	if text == "" {
+		text = fmt.Sprintf("%s re-exports dataframe.%s.", name, name)
+	}
+	return strings.ReplaceAll("// "+strings.ReplaceAll(text, "\n", "\n// "), "// \n", "//\n") + "\n"
+}
+
+// render writes the façade source
+func (g *generator) render() ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("// Code generated by facadegen from the dataframe package; DO NOT EDIT.\n\n")
+	b.WriteString("package goframe\n\nimport (\n")
+	// standard library first, like goimports
+	var std, others []string
+	for path := range g.imports {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			others = append(others, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(others)
+	for i, group := range [][]string{std, others} {
+		if i > 0 && len(std) > 0 && len(others) > 0 {
+			b.WriteString("\n")
+		}
+		for _, path := range group {
+			name := g.imports[path]
+			if name == defaultName(path) {
+				name = ""
+			}
+			fmt.Fprintf(&b, "\t%s %q\n", name, path)
+		}
+	}
+	b.WriteString(")\n\n// Re-export all public types from the dataframe package\n")
+	b.WriteString(strings.Join(g.types, "\n"))
+	if len(g.consts) > 0 {
+		b.WriteString("\n\n// Re-export all public constants from the dataframe package\n")
+		b.WriteString(strings.Join(g.consts, "\n"))
+	}
+	b.WriteString("\n\n// Re-export all public constructor and utility functions\n\n")
+	b.WriteString(strings.Join(g.funcs, "\n\n"))
+	b.WriteString("\n")
+
+	out, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("error formatting the façade: %w", err)
+	}
+	return out, nil
+}
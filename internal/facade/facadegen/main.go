@@ -0,0 +1,27 @@
+// Command facadegen regenerates the root goframe package from the dataframe package.
+// It is run by go generate from the module root:
+//
+//	go generate ./...
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/kishyassin/goframe/internal/facade"
+)
+
+func main() {
+	src := flag.String("src", "dataframe", "directory of the dataframe package")
+	out := flag.String("out", "facade_gen.go", "file to write the façade to")
+	flag.Parse()
+
+	code, err := facade.Generate(*src)
+	if err != nil {
+		log.Fatalf("facadegen: %v", err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("facadegen: %v", err)
+	}
+}